@@ -281,9 +281,11 @@ func StartControllers(s *options.MCMServer,
 		machineSharedInformers.Machines(),
 		machineSharedInformers.MachineSets(),
 		machineSharedInformers.MachineDeployments(),
+		machineSharedInformers.MachineClasses(),
 		recorder,
 		s.SafetyOptions,
 		s.AutoscalerScaleDownAnnotationDuringRollout,
+		s.AutoscalerMachineDeletionCost,
 	)
 	if err != nil {
 		return err
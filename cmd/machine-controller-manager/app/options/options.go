@@ -34,6 +34,7 @@ import (
 	"k8s.io/component-base/logs"
 
 	"github.com/gardener/machine-controller-manager/pkg/util/client/leaderelectionconfig"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
 
 	// add the machine feature gates
 	"github.com/gardener/machine-controller-manager/pkg/apis/constants"
@@ -70,6 +71,7 @@ func NewMCMServer() *MCMServer {
 				SafetyUp:                        2,
 				SafetyDown:                      1,
 				MachineSafetyOvershootingPeriod: metav1.Duration{Duration: 1 * time.Minute},
+				MachineLabelDriftPolicy:         machineutils.LabelDriftPolicyRelease,
 			},
 		},
 	}
@@ -99,7 +101,19 @@ func (s *MCMServer) AddFlags(fs *pflag.FlagSet) {
 
 	fs.DurationVar(&s.SafetyOptions.MachineSafetyOvershootingPeriod.Duration, "machine-safety-overshooting-period", s.SafetyOptions.MachineSafetyOvershootingPeriod.Duration, "Time period (in duration) used to poll for overshooting of machine objects backing a machineSet by safety controller.")
 
+	fs.Int32Var(&s.SafetyOptions.MaxConcurrentInPlaceRollouts, "max-concurrent-inplace-rollouts", s.SafetyOptions.MaxConcurrentInPlaceRollouts, "Maximum number of machineDeployments which are automatically updated in-place at the same time. 0 means no limit.")
+
+	fs.BoolVar(&s.SafetyOptions.InPlaceUpdateRequireDrainPreviewAck, "inplace-update-require-drain-preview-ack", s.SafetyOptions.InPlaceUpdateRequireDrainPreviewAck, "Require the acknowledgment of the drain preview of a machine by an operator, by setting the annotation node.machine.sapcloud.io/drain-preview-acknowledged to the value of its node.machine.sapcloud.io/drain-preview-hash annotation, before it is selected for an in-place update.")
+
+	fs.Int32Var(&s.SafetyOptions.MachineSetScaleUpStride, "machineset-scale-up-stride", s.SafetyOptions.MachineSetScaleUpStride, "Maximum number of machines created for a machineSet per reconciliation, to stagger the load on the provider API during large scale-ups. 0 means no limit.")
+	fs.StringVar(&s.SafetyOptions.MachineLabelDriftPolicy, "machine-label-drift-policy", s.SafetyOptions.MachineLabelDriftPolicy, "Policy applied to machines owned by a machineSet whose labels no longer match its selector. One of: Release, Readopt, Delete. Release leaves the machine orphaned and replaces it, Readopt re-adds the selector labels, Delete deletes and replaces it.")
+
+	fs.DurationVar(&s.SafetyOptions.MachineDeploymentEventThrottleWindow.Duration, "machinedeployment-event-throttle-window", s.SafetyOptions.MachineDeploymentEventThrottleWindow.Duration, "Window (in duration) within which repeated events of a machineDeployment with the same type and reason are aggregated into a single event, counting the suppressed ones. 0 disables the throttling.")
+	fs.DurationVar(&s.SafetyOptions.FailedMachineRetention.Duration, "failed-machine-retention", s.SafetyOptions.FailedMachineRetention.Duration, "Period (in duration) for which Failed machines of a machineSet are retained before they are deleted and replaced, so that their failure can be inspected. Retained machines still count towards the replicas of the machineSet. 0 disables the retention.")
+	fs.BoolVar(&s.SafetyOptions.AbortTerminationOnNodeRecovery, "abort-termination-on-node-recovery", s.SafetyOptions.AbortTerminationOnNodeRecovery, "Abort the termination of machines marked Failed by the health check if their node becomes Ready again before the machine is deleted, keeping the machine instead of replacing it.")
+
 	fs.BoolVar(&s.AutoscalerScaleDownAnnotationDuringRollout, "autoscaler-scaledown-annotation-during-rollout", true, "Add cluster autoscaler scale-down disabled annotation during roll-out.")
+	fs.BoolVar(&s.AutoscalerMachineDeletionCost, "autoscaler-machine-deletion-cost", false, "Annotate machines with their deletion cost, derived from their machine priority and phase, so that the cluster autoscaler prefers the same machines for scale-down as MCM.")
 
 	logs.AddFlags(fs) // Here `logs` is `k8s.io/component-base/logs`.
 
@@ -135,6 +149,21 @@ func (s *MCMServer) Validate() error {
 	if s.KubeAPIBurst < 0 {
 		errs = append(errs, fmt.Errorf("kube api burst should not be a negative value: got: %d", s.KubeAPIBurst))
 	}
+	if s.SafetyOptions.MaxConcurrentInPlaceRollouts < 0 {
+		errs = append(errs, fmt.Errorf("max concurrent in-place rollouts should not be a negative value: got: %d", s.SafetyOptions.MaxConcurrentInPlaceRollouts))
+	}
+	if s.SafetyOptions.MachineSetScaleUpStride < 0 {
+		errs = append(errs, fmt.Errorf("machineset scale up stride should not be a negative value: got: %d", s.SafetyOptions.MachineSetScaleUpStride))
+	}
+	if p := s.SafetyOptions.MachineLabelDriftPolicy; p != machineutils.LabelDriftPolicyRelease && p != machineutils.LabelDriftPolicyReadopt && p != machineutils.LabelDriftPolicyDelete {
+		errs = append(errs, fmt.Errorf("machine label drift policy should be one of %s, %s, %s: got: %q", machineutils.LabelDriftPolicyRelease, machineutils.LabelDriftPolicyReadopt, machineutils.LabelDriftPolicyDelete, p))
+	}
+	if s.SafetyOptions.MachineDeploymentEventThrottleWindow.Duration < 0 {
+		errs = append(errs, fmt.Errorf("machinedeployment event throttle window should be a non negative value: got: %v", s.SafetyOptions.MachineDeploymentEventThrottleWindow.Duration))
+	}
+	if s.SafetyOptions.FailedMachineRetention.Duration < 0 {
+		errs = append(errs, fmt.Errorf("failed machine retention should be a non negative value: got: %v", s.SafetyOptions.FailedMachineRetention.Duration))
+	}
 	if s.ControllerStartInterval.Duration < 0 {
 		errs = append(errs, fmt.Errorf("controller start interval should be a non negative value: got: %v", s.ControllerStartInterval.Duration))
 	}
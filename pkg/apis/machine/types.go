@@ -175,6 +175,33 @@ type MachineStatus struct {
 	// It can be used by future operation calls to determine current infrastucture state
 	// +optional
 	LastKnownState string
+
+	// Capacity is the allocatable capacity of the VM as reported by the provider, e.g. cpu, memory and nvidia.com/gpu
+	// +optional
+	Capacity corev1.ResourceList
+
+	// ObservedGeneration is the generation of the machine observed by the machine controller when its deletion flow started.
+	// Destructive deletion steps are deferred if the generation advanced since then.
+	// +optional
+	ObservedGeneration int64
+
+	// FailureCategory is the category of the last provisioning failure of the machine, derived from the provider error.
+	// It is cleared once the VM of the machine was created.
+	// +optional
+	FailureCategory MachineFailureCategory
+
+	// PhaseDurations is the cumulative time the machine spent in each of its previous phases, keyed by the phase.
+	// The time is added on each transition to another phase, measured from the transition to the previous phase.
+	// +optional
+	PhaseDurations map[string]metav1.Duration
+
+	// LastSuccessfulReconcile is the time the machine controller last reconciled the machine without error.
+	// +optional
+	LastSuccessfulReconcile *metav1.Time
+
+	// PhaseTransitionTimes is the time the machine last transitioned to each of the known phases, keyed by the phase.
+	// +optional
+	PhaseTransitionTimes map[MachinePhase]metav1.Time
 }
 
 // LastOperation suggests the last operation performed on the object
@@ -210,6 +237,12 @@ const (
 	// MachineRunning means node is ready and running successfully
 	MachineRunning MachinePhase = "Running"
 
+	// MachineVerifying means node has joined the cluster, but the post-creation verification of the machine hasn't passed yet
+	MachineVerifying MachinePhase = "Verifying"
+
+	// MachineQuarantined means the node of the machine is cordoned and health based actions are suspended on an operator's request
+	MachineQuarantined MachinePhase = "Quarantined"
+
 	// MachineTerminating means node is terminating
 	MachineTerminating MachinePhase = "Terminating"
 
@@ -245,6 +278,33 @@ const (
 
 	// MachineStateSuccessful indicates that the node is not ready at the moment
 	MachineStateSuccessful MachineState = "Successful"
+
+	// MachineStateDeletionStalled means the deletion of the machine is stalled as the provider is unreachable
+	MachineStateDeletionStalled MachineState = "DeletionStalled"
+)
+
+// MachineFailureCategory is a category of provisioning failures of machines.
+type MachineFailureCategory string
+
+// These are the valid categories of provisioning failures of machines.
+const (
+	// MachineFailureCategoryQuota means the provider quota or capacity for the VM is exhausted
+	MachineFailureCategoryQuota MachineFailureCategory = "Quota"
+
+	// MachineFailureCategoryImageNotFound means the image of the VM couldn't be found
+	MachineFailureCategoryImageNotFound MachineFailureCategory = "ImageNotFound"
+
+	// MachineFailureCategoryNetwork means the network resources of the VM are missing or misconfigured
+	MachineFailureCategoryNetwork MachineFailureCategory = "Network"
+
+	// MachineFailureCategoryAuth means the credentials for the provider are invalid or lack permissions
+	MachineFailureCategoryAuth MachineFailureCategory = "Auth"
+
+	// MachineFailureCategoryTransient means the provider failed temporarily and the provisioning is expected to succeed on retry
+	MachineFailureCategoryTransient MachineFailureCategory = "Transient"
+
+	// MachineFailureCategoryUnknown means the provisioning failure couldn't be categorized
+	MachineFailureCategoryUnknown MachineFailureCategory = "Unknown"
 )
 
 // MachineOperationType is a label for the operation performed on a machine object.
@@ -405,6 +465,18 @@ type MachineSetStatus struct {
 
 	// FailedMachines has summary of machines on which lastOperation Failed
 	FailedMachines *[]MachineSummary
+
+	// CandidateForUpdateReplicas is the number of machines whose nodes are labelled as candidate for an in-place update.
+	CandidateForUpdateReplicas int32
+
+	// SelectedForUpdateReplicas is the number of machines whose nodes are labelled as selected for an in-place update.
+	SelectedForUpdateReplicas int32
+
+	// UpdateSuccessfulReplicas is the number of machines whose nodes are labelled with a successful in-place update result.
+	UpdateSuccessfulReplicas int32
+
+	// UpdateFailedReplicas is the number of machines whose nodes are labelled with a failed in-place update result.
+	UpdateFailedReplicas int32
 }
 
 // MachineSummary store the summary of machine.
@@ -558,6 +630,16 @@ type InPlaceUpdateMachineDeployment struct {
 
 	// OrchestrationType specifies the orchestration type for the inplace update.
 	OrchestrationType OrchestrationType
+
+	// MinAvailableAbsolute is the absolute minimum number of machines which have to be available during the update.
+	// The larger one of it and the minimum derived from MaxUnavailable is honored.
+	// +optional
+	MinAvailableAbsolute *int32
+
+	// MaxConcurrentDrains is the maximum number of machines which are selected for the update, and thus drained, at the same time
+	// across all old machine sets. Unset means unlimited.
+	// +optional
+	MaxConcurrentDrains *int32
 }
 
 // UpdateConfiguration specifies the udpate configuration for the deployment strategy.
@@ -656,6 +738,11 @@ const (
 	// MachineDeploymentFrozen is added in a MachineDeployment when one of its
 	// machineSet has either the "freeze" label or the "Frozen" condition on it.
 	MachineDeploymentFrozen MachineDeploymentConditionType = "Frozen"
+
+	// MachineDeploymentInPlaceUpdateProgressing reflects the phase of the in-place update of a MachineDeployment,
+	// i.e. whether the nodes are labeled, the machines are drained, or the updated machines are transferred to
+	// the new machineSet, and whether the update completed.
+	MachineDeploymentInPlaceUpdateProgressing MachineDeploymentConditionType = "InPlaceUpdateProgressing"
 )
 
 // MachineDeploymentCondition describes the state of a MachineDeployment at a certain point.
@@ -7,6 +7,17 @@ package v1alpha1
 const (
 	// AnnotationKeyMachineUpdateFailedReason is the annotation key that indicates the reason for a machine update failure.
 	AnnotationKeyMachineUpdateFailedReason = "node.machine.sapcloud.io/update-failed-reason"
+	// AnnotationKeyMachineNotSelectedForUpdateReason is the annotation key that indicates why a machine which is a candidate
+	// for update hasn't been selected for update yet.
+	AnnotationKeyMachineNotSelectedForUpdateReason = "node.machine.sapcloud.io/not-selected-for-update-reason"
+	// AnnotationKeyMachineDrainPreview is the annotation key that holds the pods which would be evicted by the drain of
+	// the node of a machine, computed when the machine is about to be selected for update.
+	AnnotationKeyMachineDrainPreview = "node.machine.sapcloud.io/drain-preview"
+	// AnnotationKeyMachineDrainPreviewHash is the annotation key that holds the hash of all pods in the drain preview of a machine.
+	AnnotationKeyMachineDrainPreviewHash = "node.machine.sapcloud.io/drain-preview-hash"
+	// AnnotationKeyMachineDrainPreviewAcknowledged is the annotation key set to the drain preview hash by an operator to
+	// acknowledge the drain preview of a machine, if the acknowledgment is required before the machine is selected for update.
+	AnnotationKeyMachineDrainPreviewAcknowledged = "node.machine.sapcloud.io/drain-preview-acknowledged"
 
 	// LabelKeyNodeCandidateForUpdate is the label key that indicates a node is a candidate for update.
 	LabelKeyNodeCandidateForUpdate = "node.machine.sapcloud.io/candidate-for-update"
@@ -19,4 +30,13 @@ const (
 	LabelValueNodeUpdateSuccessful = "successful"
 	// LabelValueNodeUpdateFailed is the label value that indicates the update on the node has failed.
 	LabelValueNodeUpdateFailed = "failed"
+
+	// NotSelectedForUpdateReasonMinAvailableConstraint indicates that updating the machine would violate the minimum number of available machines.
+	NotSelectedForUpdateReasonMinAvailableConstraint = "min-available-constraint"
+	// NotSelectedForUpdateReasonAlreadyUndergoingUpdate indicates that enough other machines are already undergoing update.
+	NotSelectedForUpdateReasonAlreadyUndergoingUpdate = "already-undergoing-update"
+	// NotSelectedForUpdateReasonNewIsUnavailable indicates that machines of the new machine set are unavailable.
+	NotSelectedForUpdateReasonNewIsUnavailable = "new-is-unavailable"
+	// NotSelectedForUpdateReasonDrainPreviewNotAcknowledged indicates that the drain preview of the machine hasn't been acknowledged yet.
+	NotSelectedForUpdateReasonDrainPreviewNotAcknowledged = "drain-preview-not-acknowledged"
 )
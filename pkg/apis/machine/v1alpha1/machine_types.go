@@ -105,6 +105,33 @@ type MachineStatus struct {
 	// It can be used by future operation calls to determine current infrastucture state
 	// +optional
 	LastKnownState string `json:"lastKnownState,omitempty"`
+
+	// Capacity is the allocatable capacity of the VM as reported by the provider, e.g. cpu, memory and nvidia.com/gpu
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// ObservedGeneration is the generation of the machine observed by the machine controller when its deletion flow started.
+	// Destructive deletion steps are deferred if the generation advanced since then.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// FailureCategory is the category of the last provisioning failure of the machine, derived from the provider error.
+	// It is cleared once the VM of the machine was created.
+	// +optional
+	FailureCategory MachineFailureCategory `json:"failureCategory,omitempty"`
+
+	// PhaseDurations is the cumulative time the machine spent in each of its previous phases, keyed by the phase.
+	// The time is added on each transition to another phase, measured from the transition to the previous phase.
+	// +optional
+	PhaseDurations map[string]metav1.Duration `json:"phaseDurations,omitempty"`
+
+	// LastSuccessfulReconcile is the time the machine controller last reconciled the machine without error.
+	// +optional
+	LastSuccessfulReconcile *metav1.Time `json:"lastSuccessfulReconcile,omitempty"`
+
+	// PhaseTransitionTimes is the time the machine last transitioned to each of the known phases, keyed by the phase.
+	// +optional
+	PhaseTransitionTimes map[MachinePhase]metav1.Time `json:"phaseTransitionTimes,omitempty"`
 }
 
 // LastOperation suggests the last operation performed on the object
@@ -140,6 +167,12 @@ const (
 	// MachineRunning means node is ready and running successfully
 	MachineRunning MachinePhase = "Running"
 
+	// MachineVerifying means node has joined the cluster, but the post-creation verification of the machine hasn't passed yet
+	MachineVerifying MachinePhase = "Verifying"
+
+	// MachineQuarantined means the node of the machine is cordoned and health based actions are suspended on an operator's request
+	MachineQuarantined MachinePhase = "Quarantined"
+
 	// MachineTerminating means node is terminating
 	MachineTerminating MachinePhase = "Terminating"
 
@@ -175,6 +208,33 @@ const (
 
 	// MachineStateSuccessful means operation completed successfully
 	MachineStateSuccessful MachineState = "Successful"
+
+	// MachineStateDeletionStalled means the deletion of the machine is stalled as the provider is unreachable
+	MachineStateDeletionStalled MachineState = "DeletionStalled"
+)
+
+// MachineFailureCategory is a category of provisioning failures of machines.
+type MachineFailureCategory string
+
+// These are the valid categories of provisioning failures of machines.
+const (
+	// MachineFailureCategoryQuota means the provider quota or capacity for the VM is exhausted
+	MachineFailureCategoryQuota MachineFailureCategory = "Quota"
+
+	// MachineFailureCategoryImageNotFound means the image of the VM couldn't be found
+	MachineFailureCategoryImageNotFound MachineFailureCategory = "ImageNotFound"
+
+	// MachineFailureCategoryNetwork means the network resources of the VM are missing or misconfigured
+	MachineFailureCategoryNetwork MachineFailureCategory = "Network"
+
+	// MachineFailureCategoryAuth means the credentials for the provider are invalid or lack permissions
+	MachineFailureCategoryAuth MachineFailureCategory = "Auth"
+
+	// MachineFailureCategoryTransient means the provider failed temporarily and the provisioning is expected to succeed on retry
+	MachineFailureCategoryTransient MachineFailureCategory = "Transient"
+
+	// MachineFailureCategoryUnknown means the provisioning failure couldn't be categorized
+	MachineFailureCategoryUnknown MachineFailureCategory = "Unknown"
 )
 
 // MachineOperationType is a label for the operation performed on a machine object.
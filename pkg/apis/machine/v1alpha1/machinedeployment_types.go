@@ -146,6 +146,16 @@ type InPlaceUpdateMachineDeployment struct {
 
 	// OrchestrationType specifies the orchestration type for the inplace update.
 	OrchestrationType OrchestrationType `json:"orchestrationType,omitempty"`
+
+	// MinAvailableAbsolute is the absolute minimum number of machines which have to be available during the update.
+	// The larger one of it and the minimum derived from MaxUnavailable is honored.
+	// +optional
+	MinAvailableAbsolute *int32 `json:"minAvailableAbsolute,omitempty"`
+
+	// MaxConcurrentDrains is the maximum number of machines which are selected for the update, and thus drained, at the same time
+	// across all old machine sets. Unset means unlimited.
+	// +optional
+	MaxConcurrentDrains *int32 `json:"maxConcurrentDrains,omitempty"`
 }
 
 // UpdateConfiguration specifies the udpate configuration for the deployment strategy.
@@ -252,6 +262,11 @@ const (
 	// MachineDeploymentFrozen is added in a MachineDeployment when one of its
 	// machineSet has either the "freeze" label or the "Frozen" condition on it.
 	MachineDeploymentFrozen MachineDeploymentConditionType = "Frozen"
+
+	// MachineDeploymentInPlaceUpdateProgressing reflects the phase of the in-place update of a MachineDeployment,
+	// i.e. whether the nodes are labeled, the machines are drained, or the updated machines are transferred to
+	// the new machineSet, and whether the update completed.
+	MachineDeploymentInPlaceUpdateProgressing MachineDeploymentConditionType = "InPlaceUpdateProgressing"
 )
 
 // RollbackConfig is the config to rollback a MachineDeployment
@@ -135,4 +135,20 @@ type MachineSetStatus struct {
 	// FailedMachines has summary of machines on which lastOperation Failed
 	// +optional
 	FailedMachines *[]MachineSummary `json:"failedMachines,omitempty"`
+
+	// CandidateForUpdateReplicas is the number of machines whose nodes are labelled as candidate for an in-place update.
+	// +optional
+	CandidateForUpdateReplicas int32 `json:"candidateForUpdateReplicas,omitempty"`
+
+	// SelectedForUpdateReplicas is the number of machines whose nodes are labelled as selected for an in-place update.
+	// +optional
+	SelectedForUpdateReplicas int32 `json:"selectedForUpdateReplicas,omitempty"`
+
+	// UpdateSuccessfulReplicas is the number of machines whose nodes are labelled with a successful in-place update result.
+	// +optional
+	UpdateSuccessfulReplicas int32 `json:"updateSuccessfulReplicas,omitempty"`
+
+	// UpdateFailedReplicas is the number of machines whose nodes are labelled with a failed in-place update result.
+	// +optional
+	UpdateFailedReplicas int32 `json:"updateFailedReplicas,omitempty"`
 }
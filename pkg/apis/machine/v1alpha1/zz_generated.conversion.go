@@ -373,6 +373,8 @@ func autoConvert_v1alpha1_InPlaceUpdateMachineDeployment_To_machine_InPlaceUpdat
 		return err
 	}
 	out.OrchestrationType = machine.OrchestrationType(in.OrchestrationType)
+	out.MinAvailableAbsolute = (*int32)(unsafe.Pointer(in.MinAvailableAbsolute))
+	out.MaxConcurrentDrains = (*int32)(unsafe.Pointer(in.MaxConcurrentDrains))
 	return nil
 }
 
@@ -386,6 +388,8 @@ func autoConvert_machine_InPlaceUpdateMachineDeployment_To_v1alpha1_InPlaceUpdat
 		return err
 	}
 	out.OrchestrationType = OrchestrationType(in.OrchestrationType)
+	out.MinAvailableAbsolute = (*int32)(unsafe.Pointer(in.MinAvailableAbsolute))
+	out.MaxConcurrentDrains = (*int32)(unsafe.Pointer(in.MaxConcurrentDrains))
 	return nil
 }
 
@@ -897,6 +901,10 @@ func autoConvert_v1alpha1_MachineSetStatus_To_machine_MachineSetStatus(in *Machi
 		return err
 	}
 	out.FailedMachines = (*[]machine.MachineSummary)(unsafe.Pointer(in.FailedMachines))
+	out.CandidateForUpdateReplicas = in.CandidateForUpdateReplicas
+	out.SelectedForUpdateReplicas = in.SelectedForUpdateReplicas
+	out.UpdateSuccessfulReplicas = in.UpdateSuccessfulReplicas
+	out.UpdateFailedReplicas = in.UpdateFailedReplicas
 	return nil
 }
 
@@ -916,6 +924,10 @@ func autoConvert_machine_MachineSetStatus_To_v1alpha1_MachineSetStatus(in *machi
 		return err
 	}
 	out.FailedMachines = (*[]MachineSummary)(unsafe.Pointer(in.FailedMachines))
+	out.CandidateForUpdateReplicas = in.CandidateForUpdateReplicas
+	out.SelectedForUpdateReplicas = in.SelectedForUpdateReplicas
+	out.UpdateSuccessfulReplicas = in.UpdateSuccessfulReplicas
+	out.UpdateFailedReplicas = in.UpdateFailedReplicas
 	return nil
 }
 
@@ -967,6 +979,12 @@ func autoConvert_v1alpha1_MachineStatus_To_machine_MachineStatus(in *MachineStat
 		return err
 	}
 	out.LastKnownState = in.LastKnownState
+	out.Capacity = *(*v1.ResourceList)(unsafe.Pointer(&in.Capacity))
+	out.ObservedGeneration = in.ObservedGeneration
+	out.FailureCategory = machine.MachineFailureCategory(in.FailureCategory)
+	out.PhaseDurations = *(*map[string]metav1.Duration)(unsafe.Pointer(&in.PhaseDurations))
+	out.LastSuccessfulReconcile = (*metav1.Time)(unsafe.Pointer(in.LastSuccessfulReconcile))
+	out.PhaseTransitionTimes = *(*map[machine.MachinePhase]metav1.Time)(unsafe.Pointer(&in.PhaseTransitionTimes))
 	return nil
 }
 
@@ -984,6 +1002,12 @@ func autoConvert_machine_MachineStatus_To_v1alpha1_MachineStatus(in *machine.Mac
 		return err
 	}
 	out.LastKnownState = in.LastKnownState
+	out.Capacity = *(*v1.ResourceList)(unsafe.Pointer(&in.Capacity))
+	out.ObservedGeneration = in.ObservedGeneration
+	out.FailureCategory = MachineFailureCategory(in.FailureCategory)
+	out.PhaseDurations = *(*map[string]metav1.Duration)(unsafe.Pointer(&in.PhaseDurations))
+	out.LastSuccessfulReconcile = (*metav1.Time)(unsafe.Pointer(in.LastSuccessfulReconcile))
+	out.PhaseTransitionTimes = *(*map[MachinePhase]metav1.Time)(unsafe.Pointer(&in.PhaseTransitionTimes))
 	return nil
 }
 
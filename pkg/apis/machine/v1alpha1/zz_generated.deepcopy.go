@@ -53,6 +53,16 @@ func (in *CurrentStatus) DeepCopy() *CurrentStatus {
 func (in *InPlaceUpdateMachineDeployment) DeepCopyInto(out *InPlaceUpdateMachineDeployment) {
 	*out = *in
 	in.UpdateConfiguration.DeepCopyInto(&out.UpdateConfiguration)
+	if in.MinAvailableAbsolute != nil {
+		in, out := &in.MinAvailableAbsolute, &out.MinAvailableAbsolute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentDrains != nil {
+		in, out := &in.MaxConcurrentDrains, &out.MaxConcurrentDrains
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -623,6 +633,31 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 	}
 	in.LastOperation.DeepCopyInto(&out.LastOperation)
 	in.CurrentStatus.DeepCopyInto(&out.CurrentStatus)
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.PhaseDurations != nil {
+		in, out := &in.PhaseDurations, &out.PhaseDurations
+		*out = make(map[string]metav1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastSuccessfulReconcile != nil {
+		in, out := &in.LastSuccessfulReconcile, &out.LastSuccessfulReconcile
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseTransitionTimes != nil {
+		in, out := &in.PhaseTransitionTimes, &out.PhaseTransitionTimes
+		*out = make(map[MachinePhase]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -11,6 +11,7 @@ import (
 	"sort"
 
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -21,6 +22,15 @@ func ValidateMachineDeployment(machineDeployment *machine.MachineDeployment) fie
 	return internalValidateMachineDeployment(machineDeployment)
 }
 
+// ValidateMachineDeploymentUpdate validates an update of a MachineDeployment against the stored object
+// and returns a list of errors. Like the selector of a Deployment, the selector of a MachineDeployment is
+// immutable, as changing it would orphan the MachineSets it owns.
+func ValidateMachineDeploymentUpdate(newMachineDeployment, oldMachineDeployment *machine.MachineDeployment) field.ErrorList {
+	allErrs := internalValidateMachineDeployment(newMachineDeployment)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newMachineDeployment.Spec.Selector, oldMachineDeployment.Spec.Selector, field.NewPath("spec", "selector"))...)
+	return allErrs
+}
+
 func internalValidateMachineDeployment(machineDeployment *machine.MachineDeployment) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateMachineDeploymentSpec(&machineDeployment.Spec, field.NewPath("spec"))...)
@@ -65,6 +75,14 @@ func validateUpdateStrategy(spec *machine.MachineDeploymentSpec, fldPath *field.
 			if spec.Strategy.InPlaceUpdate.OrchestrationType != machine.OrchestrationTypeAuto && spec.Strategy.InPlaceUpdate.OrchestrationType != machine.OrchestrationTypeManual {
 				allErrs = append(allErrs, field.Invalid(fldPath.Child("strategy.inPlaceUpdate.orchestrationType"), spec.Strategy.InPlaceUpdate.OrchestrationType, "orchestrationType must be either Auto or Manual"))
 			}
+
+			if minAvailable := spec.Strategy.InPlaceUpdate.MinAvailableAbsolute; minAvailable != nil && *minAvailable < 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("strategy.inPlaceUpdate.minAvailableAbsolute"), *minAvailable, "minAvailableAbsolute must not be negative"))
+			}
+
+			if maxConcurrentDrains := spec.Strategy.InPlaceUpdate.MaxConcurrentDrains; maxConcurrentDrains != nil && *maxConcurrentDrains < 1 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("strategy.inPlaceUpdate.maxConcurrentDrains"), *maxConcurrentDrains, "maxConcurrentDrains must be positive"))
+			}
 		}
 	}
 
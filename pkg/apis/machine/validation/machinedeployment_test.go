@@ -177,7 +177,56 @@ var _ = Describe("MachineDeployment API Validation", func() {
 						"Detail": Equal("orchestrationType must be either Auto or Manual"),
 					}))))
 				})
+
+				It("should return error if MinAvailableAbsolute is negative", func() {
+					machineDeployment.Spec.Strategy.InPlaceUpdate.MinAvailableAbsolute = ptr.To[int32](-1)
+
+					Expect(ValidateMachineDeployment(machineDeployment)).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.strategy.inPlaceUpdate.minAvailableAbsolute"),
+						"Detail": Equal("minAvailableAbsolute must not be negative"),
+					}))))
+				})
+
+				It("should return error if MaxConcurrentDrains isn't positive", func() {
+					machineDeployment.Spec.Strategy.InPlaceUpdate.MaxConcurrentDrains = ptr.To[int32](0)
+
+					Expect(ValidateMachineDeployment(machineDeployment)).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(field.ErrorTypeInvalid),
+						"Field":  Equal("spec.strategy.inPlaceUpdate.maxConcurrentDrains"),
+						"Detail": Equal("maxConcurrentDrains must be positive"),
+					}))))
+				})
 			})
 		})
 	})
+
+	Describe("ValidateMachineDeploymentUpdate", func() {
+		var oldMachineDeployment *machine.MachineDeployment
+
+		BeforeEach(func() {
+			oldMachineDeployment = machineDeployment.DeepCopy()
+		})
+
+		It("should not return error if the machine deployment is unchanged", func() {
+			Expect(ValidateMachineDeploymentUpdate(machineDeployment, oldMachineDeployment)).To(BeEmpty())
+		})
+
+		It("should not return error if only the replicas are changed", func() {
+			machineDeployment.Spec.Replicas = 3
+
+			Expect(ValidateMachineDeploymentUpdate(machineDeployment, oldMachineDeployment)).To(BeEmpty())
+		})
+
+		It("should return error if the selector is changed", func() {
+			machineDeployment.Spec.Selector.MatchLabels["key"] = "value1"
+			machineDeployment.Spec.Template.Labels["key"] = "value1"
+
+			Expect(ValidateMachineDeploymentUpdate(machineDeployment, oldMachineDeployment)).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(field.ErrorTypeInvalid),
+				"Field":  Equal("spec.selector"),
+				"Detail": Equal("field is immutable"),
+			}))))
+		})
+	})
 })
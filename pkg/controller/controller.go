@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"time"
 
 	machineinternal "github.com/gardener/machine-controller-manager/pkg/apis/machine"
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
@@ -22,9 +23,13 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	kubernetesinformers "k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -49,9 +54,11 @@ func NewController(
 	machineInformer machineinformers.MachineInformer,
 	machineSetInformer machineinformers.MachineSetInformer,
 	machineDeploymentInformer machineinformers.MachineDeploymentInformer,
+	machineClassInformer machineinformers.MachineClassInformer,
 	recorder record.EventRecorder,
 	safetyOptions options.SafetyOptions,
 	autoscalerScaleDownAnnotationDuringRollout bool,
+	autoscalerMachineDeletionCost bool,
 ) (Controller, error) {
 	controller := &controller{
 		namespace:                      namespace,
@@ -67,6 +74,8 @@ func NewController(
 		machineSafetyOvershootingQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyovershooting"),
 		safetyOptions:                  safetyOptions,
 		autoscalerScaleDownAnnotationDuringRollout: autoscalerScaleDownAnnotationDuringRollout,
+		autoscalerMachineDeletionCost:              autoscalerMachineDeletionCost,
+		machineDeploymentEventThrottler:            newMachineDeploymentEventThrottler(safetyOptions.MachineDeploymentEventThrottleWindow.Duration),
 	}
 
 	controller.internalExternalScheme = runtime.NewScheme()
@@ -96,10 +105,15 @@ func NewController(
 	// Controller listers
 	if targetCoreInformerFactory != nil {
 		controller.nodeLister = targetCoreInformerFactory.Core().V1().Nodes().Lister()
+		podInformer := targetCoreInformerFactory.InformerFor(&corev1.Pod{}, newActivePodInformer)
+		controller.podLister = corelisters.NewPodLister(podInformer.GetIndexer())
+		controller.podIndexer = podInformer.GetIndexer()
+		controller.podSynced = podInformer.HasSynced
 	}
 	controller.machineLister = machineInformer.Lister()
 	controller.machineSetLister = machineSetInformer.Lister()
 	controller.machineDeploymentLister = machineDeploymentInformer.Lister()
+	controller.machineClassLister = machineClassInformer.Lister()
 
 	// Controller syncs
 	if targetCoreInformerFactory != nil {
@@ -108,6 +122,7 @@ func NewController(
 	controller.machineSynced = machineInformer.Informer().HasSynced
 	controller.machineSetSynced = machineSetInformer.Informer().HasSynced
 	controller.machineDeploymentSynced = machineDeploymentInformer.Informer().HasSynced
+	controller.machineClassSynced = machineClassInformer.Informer().HasSynced
 
 	// MachineSet Controller Informers
 	_, _ = machineInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -155,6 +170,39 @@ func NewController(
 	return controller, nil
 }
 
+// podNodeNameIndex is the name of the index of the pods by the name of their node
+const podNodeNameIndex = "spec.nodeName"
+
+// activePodsFieldSelector selects the pods which are scheduled and not completed, the only pods the controller considers
+const activePodsFieldSelector = "spec.nodeName!=,status.phase!=Failed,status.phase!=Succeeded"
+
+// newActivePodInformer returns an informer for the active pods of the target cluster, indexed by the name of their node.
+// It is scoped by the activePodsFieldSelector and drops the managed fields of the pods to limit the memory of its cache.
+func newActivePodInformer(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	informer := coreinformers.NewFilteredPodInformer(client, metav1.NamespaceAll, resyncPeriod, cache.Indexers{
+		cache.NamespaceIndex: cache.MetaNamespaceIndexFunc,
+		podNodeNameIndex:     podNodeNameIndexFunc,
+	}, func(options *metav1.ListOptions) {
+		options.FieldSelector = activePodsFieldSelector
+	})
+	_ = informer.SetTransform(func(obj interface{}) (interface{}, error) {
+		if accessor, err := meta.Accessor(obj); err == nil {
+			accessor.SetManagedFields(nil)
+		}
+		return obj, nil
+	})
+	return informer
+}
+
+// podNodeNameIndexFunc indexes the pods by the name of their node, leaving out unscheduled pods
+func podNodeNameIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
+
 // Controller describes a controller for
 type Controller interface {
 	// Run runs the controller until the given stop channel can be read from.
@@ -167,6 +215,7 @@ type Controller interface {
 type controller struct {
 	namespace                                  string
 	autoscalerScaleDownAnnotationDuringRollout bool
+	autoscalerMachineDeletionCost              bool
 
 	// control clients
 	controlMachineClient machineapi.MachineV1alpha1Interface
@@ -179,14 +228,22 @@ type controller struct {
 	machineSetControl MachineSetControlInterface
 	safetyOptions     options.SafetyOptions
 	expectations      *UIDTrackingContExpectations
+	// machineDeploymentEventThrottler aggregates repeated events of machineDeployments – nil when disabled
+	machineDeploymentEventThrottler *machineDeploymentEventThrottler
+	// inPlaceRolloutTokens accounts the in-place rollout tokens held by machineDeployments
+	inPlaceRolloutTokens inPlaceRolloutTokens
 
 	internalExternalScheme *runtime.Scheme
 	// control listers
 	machineLister           machinelisters.MachineLister
 	machineSetLister        machinelisters.MachineSetLister
 	machineDeploymentLister machinelisters.MachineDeploymentLister
+	machineClassLister      machinelisters.MachineClassLister
 	// target listers – nil when running without a target cluster
 	nodeLister corelisters.NodeLister
+	podLister  corelisters.PodLister
+	// podIndexer indexes the pods by the name of their node with the podNodeNameIndex – nil when running without a target cluster
+	podIndexer cache.Indexer
 	// queues
 	nodeQueue                      workqueue.RateLimitingInterface
 	machineQueue                   workqueue.RateLimitingInterface
@@ -195,9 +252,11 @@ type controller struct {
 	machineSafetyOvershootingQueue workqueue.RateLimitingInterface
 	// syncs
 	nodeSynced              cache.InformerSynced
+	podSynced               cache.InformerSynced
 	machineSynced           cache.InformerSynced
 	machineSetSynced        cache.InformerSynced
 	machineDeploymentSynced cache.InformerSynced
+	machineClassSynced      cache.InformerSynced
 }
 
 func (c *controller) Run(workers int, stopCh <-chan struct{}) {
@@ -215,9 +274,11 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 
 	syncedFuncs := []cache.InformerSynced{
 		c.nodeSynced,
+		c.podSynced,
 		c.machineSynced,
 		c.machineSetSynced,
 		c.machineDeploymentSynced,
+		c.machineClassSynced,
 	}
 	// filter out nil funcs (disabled target cluster)
 	syncedFuncs = slices.DeleteFunc(syncedFuncs, func(fn cache.InformerSynced) bool { return fn == nil })
@@ -241,6 +302,11 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 		worker.Run(c.machineSafetyOvershootingQueue, "ClusterMachineSafetyOvershooting", worker.DefaultMaxRetries, true, c.reconcileClusterMachineSafetyOvershooting, stopCh, &waitGroup)
 	}
 
+	if c.machineDeploymentEventThrottler != nil {
+		// report the suppressed events of windows which expired without a further event
+		go wait.Until(c.flushMachineDeploymentEvents, c.machineDeploymentEventThrottler.window, stopCh)
+	}
+
 	<-stopCh
 	klog.V(1).Info("Shutting down Machine Controller Manager ")
 	handlers.UpdateHealth(false)
@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	coreinformers "k8s.io/client-go/informers"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -401,6 +402,8 @@ func createController(
 	defer coreTargetInformerFactory.Start(stop)
 	coreTargetSharedInformers := coreTargetInformerFactory.Core().V1()
 	nodes := coreTargetSharedInformers.Nodes()
+	pods := coreTargetSharedInformers.Pods()
+	Expect(pods.Informer().AddIndexers(toolscache.Indexers{podNodeNameIndex: podNodeNameIndexFunc})).To(Succeed())
 
 	coreControlInformerFactory := coreinformers.NewFilteredSharedInformerFactory(
 		fakeControlCoreClient,
@@ -422,6 +425,7 @@ func createController(
 	machines := machineSharedInformers.Machines()
 	machineSets := machineSharedInformers.MachineSets()
 	machineDeployments := machineSharedInformers.MachineDeployments()
+	machineClasses := machineSharedInformers.MachineClasses()
 
 	internalExternalScheme := runtime.NewScheme()
 	Expect(machine_internal.AddToScheme(internalExternalScheme)).To(Succeed())
@@ -441,13 +445,18 @@ func createController(
 		controlMachineClient:           fakeTypedMachineClient,
 		internalExternalScheme:         internalExternalScheme,
 		nodeLister:                     nodes.Lister(),
+		podLister:                      pods.Lister(),
+		podIndexer:                     pods.Informer().GetIndexer(),
 		machineLister:                  machines.Lister(),
 		machineSetLister:               machineSets.Lister(),
 		machineDeploymentLister:        machineDeployments.Lister(),
+		machineClassLister:             machineClasses.Lister(),
 		machineSynced:                  machines.Informer().HasSynced,
 		machineSetSynced:               machineSets.Informer().HasSynced,
 		machineDeploymentSynced:        machineDeployments.Informer().HasSynced,
+		machineClassSynced:             machineClasses.Informer().HasSynced,
 		nodeSynced:                     nodes.Informer().HasSynced,
+		podSynced:                      pods.Informer().HasSynced,
 		nodeQueue:                      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "node"),
 		machineQueue:                   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machine"),
 		machineSetQueue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machineset"),
@@ -477,7 +486,9 @@ func waitForCacheSync(stop <-chan struct{}, controller *controller) {
 		controller.machineSynced,
 		controller.machineSetSynced,
 		controller.machineDeploymentSynced,
+		controller.machineClassSynced,
 		controller.nodeSynced,
+		controller.podSynced,
 	)).To(BeTrue())
 }
 
@@ -735,50 +735,18 @@ func (s ActiveMachines) Len() int      { return len(s) }
 func (s ActiveMachines) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
 func (s ActiveMachines) Less(i, j int) bool {
-	// Default priority for machine objects
-	machineIPriority := 3
-	machineJPriority := 3
-
-	if s[i].Annotations != nil && s[i].Annotations[machineutils.MachinePriority] != "" {
-		num, err := strconv.Atoi(s[i].Annotations[machineutils.MachinePriority])
-		if err == nil {
-			machineIPriority = num
-		} else {
-			klog.Errorf("Machine priority is taken to be the default value (3). Couldn't convert machine priority to integer for machine:%s. Error message - %s", s[i].Name, err)
-		}
-	}
-
-	if s[j].Annotations != nil && s[j].Annotations[machineutils.MachinePriority] != "" {
-		num, err := strconv.Atoi(s[j].Annotations[machineutils.MachinePriority])
-		if err == nil {
-			machineJPriority = num
-		} else {
-			klog.Errorf("Machine priority is taken to be the default value (3). Couldn't convert machine priority to integer for machine:%s. Error message - %s", s[j].Name, err)
-		}
-	}
-
-	// Map containing machinePhase priority
-	// the lower the priority, the more likely
-	// it is to be deleted
-	m := map[v1alpha1.MachinePhase]int{
-		v1alpha1.MachineTerminating:      0,
-		v1alpha1.MachineFailed:           1,
-		v1alpha1.MachineCrashLoopBackOff: 2,
-		v1alpha1.MachineUnknown:          3,
-		v1alpha1.MachinePending:          4,
-		v1alpha1.MachineAvailable:        5,
-		v1alpha1.MachineRunning:          6,
-	}
+	machineIPriority := getMachinePriority(s[i])
+	machineJPriority := getMachinePriority(s[j])
 
 	// Case-1: Initially we try to prioritize machine deletion based on
 	// machinePriority annotation.
 	// Case-2: If both priorities are equal, then we look at their machinePhase
-	// and prioritize as mentioned in the above map
+	// and prioritize as mentioned in machinePhasePriorities
 	// Case-3: If both Case-1 & Case-2 is false, we prioritize based on creation time
 	if machineIPriority != machineJPriority {
 		return machineIPriority < machineJPriority
-	} else if m[s[i].Status.CurrentStatus.Phase] != m[s[j].Status.CurrentStatus.Phase] {
-		return m[s[i].Status.CurrentStatus.Phase] < m[s[j].Status.CurrentStatus.Phase]
+	} else if machinePhasePriorities[s[i].Status.CurrentStatus.Phase] != machinePhasePriorities[s[j].Status.CurrentStatus.Phase] {
+		return machinePhasePriorities[s[i].Status.CurrentStatus.Phase] < machinePhasePriorities[s[j].Status.CurrentStatus.Phase]
 	} else if s[i].CreationTimestamp != s[j].CreationTimestamp {
 		return s[i].CreationTimestamp.Before(&s[j].CreationTimestamp)
 	}
@@ -786,6 +754,35 @@ func (s ActiveMachines) Less(i, j int) bool {
 	return false
 }
 
+// machinePhasePriorities maps the machine phases to their priority,
+// the lower the priority, the more likely it is to be deleted
+var machinePhasePriorities = map[v1alpha1.MachinePhase]int{
+	v1alpha1.MachineTerminating:      0,
+	v1alpha1.MachineFailed:           1,
+	v1alpha1.MachineCrashLoopBackOff: 2,
+	v1alpha1.MachineUnknown:          3,
+	v1alpha1.MachinePending:          4,
+	v1alpha1.MachineVerifying:        4,
+	v1alpha1.MachineAvailable:        5,
+	v1alpha1.MachineRunning:          6,
+	v1alpha1.MachineQuarantined:      6,
+}
+
+// getMachinePriority returns the value of the machineutils.MachinePriority annotation of the machine, defaulting to 3
+func getMachinePriority(machine *v1alpha1.Machine) int {
+	// Default priority for machine objects
+	priority := 3
+	if machine.Annotations != nil && machine.Annotations[machineutils.MachinePriority] != "" {
+		num, err := strconv.Atoi(machine.Annotations[machineutils.MachinePriority])
+		if err == nil {
+			priority = num
+		} else {
+			klog.Errorf("Machine priority is taken to be the default value (3). Couldn't convert machine priority to integer for machine:%s. Error message - %s", machine.Name, err)
+		}
+	}
+	return priority
+}
+
 // MachineKey is the function used to get the machine name from machine object
 // ToCheck : as machine-namespace does not matter
 func MachineKey(machine *v1alpha1.Machine) string {
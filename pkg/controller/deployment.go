@@ -62,6 +62,25 @@ func (dc *controller) updateMachineDeployment(old, cur interface{}) {
 	oldD := old.(*v1alpha1.MachineDeployment)
 	curD := cur.(*v1alpha1.MachineDeployment)
 	klog.V(4).Infof("Updating machine deployment %s", oldD.Name)
+
+	// Validate the update against the previous MachineDeployment, e.g. a changed selector would orphan its MachineSets
+	internalOldD := &machine.MachineDeployment{}
+	internalCurD := &machine.MachineDeployment{}
+	if err := v1alpha1.Convert_v1alpha1_MachineDeployment_To_machine_MachineDeployment(oldD, internalOldD, nil); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	if err := v1alpha1.Convert_v1alpha1_MachineDeployment_To_machine_MachineDeployment(curD, internalCurD, nil); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	validationerr := validation.ValidateMachineDeploymentUpdate(internalCurD, internalOldD)
+	if validationerr.ToAggregate() != nil && len(validationerr.ToAggregate().Errors()) > 0 {
+		klog.Errorf("Validation of the update of MachineDeployment %q failed %s", curD.Name, validationerr.ToAggregate().Error())
+		dc.recordMachineDeploymentEvent(curD, v1.EventTypeWarning, "InvalidUpdate", "The update of the MachineDeployment was rejected: %s", validationerr.ToAggregate().Error())
+		return
+	}
+
 	dc.enqueueMachineDeployment(curD)
 }
 
@@ -452,6 +471,7 @@ func (dc *controller) reconcileClusterMachineDeployment(key string) error {
 	deployment, err := dc.controlMachineClient.MachineDeployments(dc.namespace).Get(ctx, name, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
 		klog.V(4).Infof("Deployment %v has been deleted", key)
+		dc.inPlaceRolloutTokens.release(types.NamespacedName{Namespace: dc.namespace, Name: name}.String())
 		return nil
 	}
 	if err != nil {
@@ -493,7 +513,7 @@ func (dc *controller) reconcileClusterMachineDeployment(key string) error {
 
 	everything := metav1.LabelSelector{}
 	if reflect.DeepEqual(d.Spec.Selector, &everything) {
-		dc.recorder.Eventf(d, v1.EventTypeWarning, "SelectingAll", "This deployment is selecting all machines. A non-empty selector is required.")
+		dc.recordMachineDeploymentEvent(d, v1.EventTypeWarning, "SelectingAll", "This deployment is selecting all machines. A non-empty selector is required.")
 		if d.Status.ObservedGeneration < d.Generation {
 			d.Status.ObservedGeneration = d.Generation
 			if _, err := dc.controlMachineClient.MachineDeployments(d.Namespace).UpdateStatus(ctx, d, metav1.UpdateOptions{}); err != nil {
@@ -521,6 +541,7 @@ func (dc *controller) reconcileClusterMachineDeployment(key string) error {
 	}
 
 	if d.DeletionTimestamp != nil {
+		dc.inPlaceRolloutTokens.release(types.NamespacedName{Namespace: d.Namespace, Name: d.Name}.String())
 		if finalizers := sets.NewString(d.Finalizers...); !finalizers.Has(DeleteFinalizerName) {
 			return nil
 		}
@@ -540,6 +561,10 @@ func (dc *controller) reconcileClusterMachineDeployment(key string) error {
 		return err
 	}
 
+	if err = dc.releaseInPlaceRolloutTokenIfInactive(ctx, d); err != nil {
+		return err
+	}
+
 	err = dc.setMachinePriorityAnnotationAndUpdateTriggeredForDeletion(ctx, d)
 	if err != nil {
 		return err
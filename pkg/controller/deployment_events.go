@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+)
+
+// machineDeploymentEventKey identifies the events of a machineDeployment which are aggregated
+type machineDeploymentEventKey struct {
+	namespace string
+	name      string
+	eventType string
+	reason    string
+}
+
+// throttledMachineDeploymentEvent tracks the events of a key within the current throttling window
+type throttledMachineDeploymentEvent struct {
+	windowStart time.Time
+	suppressed  int
+	// machineDeployment and message of the last suppressed event
+	machineDeployment *v1alpha1.MachineDeployment
+	message           string
+}
+
+// aggregatedMachineDeploymentEvent is an event which reports the events suppressed within an expired window
+type aggregatedMachineDeploymentEvent struct {
+	machineDeployment *v1alpha1.MachineDeployment
+	eventType         string
+	reason            string
+	message           string
+}
+
+// machineDeploymentEventThrottler aggregates the events with the same type and reason of a machineDeployment,
+// so that at most one of them is emitted per window. The events suppressed within a window are counted and
+// reported with the next event emitted for the same key, or by flush once the window expired.
+type machineDeploymentEventThrottler struct {
+	window time.Duration
+	now    func() time.Time
+
+	lock   sync.Mutex
+	events map[machineDeploymentEventKey]*throttledMachineDeploymentEvent
+}
+
+// newMachineDeploymentEventThrottler returns a machineDeploymentEventThrottler with the given window,
+// or nil if the window is not positive, which disables the throttling.
+func newMachineDeploymentEventThrottler(window time.Duration) *machineDeploymentEventThrottler {
+	if window <= 0 {
+		return nil
+	}
+	return &machineDeploymentEventThrottler{
+		window: window,
+		now:    time.Now,
+		events: make(map[machineDeploymentEventKey]*throttledMachineDeploymentEvent),
+	}
+}
+
+// admit returns whether an event with the given key has to be emitted, and if so how many
+// events with the same key were suppressed before it
+func (t *machineDeploymentEventThrottler) admit(key machineDeploymentEventKey, d *v1alpha1.MachineDeployment, message string) (bool, int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	event, ok := t.events[key]
+	if ok && now.Sub(event.windowStart) < t.window {
+		event.suppressed++
+		event.machineDeployment = d
+		event.message = message
+		return false, 0
+	}
+
+	suppressed := 0
+	if ok {
+		suppressed = event.suppressed
+	}
+	t.events[key] = &throttledMachineDeploymentEvent{windowStart: now}
+	return true, suppressed
+}
+
+// flush returns an event for every key whose window expired with suppressed events, which reports them with the
+// message of the last one, and starts a new window for these keys. The keys whose window expired without
+// suppressed events are forgotten, so that the deleted machineDeployments don't accumulate.
+func (t *machineDeploymentEventThrottler) flush() []aggregatedMachineDeploymentEvent {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	var aggregated []aggregatedMachineDeploymentEvent
+	for key, event := range t.events {
+		if now.Sub(event.windowStart) < t.window {
+			continue
+		}
+		if event.suppressed == 0 {
+			delete(t.events, key)
+			continue
+		}
+		aggregated = append(aggregated, aggregatedMachineDeploymentEvent{
+			machineDeployment: event.machineDeployment,
+			eventType:         key.eventType,
+			reason:            key.reason,
+			message:           fmt.Sprintf("%s (aggregated %d similar events)", event.message, event.suppressed),
+		})
+		t.events[key] = &throttledMachineDeploymentEvent{windowStart: now}
+	}
+	return aggregated
+}
+
+// recordMachineDeploymentEvent records an event for the machineDeployment, aggregating repeated events
+// with the same type and reason if the throttling of machineDeployment events is enabled
+func (dc *controller) recordMachineDeploymentEvent(d *v1alpha1.MachineDeployment, eventType, reason, messageFmt string, args ...interface{}) {
+	if dc.machineDeploymentEventThrottler == nil {
+		dc.recorder.Eventf(d, eventType, reason, messageFmt, args...)
+		return
+	}
+
+	message := fmt.Sprintf(messageFmt, args...)
+	admitted, suppressed := dc.machineDeploymentEventThrottler.admit(machineDeploymentEventKey{
+		namespace: d.Namespace,
+		name:      d.Name,
+		eventType: eventType,
+		reason:    reason,
+	}, d, message)
+	if !admitted {
+		return
+	}
+
+	if suppressed > 0 {
+		message = fmt.Sprintf("%s (aggregated %d similar events)", message, suppressed+1)
+	}
+	dc.recorder.Event(d, eventType, reason, message)
+}
+
+// flushMachineDeploymentEvents records the events which report the machineDeployment events
+// suppressed within the expired throttling windows
+func (dc *controller) flushMachineDeploymentEvents() {
+	for _, event := range dc.machineDeploymentEventThrottler.flush() {
+		dc.recorder.Event(event.machineDeployment, event.eventType, event.reason, event.message)
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	machinev1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+)
+
+var _ = Describe("deployment_events", func() {
+
+	Describe("#recordMachineDeploymentEvent", func() {
+		var (
+			recorder *record.FakeRecorder
+			dc       *controller
+			now      time.Time
+			d        *machinev1.MachineDeployment
+		)
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(100)
+			now = time.Now()
+			d = &machinev1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Name: "md-1", Namespace: testNamespace}}
+		})
+
+		recordedEvents := func() []string {
+			var events []string
+			for {
+				select {
+				case event := <-recorder.Events:
+					events = append(events, event)
+				default:
+					return events
+				}
+			}
+		}
+
+		It("should aggregate repeated identical events within the window into a single counted event", func() {
+			throttler := newMachineDeploymentEventThrottler(time.Minute)
+			throttler.now = func() time.Time { return now }
+			dc = &controller{recorder: recorder, machineDeploymentEventThrottler: throttler}
+
+			for i := 1; i <= 5; i++ {
+				dc.recordMachineDeploymentEvent(d, corev1.EventTypeNormal, "ScalingMachineSet", "Scaled up machine set %s to %d", "ms-1", i)
+			}
+			Expect(recordedEvents()).To(ConsistOf("Normal ScalingMachineSet Scaled up machine set ms-1 to 1"))
+
+			// Events with another reason or of another machineDeployment are not aggregated with them
+			dc.recordMachineDeploymentEvent(d, corev1.EventTypeNormal, InPlaceRolloutDeferredReason, "In-place rollout deferred")
+			dc.recordMachineDeploymentEvent(&machinev1.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Name: "md-2", Namespace: testNamespace}},
+				corev1.EventTypeNormal, "ScalingMachineSet", "Scaled up machine set %s to %d", "ms-2", 1)
+			Expect(recordedEvents()).To(HaveLen(2))
+
+			now = now.Add(time.Minute)
+			dc.recordMachineDeploymentEvent(d, corev1.EventTypeNormal, "ScalingMachineSet", "Scaled up machine set %s to %d", "ms-1", 6)
+			Expect(recordedEvents()).To(ConsistOf("Normal ScalingMachineSet Scaled up machine set ms-1 to 6 (aggregated 5 similar events)"))
+		})
+
+		It("should report the suppressed events once their window expired without a further event", func() {
+			throttler := newMachineDeploymentEventThrottler(time.Minute)
+			throttler.now = func() time.Time { return now }
+			dc = &controller{recorder: recorder, machineDeploymentEventThrottler: throttler}
+
+			dc.recordMachineDeploymentEvent(d, corev1.EventTypeNormal, InPlaceRolloutDeferredReason, "In-place rollout deferred")
+			for i := 1; i <= 3; i++ {
+				dc.recordMachineDeploymentEvent(d, corev1.EventTypeNormal, "ScalingMachineSet", "Scaled up machine set %s to %d", "ms-1", i)
+			}
+			Expect(recordedEvents()).To(HaveLen(2))
+
+			// Nothing is reported before the window expired
+			now = now.Add(30 * time.Second)
+			dc.flushMachineDeploymentEvents()
+			Expect(recordedEvents()).To(BeEmpty())
+
+			now = now.Add(30 * time.Second)
+			dc.flushMachineDeploymentEvents()
+			Expect(recordedEvents()).To(ConsistOf("Normal ScalingMachineSet Scaled up machine set ms-1 to 3 (aggregated 2 similar events)"))
+			Expect(throttler.events).To(HaveLen(1))
+
+			// The report starts a new window, which is forgotten once it expired without suppressed events
+			dc.recordMachineDeploymentEvent(d, corev1.EventTypeNormal, "ScalingMachineSet", "Scaled up machine set %s to %d", "ms-1", 4)
+			Expect(recordedEvents()).To(BeEmpty())
+			now = now.Add(time.Minute)
+			dc.flushMachineDeploymentEvents()
+			Expect(recordedEvents()).To(ConsistOf("Normal ScalingMachineSet Scaled up machine set ms-1 to 4 (aggregated 1 similar events)"))
+			now = now.Add(time.Minute)
+			dc.flushMachineDeploymentEvents()
+			Expect(recordedEvents()).To(BeEmpty())
+			Expect(throttler.events).To(BeEmpty())
+		})
+
+		It("should record every event if the throttling is disabled", func() {
+			dc = &controller{recorder: recorder, machineDeploymentEventThrottler: newMachineDeploymentEventThrottler(0)}
+
+			for i := 1; i <= 5; i++ {
+				dc.recordMachineDeploymentEvent(d, corev1.EventTypeNormal, "ScalingMachineSet", "Scaled up machine set %s to %d", "ms-1", i)
+			}
+			Expect(recordedEvents()).To(HaveLen(5))
+		})
+	})
+})
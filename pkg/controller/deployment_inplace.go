@@ -7,14 +7,19 @@ package controller
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"maps"
 	"slices"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/controller/autoscaler"
 	labelsutil "github.com/gardener/machine-controller-manager/pkg/util/labels"
 	"github.com/gardener/machine-controller-manager/pkg/util/nodeops"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/drain"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
 
 	v1 "k8s.io/api/core/v1"
@@ -22,6 +27,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientretry "k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/integer"
 )
@@ -44,7 +52,11 @@ func (dc *controller) rolloutInPlace(ctx context.Context, d *v1alpha1.MachineDep
 	}
 	allMachineSets := append(oldMachineSets, newMachineSet)
 
-	if len(oldMachineSets) > 0 && !dc.machineSetsScaledToZero(oldMachineSets) {
+	// If the deployment is scaled to zero, the machines are neither prepared nor selected for update,
+	// instead reconcileNewMachineSetInPlace and reconcileOldMachineSetsInPlace tear down the rollout.
+	scaledToZero := d.Spec.Replicas == 0
+
+	if len(oldMachineSets) > 0 && !dc.machineSetsScaledToZero(oldMachineSets) && !scaledToZero {
 		// Label all the old machine sets to disable the scale up.
 		err := dc.labelMachineSets(ctx, oldMachineSets, map[string]string{machineutils.LabelKeyMachineSetScaleUpDisabled: "true"})
 		if err != nil {
@@ -65,25 +77,38 @@ func (dc *controller) rolloutInPlace(ctx context.Context, d *v1alpha1.MachineDep
 		}
 	}
 
-	err = dc.taintNodesBackingMachineSets(
-		ctx,
-		oldMachineSets, &v1.Taint{
-			Key:    PreferNoScheduleKey,
-			Value:  "True",
-			Effect: v1.TaintEffectPreferNoSchedule,
-		},
-	)
-	if err != nil {
-		klog.Warningf("failed to add taint %s on all nodes. Error: %v", PreferNoScheduleKey, err)
-	}
+	if !scaledToZero {
+		err = dc.taintNodesBackingMachineSets(
+			ctx,
+			oldMachineSets, &v1.Taint{
+				Key:    PreferNoScheduleKey,
+				Value:  "True",
+				Effect: v1.TaintEffectPreferNoSchedule,
+			},
+		)
+		if err != nil {
+			klog.Warningf("failed to add taint %s on all nodes. Error: %v", PreferNoScheduleKey, err)
+		}
 
-	// label all nodes backing old machine sets as candidate for update
-	if err := dc.labelNodesBackingMachineSets(ctx, oldMachineSets, v1alpha1.LabelKeyNodeCandidateForUpdate, "true"); err != nil {
-		return fmt.Errorf("failed to label nodes backing old machine sets as candidate for update: %v", err)
-	}
+		// label all nodes backing old machine sets as candidate for update
+		if err := dc.labelNodesBackingMachineSets(ctx, oldMachineSets, v1alpha1.LabelKeyNodeCandidateForUpdate, "true"); err != nil {
+			return fmt.Errorf("failed to label nodes backing old machine sets as candidate for update: %v", err)
+		}
 
-	if err := dc.syncMachineSets(ctx, oldMachineSets, newMachineSet, d); err != nil {
-		return err
+		// converge the selection for update which was left half-applied, e.g. as MCM crashed in between
+		if err := dc.reconcileHalfAppliedSelectionForUpdate(ctx, oldMachineSets); err != nil {
+			return fmt.Errorf("failed to reconcile half-applied selection for update of machines of old machine sets: %v", err)
+		}
+
+		if err := dc.syncMachineSets(ctx, oldMachineSets, newMachineSet, d); err != nil {
+			return err
+		}
+
+		if len(oldMachineSets) > 0 && !dc.machineSetsScaledToZero(oldMachineSets) {
+			if err := dc.markInPlaceRolloutStarted(ctx, d); err != nil {
+				return err
+			}
+		}
 	}
 
 	// In this section, we will attempt to scale up the new machine set. Machines with the `node.machine.sapcloud.io/update-successful` label
@@ -122,6 +147,12 @@ func (dc *controller) rolloutInPlace(ctx context.Context, d *v1alpha1.MachineDep
 		if err := dc.cleanupMachineDeployment(ctx, oldMachineSets, d); err != nil {
 			return err
 		}
+		if err := dc.recordInPlaceRolloutComplete(ctx, allMachineSets, d); err != nil {
+			return err
+		}
+		if err := dc.releaseInPlaceRolloutToken(ctx, d); err != nil {
+			return err
+		}
 	}
 
 	// Sync deployment status
@@ -191,8 +222,10 @@ func (dc *controller) syncMachineSets(ctx context.Context, oldMachineSets []*v1a
 		// remove annotations related to the inplace update.
 		delete(node.Annotations, v1alpha1.AnnotationKeyMachineUpdateFailedReason)
 
-		// uncordon the node since the inplace update is successful.
-		node.Spec.Unschedulable = false
+		// uncordon the node since the inplace update is successful, unless it wasn't cordoned for the update.
+		if !isInPlaceUpdateCordonSkipped(newMachine) {
+			node.Spec.Unschedulable = false
+		}
 
 		// remove the PreferNoSchedule taint if it exists which was added during the inplace update.
 		node.Spec.Taints = slices.DeleteFunc(node.Spec.Taints, func(t v1.Taint) bool {
@@ -234,6 +267,10 @@ func (dc *controller) syncMachineSets(ctx context.Context, oldMachineSets []*v1a
 func (dc *controller) reconcileNewMachineSetInPlace(ctx context.Context, oldMachineSets []*v1alpha1.MachineSet, newMachineSet *v1alpha1.MachineSet, deployment *v1alpha1.MachineDeployment) (bool, error) {
 	klog.V(3).Infof("reconcile new machine set %q having replicas %d", newMachineSet.Name, newMachineSet.Spec.Replicas)
 
+	if deployment.Spec.Replicas == 0 {
+		return dc.scaleMachineSetsToZeroInPlace(ctx, []*v1alpha1.MachineSet{newMachineSet}, deployment)
+	}
+
 	if newMachineSet.Spec.Replicas == deployment.Spec.Replicas {
 		// Scaling not required.
 		return false, nil
@@ -271,6 +308,10 @@ func (dc *controller) reconcileNewMachineSetInPlace(ctx context.Context, oldMach
 }
 
 func (dc *controller) reconcileOldMachineSetsInPlace(ctx context.Context, allMachineSets []*v1alpha1.MachineSet, oldMachineSets []*v1alpha1.MachineSet, newMachineSet *v1alpha1.MachineSet, deployment *v1alpha1.MachineDeployment) (workDone bool, err error) {
+	if deployment.Spec.Replicas == 0 {
+		return dc.scaleMachineSetsToZeroInPlace(ctx, oldMachineSets, deployment)
+	}
+
 	oldMachinesCount := GetReplicaCountForMachineSets(oldMachineSets)
 	if oldMachinesCount == 0 {
 		// Can't scale down further
@@ -296,11 +337,15 @@ func (dc *controller) reconcileOldMachineSetsInPlace(ctx context.Context, allMac
 		return false, nil
 	}
 
+	acquired, err := dc.acquireInPlaceRolloutToken(ctx, deployment)
+	if err != nil || !acquired {
+		return false, err
+	}
+
 	allMachinesCount := GetReplicaCountForMachineSets(allMachineSets)
 	klog.V(3).Infof("New machine set %s has %d available machines.", newMachineSet.Name, newMachineSet.Status.AvailableReplicas)
-	maxUnavailable := MaxUnavailable(*deployment)
 
-	minAvailable := deployment.Spec.Replicas - maxUnavailable
+	minAvailable := getInPlaceMinAvailable(deployment)
 	newMachineSetUnavailableMachineCount := newMachineSet.Spec.Replicas - newMachineSet.Status.AvailableReplicas
 	oldMachineSetsMachinesUndergoingUpdate, err := dc.getMachinesUndergoingUpdate(oldMachineSets)
 	if err != nil {
@@ -311,6 +356,7 @@ func (dc *controller) reconcileOldMachineSetsInPlace(ctx context.Context, allMac
 	// So once the current new machine set replcas + old machine set replicas undergoing update reaches the desired replicas,
 	// we can stop selecting machines from old machine sets for update.
 	if oldMachineSetsMachinesUndergoingUpdate+newMachineSet.Spec.Replicas >= deployment.Spec.Replicas {
+		dc.annotateMachinesNotSelectedForUpdate(ctx, oldMachineSets, v1alpha1.NotSelectedForUpdateReasonAlreadyUndergoingUpdate, nil)
 		return false, nil
 	}
 
@@ -325,6 +371,19 @@ func (dc *controller) reconcileOldMachineSetsInPlace(ctx context.Context, allMac
 	maxUpdatePossible := allMachinesCount - minAvailable - newMachineSetUnavailableMachineCount - oldMachineSetsMachinesUndergoingUpdate
 	if maxUpdatePossible <= 0 {
 		klog.V(3).Infof("no machines can be selected for update from old machine sets")
+		reason := v1alpha1.NotSelectedForUpdateReasonMinAvailableConstraint
+		if newMachineSetUnavailableMachineCount > 0 {
+			reason = v1alpha1.NotSelectedForUpdateReasonNewIsUnavailable
+		} else if oldMachineSetsMachinesUndergoingUpdate > 0 {
+			reason = v1alpha1.NotSelectedForUpdateReasonAlreadyUndergoingUpdate
+		}
+		dc.annotateMachinesNotSelectedForUpdate(ctx, oldMachineSets, reason, nil)
+		return false, nil
+	}
+
+	if maxConcurrentDrains, ok := getInPlaceMaxConcurrentDrains(deployment); ok && oldMachineSetsMachinesUndergoingUpdate >= maxConcurrentDrains {
+		klog.V(3).Infof("no machines can be selected for update from old machine sets as %d machines are already undergoing update, maxConcurrentDrains:%d", oldMachineSetsMachinesUndergoingUpdate, maxConcurrentDrains)
+		dc.annotateMachinesNotSelectedForUpdate(ctx, oldMachineSets, v1alpha1.NotSelectedForUpdateReasonAlreadyUndergoingUpdate, nil)
 		return false, nil
 	}
 
@@ -337,6 +396,243 @@ func (dc *controller) reconcileOldMachineSetsInPlace(ctx context.Context, allMac
 	return numOfMachinesSelectedForUpdate > 0, nil
 }
 
+// scaleMachineSetsToZeroInPlace tears down an in-place rollout of a deployment scaled to zero. It clears the
+// in-place update labels, taints and cordons from the nodes backing the machine sets, the reasons for not selecting
+// their machines for update and scales them down to zero.
+func (dc *controller) scaleMachineSetsToZeroInPlace(ctx context.Context, machineSets []*v1alpha1.MachineSet, deployment *v1alpha1.MachineDeployment) (bool, error) {
+	scaledDown := false
+	for _, machineSet := range machineSets {
+		if machineSet == nil {
+			continue
+		}
+
+		machines, err := dc.machineLister.List(labels.SelectorFromSet(machineSet.Spec.Selector.MatchLabels))
+		if err != nil {
+			return scaledDown, err
+		}
+		for _, machine := range machines {
+			if err := dc.clearInPlaceUpdateStateOfNode(ctx, machine); err != nil {
+				return scaledDown, err
+			}
+			if err := dc.removeMachineNotSelectedForUpdateReason(ctx, machine); err != nil {
+				return scaledDown, err
+			}
+		}
+
+		if machineSet.Spec.Replicas == 0 {
+			continue
+		}
+		scaled, _, err := dc.scaleMachineSetAndRecordEvent(ctx, machineSet, 0, deployment)
+		if err != nil {
+			return scaledDown, fmt.Errorf("failed to scale down machine set %s to zero: %w", machineSet.Name, err)
+		}
+		scaledDown = scaledDown || scaled
+	}
+	return scaledDown, nil
+}
+
+// clearInPlaceUpdateStateOfNode removes the labels and the taint added for an in-place update from the node backing the machine and uncordons it.
+func (dc *controller) clearInPlaceUpdateStateOfNode(ctx context.Context, machine *v1alpha1.Machine) error {
+	if machine.Labels[v1alpha1.NodeLabelKey] == "" {
+		return nil
+	}
+
+	node, err := dc.nodeLister.Get(machine.Labels[v1alpha1.NodeLabelKey])
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	nodeCopy := node.DeepCopy()
+	delete(nodeCopy.Labels, v1alpha1.LabelKeyNodeCandidateForUpdate)
+	delete(nodeCopy.Labels, v1alpha1.LabelKeyNodeSelectedForUpdate)
+	delete(nodeCopy.Labels, v1alpha1.LabelKeyNodeUpdateResult)
+	if !isInPlaceUpdateCordonSkipped(machine) {
+		nodeCopy.Spec.Unschedulable = false
+	}
+	nodeCopy.Spec.Taints = slices.DeleteFunc(nodeCopy.Spec.Taints, func(t v1.Taint) bool {
+		return t.Key == PreferNoScheduleKey && t.Value == "True" && t.Effect == v1.TaintEffectPreferNoSchedule
+	})
+	if len(nodeCopy.Labels) == len(node.Labels) && nodeCopy.Spec.Unschedulable == node.Spec.Unschedulable && len(nodeCopy.Spec.Taints) == len(node.Spec.Taints) {
+		return nil
+	}
+
+	if _, err := dc.targetCoreClient.CoreV1().Nodes().Update(ctx, nodeCopy, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to clear in-place update state of node %s: %w", node.Name, err)
+	}
+	klog.V(3).Infof("cleared in-place update state of node %s backing machine %s", node.Name, machine.Name)
+	return nil
+}
+
+// inPlaceRolloutTokens accounts the in-place rollout tokens held by machineDeployments, keyed by their namespaced name.
+// The token annotations of other machineDeployments observed through the lister may lag behind, so the tokens
+// granted by this controller are tracked here to not hand out more tokens than permitted.
+type inPlaceRolloutTokens struct {
+	lock    sync.Mutex
+	holders sets.Set[string]
+}
+
+// tryAcquire grants a token to the key unless the key and the given holders known from the annotations
+// already hold maxTokens tokens. It returns whether the key holds a token and the other holders otherwise.
+func (t *inPlaceRolloutTokens) tryAcquire(key string, annotatedHolders []string, maxTokens int32) (bool, []string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.holders == nil {
+		t.holders = sets.New[string]()
+	}
+	if t.holders.Has(key) {
+		return true, nil
+	}
+	holders := t.holders.Clone().Insert(annotatedHolders...).Delete(key)
+	if int32(holders.Len()) >= maxTokens { // #nosec G115 (CWE-190) -- number of deployments cannot exceed MaxInt32
+		return false, sets.List(holders)
+	}
+	t.holders.Insert(key)
+	return true, nil
+}
+
+// hold records that the key holds a token, e.g. as recorded by its annotation before a restart.
+func (t *inPlaceRolloutTokens) hold(key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.holders == nil {
+		t.holders = sets.New[string]()
+	}
+	t.holders.Insert(key)
+}
+
+// release releases the token held by the key, if any.
+func (t *inPlaceRolloutTokens) release(key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.holders.Delete(key)
+}
+
+// acquireInPlaceRolloutToken makes sure the deployment holds an in-place rollout token before machines are selected for update.
+// It returns false if the deployment has to defer its rollout as all tokens are held by other deployments.
+func (dc *controller) acquireInPlaceRolloutToken(ctx context.Context, deployment *v1alpha1.MachineDeployment) (bool, error) {
+	maxConcurrentRollouts := dc.safetyOptions.MaxConcurrentInPlaceRollouts
+	if maxConcurrentRollouts <= 0 {
+		return true, nil
+	}
+	key := types.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}.String()
+	if metav1.HasAnnotation(deployment.ObjectMeta, InPlaceRolloutTokenAnnotation) {
+		dc.inPlaceRolloutTokens.hold(key)
+		return true, nil
+	}
+
+	deployments, err := dc.machineDeploymentLister.MachineDeployments(deployment.Namespace).List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	var annotatedHolders []string
+	for _, d := range deployments {
+		if metav1.HasAnnotation(d.ObjectMeta, InPlaceRolloutTokenAnnotation) {
+			annotatedHolders = append(annotatedHolders, types.NamespacedName{Namespace: d.Namespace, Name: d.Name}.String())
+		}
+	}
+	acquired, tokenHolders := dc.inPlaceRolloutTokens.tryAcquire(key, annotatedHolders, maxConcurrentRollouts)
+	if !acquired {
+		klog.V(3).Infof("Deferring in-place rollout of MachineDeployment %q as in-place rollout tokens are held by %v", deployment.Name, tokenHolders)
+		dc.recordMachineDeploymentEvent(deployment, v1.EventTypeNormal, InPlaceRolloutDeferredReason, "In-place rollout deferred as %d of %d in-place rollout tokens are held by other MachineDeployments", len(tokenHolders), maxConcurrentRollouts)
+		return false, nil
+	}
+
+	clone := deployment.DeepCopy()
+	metav1.SetMetaDataAnnotation(&clone.ObjectMeta, InPlaceRolloutTokenAnnotation, "true")
+	updated, err := dc.controlMachineClient.MachineDeployments(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		dc.inPlaceRolloutTokens.release(key)
+		return false, err
+	}
+	deployment.ObjectMeta = updated.ObjectMeta
+	klog.V(3).Infof("MachineDeployment %q acquired an in-place rollout token", deployment.Name)
+	return true, nil
+}
+
+// releaseInPlaceRolloutToken releases the in-place rollout token held by the deployment, if any.
+func (dc *controller) releaseInPlaceRolloutToken(ctx context.Context, deployment *v1alpha1.MachineDeployment) error {
+	dc.inPlaceRolloutTokens.release(types.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}.String())
+	if !metav1.HasAnnotation(deployment.ObjectMeta, InPlaceRolloutTokenAnnotation) {
+		return nil
+	}
+
+	clone := deployment.DeepCopy()
+	delete(clone.Annotations, InPlaceRolloutTokenAnnotation)
+	updated, err := dc.controlMachineClient.MachineDeployments(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	deployment.ObjectMeta = updated.ObjectMeta
+	klog.V(3).Infof("MachineDeployment %q released its in-place rollout token", deployment.Name)
+	return nil
+}
+
+// releaseInPlaceRolloutTokenIfInactive releases the in-place rollout token of the deployment if it no longer
+// performs an automatic in-place rollout, as it is paused or its strategy changed.
+func (dc *controller) releaseInPlaceRolloutTokenIfInactive(ctx context.Context, deployment *v1alpha1.MachineDeployment) error {
+	if !deployment.Spec.Paused && deployment.Spec.Strategy.Type == v1alpha1.InPlaceUpdateMachineDeploymentStrategyType &&
+		deployment.Spec.Strategy.InPlaceUpdate != nil && deployment.Spec.Strategy.InPlaceUpdate.OrchestrationType != v1alpha1.OrchestrationTypeManual {
+		return nil
+	}
+	return dc.releaseInPlaceRolloutToken(ctx, deployment)
+}
+
+// markInPlaceRolloutStarted records the start time of the automatic in-place rollout of the deployment, if not yet recorded.
+func (dc *controller) markInPlaceRolloutStarted(ctx context.Context, deployment *v1alpha1.MachineDeployment) error {
+	if deployment.Spec.Strategy.InPlaceUpdate == nil || deployment.Spec.Strategy.InPlaceUpdate.OrchestrationType == v1alpha1.OrchestrationTypeManual ||
+		metav1.HasAnnotation(deployment.ObjectMeta, InPlaceRolloutStartedAnnotation) {
+		return nil
+	}
+
+	clone := deployment.DeepCopy()
+	metav1.SetMetaDataAnnotation(&clone.ObjectMeta, InPlaceRolloutStartedAnnotation, time.Now().UTC().Format(time.RFC3339))
+	updated, err := dc.controlMachineClient.MachineDeployments(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	deployment.ObjectMeta = updated.ObjectMeta
+	klog.V(3).Infof("MachineDeployment %q started an in-place rollout", deployment.Name)
+	return nil
+}
+
+// recordInPlaceRolloutComplete emits the InPlaceRolloutComplete event summarizing the completed automatic in-place rollout
+// of the deployment. The start time of the rollout is removed with it, so that the event is emitted only once per rollout.
+func (dc *controller) recordInPlaceRolloutComplete(ctx context.Context, allMachineSets []*v1alpha1.MachineSet, deployment *v1alpha1.MachineDeployment) error {
+	started, ok := deployment.Annotations[InPlaceRolloutStartedAnnotation]
+	if !ok {
+		return nil
+	}
+
+	clone := deployment.DeepCopy()
+	delete(clone.Annotations, InPlaceRolloutStartedAnnotation)
+	updated, err := dc.controlMachineClient.MachineDeployments(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	deployment.ObjectMeta = updated.ObjectMeta
+
+	updateFailures, err := dc.getMachinesWithFailedUpdate(allMachineSets)
+	if err != nil {
+		klog.Warningf("Failed to count the failed in-place updates of MachineDeployment %q: %v", deployment.Name, err)
+	}
+	duration := "unknown"
+	if startTime, err := time.Parse(time.RFC3339, started); err == nil {
+		duration = time.Since(startTime).Round(time.Second).String()
+	} else {
+		klog.Warningf("MachineDeployment %q has an invalid in-place rollout start time %q: %v", deployment.Name, started, err)
+	}
+
+	klog.V(2).Infof("In-place rollout of MachineDeployment %q completed after %s", deployment.Name, duration)
+	dc.recordMachineDeploymentEvent(deployment, v1.EventTypeNormal, InPlaceRolloutCompleteReason, "In-place rollout completed after %s with %d machine(s) updated and %d failed update(s)", duration, deployment.Status.UpdatedReplicas, updateFailures)
+	return nil
+}
+
 func (dc *controller) transferMachinesFromOldToNewMachineSet(ctx context.Context, oldMachineSets []*v1alpha1.MachineSet, newMachineSet *v1alpha1.MachineSet, deployment *v1alpha1.MachineDeployment) (int32, error) {
 	var addedNewReplicasCount int32
 
@@ -393,11 +689,14 @@ func (dc *controller) transferMachinesFromOldToNewMachineSet(ctx context.Context
 				return addedNewReplicasCount, err
 			}
 
-			// uncordon the node since the ownership of the machine has been transferred to the new machine set.
-			node.Spec.Unschedulable = false
-			_, err = dc.targetCoreClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
-			if err != nil {
-				return addedNewReplicasCount, fmt.Errorf("failed to uncordon the node %s: %w", node.Name, err)
+			// uncordon the node since the ownership of the machine has been transferred to the new machine set,
+			// unless it wasn't cordoned for the update.
+			if !isInPlaceUpdateCordonSkipped(oldMachine) {
+				node.Spec.Unschedulable = false
+				_, err = dc.targetCoreClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+				if err != nil {
+					return addedNewReplicasCount, fmt.Errorf("failed to uncordon the node %s: %w", node.Name, err)
+				}
 			}
 
 			transferredMachineCount++ // scale down the old machine set.
@@ -421,23 +720,37 @@ func (dc *controller) transferMachinesFromOldToNewMachineSet(ctx context.Context
 }
 
 func (dc *controller) selectNumOfMachineForUpdate(ctx context.Context, allMachineSets []*v1alpha1.MachineSet, oldMachineSets []*v1alpha1.MachineSet, newMachineSet *v1alpha1.MachineSet, deployment *v1alpha1.MachineDeployment, oldMachineSetsMachinesUndergoingUpdate int32) (int32, error) {
-	maxUnavailable := MaxUnavailable(*deployment)
-
 	// Check if we can pick machines from old ISes for updating to new IS.
-	minAvailable := deployment.Spec.Replicas - maxUnavailable
+	minAvailable := getInPlaceMinAvailable(deployment)
 
 	// Find the number of available machines.
 	availableMachineCount := GetAvailableReplicaCountForMachineSets(allMachineSets) - oldMachineSetsMachinesUndergoingUpdate
 	if availableMachineCount <= minAvailable {
 		// Cannot pick for updating.
+		dc.annotateMachinesNotSelectedForUpdate(ctx, oldMachineSets, v1alpha1.NotSelectedForUpdateReasonMinAvailableConstraint, nil)
 		return 0, nil
 	}
 
 	sort.Sort(MachineSetsByCreationTimestamp(oldMachineSets))
 
+	updateOrder := getInPlaceUpdateOrder(deployment)
+	skipCordon := metav1.HasAnnotation(deployment.ObjectMeta, machineutils.MachineDeploymentInPlaceSkipCordon)
 	totalSelectedForUpdate := int32(0)
 	maxSelectableForUpdate := min(availableMachineCount-minAvailable, max(deployment.Spec.Replicas-newMachineSet.Spec.Replicas, 0))
-	for _, targetMachineSet := range oldMachineSets {
+	if maxConcurrentDrains, ok := getInPlaceMaxConcurrentDrains(deployment); ok {
+		maxSelectableForUpdate = min(maxSelectableForUpdate, max(maxConcurrentDrains-oldMachineSetsMachinesUndergoingUpdate, 0))
+	}
+
+	// shares limits the machines selected per old machine set, nil selects from the oldest machine sets first
+	// handled holds the machines which are selected for update or annotated with another reason below
+	handled := sets.New[string]()
+
+	var shares []int32
+	if getInPlaceUpdateSelection(deployment) == machineutils.InPlaceUpdateSelectionProportional {
+		shares = getProportionalShares(oldMachineSets, maxSelectableForUpdate)
+	}
+
+	for i, targetMachineSet := range oldMachineSets {
 		if totalSelectedForUpdate >= maxSelectableForUpdate {
 			// No further updating required.
 			break
@@ -448,12 +761,17 @@ func (dc *controller) selectNumOfMachineForUpdate(ctx context.Context, allMachin
 		}
 		// prepare for update
 		readyForUpdateCount := integer.Int32Min(targetMachineSet.Spec.Replicas, maxSelectableForUpdate-totalSelectedForUpdate) // #nosec G115 (CWE-190) -- value already validated
+		if shares != nil {
+			if readyForUpdateCount = integer.Int32Min(readyForUpdateCount, shares[i]); readyForUpdateCount == 0 {
+				continue
+			}
+		}
 		newReplicasCount := targetMachineSet.Spec.Replicas - readyForUpdateCount
 
 		if newReplicasCount > targetMachineSet.Spec.Replicas {
 			return 0, fmt.Errorf("when selecting machine from old IS for update, got invalid request %s %d -> %d", targetMachineSet.Name, targetMachineSet.Spec.Replicas, newReplicasCount)
 		}
-		selectedFromCurrentMachineSet, err := dc.labelMachinesToSelectedForUpdate(ctx, targetMachineSet, readyForUpdateCount)
+		selectedFromCurrentMachineSet, err := dc.labelMachinesToSelectedForUpdate(ctx, targetMachineSet, readyForUpdateCount, updateOrder, skipCordon, handled)
 		if err != nil {
 			return totalSelectedForUpdate + selectedFromCurrentMachineSet, err
 		}
@@ -461,6 +779,9 @@ func (dc *controller) selectNumOfMachineForUpdate(ctx context.Context, allMachin
 		totalSelectedForUpdate += selectedFromCurrentMachineSet
 	}
 
+	// the remaining candidates can't be selected without violating the minimum number of available machines
+	dc.annotateMachinesNotSelectedForUpdate(ctx, oldMachineSets, v1alpha1.NotSelectedForUpdateReasonMinAvailableConstraint, handled)
+
 	return totalSelectedForUpdate, nil
 }
 
@@ -508,19 +829,93 @@ func (dc *controller) labelNodeForMachine(ctx context.Context, machine *v1alpha1
 		return nil
 	}
 
-	nodeCopy := node.DeepCopy()
-	nodeCopy.Labels = labelsutil.AddLabel(nodeCopy.Labels, labelKey, labelValue)
-	if _, err := dc.targetCoreClient.CoreV1().Nodes().Update(ctx, nodeCopy, metav1.UpdateOptions{}); err != nil {
+	// the node is updated frequently by the kubelet, hence the update is retried on conflicts with the latest node
+	firstTry := true
+	return clientretry.RetryOnConflict(nodeops.Backoff, func() error {
+		if firstTry {
+			firstTry = false
+		} else if node, err = dc.targetCoreClient.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		} else if node.Labels[labelKey] == labelValue {
+			return nil
+		}
+
+		nodeCopy := node.DeepCopy()
+		nodeCopy.Labels = labelsutil.AddLabel(nodeCopy.Labels, labelKey, labelValue)
+		_, err := dc.targetCoreClient.CoreV1().Nodes().Update(ctx, nodeCopy, metav1.UpdateOptions{})
 		return err
+	})
+}
+
+// reconcileHalfAppliedSelectionForUpdate converges the state of the machines of the machine sets and their nodes which
+// the selection for update left half-applied, e.g. as MCM crashed in between. The selection annotates the machine
+// before it labels the node, and the label triggers the drain which cordons the node, hence
+//   - a machine annotated to skip the cordon whose node isn't selected for update is rolled back, so that it is
+//     selected again respecting maxUnavailable,
+//   - a machine whose node is selected for update loses the reason for not being selected for update,
+//   - a node which is drained for its update, but isn't cordoned although the cordon isn't skipped, is cordoned.
+func (dc *controller) reconcileHalfAppliedSelectionForUpdate(ctx context.Context, machineSets []*v1alpha1.MachineSet) error {
+	for _, machineSet := range machineSets {
+		machines, err := dc.machineLister.List(labels.SelectorFromSet(machineSet.Spec.Selector.MatchLabels))
+		if err != nil {
+			return err
+		}
+
+		for _, machine := range machines {
+			if machine.Labels[v1alpha1.NodeLabelKey] == "" {
+				continue
+			}
+
+			node, err := dc.nodeLister.Get(machine.Labels[v1alpha1.NodeLabelKey])
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+
+			if !metav1.HasLabel(node.ObjectMeta, v1alpha1.LabelKeyNodeSelectedForUpdate) {
+				if isInPlaceUpdateCordonSkipped(machine) && !metav1.HasLabel(node.ObjectMeta, v1alpha1.LabelKeyNodeUpdateResult) {
+					klog.V(2).Infof("Rolling back selection for update of machine %q as its node %q isn't labeled with %q", machine.Name, node.Name, v1alpha1.LabelKeyNodeSelectedForUpdate)
+					if err := dc.setInPlaceUpdateSkipCordon(ctx, machine, false); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			if metav1.HasAnnotation(machine.ObjectMeta, v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason) {
+				klog.V(2).Infof("Completing selection for update of machine %q as its node %q is labeled with %q", machine.Name, node.Name, v1alpha1.LabelKeyNodeSelectedForUpdate)
+				if err := dc.removeMachineNotSelectedForUpdateReason(ctx, machine); err != nil {
+					return err
+				}
+			}
+
+			if cond := nodeops.GetCondition(node, v1alpha1.NodeInPlaceUpdate); cond != nil &&
+				(cond.Reason == v1alpha1.DrainSuccessful || cond.Reason == v1alpha1.ReadyForUpdate) &&
+				!node.Spec.Unschedulable && !isInPlaceUpdateCordonSkipped(machine) {
+				klog.V(2).Infof("Cordoning node %q of machine %q as it is drained for its in-place update, but isn't cordoned", node.Name, machine.Name)
+				nodeCopy := node.DeepCopy()
+				nodeCopy.Spec.Unschedulable = true
+				if _, err := dc.targetCoreClient.CoreV1().Nodes().Update(ctx, nodeCopy, metav1.UpdateOptions{}); err != nil {
+					return fmt.Errorf("failed to cordon node %s: %w", node.Name, err)
+				}
+			}
+		}
 	}
 
 	return nil
 }
 
-func (dc *controller) labelMachinesToSelectedForUpdate(ctx context.Context, machineSet *v1alpha1.MachineSet, drainCount int32) (int32, error) {
+// labelMachinesToSelectedForUpdate selects up to drainCount machines of the machine set for update. The names of the
+// machines which are selected or wait for the acknowledgment of their drain preview are inserted into handled.
+func (dc *controller) labelMachinesToSelectedForUpdate(ctx context.Context, machineSet *v1alpha1.MachineSet, drainCount int32, updateOrder string, skipCordon bool, handled sets.Set[string]) (int32, error) {
 	numOfMachinesSelectedForUpdate := int32(0)
 
-	machines, err := dc.getMachinesForDrain(machineSet, drainCount)
+	machines, err := dc.getMachinesForDrain(machineSet, drainCount, updateOrder)
 	if err != nil {
 		return numOfMachinesSelectedForUpdate, err
 	}
@@ -528,16 +923,191 @@ func (dc *controller) labelMachinesToSelectedForUpdate(ctx context.Context, mach
 	klog.V(3).Infof("machines selected for drain %v", machines)
 
 	for _, machine := range machines {
+		acknowledged, err := dc.previewDrainOfMachine(ctx, machine)
+		if err != nil {
+			return numOfMachinesSelectedForUpdate, err
+		}
+		handled.Insert(machine.Name)
+		if !acknowledged {
+			klog.V(3).Infof("Machine %q is not selected for update as its drain preview hasn't been acknowledged yet", machine.Name)
+			continue
+		}
+		// the machine is annotated before its node is labeled, as the label triggers the drain by the machine controller
+		if err := dc.setInPlaceUpdateSkipCordon(ctx, machine, skipCordon); err != nil {
+			return numOfMachinesSelectedForUpdate, err
+		}
 		// labels on the node are added cumulatively and we can find both candidate-for-update and selected-for-update labels on the node.
 		if err := dc.labelNodeForMachine(ctx, machine, v1alpha1.LabelKeyNodeSelectedForUpdate, "true"); err != nil {
 			return numOfMachinesSelectedForUpdate, err
 		}
+		if err := dc.removeMachineNotSelectedForUpdateReason(ctx, machine); err != nil {
+			klog.Warningf("Failed to remove the reason for not selecting machine %q for update: %v", machine.Name, err)
+		}
 		numOfMachinesSelectedForUpdate++
 	}
 
 	return numOfMachinesSelectedForUpdate, nil
 }
 
+// setInPlaceUpdateSkipCordon sets or removes the machineutils.MachineInPlaceUpdateSkipCordon annotation on the machine,
+// so that it reflects whether the node of the machine is cordoned for its current in-place update
+func (dc *controller) setInPlaceUpdateSkipCordon(ctx context.Context, machine *v1alpha1.Machine, skipCordon bool) error {
+	if isInPlaceUpdateCordonSkipped(machine) == skipCordon {
+		return nil
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, machineutils.MachineInPlaceUpdateSkipCordon)
+	if skipCordon {
+		patch = fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}}}`, machineutils.MachineInPlaceUpdateSkipCordon)
+	}
+	return dc.machineControl.PatchMachine(ctx, machine.Namespace, machine.Name, []byte(patch))
+}
+
+// isInPlaceUpdateCordonSkipped returns true if the node of the machine isn't cordoned for its in-place update
+func isInPlaceUpdateCordonSkipped(machine *v1alpha1.Machine) bool {
+	return machine.Annotations[machineutils.MachineInPlaceUpdateSkipCordon] == "true"
+}
+
+// previewDrainOfMachine stores the pods which the drain of the node of the machine would evict in the
+// v1alpha1.AnnotationKeyMachineDrainPreview annotation on the machine, and the hash of all of them in the
+// v1alpha1.AnnotationKeyMachineDrainPreviewHash annotation. It returns whether the machine can be selected for update,
+// which requires the acknowledgment of the preview by an operator if InPlaceUpdateRequireDrainPreviewAck is set.
+// An acknowledgment only holds as long as it names the hash of the current preview, so it goes stale once the pods change.
+func (dc *controller) previewDrainOfMachine(ctx context.Context, machine *v1alpha1.Machine) (bool, error) {
+	if dc.podLister == nil {
+		return true, nil
+	}
+
+	pods, err := drain.DryRun(dc.podLister, machine.Labels[v1alpha1.NodeLabelKey])
+	if err != nil {
+		return false, err
+	}
+	preview, hash := formatDrainPreview(pods)
+	previewUnchanged := machine.Annotations[v1alpha1.AnnotationKeyMachineDrainPreview] == preview &&
+		machine.Annotations[v1alpha1.AnnotationKeyMachineDrainPreviewHash] == hash
+
+	acknowledged := !dc.safetyOptions.InPlaceUpdateRequireDrainPreviewAck || machine.Annotations[v1alpha1.AnnotationKeyMachineDrainPreviewAcknowledged] == hash
+	if acknowledged {
+		if previewUnchanged {
+			return true, nil
+		}
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q,%q:%q}}}`,
+			v1alpha1.AnnotationKeyMachineDrainPreview, preview,
+			v1alpha1.AnnotationKeyMachineDrainPreviewHash, hash)
+		return true, dc.machineControl.PatchMachine(ctx, machine.Namespace, machine.Name, []byte(patch))
+	}
+
+	if previewUnchanged &&
+		machine.Annotations[v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason] == v1alpha1.NotSelectedForUpdateReasonDrainPreviewNotAcknowledged {
+		return false, nil
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q,%q:%q,%q:%q}}}`,
+		v1alpha1.AnnotationKeyMachineDrainPreview, preview,
+		v1alpha1.AnnotationKeyMachineDrainPreviewHash, hash,
+		v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason, v1alpha1.NotSelectedForUpdateReasonDrainPreviewNotAcknowledged)
+	return false, dc.machineControl.PatchMachine(ctx, machine.Namespace, machine.Name, []byte(patch))
+}
+
+// maxDrainPreviewPods is the maximum number of pods named in the drain preview of a machine
+const maxDrainPreviewPods = 10
+
+// formatDrainPreview returns the number of the pods and their sorted names, limited to maxDrainPreviewPods,
+// so that the preview only changes with the pods, along with the hash of the names of all pods
+func formatDrainPreview(pods []v1.Pod) (string, string) {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	slices.Sort(names)
+
+	hasher := fnv.New32a()
+	for _, name := range names {
+		// the names are separated by a newline, which they can't contain
+		_, _ = hasher.Write([]byte(name + "\n"))
+	}
+	hash := rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
+
+	if len(names) > maxDrainPreviewPods {
+		names = append(names[:maxDrainPreviewPods], fmt.Sprintf("and %d more", len(pods)-maxDrainPreviewPods))
+	}
+
+	preview := fmt.Sprintf("%d pod(s) would be evicted", len(pods))
+	if len(names) > 0 {
+		preview += ": " + strings.Join(names, ", ")
+	}
+	return preview, hash
+}
+
+// annotateMachinesNotSelectedForUpdate sets the reason why they haven't been selected for update on the machines
+// of the old machine sets which are candidates for update, except for the ones named in skip. Failures are only logged
+// as the reason is informational.
+func (dc *controller) annotateMachinesNotSelectedForUpdate(ctx context.Context, oldMachineSets []*v1alpha1.MachineSet, reason string, skip sets.Set[string]) {
+	for _, machineSet := range oldMachineSets {
+		machines, err := dc.machineLister.List(labels.SelectorFromSet(machineSet.Spec.Selector.MatchLabels))
+		if err != nil {
+			klog.Warningf("Failed to list machines of machine set %q to annotate the reason for not selecting them for update: %v", machineSet.Name, err)
+			return
+		}
+
+		for _, machine := range machines {
+			if machine.Labels[v1alpha1.NodeLabelKey] == "" || skip.Has(machine.Name) {
+				continue
+			}
+			node, err := dc.nodeLister.Get(machine.Labels[v1alpha1.NodeLabelKey])
+			if err != nil {
+				continue
+			}
+			if _, ok := node.Labels[v1alpha1.LabelKeyNodeCandidateForUpdate]; !ok {
+				continue
+			}
+			if _, ok := node.Labels[v1alpha1.LabelKeyNodeSelectedForUpdate]; ok {
+				continue
+			}
+			if err := dc.setMachineNotSelectedForUpdateReason(ctx, machine, reason); err != nil {
+				klog.Warningf("Failed to set the reason for not selecting machine %q for update: %v", machine.Name, err)
+			}
+		}
+	}
+}
+
+// setMachineNotSelectedForUpdateReason sets the v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason annotation on the machine
+func (dc *controller) setMachineNotSelectedForUpdateReason(ctx context.Context, machine *v1alpha1.Machine, reason string) error {
+	if machine.Annotations[v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason] == reason {
+		return nil
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason, reason)
+	return dc.machineControl.PatchMachine(ctx, machine.Namespace, machine.Name, []byte(patch))
+}
+
+// removeMachineNotSelectedForUpdateReason removes the v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason annotation
+// from the machine
+func (dc *controller) removeMachineNotSelectedForUpdateReason(ctx context.Context, machine *v1alpha1.Machine) error {
+	if !metav1.HasAnnotation(machine.ObjectMeta, v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason) {
+		return nil
+	}
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, v1alpha1.AnnotationKeyMachineNotSelectedForUpdateReason)
+	return dc.machineControl.PatchMachine(ctx, machine.Namespace, machine.Name, []byte(patch))
+}
+
+// getMachinesWithFailedUpdate returns the number of machines of the machine sets whose node is labeled with a failed
+// in-place update result.
+func (dc *controller) getMachinesWithFailedUpdate(machineSets []*v1alpha1.MachineSet) (int32, error) {
+	machinesWithFailedUpdate := int32(0)
+	for _, machineSet := range machineSets {
+		machines, err := dc.machineLister.List(labels.SelectorFromSet(machineSet.Spec.Selector.MatchLabels))
+		if err != nil {
+			return machinesWithFailedUpdate, err
+		}
+
+		for _, machine := range machines {
+			if node := dc.getNodeOfMachine(machine); node != nil && node.Labels[v1alpha1.LabelKeyNodeUpdateResult] == v1alpha1.LabelValueNodeUpdateFailed {
+				machinesWithFailedUpdate++
+			}
+		}
+	}
+
+	return machinesWithFailedUpdate, nil
+}
+
 func (dc *controller) getMachinesUndergoingUpdate(oldMachineSets []*v1alpha1.MachineSet) (int32, error) {
 	machineInUpdateProcess := int32(0)
 	for _, machineSet := range oldMachineSets {
@@ -565,13 +1135,190 @@ func (dc *controller) getMachinesUndergoingUpdate(oldMachineSets []*v1alpha1.Mac
 	return machineInUpdateProcess, nil
 }
 
-func (dc *controller) getMachinesForDrain(machineSet *v1alpha1.MachineSet, readyForDrain int32) ([]*v1alpha1.Machine, error) {
+// syncInPlaceUpdateProgressingCondition sets the InPlaceUpdateProgressing condition in the status of the deployment
+// to the phase of its in-place update. Its LastTransitionTime only changes with its reason. The condition isn't
+// added to deployments which aren't updated in place, and failures to determine the phase are only logged.
+func (dc *controller) syncInPlaceUpdateProgressingCondition(allMachineSets []*v1alpha1.MachineSet, newMachineSet *v1alpha1.MachineSet, deployment *v1alpha1.MachineDeployment, status *v1alpha1.MachineDeploymentStatus) {
+	var oldMachineSets []*v1alpha1.MachineSet
+	for _, machineSet := range allMachineSets {
+		if newMachineSet == nil || machineSet.Name != newMachineSet.Name {
+			oldMachineSets = append(oldMachineSets, machineSet)
+		}
+	}
+	oldMachineSets = FilterActiveMachineSets(oldMachineSets)
+
+	currentCond := GetMachineDeploymentCondition(*status, v1alpha1.MachineDeploymentInPlaceUpdateProgressing)
+	if len(oldMachineSets) == 0 && currentCond == nil {
+		return
+	}
+
+	var condition *v1alpha1.MachineDeploymentCondition
+	if len(oldMachineSets) == 0 {
+		if status.UpdatedReplicas != deployment.Spec.Replicas {
+			// the new machine set is still scaled, e.g. after the deployment was scaled to zero, keep the last phase
+			return
+		}
+		condition = NewMachineDeploymentCondition(v1alpha1.MachineDeploymentInPlaceUpdateProgressing, v1alpha1.ConditionFalse, InPlaceUpdateCompletedReason,
+			fmt.Sprintf("All %d machine(s) are updated in place.", status.UpdatedReplicas))
+	} else {
+		selected, updated, err := dc.getInPlaceUpdateProgress(oldMachineSets)
+		if err != nil {
+			klog.Warningf("Failed to determine the in-place update progress of MachineDeployment %q: %v", deployment.Name, err)
+			return
+		}
+		switch {
+		case updated > 0:
+			condition = NewMachineDeploymentCondition(v1alpha1.MachineDeploymentInPlaceUpdateProgressing, v1alpha1.ConditionTrue, InPlaceUpdateTransferringOwnershipReason,
+				fmt.Sprintf("%d updated machine(s) are transferred to the new machine set.", updated))
+		case selected > 0:
+			condition = NewMachineDeploymentCondition(v1alpha1.MachineDeploymentInPlaceUpdateProgressing, v1alpha1.ConditionTrue, InPlaceUpdateDrainingMachinesReason,
+				fmt.Sprintf("%d machine(s) are drained and updated.", selected))
+		default:
+			condition = NewMachineDeploymentCondition(v1alpha1.MachineDeploymentInPlaceUpdateProgressing, v1alpha1.ConditionTrue, InPlaceUpdateLabelingNodesReason,
+				fmt.Sprintf("%d machine(s) of old machine sets are labeled as candidates for the update.", GetReplicaCountForMachineSets(oldMachineSets)))
+		}
+	}
+
+	if currentCond != nil {
+		if currentCond.Reason == condition.Reason && currentCond.Status == condition.Status && currentCond.Message == condition.Message {
+			return
+		}
+		if currentCond.Reason == condition.Reason {
+			condition.LastTransitionTime = currentCond.LastTransitionTime
+		}
+	}
+	RemoveMachineDeploymentCondition(status, v1alpha1.MachineDeploymentInPlaceUpdateProgressing)
+	SetMachineDeploymentCondition(status, *condition)
+}
+
+// getInPlaceUpdateProgress returns the number of machines of the old machine sets which are selected for the update,
+// and the number of those which were updated successfully, according to the labels of their nodes
+func (dc *controller) getInPlaceUpdateProgress(oldMachineSets []*v1alpha1.MachineSet) (selected, updated int32, err error) {
+	for _, machineSet := range oldMachineSets {
+		machines, err := dc.machineLister.List(labels.SelectorFromSet(machineSet.Spec.Selector.MatchLabels))
+		if err != nil {
+			return 0, 0, err
+		}
+
+		for _, machine := range machines {
+			if machine.Labels[v1alpha1.NodeLabelKey] == "" {
+				continue
+			}
+			node, err := dc.nodeLister.Get(machine.Labels[v1alpha1.NodeLabelKey])
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return 0, 0, err
+			}
+
+			if node.Labels[v1alpha1.LabelKeyNodeUpdateResult] == v1alpha1.LabelValueNodeUpdateSuccessful {
+				updated++
+			} else if _, ok := node.Labels[v1alpha1.LabelKeyNodeSelectedForUpdate]; ok {
+				selected++
+			}
+		}
+	}
+	return selected, updated, nil
+}
+
+// getInPlaceMinAvailable returns the minimum number of machines of the deployment which have to be available during an
+// in-place update, i.e. the larger one of the minimum derived from MaxUnavailable and the MinAvailableAbsolute.
+func getInPlaceMinAvailable(deployment *v1alpha1.MachineDeployment) int32 {
+	minAvailable := deployment.Spec.Replicas - MaxUnavailable(*deployment)
+	if deployment.Spec.Strategy.InPlaceUpdate != nil && deployment.Spec.Strategy.InPlaceUpdate.MinAvailableAbsolute != nil {
+		minAvailable = max(minAvailable, *deployment.Spec.Strategy.InPlaceUpdate.MinAvailableAbsolute)
+	}
+	return minAvailable
+}
+
+// getInPlaceMaxConcurrentDrains returns the maximum number of machines of the deployment which may undergo an in-place
+// update at the same time, and false if it is unlimited.
+func getInPlaceMaxConcurrentDrains(deployment *v1alpha1.MachineDeployment) (int32, bool) {
+	if deployment.Spec.Strategy.InPlaceUpdate == nil || deployment.Spec.Strategy.InPlaceUpdate.MaxConcurrentDrains == nil {
+		return 0, false
+	}
+	return *deployment.Spec.Strategy.InPlaceUpdate.MaxConcurrentDrains, true
+}
+
+// getInPlaceUpdateOrder returns the valid machineutils.MachineInPlaceUpdateOrder of the deployment, or an empty string if none is set
+func getInPlaceUpdateOrder(deployment *v1alpha1.MachineDeployment) string {
+	updateOrder := deployment.Annotations[machineutils.MachineInPlaceUpdateOrder]
+	switch updateOrder {
+	case "", machineutils.InPlaceUpdateOrderOldestNodeFirst:
+		return updateOrder
+	default:
+		klog.Warningf("Ignoring unknown value %q of annotation %q on MachineDeployment %q", updateOrder, machineutils.MachineInPlaceUpdateOrder, deployment.Name)
+		return ""
+	}
+}
+
+// getInPlaceUpdateSelection returns the valid machineutils.MachineInPlaceUpdateSelection of the deployment, or an empty string if none is set
+func getInPlaceUpdateSelection(deployment *v1alpha1.MachineDeployment) string {
+	selection := deployment.Annotations[machineutils.MachineInPlaceUpdateSelection]
+	switch selection {
+	case "", machineutils.InPlaceUpdateSelectionProportional:
+		return selection
+	default:
+		klog.Warningf("Ignoring unknown value %q of annotation %q on MachineDeployment %q", selection, machineutils.MachineInPlaceUpdateSelection, deployment.Name)
+		return ""
+	}
+}
+
+// getProportionalShares distributes count across the machineSets proportionally to their replicas, capped at their replicas.
+// The rounding remainders go to the machine sets with the largest fractional shares, the earlier machine set winning ties.
+func getProportionalShares(machineSets []*v1alpha1.MachineSet, count int32) []int32 {
+	shares := make([]int32, len(machineSets))
+	totalReplicas := int64(GetReplicaCountForMachineSets(machineSets))
+	if totalReplicas == 0 || count <= 0 {
+		return shares
+	}
+	if int64(count) >= totalReplicas {
+		for i, machineSet := range machineSets {
+			shares[i] = machineSet.Spec.Replicas
+		}
+		return shares
+	}
+
+	remainders := make([]int64, len(machineSets))
+	distributed := int32(0)
+	for i, machineSet := range machineSets {
+		product := int64(count) * int64(machineSet.Spec.Replicas)
+		shares[i] = int32(product / totalReplicas) // #nosec G115 (CWE-190) -- bounded by count
+		remainders[i] = product % totalReplicas
+		distributed += shares[i]
+	}
+
+	byRemainder := make([]int, len(machineSets))
+	for i := range byRemainder {
+		byRemainder[i] = i
+	}
+	sort.SliceStable(byRemainder, func(i, j int) bool {
+		return remainders[byRemainder[i]] > remainders[byRemainder[j]]
+	})
+	for _, i := range byRemainder {
+		if distributed >= count {
+			break
+		}
+		if shares[i] < machineSets[i].Spec.Replicas {
+			shares[i]++
+			distributed++
+		}
+	}
+
+	return shares
+}
+
+// getMachinesForDrain returns up to readyForDrain machines of the machineSet which are candidates for update and not yet
+// selected for update, in the given machineutils.MachineInPlaceUpdateOrder.
+func (dc *controller) getMachinesForDrain(machineSet *v1alpha1.MachineSet, readyForDrain int32, updateOrder string) ([]*v1alpha1.Machine, error) {
 	machines, err := dc.machineLister.List(labels.SelectorFromSet(machineSet.Spec.Selector.MatchLabels))
 	if err != nil {
 		return nil, err
 	}
 
 	var candidateForUpdateMachines []*v1alpha1.Machine
+	nodeCreationTimestamps := make(map[string]metav1.Time)
 	for _, machine := range machines {
 		if machine.Labels[v1alpha1.NodeLabelKey] == "" {
 			continue
@@ -585,13 +1332,27 @@ func (dc *controller) getMachinesForDrain(machineSet *v1alpha1.MachineSet, ready
 		if _, ok := node.Labels[v1alpha1.LabelKeyNodeCandidateForUpdate]; ok {
 			if _, ok := node.Labels[v1alpha1.LabelKeyNodeSelectedForUpdate]; !ok {
 				candidateForUpdateMachines = append(candidateForUpdateMachines, machine)
+				nodeCreationTimestamps[machine.Name] = node.CreationTimestamp
 			}
-			if len(candidateForUpdateMachines) == int(readyForDrain) {
+			if updateOrder == "" && len(candidateForUpdateMachines) == int(readyForDrain) {
 				return candidateForUpdateMachines, nil
 			}
 		}
 	}
 
+	if updateOrder == machineutils.InPlaceUpdateOrderOldestNodeFirst {
+		sort.SliceStable(candidateForUpdateMachines, func(i, j int) bool {
+			iTime, jTime := nodeCreationTimestamps[candidateForUpdateMachines[i].Name], nodeCreationTimestamps[candidateForUpdateMachines[j].Name]
+			if iTime.Equal(&jTime) {
+				return candidateForUpdateMachines[i].Name < candidateForUpdateMachines[j].Name
+			}
+			return iTime.Before(&jTime)
+		})
+		if len(candidateForUpdateMachines) > int(readyForDrain) {
+			candidateForUpdateMachines = candidateForUpdateMachines[:readyForDrain]
+		}
+	}
+
 	return candidateForUpdateMachines, nil
 }
 
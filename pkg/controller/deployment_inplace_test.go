@@ -7,16 +7,28 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 
 	machinev1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	faketyped "github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/typed/machine/v1alpha1/fake"
+	customfake "github.com/gardener/machine-controller-manager/pkg/fakeclient"
+	"github.com/gardener/machine-controller-manager/pkg/util/nodeops"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
 )
 
 var _ = Describe("deployment_inplace", func() {
@@ -382,6 +394,8 @@ var _ = Describe("deployment_inplace", func() {
 		}
 		type expect struct {
 			scaled bool
+			// notSelectedReason is the reason expected on the candidates which haven't been selected for update, none if empty
+			notSelectedReason string
 		}
 		type data struct {
 			setup  setup
@@ -438,7 +452,8 @@ var _ = Describe("deployment_inplace", func() {
 				machines = append(machines, newMachinesFromMachineSet(int(data.setup.oldMachineSetReplicas), oldMachineSet, &machinev1.MachineStatus{}, nil, nil)...)
 				machines = append(machines, newMachinesFromMachineSet(int(data.setup.newMachineSetReplicas), newMachineSet, &machinev1.MachineStatus{}, nil, nil)...)
 				for i, machine := range machines {
-					machine.Labels[machinev1.NodeLabelKey] = fmt.Sprintf("node-%d", i)
+					// machines of a machine set share their labels map, copy it so that every machine maps to its own node
+					machine.Labels = MergeStringMaps(machine.Labels, map[string]string{machinev1.NodeLabelKey: fmt.Sprintf("node-%d", i)})
 				}
 
 				for _, o := range machines {
@@ -472,6 +487,21 @@ var _ = Describe("deployment_inplace", func() {
 				count, err := controller.reconcileOldMachineSetsInPlace(context.TODO(), []*machinev1.MachineSet{oldMachineSet, newMachineSet}, []*machinev1.MachineSet{oldMachineSet}, newMachineSet, deployment)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(count).To(Equal(data.expect.scaled))
+
+				for i := range int(data.setup.oldMachineSetReplicas) {
+					if _, ok := nodes[i].Labels[machinev1.LabelKeyNodeCandidateForUpdate]; !ok {
+						continue
+					}
+					machine, err := controller.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machines[i].Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					updatedNode, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), nodes[i].Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					if _, selected := updatedNode.Labels[machinev1.LabelKeyNodeSelectedForUpdate]; selected || data.expect.notSelectedReason == "" {
+						Expect(machine.Annotations).ToNot(HaveKey(machinev1.AnnotationKeyMachineNotSelectedForUpdateReason))
+					} else {
+						Expect(machine.Annotations).To(HaveKeyWithValue(machinev1.AnnotationKeyMachineNotSelectedForUpdateReason, data.expect.notSelectedReason))
+					}
+				}
 			},
 			Entry("no machines selected for update because there is no machines with candidate for update label", &data{
 				setup: setup{
@@ -496,7 +526,8 @@ var _ = Describe("deployment_inplace", func() {
 					newISAvailableMachines:          0,
 				},
 				expect: expect{
-					scaled: false,
+					scaled:            false,
+					notSelectedReason: machinev1.NotSelectedForUpdateReasonNewIsUnavailable,
 				},
 			}),
 			Entry("no machines selected for update because there is still old replicas undergoing update respecting min available", &data{
@@ -522,7 +553,22 @@ var _ = Describe("deployment_inplace", func() {
 					newISAvailableMachines:          1,
 				},
 				expect: expect{
-					scaled: true,
+					scaled:            true,
+					notSelectedReason: machinev1.NotSelectedForUpdateReasonMinAvailableConstraint,
+				},
+			}),
+			Entry("no machines selected for update because enough old replicas are already undergoing update", &data{
+				setup: setup{
+					oldMachineSetReplicas:           2,
+					oldISAvailableMachines:          2,
+					oldISCandidateForUpdateMachines: 2,
+					oldISSelectedForUpdateMachines:  1,
+					newMachineSetReplicas:           2,
+					newISAvailableMachines:          2,
+				},
+				expect: expect{
+					scaled:            false,
+					notSelectedReason: machinev1.NotSelectedForUpdateReasonAlreadyUndergoingUpdate,
 				},
 			}),
 			Entry("scale down all old machine sets if new machine set already has replicas equal to deployment replicas", &data{
@@ -552,6 +598,287 @@ var _ = Describe("deployment_inplace", func() {
 				},
 			}),
 		)
+
+		It("should let only one of two deployments contending for a single in-place rollout token proceed", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			oldMachineSet.Spec.Replicas = 2
+			oldMachineSet.Status.AvailableReplicas = 2
+			newMachineSet.Spec.Replicas = 1
+			newMachineSet.Status.AvailableReplicas = 1
+
+			deployments := []*machinev1.MachineDeployment{}
+			for _, name := range []string{"deployment-a", "deployment-b"} {
+				d := deployment.DeepCopy()
+				d.Name = name
+				d.Namespace = testNamespace
+				deployments = append(deployments, d)
+			}
+			metav1.SetMetaDataAnnotation(&deployments[0].ObjectMeta, InPlaceRolloutTokenAnnotation, "true")
+
+			controlMachineObjects := []runtime.Object{oldMachineSet, newMachineSet, deployments[0], deployments[1]}
+			machines := []*machinev1.Machine{}
+			machines = append(machines, newMachinesFromMachineSet(2, oldMachineSet, &machinev1.MachineStatus{}, nil, nil)...)
+			machines = append(machines, newMachinesFromMachineSet(1, newMachineSet, &machinev1.MachineStatus{}, nil, nil)...)
+			for i, machine := range machines {
+				machine.Labels[machinev1.NodeLabelKey] = fmt.Sprintf("node-%d", i)
+				controlMachineObjects = append(controlMachineObjects, machine)
+			}
+
+			nodes := newNodes(len(machines), map[string]string{}, &corev1.NodeSpec{}, nil)
+			targetCoreObjects := []runtime.Object{}
+			for i := range machines {
+				nodes[i].Labels = machines[i].Labels
+				if i < 2 {
+					nodes[i].Labels[machinev1.LabelKeyNodeCandidateForUpdate] = "true"
+				}
+				targetCoreObjects = append(targetCoreObjects, nodes[i])
+			}
+
+			controller, trackers := createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects)
+			defer trackers.Stop()
+			controller.safetyOptions.MaxConcurrentInPlaceRollouts = 1
+			waitForCacheSync(stop, controller)
+
+			// deployment-a already holds the only token, so deployment-b has to defer its rollout
+			scaled, err := controller.reconcileOldMachineSetsInPlace(context.TODO(), []*machinev1.MachineSet{oldMachineSet, newMachineSet}, []*machinev1.MachineSet{oldMachineSet}, newMachineSet, deployments[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scaled).To(BeFalse())
+
+			actual, err := controller.controlMachineClient.MachineDeployments(testNamespace).Get(context.TODO(), deployments[1].Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.Annotations).ToNot(HaveKey(InPlaceRolloutTokenAnnotation))
+
+			scaled, err = controller.reconcileOldMachineSetsInPlace(context.TODO(), []*machinev1.MachineSet{oldMachineSet, newMachineSet}, []*machinev1.MachineSet{oldMachineSet}, newMachineSet, deployments[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scaled).To(BeTrue())
+		})
+
+		It("should not grant more in-place rollout tokens than permitted while the token annotations lag behind", func() {
+			tokens := &inPlaceRolloutTokens{}
+
+			acquired, _ := tokens.tryAcquire("test/deployment-a", nil, 1)
+			Expect(acquired).To(BeTrue())
+			// the annotation of deployment-a isn't observed yet
+			acquired, holders := tokens.tryAcquire("test/deployment-b", nil, 1)
+			Expect(acquired).To(BeFalse())
+			Expect(holders).To(ConsistOf("test/deployment-a"))
+			acquired, _ = tokens.tryAcquire("test/deployment-a", []string{"test/deployment-a"}, 1)
+			Expect(acquired).To(BeTrue())
+
+			tokens.release("test/deployment-a")
+			acquired, _ = tokens.tryAcquire("test/deployment-b", nil, 1)
+			Expect(acquired).To(BeTrue())
+		})
+
+		DescribeTable("##releasing the in-place rollout token of an inactive deployment",
+			func(mutate func(*machinev1.MachineDeployment), expectReleased bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				d := deployment.DeepCopy()
+				d.Name = "deployment-a"
+				d.Namespace = testNamespace
+				metav1.SetMetaDataAnnotation(&d.ObjectMeta, InPlaceRolloutTokenAnnotation, "true")
+				mutate(d)
+
+				controller, trackers := createController(stop, testNamespace, []runtime.Object{d}, nil, nil)
+				defer trackers.Stop()
+				controller.safetyOptions.MaxConcurrentInPlaceRollouts = 1
+				waitForCacheSync(stop, controller)
+				controller.inPlaceRolloutTokens.hold(types.NamespacedName{Namespace: d.Namespace, Name: d.Name}.String())
+
+				Expect(controller.releaseInPlaceRolloutTokenIfInactive(context.TODO(), d)).To(Succeed())
+				actual, err := controller.controlMachineClient.MachineDeployments(testNamespace).Get(context.TODO(), d.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				acquired, _ := controller.inPlaceRolloutTokens.tryAcquire(testNamespace+"/other-deployment", nil, 1)
+				if expectReleased {
+					Expect(actual.Annotations).ToNot(HaveKey(InPlaceRolloutTokenAnnotation))
+					Expect(acquired).To(BeTrue())
+				} else {
+					Expect(actual.Annotations).To(HaveKey(InPlaceRolloutTokenAnnotation))
+					Expect(acquired).To(BeFalse())
+				}
+			},
+			Entry("should keep the token of an active rollout", func(_ *machinev1.MachineDeployment) {}, false),
+			Entry("should release the token once the deployment is paused", func(d *machinev1.MachineDeployment) {
+				d.Spec.Paused = true
+			}, true),
+			Entry("should release the token once the strategy changes", func(d *machinev1.MachineDeployment) {
+				d.Spec.Strategy = machinev1.MachineDeploymentStrategy{Type: machinev1.RollingUpdateMachineDeploymentStrategyType}
+			}, true),
+			Entry("should release the token once the orchestration becomes manual", func(d *machinev1.MachineDeployment) {
+				d.Spec.Strategy.InPlaceUpdate.OrchestrationType = machinev1.OrchestrationTypeManual
+			}, true),
+		)
+
+		It("should tear down the rollout if the deployment is scaled to zero mid-rollout", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			oldMachineSet.Spec.Replicas = 2
+			oldMachineSet.Status.AvailableReplicas = 1
+			newMachineSet.Spec.Replicas = 1
+			newMachineSet.Status.AvailableReplicas = 1
+
+			scaledToZeroDeployment := deployment.DeepCopy()
+			scaledToZeroDeployment.Spec.Replicas = 0
+
+			controlMachineObjects := []runtime.Object{oldMachineSet, newMachineSet}
+			machines := []*machinev1.Machine{}
+			machines = append(machines, newMachinesFromMachineSet(2, oldMachineSet, &machinev1.MachineStatus{}, nil, nil)...)
+			machines = append(machines, newMachinesFromMachineSet(1, newMachineSet, &machinev1.MachineStatus{}, nil, nil)...)
+			for i, machine := range machines {
+				// the machines of a machine set share their labels, so copy them to back each machine by its own node
+				machine.Labels = maps.Clone(machine.Labels)
+				machine.Labels[machinev1.NodeLabelKey] = fmt.Sprintf("node-%d", i)
+				controlMachineObjects = append(controlMachineObjects, machine)
+			}
+			machines[1].Annotations = map[string]string{machinev1.AnnotationKeyMachineNotSelectedForUpdateReason: machinev1.NotSelectedForUpdateReasonMinAvailableConstraint}
+
+			// node-0 is cordoned as it is being updated, node-1 is a candidate for update
+			nodes := newNodes(len(machines), map[string]string{}, &corev1.NodeSpec{}, nil)
+			nodes[0].Labels = map[string]string{
+				machinev1.LabelKeyNodeCandidateForUpdate: "true",
+				machinev1.LabelKeyNodeSelectedForUpdate:  "true",
+			}
+			nodes[0].Spec.Unschedulable = true
+			nodes[1].Labels = map[string]string{
+				machinev1.LabelKeyNodeCandidateForUpdate: "true",
+			}
+			targetCoreObjects := []runtime.Object{}
+			for _, node := range nodes {
+				if node.Labels[machinev1.LabelKeyNodeCandidateForUpdate] == "true" {
+					node.Spec.Taints = []corev1.Taint{{Key: PreferNoScheduleKey, Value: "True", Effect: corev1.TaintEffectPreferNoSchedule}}
+				}
+				targetCoreObjects = append(targetCoreObjects, node)
+			}
+
+			controller, trackers := createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects)
+			defer trackers.Stop()
+			waitForCacheSync(stop, controller)
+
+			scaled, err := controller.reconcileNewMachineSetInPlace(context.TODO(), []*machinev1.MachineSet{oldMachineSet}, newMachineSet, scaledToZeroDeployment)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scaled).To(BeTrue())
+
+			scaled, err = controller.reconcileOldMachineSetsInPlace(context.TODO(), []*machinev1.MachineSet{oldMachineSet, newMachineSet}, []*machinev1.MachineSet{oldMachineSet}, newMachineSet, scaledToZeroDeployment)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scaled).To(BeTrue())
+
+			for _, machineSet := range []*machinev1.MachineSet{oldMachineSet, newMachineSet} {
+				actual, err := controller.controlMachineClient.MachineSets(testNamespace).Get(context.TODO(), machineSet.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(actual.Spec.Replicas).To(BeZero())
+			}
+			for _, node := range nodes {
+				actual, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(actual.Labels).ToNot(HaveKey(machinev1.LabelKeyNodeCandidateForUpdate))
+				Expect(actual.Labels).ToNot(HaveKey(machinev1.LabelKeyNodeSelectedForUpdate))
+				Expect(actual.Spec.Unschedulable).To(BeFalse())
+				Expect(actual.Spec.Taints).To(BeEmpty())
+			}
+			actual, err := controller.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machines[1].Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.Annotations).ToNot(HaveKey(machinev1.AnnotationKeyMachineNotSelectedForUpdateReason))
+		})
+	})
+
+	Describe("rolloutInPlace", func() {
+		It("should emit the InPlaceRolloutComplete event exactly once when the rollout completes", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			template := &machinev1.MachineTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"test-label": "test-label"},
+				},
+				Spec: machinev1.MachineSpec{
+					Class: machinev1.ClassSpec{
+						Kind: "MachineClass",
+						Name: "test-machine-class-new",
+					},
+				},
+			}
+			deployment := newMachineDeployment(template, 2, 0, 0, 1, &machinev1.MachineDeploymentStatus{
+				Replicas:          2,
+				UpdatedReplicas:   2,
+				AvailableReplicas: 2,
+			}, nil, map[string]string{
+				InPlaceRolloutStartedAnnotation: time.Now().Add(-5 * time.Minute).UTC().Format(time.RFC3339),
+			}, nil)
+			deployment.UID = "deployment-uid"
+			deployment.Spec.Strategy = machinev1.MachineDeploymentStrategy{
+				Type: machinev1.InPlaceUpdateMachineDeploymentStrategyType,
+				InPlaceUpdate: &machinev1.InPlaceUpdateMachineDeployment{
+					UpdateConfiguration: machinev1.UpdateConfiguration{
+						MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+						MaxSurge:       ptr.To(intstr.FromInt32(0)),
+					},
+				},
+			}
+			owner := &metav1.OwnerReference{APIVersion: "machine.sapcloud.io/v1alpha1", Kind: "MachineDeployment", Name: deployment.Name, UID: deployment.UID, Controller: ptr.To(true)}
+
+			oldTemplate := template.DeepCopy()
+			oldTemplate.Spec.Class.Name = "test-machine-class-old"
+			oldMachineSet := newMachineSet(oldTemplate, "machineset-old", 0, 0, &machinev1.MachineSetStatus{}, owner, map[string]string{RevisionAnnotation: "1"}, nil)
+			oldMachineSet.UID = "machineset-old-uid"
+			newMachineSet := newMachineSet(template, "machineset-new", 2, 0, &machinev1.MachineSetStatus{
+				Replicas:          2,
+				ReadyReplicas:     2,
+				AvailableReplicas: 2,
+			}, owner, map[string]string{RevisionAnnotation: "2"}, nil)
+			newMachineSet.UID = "machineset-new-uid"
+			for _, machineSet := range []*machinev1.MachineSet{oldMachineSet, newMachineSet} {
+				machineSet.Spec.Selector.MatchLabels[machinev1.DefaultMachineDeploymentUniqueLabelKey] = string(machineSet.UID)
+				machineSet.Spec.Template.Labels = maps.Clone(machineSet.Spec.Template.Labels)
+				machineSet.Spec.Template.Labels[machinev1.DefaultMachineDeploymentUniqueLabelKey] = string(machineSet.UID)
+			}
+
+			controlMachineObjects := []runtime.Object{deployment, oldMachineSet, newMachineSet}
+			targetCoreObjects := []runtime.Object{}
+			machines := newMachinesFromMachineSet(2, newMachineSet, &machinev1.MachineStatus{CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineRunning}}, nil, nil)
+			nodes := newNodes(len(machines), map[string]string{}, &corev1.NodeSpec{}, nil)
+			// the in-place update of the second machine failed
+			nodes[1].Labels = map[string]string{machinev1.LabelKeyNodeUpdateResult: machinev1.LabelValueNodeUpdateFailed}
+			for i, machine := range machines {
+				machine.Labels = maps.Clone(machine.Labels)
+				machine.Labels[machinev1.NodeLabelKey] = nodes[i].Name
+				controlMachineObjects = append(controlMachineObjects, machine)
+				targetCoreObjects = append(targetCoreObjects, nodes[i])
+			}
+
+			controller, trackers := createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects)
+			defer trackers.Stop()
+			recorder := record.NewFakeRecorder(100)
+			controller.recorder = recorder
+			waitForCacheSync(stop, controller)
+
+			completeEvents := 0
+			for range 2 {
+				d, err := controller.controlMachineClient.MachineDeployments(testNamespace).Get(context.TODO(), deployment.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				machineSets := []*machinev1.MachineSet{oldMachineSet, newMachineSet}
+				machineMap, err := controller.getMachineMapForMachineDeployment(d, machineSets)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(controller.rolloutInPlace(context.TODO(), d, machineSets, machineMap)).To(Succeed())
+
+				for len(recorder.Events) > 0 {
+					if event := <-recorder.Events; strings.Contains(event, InPlaceRolloutCompleteReason) {
+						Expect(event).To(ContainSubstring("with 2 machine(s) updated and 1 failed update(s)"))
+						completeEvents++
+					}
+				}
+			}
+			Expect(completeEvents).To(Equal(1))
+
+			actual, err := controller.controlMachineClient.MachineDeployments(testNamespace).Get(context.TODO(), deployment.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.Annotations).ToNot(HaveKey(InPlaceRolloutStartedAnnotation))
+		})
 	})
 
 	Describe("selectNumOfMachineForUpdate", func() {
@@ -705,6 +1032,252 @@ var _ = Describe("deployment_inplace", func() {
 		)
 	})
 
+	Describe("selectNumOfMachineForUpdate with an absolute minimum of available machines", func() {
+		DescribeTable("##table",
+			func(minAvailableAbsolute *int32, expectedCount int32) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineSets := newMachineSets(
+					2,
+					&machinev1.MachineTemplateSpec{
+						Spec: machinev1.MachineSpec{
+							Class: machinev1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "test-machine-class",
+							},
+						},
+					}, 1, 500, nil, nil, nil, nil,
+				)
+				oldMachineSet, newMachineSet := machineSets[0], machineSets[1]
+				oldMachineSet.Spec.Template.Labels = map[string]string{"machineset": "old"}
+				oldMachineSet.Spec.Selector.MatchLabels = oldMachineSet.Spec.Template.Labels
+				oldMachineSet.Spec.Replicas, oldMachineSet.Status.AvailableReplicas = 3, 3
+				newMachineSet.Spec.Template.Labels = map[string]string{"machineset": "new"}
+				newMachineSet.Spec.Selector.MatchLabels = newMachineSet.Spec.Template.Labels
+				newMachineSet.Spec.Replicas, newMachineSet.Status.AvailableReplicas = 1, 1
+
+				deployment := &machinev1.MachineDeployment{
+					Spec: machinev1.MachineDeploymentSpec{
+						Replicas: int32(4),
+						Strategy: machinev1.MachineDeploymentStrategy{
+							Type: machinev1.InPlaceUpdateMachineDeploymentStrategyType,
+							InPlaceUpdate: &machinev1.InPlaceUpdateMachineDeployment{
+								UpdateConfiguration: machinev1.UpdateConfiguration{
+									MaxUnavailable: ptr.To(intstr.FromString("50%")),
+									MaxSurge:       ptr.To(intstr.FromInt32(0)),
+								},
+								MinAvailableAbsolute: minAvailableAbsolute,
+							},
+						},
+					},
+				}
+
+				controlMachineObjects := []runtime.Object{oldMachineSet, newMachineSet}
+				targetCoreObjects := []runtime.Object{}
+				for _, machineSet := range []*machinev1.MachineSet{oldMachineSet, newMachineSet} {
+					for _, machine := range newMachinesFromMachineSet(int(machineSet.Spec.Replicas), machineSet, &machinev1.MachineStatus{}, nil, nil) {
+						nodeName := "node-" + machine.Name
+						machine.Labels = maps.Clone(machine.Labels)
+						machine.Labels[machinev1.NodeLabelKey] = nodeName
+						nodeLabels := map[string]string{}
+						if machineSet == oldMachineSet {
+							nodeLabels[machinev1.LabelKeyNodeCandidateForUpdate] = "true"
+						}
+						controlMachineObjects = append(controlMachineObjects, machine)
+						targetCoreObjects = append(targetCoreObjects, &corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{Name: nodeName, Labels: nodeLabels},
+						})
+					}
+				}
+
+				controller, trackers := createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				count, err := controller.selectNumOfMachineForUpdate(context.TODO(), []*machinev1.MachineSet{oldMachineSet, newMachineSet}, []*machinev1.MachineSet{oldMachineSet}, newMachineSet, deployment, 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(expectedCount))
+			},
+			Entry("should select as many machines as the percentage of MaxUnavailable allows without an absolute minimum", nil, int32(2)),
+			Entry("should respect the absolute minimum if it is larger than the one derived from MaxUnavailable", ptr.To[int32](3), int32(1)),
+			Entry("should select no machine if the absolute minimum equals the replicas", ptr.To[int32](4), int32(0)),
+			Entry("should ignore the absolute minimum if it is smaller than the one derived from MaxUnavailable", ptr.To[int32](1), int32(2)),
+		)
+	})
+
+	Describe("selectNumOfMachineForUpdate with a maximum of concurrent drains", func() {
+		DescribeTable("##table",
+			func(maxConcurrentDrains *int32, machinesUndergoingUpdate, expectedCount int32) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineSets := newMachineSets(
+					2,
+					&machinev1.MachineTemplateSpec{
+						Spec: machinev1.MachineSpec{
+							Class: machinev1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "test-machine-class",
+							},
+						},
+					}, 1, 500, nil, nil, nil, nil,
+				)
+				oldMachineSet, newMachineSet := machineSets[0], machineSets[1]
+				oldMachineSet.Spec.Template.Labels = map[string]string{"machineset": "old"}
+				oldMachineSet.Spec.Selector.MatchLabels = oldMachineSet.Spec.Template.Labels
+				oldMachineSet.Spec.Replicas, oldMachineSet.Status.AvailableReplicas = 3, 3
+				newMachineSet.Spec.Template.Labels = map[string]string{"machineset": "new"}
+				newMachineSet.Spec.Selector.MatchLabels = newMachineSet.Spec.Template.Labels
+				newMachineSet.Spec.Replicas, newMachineSet.Status.AvailableReplicas = 1, 1
+
+				deployment := &machinev1.MachineDeployment{
+					Spec: machinev1.MachineDeploymentSpec{
+						Replicas: int32(4),
+						Strategy: machinev1.MachineDeploymentStrategy{
+							Type: machinev1.InPlaceUpdateMachineDeploymentStrategyType,
+							InPlaceUpdate: &machinev1.InPlaceUpdateMachineDeployment{
+								UpdateConfiguration: machinev1.UpdateConfiguration{
+									MaxUnavailable: ptr.To(intstr.FromString("100%")),
+									MaxSurge:       ptr.To(intstr.FromInt32(0)),
+								},
+								MaxConcurrentDrains: maxConcurrentDrains,
+							},
+						},
+					},
+				}
+
+				controlMachineObjects := []runtime.Object{oldMachineSet, newMachineSet}
+				targetCoreObjects := []runtime.Object{}
+				for _, machineSet := range []*machinev1.MachineSet{oldMachineSet, newMachineSet} {
+					for _, machine := range newMachinesFromMachineSet(int(machineSet.Spec.Replicas), machineSet, &machinev1.MachineStatus{}, nil, nil) {
+						nodeName := "node-" + machine.Name
+						machine.Labels = maps.Clone(machine.Labels)
+						machine.Labels[machinev1.NodeLabelKey] = nodeName
+						nodeLabels := map[string]string{}
+						if machineSet == oldMachineSet {
+							nodeLabels[machinev1.LabelKeyNodeCandidateForUpdate] = "true"
+						}
+						controlMachineObjects = append(controlMachineObjects, machine)
+						targetCoreObjects = append(targetCoreObjects, &corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{Name: nodeName, Labels: nodeLabels},
+						})
+					}
+				}
+
+				controller, trackers := createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				count, err := controller.selectNumOfMachineForUpdate(context.TODO(), []*machinev1.MachineSet{oldMachineSet, newMachineSet}, []*machinev1.MachineSet{oldMachineSet}, newMachineSet, deployment, machinesUndergoingUpdate)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(expectedCount))
+			},
+			Entry("should select as many machines as MaxUnavailable allows without a maximum of concurrent drains", nil, int32(0), int32(3)),
+			Entry("should select no more machines than the maximum of concurrent drains", ptr.To[int32](2), int32(0), int32(2)),
+			Entry("should count the machines already undergoing update against the maximum", ptr.To[int32](2), int32(1), int32(1)),
+			Entry("should select no machine if the maximum is reached by the machines undergoing update", ptr.To[int32](2), int32(2), int32(0)),
+		)
+	})
+
+	Describe("selectNumOfMachineForUpdate across multiple old machine sets", func() {
+		newOldMachineSet := func(name string, replicas int32, creationTimestamp time.Time) *machinev1.MachineSet {
+			machineSet := newMachineSets(
+				1,
+				&machinev1.MachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"machineset": name},
+					},
+					Spec: machinev1.MachineSpec{
+						Class: machinev1.ClassSpec{
+							Kind: "MachineClass",
+							Name: "test-machine-class",
+						},
+					},
+				}, replicas, 500, &machinev1.MachineSetStatus{AvailableReplicas: replicas}, nil, nil, nil,
+			)[0]
+			machineSet.Name = name
+			machineSet.CreationTimestamp = metav1.NewTime(creationTimestamp)
+			return machineSet
+		}
+
+		DescribeTable("##table",
+			func(selection string, expectedSelected map[string]int) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				now := time.Now()
+				smallerMachineSet := newOldMachineSet("machineset-small", 2, now.Add(-2*time.Hour))
+				largerMachineSet := newOldMachineSet("machineset-large", 4, now.Add(-time.Hour))
+				newMachineSet := newOldMachineSet("machineset-new", 1, now)
+
+				deployment := &machinev1.MachineDeployment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "machinedeployment",
+						Annotations: map[string]string{},
+					},
+					Spec: machinev1.MachineDeploymentSpec{
+						Replicas: int32(7),
+						Strategy: machinev1.MachineDeploymentStrategy{
+							Type: machinev1.InPlaceUpdateMachineDeploymentStrategyType,
+							InPlaceUpdate: &machinev1.InPlaceUpdateMachineDeployment{
+								UpdateConfiguration: machinev1.UpdateConfiguration{
+									MaxUnavailable: ptr.To(intstr.FromInt32(3)),
+									MaxSurge:       ptr.To(intstr.FromInt32(0)),
+								},
+							},
+						},
+					},
+				}
+				if selection != "" {
+					deployment.Annotations[machineutils.MachineInPlaceUpdateSelection] = selection
+				}
+
+				controlMachineObjects := []runtime.Object{smallerMachineSet, largerMachineSet, newMachineSet}
+				targetCoreObjects := []runtime.Object{}
+				machineSetOfNode := map[string]string{}
+				for _, machineSet := range []*machinev1.MachineSet{smallerMachineSet, largerMachineSet, newMachineSet} {
+					for _, machine := range newMachinesFromMachineSet(int(machineSet.Spec.Replicas), machineSet, &machinev1.MachineStatus{}, nil, nil) {
+						nodeName := "node-" + machine.Name
+						machine.Labels = maps.Clone(machine.Labels)
+						machine.Labels[machinev1.NodeLabelKey] = nodeName
+						nodeLabels := map[string]string{}
+						if machineSet != newMachineSet {
+							nodeLabels[machinev1.LabelKeyNodeCandidateForUpdate] = "true"
+						}
+						controlMachineObjects = append(controlMachineObjects, machine)
+						targetCoreObjects = append(targetCoreObjects, &corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{Name: nodeName, Labels: nodeLabels},
+						})
+						machineSetOfNode[nodeName] = machineSet.Name
+					}
+				}
+
+				controller, trackers := createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				count, err := controller.selectNumOfMachineForUpdate(context.TODO(), []*machinev1.MachineSet{smallerMachineSet, largerMachineSet, newMachineSet}, []*machinev1.MachineSet{largerMachineSet, smallerMachineSet}, newMachineSet, deployment, 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(count).To(Equal(int32(3)))
+
+				nodes, err := controller.targetCoreClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				selected := map[string]int{}
+				for _, node := range nodes.Items {
+					if node.Labels[machinev1.LabelKeyNodeSelectedForUpdate] == "true" {
+						selected[machineSetOfNode[node.Name]]++
+					}
+				}
+				Expect(selected).To(Equal(expectedSelected))
+			},
+			Entry("should select the machines of the oldest machine set first by default", "",
+				map[string]int{"machineset-small": 2, "machineset-large": 1}),
+			Entry("should select machines from each machine set proportionally to its replicas", machineutils.InPlaceUpdateSelectionProportional,
+				map[string]int{"machineset-small": 1, "machineset-large": 2}),
+		)
+	})
+
 	Describe("labelNodesBackingMachineSets", func() {
 		type setup struct {
 			nodes       []*corev1.Node
@@ -810,6 +1383,121 @@ var _ = Describe("deployment_inplace", func() {
 		)
 	})
 
+	Describe("reconcileHalfAppliedSelectionForUpdate", func() {
+		const (
+			interruptNodeLabel     = "labeling the node"
+			interruptReasonRemoval = "removing the reason for not selecting the machine"
+			interruptCordon        = "cordoning the drained node"
+		)
+
+		DescribeTable("##interrupted selection for update",
+			func(interrupt string, expectSelected bool) {
+				machineSet := newMachineSets(
+					1,
+					&machinev1.MachineTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "machineset-0",
+						},
+					}, 1, 500, nil, nil, nil, nil,
+				)[0]
+				machine := newMachinesFromMachineSet(1, machineSet, &machinev1.MachineStatus{}, nil, nil)[0]
+				machine.Labels[machinev1.NodeLabelKey] = "node-0"
+				node := newNodes(1, map[string]string{machinev1.LabelKeyNodeCandidateForUpdate: "true"}, &corev1.NodeSpec{}, nil)[0]
+
+				// restart starts MCM on the persisted objects, so that its caches observe all changes of the previous run
+				var (
+					controller *controller
+					stop       chan struct{}
+					trackers   *customfake.FakeObjectTrackers
+				)
+				restart := func() {
+					if controller != nil {
+						var err error
+						machine, err = controller.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						node, err = controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						trackers.Stop()
+						close(stop)
+					}
+					stop = make(chan struct{})
+					controller, trackers = createController(stop, testNamespace, []runtime.Object{machineSet, machine}, nil, []runtime.Object{node})
+					controller.safetyOptions.InPlaceUpdateRequireDrainPreviewAck = interrupt == interruptReasonRemoval
+					waitForCacheSync(stop, controller)
+				}
+				restart()
+				defer func() {
+					trackers.Stop()
+					close(stop)
+				}()
+
+				skipCordon := interrupt == interruptNodeLabel
+				switch interrupt {
+				case interruptNodeLabel:
+					controller.targetCoreClient.(*customfake.Clientset).PrependReactor("update", "nodes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+						return true, nil, fmt.Errorf("MCM crashed")
+					})
+				case interruptReasonRemoval:
+					// the machine waits for the acknowledgment of its drain preview before it is selected
+					selected, err := controller.labelMachinesToSelectedForUpdate(context.TODO(), machineSet, 1, "", skipCordon, sets.New[string]())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(selected).To(BeZero())
+					_, hash := formatDrainPreview(nil)
+					_, err = controller.controlMachineClient.Machines(testNamespace).Patch(context.TODO(), machine.Name, types.MergePatchType,
+						[]byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, machinev1.AnnotationKeyMachineDrainPreviewAcknowledged, hash)), metav1.PatchOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					restart()
+
+					controller.controlMachineClient.(*faketyped.FakeMachineV1alpha1).PrependReactor("patch", "machines", func(action k8stesting.Action) (bool, runtime.Object, error) {
+						if strings.Contains(string(action.(k8stesting.PatchAction).GetPatch()), machinev1.AnnotationKeyMachineNotSelectedForUpdateReason) {
+							return true, nil, fmt.Errorf("MCM crashed")
+						}
+						return false, nil, nil
+					})
+				}
+
+				// the selection for update is interrupted before the drain can cordon the node
+				_, err := controller.labelMachinesToSelectedForUpdate(context.TODO(), machineSet, 1, "", skipCordon, sets.New[string]())
+				if interrupt == interruptNodeLabel {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+				restart()
+
+				if interrupt == interruptCordon {
+					// the drain reports success, but the cordon of the node got lost
+					drainedNode := nodeops.AddOrUpdateCondition(node.DeepCopy(), corev1.NodeCondition{
+						Type:   machinev1.NodeInPlaceUpdate,
+						Status: corev1.ConditionTrue,
+						Reason: machinev1.DrainSuccessful,
+					})
+					_, err = controller.targetCoreClient.CoreV1().Nodes().Update(context.TODO(), drainedNode, metav1.UpdateOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					restart()
+				}
+
+				Expect(controller.reconcileHalfAppliedSelectionForUpdate(context.TODO(), []*machinev1.MachineSet{machineSet})).To(Succeed())
+
+				actualMachine, err := controller.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				actualNode, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(metav1.HasLabel(actualNode.ObjectMeta, machinev1.LabelKeyNodeSelectedForUpdate)).To(Equal(expectSelected))
+				if expectSelected {
+					Expect(actualMachine.Annotations).ToNot(HaveKey(machinev1.AnnotationKeyMachineNotSelectedForUpdateReason))
+				} else {
+					Expect(actualMachine.Annotations).ToNot(HaveKey(machineutils.MachineInPlaceUpdateSkipCordon))
+				}
+				Expect(actualNode.Spec.Unschedulable).To(Equal(interrupt == interruptCordon))
+			},
+			Entry("should roll back the selection of a machine annotated to skip the cordon whose node wasn't labeled", interruptNodeLabel, false),
+			Entry("should complete the selection of a machine whose node was labeled", interruptReasonRemoval, true),
+			Entry("should cordon the node which was drained without its cordon", interruptCordon, true),
+		)
+	})
+
 	Describe("getMachinesUndergoingUpdate", func() {
 		type setup struct {
 			machineSets []*machinev1.MachineSet
@@ -1006,10 +1694,82 @@ var _ = Describe("deployment_inplace", func() {
 		)
 	})
 
+	Describe("syncInPlaceUpdateProgressingCondition", func() {
+		DescribeTable("##table",
+			func(oldReplicas int32, nodeLabels map[string]string, currentReason, expectedReason string, expectedStatus machinev1.ConditionStatus) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				oldMachineSet := newMachineSet(&machinev1.MachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"machineset": "old"}},
+					Spec:       machinev1.MachineSpec{Class: machinev1.ClassSpec{Kind: "MachineClass", Name: "test-machine-class"}},
+				}, "machineset-old", oldReplicas, 500, nil, nil, nil, nil)
+				newMachineSet := newMachineSet(&machinev1.MachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"machineset": "new"}},
+					Spec:       machinev1.MachineSpec{Class: machinev1.ClassSpec{Kind: "MachineClass", Name: "test-machine-class"}},
+				}, "machineset-new", 2-oldReplicas, 500, nil, nil, nil, nil)
+				deployment := newMachineDeployment(&machinev1.MachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"machineset": "new"}},
+				}, 2, 500, 1, 0, nil, nil, nil, nil)
+				deployment.Spec.Strategy.Type = machinev1.InPlaceUpdateMachineDeploymentStrategyType
+
+				controlMachineObjects := []runtime.Object{oldMachineSet, newMachineSet}
+				targetCoreObjects := []runtime.Object{}
+				for i, machine := range newMachinesFromMachineSet(int(oldReplicas), oldMachineSet, &machinev1.MachineStatus{}, nil, nil) {
+					machine.Name = fmt.Sprintf("machine-old-%d", i)
+					machine.Labels[machinev1.NodeLabelKey] = fmt.Sprintf("node-%d", i)
+					controlMachineObjects = append(controlMachineObjects, machine)
+					targetCoreObjects = append(targetCoreObjects, &corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i), Labels: maps.Clone(nodeLabels)},
+					})
+				}
+
+				controller, trackers := createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				lastTransitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+				status := machinev1.MachineDeploymentStatus{UpdatedReplicas: 2 - oldReplicas}
+				if currentReason != "" {
+					status.Conditions = []machinev1.MachineDeploymentCondition{{
+						Type:               machinev1.MachineDeploymentInPlaceUpdateProgressing,
+						Status:             machinev1.ConditionTrue,
+						Reason:             currentReason,
+						LastTransitionTime: lastTransitionTime,
+					}}
+				}
+
+				controller.syncInPlaceUpdateProgressingCondition([]*machinev1.MachineSet{oldMachineSet, newMachineSet}, newMachineSet, deployment, &status)
+
+				cond := GetMachineDeploymentCondition(status, machinev1.MachineDeploymentInPlaceUpdateProgressing)
+				if expectedReason == "" {
+					Expect(cond).To(BeNil())
+					return
+				}
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Reason).To(Equal(expectedReason))
+				Expect(cond.Status).To(Equal(expectedStatus))
+				if currentReason == expectedReason {
+					Expect(cond.LastTransitionTime).To(Equal(lastTransitionTime))
+				} else {
+					Expect(cond.LastTransitionTime.Time).To(BeTemporally(">", lastTransitionTime.Time))
+				}
+			},
+			Entry("should not add the condition to a deployment which isn't updated", int32(0), nil, "", "", machinev1.ConditionStatus("")),
+			Entry("should report the labeling of the nodes before machines are selected for the update", int32(2), map[string]string{machinev1.LabelKeyNodeCandidateForUpdate: "true"}, "", InPlaceUpdateLabelingNodesReason, machinev1.ConditionTrue),
+			Entry("should report the drain of the machines selected for the update", int32(2), map[string]string{machinev1.LabelKeyNodeSelectedForUpdate: "true"}, InPlaceUpdateLabelingNodesReason, InPlaceUpdateDrainingMachinesReason, machinev1.ConditionTrue),
+			Entry("should keep the transition time while the reason doesn't change", int32(2), map[string]string{machinev1.LabelKeyNodeSelectedForUpdate: "true"}, InPlaceUpdateDrainingMachinesReason, InPlaceUpdateDrainingMachinesReason, machinev1.ConditionTrue),
+			Entry("should report the transfer of the updated machines", int32(2), map[string]string{machinev1.LabelKeyNodeSelectedForUpdate: "true", machinev1.LabelKeyNodeUpdateResult: machinev1.LabelValueNodeUpdateSuccessful}, InPlaceUpdateDrainingMachinesReason, InPlaceUpdateTransferringOwnershipReason, machinev1.ConditionTrue),
+			Entry("should report the completion once the old machine sets are scaled to zero", int32(0), nil, InPlaceUpdateTransferringOwnershipReason, InPlaceUpdateCompletedReason, machinev1.ConditionFalse),
+		)
+	})
+
 	Describe("getMachinesForDrain", func() {
 		type setup struct {
-			machineSet *machinev1.MachineSet
-			machines   []*machinev1.Machine
+			machineSet             *machinev1.MachineSet
+			machines               []*machinev1.Machine
+			nodeCreationTimestamps []metav1.Time
+			updateOrder            string
 		}
 		type expect struct {
 			machines []*machinev1.Machine
@@ -1050,6 +1810,9 @@ var _ = Describe("deployment_inplace", func() {
 				for i := range data.setup.machines {
 					nodes[i].Labels = data.setup.machines[i].Labels
 				}
+				for i, creationTimestamp := range data.setup.nodeCreationTimestamps {
+					nodes[i].CreationTimestamp = creationTimestamp
+				}
 
 				targetCoreObjects := []runtime.Object{}
 				for _, o := range nodes {
@@ -1060,7 +1823,7 @@ var _ = Describe("deployment_inplace", func() {
 				defer trackers.Stop()
 				waitForCacheSync(stop, controller)
 
-				machines, err := controller.getMachinesForDrain(data.setup.machineSet, data.action)
+				machines, err := controller.getMachinesForDrain(data.setup.machineSet, data.action, data.setup.updateOrder)
 				if !data.expect.err {
 					Expect(err).To(BeNil())
 				} else {
@@ -1068,6 +1831,11 @@ var _ = Describe("deployment_inplace", func() {
 				}
 
 				Expect(len(machines)).To(Equal(len(data.expect.machines)))
+				if data.setup.updateOrder != "" {
+					for i := range machines {
+						Expect(machines[i].Name).To(Equal(data.expect.machines[i].Name))
+					}
+				}
 			},
 			Entry("select machines for drain", &data{
 				setup: setup{
@@ -1166,6 +1934,67 @@ var _ = Describe("deployment_inplace", func() {
 					err: false,
 				},
 			}),
+			Entry("select the machines with the oldest nodes first with the oldest-node-first order", &data{
+				setup: setup{
+					machineSet: machineSet,
+					machines: []*machinev1.Machine{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-0",
+								Namespace: testNamespace,
+								Labels: map[string]string{
+									machinev1.NodeLabelKey:                   fmt.Sprintf("node-%d", 0),
+									machinev1.LabelKeyNodeCandidateForUpdate: "true",
+								},
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-1",
+								Namespace: testNamespace,
+								Labels: map[string]string{
+									machinev1.NodeLabelKey:                   fmt.Sprintf("node-%d", 1),
+									machinev1.LabelKeyNodeCandidateForUpdate: "true",
+								},
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-2",
+								Namespace: testNamespace,
+								Labels: map[string]string{
+									machinev1.NodeLabelKey:                   fmt.Sprintf("node-%d", 2),
+									machinev1.LabelKeyNodeCandidateForUpdate: "true",
+								},
+							},
+						},
+					},
+					nodeCreationTimestamps: []metav1.Time{
+						metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+						metav1.NewTime(time.Now().Add(-3 * time.Hour)),
+						metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+					},
+					updateOrder: machineutils.InPlaceUpdateOrderOldestNodeFirst,
+				},
+				action: 2,
+				expect: expect{
+					machines: []*machinev1.Machine{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-1",
+								Namespace: testNamespace,
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-2",
+								Namespace: testNamespace,
+							},
+						},
+					},
+					err: false,
+				},
+			}),
 			Entry("select only required count of machines even though more machines can be selected", &data{
 				setup: setup{
 					machineSet: machineSet,
@@ -1302,4 +2131,295 @@ var _ = Describe("deployment_inplace", func() {
 			}),
 		)
 	})
+
+	Describe("labelMachinesToSelectedForUpdate", func() {
+		DescribeTable("##drain preview",
+			func(requireAck bool, acknowledgedPods []string, expectSelected bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineSet := newMachineSets(
+					1,
+					&machinev1.MachineTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "machineset-0",
+						},
+					}, 1, 500, nil, nil, nil, nil,
+				)[0]
+				machine := newMachinesFromMachineSet(1, machineSet, &machinev1.MachineStatus{}, nil, nil)[0]
+				machine.Labels[machinev1.NodeLabelKey] = "node-0"
+				machine.Labels[machinev1.LabelKeyNodeCandidateForUpdate] = "true"
+				if acknowledgedPods != nil {
+					pods := make([]corev1.Pod, 0, len(acknowledgedPods))
+					for _, name := range acknowledgedPods {
+						pods = append(pods, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace}})
+					}
+					_, hash := formatDrainPreview(pods)
+					machine.Annotations = map[string]string{machinev1.AnnotationKeyMachineDrainPreviewAcknowledged: hash}
+				}
+
+				node := newNodes(1, map[string]string{machinev1.LabelKeyNodeCandidateForUpdate: "true"}, &corev1.NodeSpec{}, nil)[0]
+				newPod := func(name, controllerKind string) *corev1.Pod {
+					return &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:            name,
+							Namespace:       testNamespace,
+							OwnerReferences: []metav1.OwnerReference{{Kind: controllerKind, Name: name, Controller: ptr.To(true)}},
+						},
+						Spec: corev1.PodSpec{NodeName: node.Name},
+					}
+				}
+
+				controller, trackers := createController(stop, testNamespace, []runtime.Object{machineSet, machine}, nil,
+					[]runtime.Object{node, newPod("app-0", "ReplicaSet"), newPod("app-1", "ReplicaSet"), newPod("ds-0", "DaemonSet")})
+				defer trackers.Stop()
+				controller.safetyOptions.InPlaceUpdateRequireDrainPreviewAck = requireAck
+				waitForCacheSync(stop, controller)
+
+				selected, err := controller.labelMachinesToSelectedForUpdate(context.TODO(), machineSet, 1, "", false, sets.New[string]())
+				Expect(err).ToNot(HaveOccurred())
+
+				updatedMachine, err := controller.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedMachine.Annotations).To(HaveKeyWithValue(machinev1.AnnotationKeyMachineDrainPreview, "2 pod(s) would be evicted: "+testNamespace+"/app-0, "+testNamespace+"/app-1"))
+				Expect(updatedMachine.Annotations).To(HaveKey(machinev1.AnnotationKeyMachineDrainPreviewHash))
+
+				updatedNode, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				if expectSelected {
+					Expect(selected).To(Equal(int32(1)))
+					Expect(updatedNode.Labels).To(HaveKey(machinev1.LabelKeyNodeSelectedForUpdate))
+					Expect(updatedMachine.Annotations).ToNot(HaveKey(machinev1.AnnotationKeyMachineNotSelectedForUpdateReason))
+				} else {
+					Expect(selected).To(Equal(int32(0)))
+					Expect(updatedNode.Labels).ToNot(HaveKey(machinev1.LabelKeyNodeSelectedForUpdate))
+					Expect(updatedMachine.Annotations).To(HaveKeyWithValue(machinev1.AnnotationKeyMachineNotSelectedForUpdateReason, machinev1.NotSelectedForUpdateReasonDrainPreviewNotAcknowledged))
+				}
+			},
+			Entry("should write the drain preview and select the machine if no acknowledgment is required", false, nil, true),
+			Entry("should write the drain preview and wait for the acknowledgment if it is required", true, nil, false),
+			Entry("should select the machine once its drain preview is acknowledged", true, []string{"app-1", "app-0"}, true),
+			Entry("should wait for a new acknowledgment once the pods of the acknowledged drain preview changed", true, []string{"app-0"}, false),
+		)
+
+		It("should change the drain preview hash with pods which aren't named in the preview", func() {
+			newPods := func(lastName string) []corev1.Pod {
+				pods := []corev1.Pod{}
+				for i := range maxDrainPreviewPods {
+					pods = append(pods, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("app-%d", i), Namespace: testNamespace}})
+				}
+				return append(pods, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: lastName, Namespace: testNamespace}})
+			}
+
+			preview, hash := formatDrainPreview(newPods("db-0"))
+			otherPreview, otherHash := formatDrainPreview(newPods("db-1"))
+			Expect(preview).To(Equal(otherPreview))
+			Expect(hash).ToNot(Equal(otherHash))
+		})
+
+		It("should not patch a machine whose unchanged drain preview waits for the acknowledgment", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			machineSet := newMachineSets(
+				1,
+				&machinev1.MachineTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "machineset-0",
+					},
+				}, 1, 500, nil, nil, nil, nil,
+			)[0]
+			machine := newMachinesFromMachineSet(1, machineSet, &machinev1.MachineStatus{}, nil, nil)[0]
+			machine.Labels[machinev1.NodeLabelKey] = "node-0"
+			_, hash := formatDrainPreview(nil)
+			machine.Annotations = map[string]string{
+				machinev1.AnnotationKeyMachineDrainPreview:               "0 pod(s) would be evicted",
+				machinev1.AnnotationKeyMachineDrainPreviewHash:           hash,
+				machinev1.AnnotationKeyMachineNotSelectedForUpdateReason: machinev1.NotSelectedForUpdateReasonDrainPreviewNotAcknowledged,
+			}
+			node := newNodes(1, map[string]string{machinev1.LabelKeyNodeCandidateForUpdate: "true"}, &corev1.NodeSpec{}, nil)[0]
+
+			controller, trackers := createController(stop, testNamespace, []runtime.Object{machineSet, machine}, nil, []runtime.Object{node})
+			defer trackers.Stop()
+			controller.safetyOptions.InPlaceUpdateRequireDrainPreviewAck = true
+			waitForCacheSync(stop, controller)
+
+			patches := 0
+			controller.controlMachineClient.(*faketyped.FakeMachineV1alpha1).PrependReactor("patch", "machines", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+				patches++
+				return false, nil, nil
+			})
+
+			handled := sets.New[string]()
+			selected, err := controller.labelMachinesToSelectedForUpdate(context.TODO(), machineSet, 1, "", false, handled)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(selected).To(BeZero())
+			Expect(handled.UnsortedList()).To(ConsistOf(machine.Name))
+			Expect(patches).To(BeZero())
+		})
+
+		DescribeTable("##skip cordon",
+			func(skipCordon bool, previouslySkipped bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineSet := newMachineSets(
+					1,
+					&machinev1.MachineTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "machineset-0",
+						},
+					}, 1, 500, nil, nil, nil, nil,
+				)[0]
+				machine := newMachinesFromMachineSet(1, machineSet, &machinev1.MachineStatus{}, nil, nil)[0]
+				machine.Labels[machinev1.NodeLabelKey] = "node-0"
+				if previouslySkipped {
+					machine.Annotations = map[string]string{machineutils.MachineInPlaceUpdateSkipCordon: "true"}
+				}
+				node := newNodes(1, map[string]string{machinev1.LabelKeyNodeCandidateForUpdate: "true"}, &corev1.NodeSpec{}, nil)[0]
+
+				controller, trackers := createController(stop, testNamespace, []runtime.Object{machineSet, machine}, nil, []runtime.Object{node})
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				selected, err := controller.labelMachinesToSelectedForUpdate(context.TODO(), machineSet, 1, "", skipCordon, sets.New[string]())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(selected).To(Equal(int32(1)))
+
+				updatedNode, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedNode.Labels).To(HaveKeyWithValue(machinev1.LabelKeyNodeSelectedForUpdate, "true"))
+				Expect(updatedNode.Spec.Unschedulable).To(BeFalse())
+
+				updatedMachine, err := controller.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				if skipCordon {
+					Expect(updatedMachine.Annotations).To(HaveKeyWithValue(machineutils.MachineInPlaceUpdateSkipCordon, "true"))
+				} else {
+					Expect(updatedMachine.Annotations).ToNot(HaveKey(machineutils.MachineInPlaceUpdateSkipCordon))
+				}
+			},
+			Entry("should annotate the selected machine to skip the cordon of its node", true, false),
+			Entry("should not annotate the selected machine if the cordon isn't skipped", false, false),
+			Entry("should remove the annotation of a previous update which skipped the cordon", false, true),
+		)
+	})
+
+	Describe("labelNodesBackingMachineSets", func() {
+		DescribeTable("##conflicts",
+			func(nodeConflicts int, expectErr bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineSet := newMachineSets(
+					1,
+					&machinev1.MachineTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "machineset-0",
+						},
+					}, 1, 500, nil, nil, nil, nil,
+				)[0]
+				machine := newMachinesFromMachineSet(1, machineSet, &machinev1.MachineStatus{}, nil, nil)[0]
+				machine.Labels[machinev1.NodeLabelKey] = "node-0"
+				node := newNodes(1, nil, &corev1.NodeSpec{}, nil)[0]
+
+				controller, trackers := createController(stop, testNamespace, []runtime.Object{machineSet, machine}, nil, []runtime.Object{node})
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				// the kubelet updating the node concurrently is simulated by conflicts of the first updates
+				controller.targetCoreClient.(*customfake.Clientset).PrependReactor("update", "nodes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+					if nodeConflicts == 0 {
+						return false, nil, nil
+					}
+					nodeConflicts--
+					return true, nil, apierrors.NewConflict(corev1.Resource("nodes"), node.Name, fmt.Errorf("the object has been modified"))
+				})
+
+				err := controller.labelNodesBackingMachineSets(context.TODO(), []*machinev1.MachineSet{machineSet}, machinev1.LabelKeyNodeCandidateForUpdate, "true")
+				if err == nil {
+					err = controller.setMachineNotSelectedForUpdateReason(context.TODO(), machine, machinev1.NotSelectedForUpdateReasonAlreadyUndergoingUpdate)
+				}
+
+				if expectErr {
+					Expect(apierrors.IsConflict(err)).To(BeTrue())
+					return
+				}
+				Expect(err).ToNot(HaveOccurred())
+
+				updatedNode, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				updatedMachine, err := controller.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedNode.Labels).To(HaveKeyWithValue(machinev1.LabelKeyNodeCandidateForUpdate, "true"))
+				Expect(updatedMachine.Annotations).To(HaveKeyWithValue(machinev1.AnnotationKeyMachineNotSelectedForUpdateReason, machinev1.NotSelectedForUpdateReasonAlreadyUndergoingUpdate))
+			},
+			Entry("should label the node without conflicts", 0, false),
+			Entry("should label the node once a conflict of its update is resolved", 1, false),
+			Entry("should label the node despite repeated conflicts of its update", 3, false),
+			Entry("should give up on the node once the conflicts of its update outlast the retries", 10, true),
+		)
+	})
+
+	Describe("uncordon after the in-place update", func() {
+		DescribeTable("##table",
+			func(cordonSkipped bool, expectUnschedulable bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineSets := newMachineSets(
+					2,
+					&machinev1.MachineTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "machineset-0",
+						},
+					}, 1, 500, nil, nil, nil, nil,
+				)
+				oldMachineSet, newMachineSet := machineSets[0], machineSets[1]
+				oldMachineSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"machineset": "old"}}
+				newMachineSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"machineset": "new"}}
+				newMachineSet.Spec.Replicas = 0
+
+				machine := newMachinesFromMachineSet(1, newMachineSet, &machinev1.MachineStatus{
+					Conditions: []corev1.NodeCondition{{Type: machinev1.NodeInPlaceUpdate, Reason: machinev1.UpdateSuccessful}},
+				}, nil, nil)[0]
+				machine.Labels = map[string]string{
+					"machineset":                       "new",
+					machinev1.NodeLabelKey:             "node-0",
+					machinev1.LabelKeyNodeUpdateResult: machinev1.LabelValueNodeUpdateSuccessful,
+				}
+				if cordonSkipped {
+					machine.Annotations = map[string]string{machineutils.MachineInPlaceUpdateSkipCordon: "true"}
+				}
+
+				// the node is cordoned, either for the update or by another actor if the cordon was skipped
+				node := newNodes(1, map[string]string{
+					machinev1.LabelKeyNodeCandidateForUpdate: "true",
+					machinev1.LabelKeyNodeSelectedForUpdate:  "true",
+					machinev1.LabelKeyNodeUpdateResult:       machinev1.LabelValueNodeUpdateSuccessful,
+				}, &corev1.NodeSpec{Unschedulable: true}, nil)[0]
+				node.Status.Conditions = []corev1.NodeCondition{{Type: machinev1.NodeInPlaceUpdate, Reason: machinev1.UpdateSuccessful}}
+
+				deployment := &machinev1.MachineDeployment{
+					Spec: machinev1.MachineDeploymentSpec{
+						Replicas: 1,
+						Strategy: machinev1.MachineDeploymentStrategy{Type: machinev1.InPlaceUpdateMachineDeploymentStrategyType},
+					},
+				}
+
+				controller, trackers := createController(stop, testNamespace, []runtime.Object{oldMachineSet, newMachineSet, machine}, nil, []runtime.Object{node})
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				Expect(controller.syncMachineSets(context.TODO(), []*machinev1.MachineSet{oldMachineSet}, newMachineSet, deployment)).To(Succeed())
+
+				updatedNode, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedNode.Spec.Unschedulable).To(Equal(expectUnschedulable))
+				Expect(updatedNode.Labels).ToNot(HaveKey(machinev1.LabelKeyNodeSelectedForUpdate))
+			},
+			Entry("should uncordon the node cordoned for the update", false, false),
+			Entry("should not uncordon the node if its cordon was skipped for the update", true, true),
+		)
+	})
 })
@@ -31,6 +31,8 @@ import (
 
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	machineapi "github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/typed/machine/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -46,6 +48,10 @@ func updateMachineSetStatus(ctx context.Context, machineClient machineapi.Machin
 		is.Status.FullyLabeledReplicas == newStatus.FullyLabeledReplicas &&
 		is.Status.ReadyReplicas == newStatus.ReadyReplicas &&
 		is.Status.AvailableReplicas == newStatus.AvailableReplicas &&
+		is.Status.CandidateForUpdateReplicas == newStatus.CandidateForUpdateReplicas &&
+		is.Status.SelectedForUpdateReplicas == newStatus.SelectedForUpdateReplicas &&
+		is.Status.UpdateSuccessfulReplicas == newStatus.UpdateSuccessfulReplicas &&
+		is.Status.UpdateFailedReplicas == newStatus.UpdateFailedReplicas &&
 		is.Generation == is.Status.ObservedGeneration &&
 		reflect.DeepEqual(is.Status.Conditions, newStatus.Conditions) &&
 		reflect.DeepEqual(is.Status.FailedMachines, newStatus.FailedMachines) {
@@ -89,7 +95,7 @@ func updateMachineSetStatus(ctx context.Context, machineClient machineapi.Machin
 	return nil, updateErr
 }
 
-func calculateMachineSetStatus(is *v1alpha1.MachineSet, filteredMachines []*v1alpha1.Machine, manageReplicasErr error) v1alpha1.MachineSetStatus {
+func (c *controller) calculateMachineSetStatus(is *v1alpha1.MachineSet, filteredMachines []*v1alpha1.Machine, manageReplicasErr error) v1alpha1.MachineSetStatus {
 	newStatus := is.Status
 	// Count the number of machines that have labels matching the labels of the machine
 	// template of the machine set, the matching machines may have more
@@ -99,6 +105,11 @@ func calculateMachineSetStatus(is *v1alpha1.MachineSet, filteredMachines []*v1al
 	fullyLabeledReplicasCount := 0
 	readyReplicasCount := 0
 	availableReplicasCount := 0
+	// Count the number of machines by the state of their in-place update, as labelled on their nodes
+	candidateForUpdateCount := 0
+	selectedForUpdateCount := 0
+	updateSuccessfulCount := 0
+	updateFailedCount := 0
 
 	failedMachines := []v1alpha1.MachineSummary{}
 	var machineSummary v1alpha1.MachineSummary
@@ -114,6 +125,20 @@ func calculateMachineSetStatus(is *v1alpha1.MachineSet, filteredMachines []*v1al
 				readyReplicasCount++
 			}
 		}
+		if node := c.getNodeOfMachine(machine); node != nil {
+			if metav1.HasLabel(node.ObjectMeta, v1alpha1.LabelKeyNodeCandidateForUpdate) {
+				candidateForUpdateCount++
+			}
+			if metav1.HasLabel(node.ObjectMeta, v1alpha1.LabelKeyNodeSelectedForUpdate) {
+				selectedForUpdateCount++
+			}
+			switch node.Labels[v1alpha1.LabelKeyNodeUpdateResult] {
+			case v1alpha1.LabelValueNodeUpdateSuccessful:
+				updateSuccessfulCount++
+			case v1alpha1.LabelValueNodeUpdateFailed:
+				updateFailedCount++
+			}
+		}
 		if machine.Status.LastOperation.State == v1alpha1.MachineStateFailed {
 			machineSummary.Name = machine.Name
 			machineSummary.ProviderID = machine.Spec.ProviderID
@@ -150,14 +175,35 @@ func calculateMachineSetStatus(is *v1alpha1.MachineSet, filteredMachines []*v1al
 		RemoveCondition(&newStatus, v1alpha1.MachineSetReplicaFailure)
 	}
 
-	newStatus.Replicas = int32(len(filteredMachines))                 // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
-	newStatus.FullyLabeledReplicas = int32(fullyLabeledReplicasCount) // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
-	newStatus.ReadyReplicas = int32(readyReplicasCount)               // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
-	newStatus.AvailableReplicas = int32(availableReplicasCount)       // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
+	newStatus.Replicas = int32(len(filteredMachines))                     // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
+	newStatus.FullyLabeledReplicas = int32(fullyLabeledReplicasCount)     // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
+	newStatus.ReadyReplicas = int32(readyReplicasCount)                   // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
+	newStatus.AvailableReplicas = int32(availableReplicasCount)           // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
+	newStatus.CandidateForUpdateReplicas = int32(candidateForUpdateCount) // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
+	newStatus.SelectedForUpdateReplicas = int32(selectedForUpdateCount)   // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
+	newStatus.UpdateSuccessfulReplicas = int32(updateSuccessfulCount)     // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
+	newStatus.UpdateFailedReplicas = int32(updateFailedCount)             // #nosec  G115 (CWE-190) -- number of machines will not exceed MaxInt32
 	newStatus.LastOperation.LastUpdateTime = metav1.Now()
 	return newStatus
 }
 
+// getNodeOfMachine returns the node backing the machine from the cache, or nil if the machine has no node (yet)
+// or the node couldn't be fetched.
+func (c *controller) getNodeOfMachine(machine *v1alpha1.Machine) *corev1.Node {
+	nodeName := machine.Labels[v1alpha1.NodeLabelKey]
+	if nodeName == "" {
+		return nil
+	}
+	node, err := c.nodeLister.Get(nodeName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Warningf("Could not fetch node %q of machine %q: %s", nodeName, machine.Name, err)
+		}
+		return nil
+	}
+	return node
+}
+
 // NewMachineSetCondition creates a new MachineSet condition.
 func NewMachineSetCondition(condType v1alpha1.MachineSetConditionType, status v1alpha1.ConditionStatus, reason, msg string) v1alpha1.MachineSetCondition {
 	return v1alpha1.MachineSetCondition{
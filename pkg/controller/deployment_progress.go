@@ -95,6 +95,10 @@ func (dc *controller) syncRolloutStatus(ctx context.Context, allISs []*v1alpha1.
 		}
 	}
 
+	if d.Spec.Strategy.Type == v1alpha1.InPlaceUpdateMachineDeploymentStrategyType {
+		dc.syncInPlaceUpdateProgressingCondition(allISs, newIS, d, &newStatus)
+	}
+
 	// Move failure conditions of all machine sets in deployment conditions. For now,
 	// only one failure condition is returned from getReplicaFailures.
 	if replicaFailureCond := dc.getReplicaFailures(allISs, newIS); len(replicaFailureCond) > 0 {
@@ -379,11 +379,11 @@ func (dc *controller) getNewMachineSet(ctx context.Context, d *v1alpha1.MachineD
 		// TODO: Identify which errors are permanent and switch DeploymentIsFailed  to take into account
 		// these reasons as well. Related issue: https://github.com/kubernetes/kubernetes/issues/18568
 		_, _ = dc.controlMachineClient.MachineDeployments(d.Namespace).UpdateStatus(ctx, d, metav1.UpdateOptions{})
-		dc.recorder.Eventf(d, v1.EventTypeWarning, FailedISCreateReason, msg)
+		dc.recordMachineDeploymentEvent(d, v1.EventTypeWarning, FailedISCreateReason, msg)
 		return nil, err
 	}
 	if !alreadyExists && newReplicasCount > 0 {
-		dc.recorder.Eventf(d, v1.EventTypeNormal, "ScalingMachineSet", "Scaled up machine set %s to %d", createdIS.Name, newReplicasCount)
+		dc.recordMachineDeploymentEvent(d, v1.EventTypeNormal, "ScalingMachineSet", "Scaled up machine set %s to %d", createdIS.Name, newReplicasCount)
 	}
 
 	needsUpdate := SetMachineDeploymentRevision(d, newRevision)
@@ -558,7 +558,7 @@ func (dc *controller) scaleMachineSet(ctx context.Context, is *v1alpha1.MachineS
 		is, err = dc.controlMachineClient.MachineSets(isCopy.Namespace).Update(ctx, isCopy, metav1.UpdateOptions{})
 		if err == nil && sizeNeedsUpdate {
 			scaled = true
-			dc.recorder.Eventf(deployment, v1.EventTypeNormal, "ScalingMachineSet", "Scaled %s machine set %s to %d", scalingOperation, is.Name, newScale)
+			dc.recordMachineDeploymentEvent(deployment, v1.EventTypeNormal, "ScalingMachineSet", "Scaled %s machine set %s to %d", scalingOperation, is.Name, newScale)
 		}
 	}
 	return scaled, is, err
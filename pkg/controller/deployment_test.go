@@ -134,6 +134,9 @@ var _ = Describe("machineDeployment", func() {
 							"test-label": "test-label",
 						},
 					},
+					Strategy: machinev1.MachineDeploymentStrategy{
+						Type: machinev1.RecreateMachineDeploymentStrategyType,
+					},
 				},
 			}
 		})
@@ -162,6 +165,24 @@ var _ = Describe("machineDeployment", func() {
 			),
 		)
 
+		It("should not enqueue the machineDeployment if its selector is changed", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			objects := []runtime.Object{}
+			objects = append(objects, testMachineDeployment)
+			c, trackers := createController(stop, testNamespace, objects, nil, nil)
+
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+			testMachineDeploymentUpdated := testMachineDeployment.DeepCopy()
+			testMachineDeploymentUpdated.Spec.Selector.MatchLabels["test-label"] = "test-label-1"
+			testMachineDeploymentUpdated.Spec.Template.Labels["test-label"] = "test-label-1"
+			c.updateMachineDeployment(testMachineDeployment, testMachineDeploymentUpdated)
+
+			waitForCacheSync(stop, c)
+			Expect(c.machineDeploymentQueue.Len()).To(Equal(0))
+		})
 	})
 
 	Describe("#deleteMachineDeployment", func() {
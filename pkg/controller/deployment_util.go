@@ -112,6 +112,12 @@ const (
 	// PreferNoScheduleKey is used to identify machineSet nodes on which PreferNoSchedule taint is added on
 	// older machineSets during a rolling update
 	PreferNoScheduleKey = "deployment.machine.sapcloud.io/prefer-no-schedule"
+	// InPlaceRolloutTokenAnnotation is set on a deployment while it holds one of the tokens permitting
+	// an automatic in-place rollout, limited by the MaxConcurrentInPlaceRollouts safety option
+	InPlaceRolloutTokenAnnotation = "deployment.machine.sapcloud.io/inplace-rollout-token"
+	// InPlaceRolloutStartedAnnotation records the start time of the automatic in-place rollout of a deployment.
+	// It is removed once the InPlaceRolloutComplete event was emitted for the rollout.
+	InPlaceRolloutStartedAnnotation = "deployment.machine.sapcloud.io/inplace-rollout-started"
 
 	// RollbackRevisionNotFound is not found rollback event reason
 	RollbackRevisionNotFound = "DeploymentRollbackRevisionNotFound"
@@ -120,6 +126,25 @@ const (
 	// RollbackDone is the done rollback event reason
 	RollbackDone = "DeploymentRollback"
 
+	// InPlaceRolloutDeferredReason is added in a deployment when its in-place rollout is deferred
+	// as all in-place rollout tokens are held by other deployments.
+	InPlaceRolloutDeferredReason = "InPlaceRolloutDeferred"
+	// InPlaceRolloutCompleteReason is added in a deployment when its automatic in-place rollout completed.
+	InPlaceRolloutCompleteReason = "InPlaceRolloutComplete"
+
+	// InPlaceUpdateLabelingNodesReason is added in the InPlaceUpdateProgressing condition of a deployment while the
+	// nodes of its old machine sets are labeled as candidates for the update, before any machine is selected for it.
+	InPlaceUpdateLabelingNodesReason = "LabelingNodes"
+	// InPlaceUpdateDrainingMachinesReason is added in the InPlaceUpdateProgressing condition of a deployment while
+	// machines of its old machine sets are selected for the update, i.e. are drained and updated.
+	InPlaceUpdateDrainingMachinesReason = "DrainingMachines"
+	// InPlaceUpdateTransferringOwnershipReason is added in the InPlaceUpdateProgressing condition of a deployment
+	// while machines of its old machine sets are updated successfully and are transferred to the new machine set.
+	InPlaceUpdateTransferringOwnershipReason = "TransferringOwnership"
+	// InPlaceUpdateCompletedReason is added in the InPlaceUpdateProgressing condition of a deployment once all its
+	// machines are updated and owned by the new machine set.
+	InPlaceUpdateCompletedReason = "Completed"
+
 	// MachineSetUpdatedReason is added in a deployment when one of its machine sets is updated as part
 	// of the rollout process.
 	MachineSetUpdatedReason = "MachineSetUpdated"
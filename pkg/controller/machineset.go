@@ -31,6 +31,7 @@ import (
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -179,6 +180,12 @@ func (c *controller) addMachineToMachineSet(obj interface{}) {
 		}
 		klog.V(4).Infof("Machine %s created: %#v.", machine.Name, machine)
 		c.expectations.CreationObserved(machineSetKey)
+		// Coalesce the reconciliations of the machine set, and hence the updates of its status, during a burst
+		// of machine creations by enqueuing it only once the last expected creation was observed
+		if c.creationsPending(machineSetKey) {
+			klog.V(4).Infof("Not enqueuing machine set %s as further machine creations are expected", machineSetKey)
+			return
+		}
 		c.enqueueMachineSet(machineSet)
 		return
 	}
@@ -305,6 +312,17 @@ func (c *controller) deleteMachineToMachineSet(obj interface{}) {
 	c.enqueueMachineSet(machineSet)
 }
 
+// creationsPending returns true if the machine set still expects to observe the creation of machines,
+// unless its expectations expired
+func (c *controller) creationsPending(machineSetKey string) bool {
+	exp, exists, err := c.expectations.GetExpectations(machineSetKey)
+	if err != nil || !exists || exp.isExpired() {
+		return false
+	}
+	add, _ := exp.GetExpectations()
+	return add > 0
+}
+
 // obj could be an *extensions.MachineSet, or a DeletionFinalStateUnknown marker item.
 func (c *controller) enqueueMachineSet(obj interface{}) {
 	key, err := KeyFunc(obj)
@@ -336,6 +354,7 @@ func (c *controller) manageReplicas(ctx context.Context, allMachines []*v1alpha1
 	}
 
 	var activeMachines, staleMachines, machinesWithUpdateSuccessfulLabel []*v1alpha1.Machine
+	var requeueAfter time.Duration
 	for _, m := range allMachines {
 		// Skip machines that are in the process of being updated.
 		if m.Labels[v1alpha1.LabelKeyNodeUpdateResult] == v1alpha1.LabelValueNodeUpdateSuccessful {
@@ -344,12 +363,27 @@ func (c *controller) manageReplicas(ctx context.Context, allMachines []*v1alpha1
 			continue
 		}
 
-		if machineutils.IsMachineFailed(m) || machineutils.IsMachineTriggeredForDeletion(m) {
+		if retention := c.getFailedMachineRetention(m); retention > 0 {
+			// Failed machines are retained for their inspection, counting towards the replicas so that they are
+			// not replaced yet. On scale-downs they are deleted first, as failed machines sort before active ones.
+			klog.V(3).Infof("Retaining failed machine %q of MachineSet %q for another %s", m.Name, machineSet.Name, retention)
+			activeMachines = append(activeMachines, m)
+			if requeueAfter == 0 || retention < requeueAfter {
+				requeueAfter = retention
+			}
+		} else if c.isRecoveredFailedMachine(m) {
+			// The node of the machine became Ready again, the machine-controller moves it back to Running
+			klog.V(2).Infof("Aborting termination of failed machine %q of MachineSet %q as its node recovered", m.Name, machineSet.Name)
+			activeMachines = append(activeMachines, m)
+		} else if machineutils.IsMachineFailed(m) || machineutils.IsMachineTriggeredForDeletion(m) {
 			staleMachines = append(staleMachines, m)
 		} else if machineutils.IsMachineActive(m) {
 			activeMachines = append(activeMachines, m)
 		}
 	}
+	if requeueAfter > 0 {
+		c.enqueueMachineSetAfter(machineSet, requeueAfter)
+	}
 
 	if len(staleMachines) >= 1 {
 		klog.V(3).Infof("Deleting stale machines %s", getMachineKeys(staleMachines))
@@ -398,6 +432,12 @@ func (c *controller) manageReplicas(ctx context.Context, allMachines []*v1alpha1
 		if diff > BurstReplicas {
 			diff = BurstReplicas
 		}
+		// Stagger large scale-ups, the remaining machines are created on the next reconciliations
+		// which are triggered by the creation of these machines
+		if stride := int(c.safetyOptions.MachineSetScaleUpStride); stride > 0 && diff > stride {
+			klog.V(3).Infof("Creating %d of %d missing machines of MachineSet %q due to the scale-up stride", stride, diff, machineSet.Name)
+			diff = stride
+		}
 		// TODO: Track UIDs of creates just like deletes. The problem currently
 		// is we'd need to wait on the result of a create to record the machine's
 		// UID, which would require locking *across* the create, which will turn
@@ -416,6 +456,7 @@ func (c *controller) manageReplicas(ctx context.Context, allMachines []*v1alpha1
 		// prevented from spamming the API service with the machine create requests
 		// after one of its machines fails.  Conveniently, this also prevents the
 		// event spam that those failures would generate.
+		zoneSpreader := c.newZoneSpreader(machineSet, activeMachines)
 		successfulCreations, err := slowStartBatch(diff, SlowStartInitialBatchSize, func() error {
 			boolPtr := func(b bool) *bool { return &b }
 			controllerRef := &metav1.OwnerReference{
@@ -426,7 +467,15 @@ func (c *controller) manageReplicas(ctx context.Context, allMachines []*v1alpha1
 				BlockOwnerDeletion: boolPtr(true),
 				Controller:         boolPtr(true),
 			}
-			err := c.machineControl.CreateMachinesWithControllerRef(ctx, machineSet.Namespace, &machineSet.Spec.Template, machineSet, controllerRef)
+			template := &machineSet.Spec.Template
+			if zoneSpreader != nil {
+				template = template.DeepCopy()
+				if template.Annotations == nil {
+					template.Annotations = make(map[string]string)
+				}
+				template.Annotations[machineutils.MachinePreferredZone] = zoneSpreader.next()
+			}
+			err := c.machineControl.CreateMachinesWithControllerRef(ctx, machineSet.Namespace, template, machineSet, controllerRef)
 			if err != nil && apierrors.IsTimeout(err) {
 				// Machine is created but its initialization has timed out.
 				// If the initialization is successful eventually, the
@@ -458,7 +507,18 @@ func (c *controller) manageReplicas(ctx context.Context, allMachines []*v1alpha1
 		klog.V(2).Infof("Too many replicas for %v %s/%s, need %d, deleting %d", machineSet.Kind, machineSet.Namespace, machineSet.Name, (machineSet.Spec.Replicas), diff)
 
 		logMachinesWithPriority1(activeMachines)
-		machinesToDelete := getMachinesToDelete(activeMachines, diff)
+		var machinesToDelete []*v1alpha1.Machine
+		if teardownOrder := getMachineSetTeardownOrder(machineSet); teardownOrder != "" && machineSet.Spec.Replicas == 0 {
+			// Stale machines have just been terminated, hence the teardown continues once they are gone
+			if len(staleMachines) == 0 {
+				machinesToDelete, err = c.getMachinesToTearDown(allMachines, activeMachines, teardownOrder)
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			machinesToDelete = getMachinesToDelete(activeMachines, diff)
+		}
 		logMachinesToDelete(machinesToDelete)
 
 		// Snapshot the UIDs (ns/name) of the machines we're expecting to see
@@ -546,6 +606,10 @@ func (c *controller) reconcileClusterMachineSet(key string) error {
 
 	// NOTE: filteredMachines are pointing to objects from cache - if you need to
 	// modify them, you need to copy it first.
+	filteredMachines, err = c.handleLabelDriftedMachines(ctx, machineSet, selector, filteredMachines)
+	if err != nil {
+		return err
+	}
 	filteredMachines, err = c.claimMachines(ctx, machineSet, selector, filteredMachines)
 	if err != nil {
 		return err
@@ -573,10 +637,26 @@ func (c *controller) reconcileClusterMachineSet(key string) error {
 	var manageReplicasErr error
 
 	if machineSet.DeletionTimestamp == nil {
+		// rotateExpiredMachines marks machines exceeding their maximum age for deletion,
+		// which are then replaced by manageReplicas
+		if err := c.rotateExpiredMachines(ctx, filteredMachines, machineSet); err != nil {
+			klog.Errorf("failed to rotate expired machines of machineset %s: %v", machineSet.Name, err)
+		}
+
 		// manageReplicas is the core machineSet method where scale up/down occurs
 		// It is not called when deletion timestamp is set
 		manageReplicasErr = c.manageReplicas(ctx, filteredMachines, machineSet)
 
+		if c.autoscalerMachineDeletionCost {
+			if err := c.syncMachineDeletionCosts(ctx, filteredMachines); err != nil {
+				klog.Errorf("failed to sync deletion costs of machines of machineset %s: %v", machineSet.Name, err)
+			}
+		}
+
+		if err := c.syncMachineDeploymentLabels(ctx, filteredMachines, machineSet); err != nil {
+			klog.Errorf("failed to sync MachineDeployment labels of machines of machineset %s: %v", machineSet.Name, err)
+		}
+
 	} else if machineSet.DeletionTimestamp != nil {
 		// When machineSet if triggered for deletion
 
@@ -589,7 +669,13 @@ func (c *controller) reconcileClusterMachineSet(key string) error {
 		} else if finalizers := sets.NewString(machineSet.Finalizers...); finalizers.Has(DeleteFinalizerName) {
 			// Trigger deletion of machines backing the machineSet
 			klog.V(3).Infof("Deleting all child machines as MachineSet %s has set deletionTimestamp", machineSet.Name)
-			if err := c.terminateMachines(ctx, filteredMachines, machineSet); err != nil {
+			machinesToTerminate := filteredMachines
+			if teardownOrder := getMachineSetTeardownOrder(machineSet); teardownOrder != "" {
+				if machinesToTerminate, err = c.getMachinesToTearDown(filteredMachines, filteredMachines, teardownOrder); err != nil {
+					return err
+				}
+			}
+			if err := c.terminateMachines(ctx, machinesToTerminate, machineSet); err != nil {
 				// TODO: proper error handling needs to happen here
 				klog.Errorf("failed terminate machines for machineset %s: %v", machineSet.Name, err)
 			}
@@ -597,7 +683,7 @@ func (c *controller) reconcileClusterMachineSet(key string) error {
 	}
 
 	machineSet = machineSet.DeepCopy()
-	newStatus := calculateMachineSetStatus(machineSet, filteredMachines, manageReplicasErr)
+	newStatus := c.calculateMachineSetStatus(machineSet, filteredMachines, manageReplicasErr)
 
 	// Always updates status as machines come up or die.
 	updatedMachineSet, err := updateMachineSetStatus(ctx, c.controlMachineClient, machineSet, newStatus)
@@ -672,6 +758,32 @@ func slowStartBatch(count int, initialBatchSize int, fn func() error) (int, erro
 	return successes, nil
 }
 
+// getFailedMachineRetention returns the remaining period for which the machine is retained if it is Failed, or 0 if it
+// isn't retained. The retention starts when the machine is marked Failed and doesn't apply to machines triggered for deletion.
+func (c *controller) getFailedMachineRetention(machine *v1alpha1.Machine) time.Duration {
+	retention := c.safetyOptions.FailedMachineRetention.Duration
+	if retention <= 0 || !machineutils.IsMachineFailed(machine) || machineutils.IsMachineTriggeredForDeletion(machine) || machine.DeletionTimestamp != nil {
+		return 0
+	}
+	return max(retention-time.Since(machine.Status.CurrentStatus.LastUpdateTime.Time), 0)
+}
+
+// isRecoveredFailedMachine checks if the machine was marked Failed by the health check and its node reports Ready again,
+// in which case its termination is aborted if enabled.
+func (c *controller) isRecoveredFailedMachine(machine *v1alpha1.Machine) bool {
+	if !c.safetyOptions.AbortTerminationOnNodeRecovery || !machineutils.IsMachineFailed(machine) ||
+		machine.Status.LastOperation.Type != v1alpha1.MachineOperationHealthCheck ||
+		machineutils.IsMachineTriggeredForDeletion(machine) || machine.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range machine.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func getMachinesToDelete(filteredMachines []*v1alpha1.Machine, diff int) []*v1alpha1.Machine {
 	// No need to sort machines if we are about to delete all of them.
 	// diff will always be <= len(filteredMachines), so not need to handle > case.
@@ -837,7 +949,7 @@ func (c *controller) updateMachineStatus(
 	clone := latestMachine.DeepCopy()
 
 	clone.Status.LastOperation = lastOperation
-	clone.Status.CurrentStatus = currentStatus
+	machineutils.SetCurrentStatus(&clone.Status, currentStatus)
 	if isMachineStatusEqual(clone.Status, machine.Status) {
 		klog.V(3).Infof("Not updating the status of the machine object %q , as it is already same", clone.Name)
 		return machine, nil
@@ -8,15 +8,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	k8sError "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
+	toolscache "k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 
 	machinev1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
@@ -755,6 +759,80 @@ var _ = Describe("machineset", func() {
 			Expect(len(machines.Items)).To(Equal(int(BurstReplicas + len(activeMachines))))
 		})
 
+		// Testcase: diff > scale-up stride
+		// Create the machines in strides across reconciliations.
+		It("should create new machines in strides of the scale-up stride across reconciliations", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			objects := []runtime.Object{}
+			testMachineSet.Spec.Replicas = 12
+			objects = append(objects, testMachineSet, testActiveMachine1, testActiveMachine2)
+			c, trackers := createController(stop, testNamespace, objects, nil, nil)
+			defer trackers.Stop()
+			c.safetyOptions.MachineSetScaleUpStride = 4
+			waitForCacheSync(stop, c)
+
+			activeMachines := []*machinev1.Machine{testActiveMachine1, testActiveMachine2}
+			for _, expectedMachines := range []int{6, 10, 12, 12} {
+				Expect(c.manageReplicas(context.Background(), activeMachines, testMachineSet)).NotTo(HaveOccurred())
+				machines, err := c.controlMachineClient.Machines(testNamespace).List(context.Background(), metav1.ListOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(machines.Items).To(HaveLen(expectedMachines))
+
+				activeMachines = activeMachines[:0]
+				for i := range machines.Items {
+					activeMachines = append(activeMachines, &machines.Items[i])
+				}
+			}
+		})
+
+		// Testcase: machineSet with zone-spread annotation
+		// Balance the preferred zones of the created machines across the zones.
+		It("should balance the preferred zones of successively created machines across the zones", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			testMachineSet.Spec.Replicas = 6
+			testMachineSet.Annotations = map[string]string{machineutils.MachineZoneSpread: "zone-a, zone-b, zone-c"}
+			testActiveMachine1.Labels[machinev1.NodeLabelKey] = "node-1"
+			testActiveMachine2.Annotations = map[string]string{machineutils.MachinePreferredZone: "zone-b"}
+			objects := []runtime.Object{testMachineSet, testActiveMachine1, testActiveMachine2}
+			targetCoreObjects := []runtime.Object{
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "node-1",
+						Labels: map[string]string{corev1.LabelTopologyZone: "zone-a"},
+					},
+				},
+			}
+			c, trackers := createController(stop, testNamespace, objects, nil, targetCoreObjects)
+			defer trackers.Stop()
+			c.safetyOptions.MachineSetScaleUpStride = 2
+			waitForCacheSync(stop, c)
+
+			activeMachines := []*machinev1.Machine{testActiveMachine1, testActiveMachine2}
+			for _, expectedZones := range []map[string]int{
+				{"zone-a": 1, "zone-b": 1, "zone-c": 1},
+				{"zone-a": 1, "zone-b": 2, "zone-c": 2},
+			} {
+				Expect(c.manageReplicas(context.Background(), activeMachines, testMachineSet)).NotTo(HaveOccurred())
+				machines, err := c.controlMachineClient.Machines(testNamespace).List(context.Background(), metav1.ListOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				// machine-1 in zone-a isn't counted as it has joined without a preferred zone
+				zones := map[string]int{}
+				activeMachines = activeMachines[:0]
+				for i := range machines.Items {
+					if zone, ok := machines.Items[i].Annotations[machineutils.MachinePreferredZone]; ok {
+						zones[zone]++
+					}
+					activeMachines = append(activeMachines, &machines.Items[i])
+				}
+				Expect(zones).To(Equal(expectedZones))
+			}
+		})
+
 		// TestCase: ActiveMachines = DesiredMachines
 		// Testcase: It should not return error.
 		It("should not create or delete machines and should not return error", func() {
@@ -821,6 +899,70 @@ var _ = Describe("machineset", func() {
 			Expect(Err).Should(BeNil())
 		})
 
+		// TestCase: Failed machine within the failed machine retention
+		// Testcase: It should retain the failed machine without replacing it until the retention elapses.
+		It("should not delete or replace a failed machine until the failed machine retention elapses", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			testActiveMachine4.Status.CurrentStatus.LastUpdateTime = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+			objects := []runtime.Object{testMachineSet, testActiveMachine1, testActiveMachine2, testActiveMachine4}
+			c, trackers := createController(stop, testNamespace, objects, nil, nil)
+			defer trackers.Stop()
+			c.safetyOptions.FailedMachineRetention = metav1.Duration{Duration: time.Hour}
+			waitForCacheSync(stop, c)
+
+			allMachines := []*machinev1.Machine{testActiveMachine1, testActiveMachine2, testActiveMachine4}
+			Expect(c.manageReplicas(context.TODO(), allMachines, testMachineSet)).To(Succeed())
+			machines, err := c.controlMachineClient.Machines(testNamespace).List(context.TODO(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(machines.Items).To(HaveLen(3))
+			failedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), testActiveMachine4.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(failedMachine.DeletionTimestamp).To(BeNil())
+
+			testActiveMachine4.Status.CurrentStatus.LastUpdateTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+			Expect(c.manageReplicas(context.TODO(), allMachines, testMachineSet)).To(Succeed())
+			_, err = c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), testActiveMachine4.Name, metav1.GetOptions{})
+			Expect(k8sError.IsNotFound(err)).To(BeTrue())
+			machines, err = c.controlMachineClient.Machines(testNamespace).List(context.TODO(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(machines.Items).To(HaveLen(3))
+		})
+
+		// TestCase: Failed machine whose node recovered
+		// Testcase: It should abort the termination of the machine only if it was marked Failed by the health check.
+		DescribeTable("##failed machine whose node recovered",
+			func(abortOnRecovery bool, operationType machinev1.MachineOperationType, readyStatus corev1.ConditionStatus, expectTerminated bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				testActiveMachine4.Status.LastOperation.Type = operationType
+				testActiveMachine4.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: readyStatus}}
+				objects := []runtime.Object{testMachineSet, testActiveMachine1, testActiveMachine2, testActiveMachine4}
+				c, trackers := createController(stop, testNamespace, objects, nil, nil)
+				defer trackers.Stop()
+				c.safetyOptions.AbortTerminationOnNodeRecovery = abortOnRecovery
+				waitForCacheSync(stop, c)
+
+				allMachines := []*machinev1.Machine{testActiveMachine1, testActiveMachine2, testActiveMachine4}
+				Expect(c.manageReplicas(context.TODO(), allMachines, testMachineSet)).To(Succeed())
+				_, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), testActiveMachine4.Name, metav1.GetOptions{})
+				if expectTerminated {
+					Expect(k8sError.IsNotFound(err)).To(BeTrue())
+				} else {
+					Expect(err).ToNot(HaveOccurred())
+				}
+				machines, err := c.controlMachineClient.Machines(testNamespace).List(context.TODO(), metav1.ListOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machines.Items).To(HaveLen(int(testMachineSet.Spec.Replicas)))
+			},
+			Entry("should abort the termination of a machine failed by the health check", true, machinev1.MachineOperationHealthCheck, corev1.ConditionTrue, false),
+			Entry("should terminate the machine if its node is still not Ready", true, machinev1.MachineOperationHealthCheck, corev1.ConditionFalse, true),
+			Entry("should terminate the machine if it failed for another reason", true, machinev1.MachineOperationCreate, corev1.ConditionTrue, true),
+			Entry("should terminate the machine if the abort is disabled", false, machinev1.MachineOperationHealthCheck, corev1.ConditionTrue, true),
+		)
+
 		// TestCase: ActiveMachines > DesiredMachines
 		// Testcase: It should not return error and delete extra running machine.
 		It("should not return error and should delete extra running machine.", func() {
@@ -1115,6 +1257,44 @@ var _ = Describe("machineset", func() {
 			Expect(Err).Should(BeNil())
 		})
 
+		It("It should update the MachineSet status once while scaling up and enqueue it once all new machines were observed", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			// The MachineSet was scaled up
+			testMachineSet.Generation = 2
+			testMachineSet.Status.ObservedGeneration = 1
+
+			objects := []runtime.Object{}
+			objects = append(objects, testMachineSet)
+			c, trackers := createController(stop, testNamespace, objects, nil, nil)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			Key := testNamespace + "/" + testMachineSet.Name
+			Expect(c.reconcileClusterMachineSet(Key)).To(Succeed())
+
+			machines, err := c.controlMachineClient.Machines(testNamespace).List(context.TODO(), metav1.ListOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(machines.Items).To(HaveLen(int(testMachineSet.Spec.Replicas)))
+
+			// The creations of the machines are coalesced into a single reconciliation
+			for i := range machines.Items {
+				Expect(c.machineSetQueue.Len()).To(Equal(0))
+				c.addMachineToMachineSet(&machines.Items[i])
+			}
+			Expect(c.machineSetQueue.Len()).To(Equal(1))
+
+			var statusUpdates []*machinev1.MachineSet
+			for _, action := range c.controlMachineClient.(*faketyped.FakeMachineV1alpha1).Actions() {
+				if action.Matches("update", "machinesets") && action.GetSubresource() == "status" {
+					statusUpdates = append(statusUpdates, action.(testing.UpdateAction).GetObject().(*machinev1.MachineSet))
+				}
+			}
+			Expect(statusUpdates).To(HaveLen(1))
+			Expect(statusUpdates[0].Status.ObservedGeneration).To(Equal(testMachineSet.Generation))
+		})
+
 		// Testcase: Should return nil if the machineset doesnt exist, to avoid constant reconciliations.
 		It("It should return nil if machineset doesnt exist.", func() {
 			stop := make(chan struct{})
@@ -1181,6 +1361,442 @@ var _ = Describe("machineset", func() {
 			Expect(len(machines.Items)).To(Equal(int(0)))
 			Expect(Err).Should(BeNil())
 		})
+
+		// Testcase: It should count the machines by the state of their in-place update in the MachineSet status.
+		It("It should count the machines by the in-place update labels of their nodes in the MachineSet status", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			testMachineSet.Finalizers = []string{DeleteFinalizerName}
+			updateLabels := []map[string]string{
+				{machinev1.LabelKeyNodeCandidateForUpdate: "true"},
+				{machinev1.LabelKeyNodeCandidateForUpdate: "true", machinev1.LabelKeyNodeSelectedForUpdate: "true"},
+				{machinev1.LabelKeyNodeCandidateForUpdate: "true", machinev1.LabelKeyNodeSelectedForUpdate: "true", machinev1.LabelKeyNodeUpdateResult: machinev1.LabelValueNodeUpdateSuccessful},
+				{machinev1.LabelKeyNodeCandidateForUpdate: "true", machinev1.LabelKeyNodeSelectedForUpdate: "true", machinev1.LabelKeyNodeUpdateResult: machinev1.LabelValueNodeUpdateFailed},
+				{},
+			}
+			testMachineSet.Spec.Replicas = int32(len(updateLabels))
+
+			objects := []runtime.Object{testMachineSet}
+			nodes := []runtime.Object{}
+			for i, nodeLabels := range updateLabels {
+				nodeName := fmt.Sprintf("node-%d", i)
+				nodes = append(nodes, &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   nodeName,
+						Labels: nodeLabels,
+					},
+				})
+				objects = append(objects, &machinev1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            fmt.Sprintf("machine-%d", i),
+						Namespace:       testNamespace,
+						Labels:          map[string]string{"test-label": "test-label", machinev1.NodeLabelKey: nodeName},
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(testMachineSet, controllerKindMachineSet)},
+					},
+					Spec: testMachineSet.Spec.Template.Spec,
+					Status: machinev1.MachineStatus{
+						CurrentStatus: machinev1.CurrentStatus{
+							Phase: machinev1.MachineRunning,
+						},
+					},
+				})
+			}
+			c, trackers := createController(stop, testNamespace, objects, nil, nodes)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			Key := testNamespace + "/" + testMachineSet.Name
+			Err := c.reconcileClusterMachineSet(Key)
+			Expect(Err).Should(BeNil())
+
+			machineSet, err := c.controlMachineClient.MachineSets(testNamespace).Get(context.TODO(), testMachineSet.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(machineSet.Status.Replicas).To(Equal(int32(5)))
+			Expect(machineSet.Status.CandidateForUpdateReplicas).To(Equal(int32(4)))
+			Expect(machineSet.Status.SelectedForUpdateReplicas).To(Equal(int32(3)))
+			Expect(machineSet.Status.UpdateSuccessfulReplicas).To(Equal(int32(1)))
+			Expect(machineSet.Status.UpdateFailedReplicas).To(Equal(int32(1)))
+		})
+	})
+
+	Describe("#handleLabelDriftedMachines", func() {
+		var (
+			testMachineSet *machinev1.MachineSet
+			testMachines   []*machinev1.Machine
+		)
+
+		BeforeEach(func() {
+			testMachineSet = &machinev1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "MachineSet-test",
+					Namespace: testNamespace,
+					UID:       "1234567",
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "MachineSet",
+					APIVersion: "machine.sapcloud.io/v1alpha1",
+				},
+				Spec: machinev1.MachineSetSpec{
+					Replicas: 2,
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"test-label": "test-label"},
+					},
+				},
+			}
+			newOwnedMachine := func(name, labelValue string) *machinev1.Machine {
+				return &machinev1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            name,
+						Namespace:       testNamespace,
+						Labels:          map[string]string{"test-label": labelValue},
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(testMachineSet, controllerKindMachineSet)},
+					},
+				}
+			}
+			testMachines = []*machinev1.Machine{
+				newOwnedMachine("machine-0", "test-label"),
+				newOwnedMachine("machine-1", "drifted"),
+			}
+		})
+
+		DescribeTable("##table",
+			func(policy string, expectedMachines []string, expectDriftedMachineExists bool, expectedLabelValue string, expectedClaimed int) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				objects := []runtime.Object{testMachineSet}
+				for _, machine := range testMachines {
+					objects = append(objects, machine)
+				}
+				c, trackers := createController(stop, testNamespace, objects, nil, nil)
+				defer trackers.Stop()
+				c.safetyOptions.MachineLabelDriftPolicy = policy
+				waitForCacheSync(stop, c)
+
+				selector, err := metav1.LabelSelectorAsSelector(testMachineSet.Spec.Selector)
+				Expect(err).ToNot(HaveOccurred())
+
+				machines, err := c.handleLabelDriftedMachines(context.TODO(), testMachineSet, selector, testMachines)
+				Expect(err).ToNot(HaveOccurred())
+				names := make([]string, 0, len(machines))
+				for _, machine := range machines {
+					names = append(names, machine.Name)
+				}
+				Expect(names).To(ConsistOf(expectedMachines))
+
+				driftedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), "machine-1", metav1.GetOptions{})
+				if !expectDriftedMachineExists {
+					Expect(k8sError.IsNotFound(err)).To(BeTrue())
+					return
+				}
+				Expect(err).ToNot(HaveOccurred())
+				Expect(driftedMachine.Labels["test-label"]).To(Equal(expectedLabelValue))
+
+				claimed, err := c.claimMachines(context.TODO(), testMachineSet, selector, machines)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(claimed).To(HaveLen(expectedClaimed))
+			},
+			Entry("should leave the drifted machine to be released with the Release policy", machineutils.LabelDriftPolicyRelease, []string{"machine-0", "machine-1"}, true, "drifted", 1),
+			Entry("should re-add the selector labels to the drifted machine with the Readopt policy", machineutils.LabelDriftPolicyReadopt, []string{"machine-0", "machine-1"}, true, "test-label", 2),
+			Entry("should delete the drifted machine with the Delete policy", machineutils.LabelDriftPolicyDelete, []string{"machine-0"}, false, "", 1),
+		)
+	})
+
+	Describe("#rotateExpiredMachines", func() {
+		var (
+			testMachineSet   *machinev1.MachineSet
+			testMachineClass *machinev1.MachineClass
+			testMachines     []*machinev1.Machine
+		)
+
+		newAgedMachine := func(name string, age time.Duration, phase machinev1.MachinePhase) *machinev1.Machine {
+			return &machinev1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         testNamespace,
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+					Labels: map[string]string{
+						"test-label": "test-label",
+					},
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Machine",
+					APIVersion: "machine.sapcloud.io/v1alpha1",
+				},
+				Status: machinev1.MachineStatus{
+					CurrentStatus: machinev1.CurrentStatus{
+						Phase: phase,
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			testMachineSet = &machinev1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "MachineSet-test",
+					Namespace: testNamespace,
+					Annotations: map[string]string{
+						machineutils.MachineMaxAge: "24h",
+					},
+				},
+				Spec: machinev1.MachineSetSpec{
+					Replicas: 3,
+					Template: machinev1.MachineTemplateSpec{
+						Spec: machinev1.MachineSpec{
+							Class: machinev1.ClassSpec{
+								Name: "MachineClass-test",
+								Kind: machineutils.MachineClassKind,
+							},
+						},
+					},
+				},
+			}
+			testMachineClass = &machinev1.MachineClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "MachineClass-test",
+					Namespace: testNamespace,
+				},
+			}
+			testMachines = []*machinev1.Machine{
+				newAgedMachine("machine-1", 48*time.Hour, machinev1.MachineRunning),
+				newAgedMachine("machine-2", 72*time.Hour, machinev1.MachineRunning),
+				newAgedMachine("machine-3", time.Hour, machinev1.MachineRunning),
+			}
+		})
+
+		rotate := func() []string {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			objects := []runtime.Object{testMachineSet, testMachineClass}
+			for _, machine := range testMachines {
+				objects = append(objects, machine)
+			}
+			c, trackers := createController(stop, testNamespace, objects, nil, nil)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			Expect(c.rotateExpiredMachines(context.TODO(), testMachines, testMachineSet)).To(Succeed())
+
+			var markedMachines []string
+			for _, machine := range testMachines {
+				updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				if updatedMachine.Annotations[machineutils.TriggerDeletionByMCM] == "true" {
+					markedMachines = append(markedMachines, machine.Name)
+				}
+			}
+			return markedMachines
+		}
+
+		It("should mark only the oldest expired machine for deletion per sync", func() {
+			Expect(rotate()).To(ConsistOf("machine-2"))
+
+			// once the oldest expired machine has been replaced, the next expired machine is rotated
+			testMachines[1] = newAgedMachine("machine-4", time.Minute, machinev1.MachineRunning)
+			Expect(rotate()).To(ConsistOf("machine-1"))
+		})
+
+		It("should not mark further machines while an expired machine is being replaced", func() {
+			testMachines[1].Annotations = map[string]string{machineutils.TriggerDeletionByMCM: "true"}
+			Expect(rotate()).To(ConsistOf("machine-2"))
+
+			testMachines[1] = newAgedMachine("machine-4", time.Minute, machinev1.MachinePending)
+			Expect(rotate()).To(BeEmpty())
+		})
+
+		It("should consider the max age configured on the MachineClass", func() {
+			delete(testMachineSet.Annotations, machineutils.MachineMaxAge)
+			Expect(rotate()).To(BeEmpty())
+
+			testMachineClass.Annotations = map[string]string{machineutils.MachineMaxAge: "60h"}
+			Expect(rotate()).To(ConsistOf("machine-2"))
+		})
+
+		It("should return an error for an invalid max age", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			testMachineSet.Annotations[machineutils.MachineMaxAge] = "a month"
+			c, trackers := createController(stop, testNamespace, []runtime.Object{testMachineSet}, nil, nil)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			Expect(c.rotateExpiredMachines(context.TODO(), testMachines, testMachineSet)).ToNot(Succeed())
+		})
+	})
+
+	Describe("#syncMachineDeletionCosts", func() {
+		newPrioritizedMachine := func(name, priority string, age time.Duration, phase machinev1.MachinePhase) *machinev1.Machine {
+			return &machinev1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         testNamespace,
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+					Annotations: map[string]string{
+						machineutils.MachinePriority: priority,
+					},
+				},
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Machine",
+					APIVersion: "machine.sapcloud.io/v1alpha1",
+				},
+				Status: machinev1.MachineStatus{
+					CurrentStatus: machinev1.CurrentStatus{
+						Phase: phase,
+					},
+				},
+			}
+		}
+
+		It("should set deletion costs consistent with the machine priority and phase", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			testMachines := []*machinev1.Machine{
+				newPrioritizedMachine("machine-a", "3", time.Hour, machinev1.MachineRunning),
+				newPrioritizedMachine("machine-b", "1", time.Hour, machinev1.MachineRunning),
+				newPrioritizedMachine("machine-c", "3", 2*time.Hour, machinev1.MachineFailed),
+				newPrioritizedMachine("machine-d", "2", 3*time.Hour, machinev1.MachineRunning),
+				newPrioritizedMachine("machine-e", "3", 5*time.Hour, machinev1.MachineRunning),
+			}
+			objects := []runtime.Object{}
+			for _, machine := range testMachines {
+				objects = append(objects, machine)
+			}
+			c, trackers := createController(stop, testNamespace, objects, nil, nil)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			Expect(c.syncMachineDeletionCosts(context.TODO(), testMachines)).To(Succeed())
+
+			costs := map[string]int{}
+			for _, machine := range testMachines {
+				updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedMachine.Annotations).To(HaveKey(machineutils.MachineDeletionCost))
+				costs[machine.Name], err = strconv.Atoi(updatedMachine.Annotations[machineutils.MachineDeletionCost])
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(costs).To(Equal(map[string]int{
+				"machine-b": 16,
+				"machine-d": 26,
+				"machine-c": 31,
+				"machine-e": 36,
+				"machine-a": 36,
+			}))
+
+			// machines with a lower priority always have a lower deletion cost
+			for _, machineI := range testMachines {
+				for _, machineJ := range testMachines {
+					if machineI.Annotations[machineutils.MachinePriority] < machineJ.Annotations[machineutils.MachinePriority] {
+						Expect(costs[machineI.Name]).To(BeNumerically("<", costs[machineJ.Name]))
+					}
+				}
+			}
+		})
+
+		It("should only patch the machines whose deletion cost changed", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			testMachines := []*machinev1.Machine{
+				newPrioritizedMachine("machine-a", "3", time.Hour, machinev1.MachineRunning),
+				newPrioritizedMachine("machine-b", "3", 2*time.Hour, machinev1.MachineRunning),
+				newPrioritizedMachine("machine-c", "1", 3*time.Hour, machinev1.MachineRunning),
+			}
+			testMachines[0].Annotations[machineutils.MachineDeletionCost] = "36"
+			testMachines[1].Annotations[machineutils.MachineDeletionCost] = "36"
+			objects := []runtime.Object{}
+			for _, machine := range testMachines {
+				objects = append(objects, machine)
+			}
+			c, trackers := createController(stop, testNamespace, objects, nil, nil)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			Expect(c.syncMachineDeletionCosts(context.TODO(), testMachines)).To(Succeed())
+
+			var patched []string
+			for _, action := range c.controlMachineClient.(*faketyped.FakeMachineV1alpha1).Actions() {
+				if action.Matches("patch", "machines") {
+					patched = append(patched, action.(testing.PatchAction).GetName())
+				}
+			}
+			Expect(patched).To(ConsistOf("machine-c"))
+		})
+	})
+
+	Describe("#syncMachineDeploymentLabels", func() {
+		machineTemplate := &machinev1.MachineTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"test-label": "test-label",
+				},
+			},
+			Spec: machinev1.MachineSpec{
+				Class: machinev1.ClassSpec{
+					Kind: "MachineClass",
+					Name: "test-machine-class",
+				},
+			},
+		}
+
+		DescribeTable("##table",
+			func(owner *metav1.OwnerReference, expectedMachineDeployment string) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineSet := newMachineSets(1, machineTemplate, 2, 0, nil, owner, nil, nil)[0]
+				machines := newMachinesFromMachineSet(2, machineSet, &machinev1.MachineStatus{}, nil, nil)
+				nodes := newNodes(2, nil, &corev1.NodeSpec{}, nil)
+				for i, machine := range machines {
+					machine.DeletionTimestamp = nil
+					machine.Labels = MergeStringMaps(machine.Labels, map[string]string{machinev1.NodeLabelKey: nodes[i].Name})
+				}
+
+				controlMachineObjects := []runtime.Object{machineSet}
+				for _, machine := range machines {
+					controlMachineObjects = append(controlMachineObjects, machine)
+				}
+				targetCoreObjects := []runtime.Object{}
+				for _, node := range nodes {
+					targetCoreObjects = append(targetCoreObjects, node)
+				}
+				c, trackers := createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects)
+				defer trackers.Stop()
+				waitForCacheSync(stop, c)
+
+				Expect(c.syncMachineDeploymentLabels(context.TODO(), machines, machineSet)).To(Succeed())
+
+				for i, machine := range machines {
+					updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					updatedNode, err := c.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), nodes[i].Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					if expectedMachineDeployment == "" {
+						Expect(updatedMachine.Labels).ToNot(HaveKey(machineutils.LabelKeyMachineDeployment))
+						Expect(updatedNode.Labels).ToNot(HaveKey(machineutils.LabelKeyMachineDeployment))
+					} else {
+						Expect(updatedMachine.Labels).To(HaveKeyWithValue(machineutils.LabelKeyMachineDeployment, expectedMachineDeployment))
+						Expect(updatedNode.Labels).To(HaveKeyWithValue(machineutils.LabelKeyMachineDeployment, expectedMachineDeployment))
+					}
+				}
+			},
+			Entry("should label the machines and nodes of a MachineSet owned by a MachineDeployment",
+				&metav1.OwnerReference{
+					APIVersion: "machine.sapcloud.io/v1alpha1",
+					Kind:       "MachineDeployment",
+					Name:       "test-machine-deployment",
+					Controller: pointer.BoolPtr(true),
+				},
+				"test-machine-deployment",
+			),
+			Entry("should not label the machines and nodes of a MachineSet not owned by a MachineDeployment", nil, ""),
+		)
 	})
 
 	Describe("#claimMachines", func() {
@@ -1334,6 +1950,156 @@ var _ = Describe("machineset", func() {
 		})
 	})
 
+	Describe("#getMachinesToTearDown", func() {
+		newPodsOnNode := func(nodeName string, count int, ownerKind string) []runtime.Object {
+			pods := make([]runtime.Object, count)
+			for i := range pods {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s-%s-pod-%d", nodeName, ownerKind, i),
+						Namespace: testNamespace,
+					},
+					Spec: corev1.PodSpec{
+						NodeName: nodeName,
+					},
+				}
+				if ownerKind != "" {
+					pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: "owner", Controller: pointer.Bool(true)}}
+				}
+				pods[i] = pod
+			}
+			return pods
+		}
+
+		DescribeTable("##teardown order of a MachineSet scaled to zero",
+			func(teardownOrder string, expectedOrder []string) {
+				testMachineSet := &machinev1.MachineSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "MachineSet-test",
+						Namespace:   testNamespace,
+						UID:         "1234567",
+						Annotations: map[string]string{machineutils.MachineTeardownOrder: teardownOrder},
+					},
+					Spec: machinev1.MachineSetSpec{
+						Replicas: 0,
+						Template: machinev1.MachineTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels: map[string]string{"test-label": "test-label"},
+							},
+						},
+						Selector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"test-label": "test-label"},
+						},
+					},
+				}
+
+				// machine-0 runs 3 pods, machine-1 runs 1 pod and 3 DaemonSet pods, machine-2 runs 2 pods
+				var remainingMachines []*machinev1.Machine
+				var pods []runtime.Object
+				for i, podCount := range []int{3, 1, 2} {
+					nodeName := fmt.Sprintf("node-%d", i)
+					remainingMachines = append(remainingMachines, &machinev1.Machine{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fmt.Sprintf("machine-%d", i),
+							Namespace: testNamespace,
+							Labels: map[string]string{
+								"test-label":           "test-label",
+								machinev1.NodeLabelKey: nodeName,
+							},
+						},
+						Status: machinev1.MachineStatus{
+							CurrentStatus: machinev1.CurrentStatus{
+								Phase: MachineRunning,
+							},
+						},
+					})
+					pods = append(pods, newPodsOnNode(nodeName, podCount, "ReplicaSet")...)
+				}
+				pods = append(pods, newPodsOnNode("node-1", 3, "DaemonSet")...)
+
+				var teardownOrderObserved []string
+				for len(remainingMachines) > 0 {
+					func() {
+						stop := make(chan struct{})
+						defer close(stop)
+
+						objects := []runtime.Object{testMachineSet}
+						for _, machine := range remainingMachines {
+							objects = append(objects, machine)
+						}
+						c, trackers := createController(stop, testNamespace, objects, nil, pods)
+						defer trackers.Stop()
+						waitForCacheSync(stop, c)
+
+						Expect(c.manageReplicas(context.TODO(), remainingMachines, testMachineSet)).To(Succeed())
+
+						machines, err := c.controlMachineClient.Machines(testNamespace).List(context.TODO(), metav1.ListOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(machines.Items).To(HaveLen(len(remainingMachines) - 1))
+
+						var stillRemaining []*machinev1.Machine
+						for _, machine := range remainingMachines {
+							if _, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{}); k8sError.IsNotFound(err) {
+								teardownOrderObserved = append(teardownOrderObserved, machine.Name)
+							} else {
+								stillRemaining = append(stillRemaining, machine)
+							}
+						}
+						remainingMachines = stillRemaining
+					}()
+				}
+				Expect(teardownOrderObserved).To(Equal(expectedOrder))
+			},
+			Entry("should terminate the machines with the fewest pods first", machineutils.TeardownOrderFewestPodsFirst, []string{"machine-1", "machine-2", "machine-0"}),
+			Entry("should terminate the machines with the most pods first", machineutils.TeardownOrderMostPodsFirst, []string{"machine-0", "machine-2", "machine-1"}),
+		)
+
+		It("should not terminate further machines while a machine is terminating", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			machines := []*machinev1.Machine{
+				{ObjectMeta: metav1.ObjectMeta{Name: "machine-0", Namespace: testNamespace}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "machine-1", Namespace: testNamespace}},
+			}
+			machines[0].Status.CurrentStatus.Phase = machinev1.MachineTerminating
+			c, trackers := createController(stop, testNamespace, nil, nil, nil)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			machinesToTearDown, err := c.getMachinesToTearDown(machines, machines[1:], machineutils.TeardownOrderFewestPodsFirst)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(machinesToTearDown).To(BeEmpty())
+		})
+	})
+
+	Describe("#newActivePodInformer", func() {
+		It("should only list and watch the scheduled pods which aren't completed and index them by their node", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			client := k8sfake.NewSimpleClientset(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-0", Namespace: testNamespace, ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}}},
+				Spec:       corev1.PodSpec{NodeName: "node-0"},
+			})
+			var fieldSelectors []string
+			client.PrependReactor("list", "pods", func(action testing.Action) (bool, runtime.Object, error) {
+				fieldSelectors = append(fieldSelectors, action.(testing.ListAction).GetListRestrictions().Fields.String())
+				return false, nil, nil
+			})
+
+			informer := newActivePodInformer(client, 0)
+			go informer.Run(stop)
+			Expect(toolscache.WaitForCacheSync(stop, informer.HasSynced)).To(BeTrue())
+
+			Expect(fieldSelectors).To(ConsistOf(activePodsFieldSelector))
+			pods, err := informer.GetIndexer().ByIndex(podNodeNameIndex, "node-0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pods).To(HaveLen(1))
+			Expect(pods[0].(*corev1.Pod).ManagedFields).To(BeEmpty())
+		})
+	})
+
 	Describe("#getMachinesToDelete", func() {
 		var (
 			testActiveMachine1 *machinev1.Machine
@@ -25,9 +25,17 @@ package controller
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	errorsutil "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
@@ -216,3 +224,325 @@ func logMachinesToDelete(machines []*v1alpha1.Machine) {
 		klog.V(3).Infof("Machine %q needs to be deleted", m.Name)
 	}
 }
+
+// getMachineMaxAge returns the maximum age of the machines of the machineSet, configured via the
+// machineutils.MachineMaxAge annotation on the machineSet or else on its MachineClass.
+// Zero is returned if no maximum age is configured.
+func (c *controller) getMachineMaxAge(machineSet *v1alpha1.MachineSet) (time.Duration, error) {
+	value, ok := machineSet.Annotations[machineutils.MachineMaxAge]
+	if !ok {
+		class := machineSet.Spec.Template.Spec.Class
+		if class.Kind != machineutils.MachineClassKind {
+			return 0, nil
+		}
+		machineClass, err := c.machineClassLister.MachineClasses(machineSet.Namespace).Get(class.Name)
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+		if value, ok = machineClass.Annotations[machineutils.MachineMaxAge]; !ok {
+			return 0, nil
+		}
+	}
+
+	maxAge, err := time.ParseDuration(value)
+	if err != nil || maxAge <= 0 {
+		return 0, fmt.Errorf("invalid value %q of annotation %q for MachineSet %q", value, machineutils.MachineMaxAge, machineSet.Name)
+	}
+	return maxAge, nil
+}
+
+// rotateExpiredMachines marks the oldest machine of the machineSet which exceeded its maximum age for deletion,
+// so that it is replaced by manageReplicas. Machines are rotated one at a time, and only while all replicas
+// of the machineSet are Running, so that rotation never reduces the availability by more than one machine.
+func (c *controller) rotateExpiredMachines(ctx context.Context, machines []*v1alpha1.Machine, machineSet *v1alpha1.MachineSet) error {
+	maxAge, err := c.getMachineMaxAge(machineSet)
+	if err != nil || maxAge == 0 {
+		return err
+	}
+
+	var (
+		expiredMachine  *v1alpha1.Machine
+		runningMachines int32
+	)
+	for _, machine := range machines {
+		if machine.DeletionTimestamp != nil || !machineutils.IsMachineActive(machine) || machineutils.IsMachineTriggeredForDeletion(machine) {
+			klog.V(4).Infof("Machine %q of MachineSet %q is being replaced, postponing rotation of expired machines", machine.Name, machineSet.Name)
+			return nil
+		}
+		if machine.Status.CurrentStatus.Phase == v1alpha1.MachineRunning {
+			runningMachines++
+		}
+		if time.Since(machine.CreationTimestamp.Time) < maxAge {
+			continue
+		}
+		if expiredMachine == nil || machine.CreationTimestamp.Before(&expiredMachine.CreationTimestamp) {
+			expiredMachine = machine
+		}
+	}
+	if expiredMachine == nil || runningMachines < machineSet.Spec.Replicas {
+		return nil
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:"true"}}}`, machineutils.TriggerDeletionByMCM)
+	if err := c.machineControl.PatchMachine(ctx, expiredMachine.Namespace, expiredMachine.Name, []byte(patch)); err != nil {
+		return fmt.Errorf("failed to mark machine %q exceeding its maximum age of %v for deletion: %w", expiredMachine.Name, maxAge, err)
+	}
+	klog.V(2).Infof("Marked machine %q of MachineSet %q for deletion as it exceeded its maximum age of %v", expiredMachine.Name, machineSet.Name, maxAge)
+	c.recorder.Eventf(machineSet, corev1.EventTypeNormal, machineutils.MachineMaxAgeExceededReason, "Marked machine %q for deletion as it exceeded its maximum age of %v", expiredMachine.Name, maxAge)
+	return nil
+}
+
+// syncMachineDeletionCosts annotates the machines with their deletion cost, so that the cluster autoscaler prefers the
+// same machines for scale-down as MCM. The cost only depends on the machine itself, so that only the machines whose
+// cost changed are patched.
+func (c *controller) syncMachineDeletionCosts(ctx context.Context, machines []*v1alpha1.Machine) error {
+	var errs []error
+	for _, machine := range machines {
+		if machine.DeletionTimestamp != nil {
+			continue
+		}
+		deletionCost := strconv.Itoa(getMachineDeletionCost(machine))
+		if machine.Annotations[machineutils.MachineDeletionCost] == deletionCost {
+			continue
+		}
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, machineutils.MachineDeletionCost, deletionCost)
+		if err := c.machineControl.PatchMachine(ctx, machine.Namespace, machine.Name, []byte(patch)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to set deletion cost of machine %q: %w", machine.Name, err))
+		}
+	}
+	return errorsutil.NewAggregate(errs)
+}
+
+// getMachineDeletionCost returns the deletion cost of the machine, which orders machines like ActiveMachines
+// apart from their age: by their machine priority first and then by the priority of their phase
+func getMachineDeletionCost(machine *v1alpha1.Machine) int {
+	return getMachinePriority(machine)*10 + machinePhasePriorities[machine.Status.CurrentStatus.Phase]
+}
+
+// syncMachineDeploymentLabels labels the machines and their nodes with the name of the MachineDeployment
+// owning the machineSet, if any.
+func (c *controller) syncMachineDeploymentLabels(ctx context.Context, machines []*v1alpha1.Machine, machineSet *v1alpha1.MachineSet) error {
+	controllerRef := metav1.GetControllerOf(machineSet)
+	if controllerRef == nil || controllerRef.Kind != controllerKind.Kind {
+		return nil
+	}
+	machineDeploymentName := controllerRef.Name
+
+	var errs []error
+	for _, machine := range machines {
+		if machine.DeletionTimestamp != nil {
+			continue
+		}
+		if machine.Labels[machineutils.LabelKeyMachineDeployment] != machineDeploymentName {
+			patch := fmt.Sprintf(`{"metadata":{"labels":{%q:%q}}}`, machineutils.LabelKeyMachineDeployment, machineDeploymentName)
+			if err := c.machineControl.PatchMachine(ctx, machine.Namespace, machine.Name, []byte(patch)); err != nil {
+				errs = append(errs, fmt.Errorf("failed to label machine %q with its MachineDeployment: %w", machine.Name, err))
+				continue
+			}
+		}
+		if c.nodeLister != nil {
+			if err := c.labelNodeForMachine(ctx, machine, machineutils.LabelKeyMachineDeployment, machineDeploymentName); err != nil {
+				errs = append(errs, fmt.Errorf("failed to label node of machine %q with its MachineDeployment: %w", machine.Name, err))
+			}
+		}
+	}
+	return errorsutil.NewAggregate(errs)
+}
+
+// getMachineSetTeardownOrder returns the valid machineutils.MachineTeardownOrder of the machineSet, or an empty string if none is set
+func getMachineSetTeardownOrder(machineSet *v1alpha1.MachineSet) string {
+	teardownOrder := machineSet.Annotations[machineutils.MachineTeardownOrder]
+	switch teardownOrder {
+	case "", machineutils.TeardownOrderFewestPodsFirst, machineutils.TeardownOrderMostPodsFirst:
+		return teardownOrder
+	default:
+		klog.Warningf("Ignoring unknown value %q of annotation %q on MachineSet %q", teardownOrder, machineutils.MachineTeardownOrder, machineSet.Name)
+		return ""
+	}
+}
+
+// zoneSpreader assigns the preferred zones of the machines created for a machineSet, so that its machines are
+// balanced across the zones of its machineutils.MachineZoneSpread annotation. It is safe for concurrent use.
+type zoneSpreader struct {
+	lock   sync.Mutex
+	zones  []string
+	counts map[string]int
+}
+
+// newZoneSpreader returns a zoneSpreader counting the zones of the given machines, or nil if the machineSet has no
+// machineutils.MachineZoneSpread annotation. The zone of a machine is taken from the zone label of its node, or from its
+// machineutils.MachinePreferredZone annotation if its node hasn't joined yet.
+func (c *controller) newZoneSpreader(machineSet *v1alpha1.MachineSet, machines []*v1alpha1.Machine) *zoneSpreader {
+	var zones []string
+	for _, zone := range strings.Split(machineSet.Annotations[machineutils.MachineZoneSpread], ",") {
+		if zone = strings.TrimSpace(zone); zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	if len(zones) == 0 {
+		return nil
+	}
+
+	counts := make(map[string]int, len(zones))
+	for _, machine := range machines {
+		zone := machine.Annotations[machineutils.MachinePreferredZone]
+		if nodeName := machine.Labels[v1alpha1.NodeLabelKey]; nodeName != "" && c.nodeLister != nil {
+			if node, err := c.nodeLister.Get(nodeName); err == nil && node.Labels[corev1.LabelTopologyZone] != "" {
+				zone = node.Labels[corev1.LabelTopologyZone]
+			}
+		}
+		if zone != "" {
+			counts[zone]++
+		}
+	}
+	return &zoneSpreader{zones: zones, counts: counts}
+}
+
+// next returns the zone with the fewest machines, preferring the zones listed first on a tie,
+// and counts the machine about to be created in it
+func (z *zoneSpreader) next() string {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+
+	preferredZone := z.zones[0]
+	for _, zone := range z.zones[1:] {
+		if z.counts[zone] < z.counts[preferredZone] {
+			preferredZone = zone
+		}
+	}
+	z.counts[preferredZone]++
+	return preferredZone
+}
+
+// getMachinesToTearDown returns the next of the candidate machines to terminate in the given machineutils.MachineTeardownOrder
+// while the machineSet is torn down. Machines are terminated one after another, i.e. no machine is returned as long as any
+// of the machines of the machineSet is still terminating.
+func (c *controller) getMachinesToTearDown(allMachines, candidateMachines []*v1alpha1.Machine, teardownOrder string) ([]*v1alpha1.Machine, error) {
+	for _, machine := range allMachines {
+		if machine.DeletionTimestamp != nil || machine.Status.CurrentStatus.Phase == v1alpha1.MachineTerminating {
+			klog.V(3).Infof("Machine %q is terminating, postponing the teardown of further machines", machine.Name)
+			return nil, nil
+		}
+	}
+	if len(candidateMachines) == 0 {
+		return nil, nil
+	}
+
+	podCounts, err := c.getPodCountsPerNode(candidateMachines)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := make([]*v1alpha1.Machine, len(candidateMachines))
+	copy(machines, candidateMachines)
+	sort.SliceStable(machines, func(i, j int) bool {
+		iCount, jCount := podCounts[machines[i].Labels[v1alpha1.NodeLabelKey]], podCounts[machines[j].Labels[v1alpha1.NodeLabelKey]]
+		if iCount == jCount {
+			return machines[i].Name < machines[j].Name
+		}
+		if teardownOrder == machineutils.TeardownOrderMostPodsFirst {
+			return iCount > jCount
+		}
+		return iCount < jCount
+	})
+	return machines[:1], nil
+}
+
+// getPodCountsPerNode returns the number of pods running on the nodes of the machines, not counting DaemonSet pods and completed pods
+func (c *controller) getPodCountsPerNode(machines []*v1alpha1.Machine) (map[string]int, error) {
+	podCounts := make(map[string]int)
+	if c.podIndexer == nil {
+		return podCounts, nil
+	}
+
+	for _, machine := range machines {
+		nodeName := machine.Labels[v1alpha1.NodeLabelKey]
+		if nodeName == "" {
+			continue
+		}
+		objs, err := c.podIndexer.ByIndex(podNodeNameIndex, nodeName)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range objs {
+			pod := obj.(*corev1.Pod)
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			if controllerRef := metav1.GetControllerOf(pod); controllerRef != nil && controllerRef.Kind == "DaemonSet" {
+				continue
+			}
+			podCounts[nodeName]++
+		}
+	}
+	return podCounts, nil
+}
+
+// handleLabelDriftedMachines applies the configured MachineLabelDriftPolicy to the machines owned by the machineSet
+// whose labels no longer match its selector. Re-adopted machines are returned with their updated labels, so that
+// they are claimed by the machineSet, while deleted machines are left out.
+func (c *controller) handleLabelDriftedMachines(ctx context.Context, machineSet *v1alpha1.MachineSet, selector labels.Selector, machines []*v1alpha1.Machine) ([]*v1alpha1.Machine, error) {
+	policy := c.safetyOptions.MachineLabelDriftPolicy
+	if policy == "" || policy == machineutils.LabelDriftPolicyRelease || machineSet.DeletionTimestamp != nil {
+		return machines, nil
+	}
+
+	var (
+		result  = make([]*v1alpha1.Machine, 0, len(machines))
+		errList []error
+	)
+	for _, machine := range machines {
+		controllerRef := metav1.GetControllerOf(machine)
+		if controllerRef == nil || controllerRef.UID != machineSet.UID || machine.DeletionTimestamp != nil || selector.Matches(labels.Set(machine.Labels)) {
+			result = append(result, machine)
+			continue
+		}
+
+		switch policy {
+		case machineutils.LabelDriftPolicyReadopt:
+			readopted, err := c.readoptMachine(ctx, machineSet, selector, machine)
+			if err != nil {
+				errList = append(errList, err)
+				result = append(result, machine)
+				continue
+			}
+			klog.V(2).Infof("Re-adopted machine %q of MachineSet %q by re-adding its selector labels", machine.Name, machineSet.Name)
+			c.recorder.Eventf(machineSet, corev1.EventTypeNormal, machineutils.MachineLabelDriftReason, "Re-adopted machine %q whose labels drifted out of the selector", machine.Name)
+			result = append(result, readopted)
+		case machineutils.LabelDriftPolicyDelete:
+			if err := c.machineControl.DeleteMachine(ctx, machine.Namespace, machine.Name, machineSet); err != nil && !apierrors.IsNotFound(err) {
+				errList = append(errList, fmt.Errorf("failed to delete machine %q whose labels drifted out of the selector of MachineSet %q: %w", machine.Name, machineSet.Name, err))
+				result = append(result, machine)
+				continue
+			}
+			klog.V(2).Infof("Deleted machine %q of MachineSet %q as its labels drifted out of the selector", machine.Name, machineSet.Name)
+			c.recorder.Eventf(machineSet, corev1.EventTypeNormal, machineutils.MachineLabelDriftReason, "Deleted machine %q whose labels drifted out of the selector", machine.Name)
+		default:
+			klog.Warningf("Ignoring unknown machine label drift policy %q", policy)
+			return machines, nil
+		}
+	}
+	return result, errorsutil.NewAggregate(errList)
+}
+
+// readoptMachine re-adds the selector labels of the machineSet to the machine and returns the updated machine
+func (c *controller) readoptMachine(ctx context.Context, machineSet *v1alpha1.MachineSet, selector labels.Selector, machine *v1alpha1.Machine) (*v1alpha1.Machine, error) {
+	clone := machine.DeepCopy()
+	if clone.Labels == nil {
+		clone.Labels = make(map[string]string, len(machineSet.Spec.Selector.MatchLabels))
+	}
+	for key, value := range machineSet.Spec.Selector.MatchLabels {
+		clone.Labels[key] = value
+	}
+	if !selector.Matches(labels.Set(clone.Labels)) {
+		return nil, fmt.Errorf("failed to re-adopt machine %q: the selector of MachineSet %q can't be satisfied by its match labels", machine.Name, machineSet.Name)
+	}
+
+	updated, err := c.controlMachineClient.Machines(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-adopt machine %q whose labels drifted out of the selector of MachineSet %q: %w", machine.Name, machineSet.Name, err)
+	}
+	return updated, nil
+}
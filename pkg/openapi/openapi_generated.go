@@ -428,6 +428,20 @@ func schema_pkg_apis_machine_v1alpha1_InPlaceUpdateMachineDeployment(ref common.
 							Format:      "",
 						},
 					},
+					"minAvailableAbsolute": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinAvailableAbsolute is the absolute minimum number of machines which have to be available during the update. The larger one of it and the minimum derived from MaxUnavailable is honored.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxConcurrentDrains": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxConcurrentDrains is the maximum number of machines which are selected for the update, and thus drained, at the same time across all old machine sets. Unset means unlimited.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
@@ -1402,6 +1416,34 @@ func schema_pkg_apis_machine_v1alpha1_MachineSetStatus(ref common.ReferenceCallb
 							},
 						},
 					},
+					"candidateForUpdateReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CandidateForUpdateReplicas is the number of machines whose nodes are labelled as candidate for an in-place update.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"selectedForUpdateReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SelectedForUpdateReplicas is the number of machines whose nodes are labelled as selected for an in-place update.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"updateSuccessfulReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdateSuccessfulReplicas is the number of machines whose nodes are labelled with a successful in-place update result.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"updateFailedReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpdateFailedReplicas is the number of machines whose nodes are labelled with a failed in-place update result.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 				},
 			},
 		},
@@ -1533,11 +1575,73 @@ func schema_pkg_apis_machine_v1alpha1_MachineStatus(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"capacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Capacity is the allocatable capacity of the VM as reported by the provider, e.g. cpu, memory and nvidia.com/gpu",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+									},
+								},
+							},
+						},
+					},
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of the machine observed by the machine controller when its deletion flow started. Destructive deletion steps are deferred if the generation advanced since then.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"failureCategory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureCategory is the category of the last provisioning failure of the machine, derived from the provider error. It is cleared once the VM of the machine was created.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"phaseDurations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PhaseDurations is the cumulative time the machine spent in each of its previous phases, keyed by the phase. The time is added on each transition to another phase, measured from the transition to the previous phase.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+									},
+								},
+							},
+						},
+					},
+					"lastSuccessfulReconcile": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSuccessfulReconcile is the time the machine controller last reconciled the machine without error.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"phaseTransitionTimes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PhaseTransitionTimes is the time the machine last transitioned to each of the known phases, keyed by the phase.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1.CurrentStatus", "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1.LastOperation", "k8s.io/api/core/v1.NodeCondition"},
+			"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1.CurrentStatus", "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1.LastOperation", "k8s.io/api/core/v1.NodeCondition", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -64,6 +64,9 @@ type MachineControllerManagerConfiguration struct {
 	// AutoscalerScaleDownAnnotationDuringRollout is an option to disable annotating the node-objects during roll-out.
 	// The cluster autoscaler native annotation is "cluster-autoscaler.kubernetes.io/scale-down-disabled".
 	AutoscalerScaleDownAnnotationDuringRollout bool
+	// AutoscalerMachineDeletionCost is an option to annotate the machines of a machineSet with their deletion cost,
+	// so that the cluster autoscaler prefers the same machines for scale-down as MCM.
+	AutoscalerMachineDeletionCost bool
 }
 
 // SafetyOptions are used to configure the upper-limit and lower-limit
@@ -78,6 +81,35 @@ type SafetyOptions struct {
 	// Period (in durartion) used to poll for overshooting
 	// of machine objects backing a machineSet by safety controller
 	MachineSafetyOvershootingPeriod metav1.Duration
+
+	// MaxConcurrentInPlaceRollouts is the maximum number of machineDeployments
+	// which are automatically updated in-place at the same time. 0 means no limit.
+	MaxConcurrentInPlaceRollouts int32
+
+	// InPlaceUpdateRequireDrainPreviewAck makes the machines of in-place updated machineDeployments wait for
+	// the acknowledgment of their drain preview by an operator before they are selected for update.
+	InPlaceUpdateRequireDrainPreviewAck bool
+
+	// MachineSetScaleUpStride is the maximum number of machines created for a machineSet
+	// per reconciliation, to stagger the load on the provider API. 0 means no limit.
+	MachineSetScaleUpStride int32
+
+	// MachineLabelDriftPolicy is the policy applied to machines owned by a machineSet
+	// whose labels no longer match its selector. One of Release, Readopt, Delete.
+	MachineLabelDriftPolicy string
+
+	// MachineDeploymentEventThrottleWindow is the window (in duration) within which repeated events
+	// of a machineDeployment with the same type and reason are aggregated into a single counted event.
+	// 0 disables the throttling.
+	MachineDeploymentEventThrottleWindow metav1.Duration
+
+	// FailedMachineRetention is the period (in duration) for which Failed machines of a machineSet are retained,
+	// so that their failure can be inspected, before they are deleted and replaced. 0 disables the retention.
+	FailedMachineRetention metav1.Duration
+
+	// AbortTerminationOnNodeRecovery aborts the termination of machines which were marked Failed by the health check,
+	// if their node becomes Ready again before the machine is deleted.
+	AbortTerminationOnNodeRecovery bool
 }
 
 // LeaderElectionConfiguration defines the configuration of leader election
@@ -37,6 +37,7 @@ import (
 	machineinformers "github.com/gardener/machine-controller-manager/pkg/client/informers/externalversions"
 	coreclientbuilder "github.com/gardener/machine-controller-manager/pkg/util/clientbuilder/core"
 	machineclientbuilder "github.com/gardener/machine-controller-manager/pkg/util/clientbuilder/machine"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/drain"
 	machinecontroller "github.com/gardener/machine-controller-manager/pkg/util/provider/machinecontroller"
 	kubernetesinformers "k8s.io/client-go/informers"
 	kubescheme "k8s.io/client-go/kubernetes/scheme"
@@ -72,7 +73,8 @@ var (
 )
 
 // Run runs the MCServer. This should never exit.
-func Run(s *options.MCServer, driver driver.Driver) error {
+// The preDrainHooks are run in order before the pods of the node of a machine are evicted.
+func Run(s *options.MCServer, driver driver.Driver, preDrainHooks ...drain.PreDrainHook) error {
 	// To help debugging, immediately log version
 	version.LogVersionInfoWithLevel(4)
 	if err := s.Validate(); err != nil {
@@ -155,6 +157,7 @@ func Run(s *options.MCServer, driver driver.Driver) error {
 			controlCoreClientBuilder,
 			targetCoreClientBuilder,
 			driver,
+			preDrainHooks,
 			recorder,
 			stop,
 		)
@@ -213,6 +216,7 @@ func StartControllers(s *options.MCServer,
 	controlCoreClientBuilder coreclientbuilder.ClientBuilder,
 	targetCoreClientBuilder coreclientbuilder.ClientBuilder,
 	driver driver.Driver,
+	preDrainHooks []drain.PreDrainHook,
 	recorder record.EventRecorder,
 	stop <-chan struct{}) error {
 
@@ -281,6 +285,11 @@ func StartControllers(s *options.MCServer,
 	// All shared informers are v1alpha1 API level
 	machineSharedInformers := controlMachineInformerFactory.Machine().V1alpha1()
 
+	machinePriorityComputer, err := machinecontroller.NewMachinePriorityComputer(s.MachinePriorityComputer)
+	if err != nil {
+		return err
+	}
+
 	klog.V(4).Infof("Creating controllers...")
 	machineController, err := machinecontroller.NewController(
 		s.Namespace,
@@ -290,13 +299,26 @@ func StartControllers(s *options.MCServer,
 		driver,
 		targetCoreInformerFactory,
 		controlCoreInformerFactory.Core().V1().Secrets(),
+		controlCoreInformerFactory.Core().V1().ConfigMaps(),
 		machineSharedInformers.MachineClasses(),
 		machineSharedInformers.Machines(),
+		machineSharedInformers.MachineSets(),
+		machineSharedInformers.MachineDeployments(),
 		recorder,
 		s.SafetyOptions,
 		s.NodeConditions,
+		s.ProviderConditions,
+		s.NodeTerminationConditionType,
+		s.NodeTerminationConditionReason,
+		s.NodeLabelTaints,
+		s.NodeLabelPropagationDenylist,
+		s.NodeTaintsRemovedOnRecovery,
+		s.MachineQueueFairness,
 		s.BootstrapTokenAuthExtraGroups,
 		targetKubernetesVersion,
+		machinePriorityComputer,
+		machinecontroller.NewHTTPPreDeletionHooks(s.MachinePreDeletionHooks, s.SafetyOptions.MachinePreDeletionHookTimeout.Duration),
+		preDrainHooks,
 	)
 	if err != nil {
 		return err
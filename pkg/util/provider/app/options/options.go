@@ -25,12 +25,15 @@ import (
 	"fmt"
 	"mime"
 	"net"
+	"net/url"
 	"time"
 
 	drain "github.com/gardener/machine-controller-manager/pkg/util/provider/drain"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
 	machineconfig "github.com/gardener/machine-controller-manager/pkg/util/provider/options"
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/component-base/logs"
 
@@ -56,28 +59,45 @@ func NewMCServer() *MCServer {
 		// Part of these default values also present in 'cmd/cloud-controller-manager/app/options/options.go'.
 		// Please keep them in sync when doing update.
 		MachineControllerConfiguration: machineconfig.MachineControllerConfiguration{
-			Port:                    10259,
-			Namespace:               "default",
-			Address:                 "0.0.0.0",
-			ConcurrentNodeSyncs:     50,
-			ContentType:             "application/vnd.kubernetes.protobuf",
-			NodeConditions:          "KernelDeadlock,ReadonlyFilesystem,DiskPressure,NetworkUnavailable",
-			MinResyncPeriod:         metav1.Duration{Duration: 12 * time.Hour},
-			KubeAPIQPS:              20.0,
-			KubeAPIBurst:            30,
-			LeaderElection:          leaderelectionconfig.DefaultLeaderElectionConfiguration(),
-			ControllerStartInterval: metav1.Duration{Duration: 0 * time.Second},
+			Port:                         10259,
+			Namespace:                    "default",
+			Address:                      "0.0.0.0",
+			ConcurrentNodeSyncs:          50,
+			ContentType:                  "application/vnd.kubernetes.protobuf",
+			NodeConditions:               "KernelDeadlock,ReadonlyFilesystem,DiskPressure,NetworkUnavailable",
+			MachinePriorityComputer:      "default",
+			NodeTerminationConditionType: string(machineutils.NodeTerminationCondition),
+			MinResyncPeriod:              metav1.Duration{Duration: 12 * time.Hour},
+			KubeAPIQPS:                   20.0,
+			KubeAPIBurst:                 30,
+			LeaderElection:               leaderelectionconfig.DefaultLeaderElectionConfiguration(),
+			ControllerStartInterval:      metav1.Duration{Duration: 0 * time.Second},
 			SafetyOptions: machineconfig.SafetyOptions{
-				MachineCreationTimeout:                   metav1.Duration{Duration: 20 * time.Minute},
-				MachineHealthTimeout:                     metav1.Duration{Duration: 10 * time.Minute},
-				MachineDrainTimeout:                      metav1.Duration{Duration: drain.DefaultMachineDrainTimeout},
-				MachineInPlaceUpdateTimeout:              metav1.Duration{Duration: 20 * time.Minute},
-				MaxEvictRetries:                          drain.DefaultMaxEvictRetries,
-				PvDetachTimeout:                          metav1.Duration{Duration: 2 * time.Minute},
-				PvReattachTimeout:                        metav1.Duration{Duration: 90 * time.Second},
-				MachineSafetyOrphanVMsPeriod:             metav1.Duration{Duration: 15 * time.Minute},
-				MachineSafetyAPIServerStatusCheckPeriod:  metav1.Duration{Duration: 1 * time.Minute},
-				MachineSafetyAPIServerStatusCheckTimeout: metav1.Duration{Duration: 30 * time.Second},
+				MachineCreationTimeout:                    metav1.Duration{Duration: 20 * time.Minute},
+				MachineHealthTimeout:                      metav1.Duration{Duration: 10 * time.Minute},
+				MachineCriticalComponentsNotReadyTimeout:  metav1.Duration{Duration: 10 * time.Minute},
+				MachineDrainTimeout:                       metav1.Duration{Duration: drain.DefaultMachineDrainTimeout},
+				MachineInPlaceUpdateTimeout:               metav1.Duration{Duration: 20 * time.Minute},
+				MachineDeletionAuthFailureTimeout:         metav1.Duration{Duration: 30 * time.Minute},
+				MachineDeletionAuthFailurePolicy:          machineutils.DeletionAuthFailurePolicyHold,
+				MachineDeletionProviderUnreachableTimeout: metav1.Duration{Duration: 30 * time.Minute},
+				MachineDeletionProviderUnreachablePolicy:  machineutils.DeletionProviderUnreachablePolicyStall,
+				NodeNotReadyPolicy:                        machineutils.NodeNotReadyPolicyReplace,
+				UnschedulableJoiningNodePolicy:            machineutils.UnschedulableJoiningNodePolicyKeep,
+				NoExecuteTaintDrainPolicy:                 machineutils.NoExecuteTaintDrainPolicyDrain,
+				NodeTerminationConditionTiming:            machineutils.NodeTerminationConditionTimingBeforeDrain,
+				NodeLocalVolumePodDrainPolicy:             drain.NodeLocalVolumePodPolicyEvict,
+				TerminalPodDrainPolicy:                    drain.TerminalPodPolicySkip,
+				MaxEvictRetries:                           drain.DefaultMaxEvictRetries,
+				PvDetachTimeout:                           metav1.Duration{Duration: 2 * time.Minute},
+				PvReattachTimeout:                         metav1.Duration{Duration: 90 * time.Second},
+				MachineSafetyOrphanVMsPeriod:              metav1.Duration{Duration: 15 * time.Minute},
+				MachineSafetyAPIServerStatusCheckPeriod:   metav1.Duration{Duration: 1 * time.Minute},
+				MachineSafetyAPIServerStatusCheckTimeout:  metav1.Duration{Duration: 30 * time.Second},
+				TargetClusterUnreachableRetryPeriod:       metav1.Duration{Duration: 30 * time.Second},
+				MachinePreDeletionHookTimeout:             metav1.Duration{Duration: 30 * time.Second},
+				PreDrainHookTimeout:                       metav1.Duration{Duration: 30 * time.Second},
+				MachineUnreachableGracePeriod:             metav1.Duration{Duration: 5 * time.Minute},
 			},
 		},
 	}
@@ -103,17 +123,61 @@ func (s *MCServer) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&s.ControllerStartInterval.Duration, "controller-start-interval", s.ControllerStartInterval.Duration, "Interval between starting controller managers.")
 
 	fs.DurationVar(&s.SafetyOptions.MachineCreationTimeout.Duration, "machine-creation-timeout", s.SafetyOptions.MachineCreationTimeout.Duration, "Timeout (in duration) used while joining (during creation) of machine before it is declared as failed.")
+	fs.DurationVar(&s.SafetyOptions.MachineCriticalComponentsNotReadyTimeout.Duration, "machine-critical-components-not-ready-timeout", s.SafetyOptions.MachineCriticalComponentsNotReadyTimeout.Duration, "Timeout (in duration) for which the node of a joining machine can have the node.gardener.cloud/critical-components-not-ready taint before the machine is declared as failed. Within it, the taint also extends the machine-health-timeout of Unknown machines. A value of 0 disables it, leaving such machines to the machine-creation-timeout.")
 	fs.DurationVar(&s.SafetyOptions.MachineHealthTimeout.Duration, "machine-health-timeout", s.SafetyOptions.MachineHealthTimeout.Duration, "Timeout (in duration) used while re-joining (in case of temporary health issues) of machine before it is declared as failed.")
 	fs.DurationVar(&s.SafetyOptions.MachineDrainTimeout.Duration, "machine-drain-timeout", drain.DefaultMachineDrainTimeout, "Timeout (in duration) used while draining of machine before deletion, beyond which MCM forcefully deletes machine.")
+	fs.DurationVar(&s.SafetyOptions.MachineDrainProgressUpdateInterval.Duration, "machine-drain-progress-update-interval", s.SafetyOptions.MachineDrainProgressUpdateInterval.Duration, "Interval (in duration) at which the status of a machine is updated with the number of pods remaining on its node and the time elapsed while it is drained. 0 disables the updates.")
+	fs.DurationVar(&s.SafetyOptions.NodeTerminationConditionPropagationRetry.Duration, "node-termination-condition-propagation-retry", s.SafetyOptions.NodeTerminationConditionPropagationRetry.Duration, "Period (in duration) after which the drain of a machine is continued once the termination condition was newly set on its node, giving it time to propagate to the informer caches. 0 drains the node right away.")
+	fs.StringVar(&s.SafetyOptions.NodeTerminationConditionTiming, "node-termination-condition-timing", s.SafetyOptions.NodeTerminationConditionTiming, "Timing of setting the termination condition on the node of a machine relative to its drain. One of: before-drain, after-drain. With after-drain the condition is only set once the pods, and hence their volumes, are gone from the node, right before its VM is deleted.")
 	fs.DurationVar(&s.SafetyOptions.MachineInPlaceUpdateTimeout.Duration, "machine-inplace-update-timeout", s.SafetyOptions.MachineInPlaceUpdateTimeout.Duration, "Timeout (in duration) used while updating a machine in-place, beyond which it is declared as failed.")
 	fs.Int32Var(&s.SafetyOptions.MaxEvictRetries, "machine-max-evict-retries", drain.DefaultMaxEvictRetries, "Maximum number of times evicts would be attempted on a pod before it is forcibly deleted during draining of a machine.")
 	fs.DurationVar(&s.SafetyOptions.PvDetachTimeout.Duration, "machine-pv-detach-timeout", s.SafetyOptions.PvDetachTimeout.Duration, "Timeout (in duration) used while waiting for detach of PV while evicting/deleting pods")
 	fs.DurationVar(&s.SafetyOptions.PvReattachTimeout.Duration, "machine-pv-reattach-timeout", s.SafetyOptions.PvReattachTimeout.Duration, "Timeout (in duration) used while waiting for reattach of PV onto a different node")
+	fs.Var(machineconfig.NodeSelectorTimeoutsVar{Val: &s.SafetyOptions.NodeNotReadyForceDrainTimeouts}, "node-not-ready-force-drain-timeout", "Mapping of the form <node-label-selector>:<duration> overriding for matching nodes how long a node has to be NotReady or have a ReadonlyFilesystem before its drain is forced during machine deletion (default machine-unreachable-grace-period). Can be repeated, the first matching selector wins.")
+	fs.DurationVar(&s.SafetyOptions.MachineUnreachableGracePeriod.Duration, "machine-unreachable-grace-period", s.SafetyOptions.MachineUnreachableGracePeriod.Duration, "Period (in duration) for which the node of a machine has to be NotReady or have a ReadonlyFilesystem before its drain is forced during machine deletion, evicting its pods without waiting for them. Overridden for matching nodes by node-not-ready-force-drain-timeout.")
+	fs.Float32Var(&s.SafetyOptions.NodeDeletionQPS, "node-deletion-qps", s.SafetyOptions.NodeDeletionQPS, "Maximum number of node objects deleted per second in the target cluster while deleting machines. 0 disables the rate limiting.")
+	fs.IntVar(&s.SafetyOptions.MaxConcurrentPodEvictions, "machine-max-concurrent-pod-evictions", s.SafetyOptions.MaxConcurrentPodEvictions, "Maximum number of concurrent pod evictions across all drains of machines, to protect the API server of the target cluster on mass teardowns. 0 disables the limit.")
+	fs.IntVar(&s.SafetyOptions.NodePodDensityThreshold, "node-pod-density-threshold", s.SafetyOptions.NodePodDensityThreshold, "Number of pods on the node of a machine above which a Warning event is emitted on the machine once it is crossed, hinting at a scheduling imbalance. Terminated pods are not counted. 0 disables the check.")
+	fs.IntVar(&s.SafetyOptions.MaxConcurrentProviderOperationsPerClass, "machine-max-concurrent-provider-operations-per-class", s.SafetyOptions.MaxConcurrentProviderOperationsPerClass, "Maximum number of concurrent VM creations and deletions combined for the machines of a MachineClass, to throttle the provider API usage while a pool is replaced. 0 disables the limit.")
+	fs.IntVar(&s.SafetyOptions.MaxConcurrentInPlaceUpdateDrains, "machine-max-concurrent-in-place-update-drains", s.SafetyOptions.MaxConcurrentInPlaceUpdateDrains, "Maximum number of machines selected for an in-place update whose nodes are drained concurrently, bounding the disruption on top of the maxUnavailable of the MachineDeployments. Further drains are deferred. 0 disables the limit.")
+	fs.StringVar(&s.SafetyOptions.NodeLocalVolumePodDrainPolicy, "node-local-volume-pod-drain-policy", s.SafetyOptions.NodeLocalVolumePodDrainPolicy, "Policy applied while draining a machine to the pods using node-local volumes of a StorageClass with the WaitForFirstConsumer binding mode, which can't be rescheduled on another node. One of: evict, skip, delete-pvc. With skip the pods are left on the node, with delete-pvc their PVCs are deleted before they are evicted so that the recreated pods bind new volumes.")
+	fs.StringVar(&s.SafetyOptions.TerminalPodDrainPolicy, "terminal-pod-drain-policy", s.SafetyOptions.TerminalPodDrainPolicy, "Policy applied while draining a machine to the pods in the Succeeded or Failed phase, which don't run any containers anymore. One of: skip, evict.")
+	fs.BoolVar(&s.SafetyOptions.PriorityOrderedDrainEviction, "machine-drain-priority-ordered-eviction", s.SafetyOptions.PriorityOrderedDrainEviction, "Evict the pods while draining a machine in groups of ascending priority, waiting for each group to be gone before the next one, so that the pods of the highest priority are evicted last. Pods without a PriorityClass have the priority 0.")
+	fs.BoolVar(&s.SafetyOptions.DrainFastPathForNodesWithoutEvictablePods, "machine-drain-fast-path-for-nodes-without-evictable-pods", s.SafetyOptions.DrainFastPathForNodesWithoutEvictablePods, "Skip the eviction of pods during the deletion of a machine whose node has no pods to evict, e.g. only DaemonSet and mirror pods, and proceed to the VM deletion right after cordoning the node without waiting for volume detachments.")
+	fs.StringVar(&s.SafetyOptions.CriticalDaemonSetPodSelector, "critical-daemonset-pod-selector", s.SafetyOptions.CriticalDaemonSetPodSelector, "Label selector of the critical DaemonSet pods (e.g. of CSI drivers) which have to report the SafeToTerminate condition on the node of a machine before its VM is deleted. If empty, the VM is deleted without waiting for them.")
+	fs.DurationVar(&s.SafetyOptions.MachineDeletionAuthFailureTimeout.Duration, "machine-deletion-auth-failure-timeout", s.SafetyOptions.MachineDeletionAuthFailureTimeout.Duration, "Timeout (in duration) for which the VM deletion of a machine has to fail due to authentication errors before the machine-deletion-auth-failure-policy is applied.")
+	fs.StringVar(&s.SafetyOptions.MachineDeletionAuthFailurePolicy, "machine-deletion-auth-failure-policy", s.SafetyOptions.MachineDeletionAuthFailurePolicy, "Policy applied to machines whose VM deletion persistently fails due to authentication errors. One of: Hold, ForceRemoveFinalizer. ForceRemoveFinalizer only applies to machines annotated with machine.sapcloud.io/force-finalizer-removal=true.")
+	fs.DurationVar(&s.SafetyOptions.MachineDeletionProviderUnreachableTimeout.Duration, "machine-deletion-provider-unreachable-timeout", s.SafetyOptions.MachineDeletionProviderUnreachableTimeout.Duration, "Timeout (in duration) for which the VM deletion of a machine has to fail as the provider is unreachable before the machine-deletion-provider-unreachable-policy is applied.")
+	fs.StringVar(&s.SafetyOptions.MachineDeletionProviderUnreachablePolicy, "machine-deletion-provider-unreachable-policy", s.SafetyOptions.MachineDeletionProviderUnreachablePolicy, "Policy applied to machines whose VM deletion persistently fails as the provider is unreachable. One of: Stall, ForceRemoveFinalizer. ForceRemoveFinalizer only applies to machines annotated with machine.sapcloud.io/force-finalizer-removal=true.")
+	fs.Int32Var(&s.SafetyOptions.MaxForceDeleteVMAttempts, "max-force-delete-vm-attempts", s.SafetyOptions.MaxForceDeleteVMAttempts, "Maximum number of failed DeleteMachine calls for a machine labeled force-deletion=true, after which the deletion continues with the deletion of the node and the removal of the finalizer without confirmation that the VM is deleted. The VM, its disks and their data may then be left behind at the provider. 0 keeps retrying the VM deletion.")
+	fs.StringVar(&s.SafetyOptions.NodeNotReadyPolicy, "node-not-ready-policy", s.SafetyOptions.NodeNotReadyPolicy, "Policy applied to machines whose node is NotReady for longer than the machine-health-timeout. One of: replace, reboot-before-replace. With reboot-before-replace the VM is rebooted first if the provider still reports it, and the machine is only replaced if its node doesn't become Ready within another machine-health-timeout.")
+	fs.StringVar(&s.SafetyOptions.UnschedulableJoiningNodePolicy, "unschedulable-joining-node-policy", s.SafetyOptions.UnschedulableJoiningNodePolicy, "Policy applied to the nodes of newly created machines which join the cluster cordoned, e.g. by a bootstrap flow keeping them unschedulable until they are ready. One of: keep, uncordon. With uncordon the node is uncordoned once it is healthy and the machine is marked Running.")
+	fs.StringVar(&s.SafetyOptions.NoExecuteTaintDrainPolicy, "noexecute-taint-drain-policy", s.SafetyOptions.NoExecuteTaintDrainPolicy, "Policy applied to the drain of a machine whose node has a NoExecute taint of another controller, e.g. a remediation of a node problem detector, which already evicts the pods not tolerating it. One of: drain, wait. With wait the drain is held until those pods are gone, or until the drain timeout, instead of evicting them concurrently.")
+	fs.DurationVar(&s.SafetyOptions.MachineConditionsUpdateInterval.Duration, "machine-conditions-update-interval", s.SafetyOptions.MachineConditionsUpdateInterval.Duration, "Minimum interval (in duration) between updates of the machine status reflecting changed node conditions, coalescing rapid changes. Phase changes of machines are not delayed. 0 disables the debouncing.")
+	fs.DurationVar(&s.SafetyOptions.MachineStatusCacheTTL.Duration, "machine-status-cache-ttl", s.SafetyOptions.MachineStatusCacheTTL.Duration, "Period (in duration) for which the result of the GetMachineStatus driver call of the health checks of a machine is cached, to reduce the load on the provider API. The cached result is invalidated on spec changes or the deletion of the machine. The result is cached for at least a minute.")
+	fs.DurationVar(&s.SafetyOptions.DeletionMachineStatusCacheTTL.Duration, "machine-deletion-status-cache-ttl", s.SafetyOptions.DeletionMachineStatusCacheTTL.Duration, "Period (in duration) for which the result of the GetMachineStatus driver call of the deletion flow of a machine is cached per provider ID, so that retries of the deletion don't call the provider API again. The cached result is invalidated by the deletion of the VM. 0 disables the caching.")
+	fs.DurationVar(&s.SafetyOptions.TargetClusterUnreachableRetryPeriod.Duration, "target-cluster-unreachable-retry-period", s.SafetyOptions.TargetClusterUnreachableRetryPeriod.Duration, "Period (in duration) after which the drain and the health timeout of a machine are re-evaluated while the APIServer of the target cluster is unreachable. Meanwhile the machine is neither force drained nor marked Failed, as its node can't be observed. 0 disables the hold.")
+	fs.DurationVar(&s.SafetyOptions.MachinePreDeletionHookTimeout.Duration, "machine-pre-deletion-hook-timeout", s.SafetyOptions.MachinePreDeletionHookTimeout.Duration, "Timeout (in duration) of a single call of a pre-deletion hook of a machine. A failed or timed out call is retried in the next sync.")
+	fs.DurationVar(&s.SafetyOptions.PreDrainHookTimeout.Duration, "pre-drain-hook-timeout", s.SafetyOptions.PreDrainHookTimeout.Duration, "Timeout (in duration) of a single call of a pre-drain hook of a machine. A failed or timed out call holds the drain until the next sync, unless the drain is forced, e.g. once the drain timeout elapsed.")
+	fs.DurationVar(&s.SafetyOptions.DriverCreateMachineTimeout.Duration, "driver-create-machine-timeout", s.SafetyOptions.DriverCreateMachineTimeout.Duration, "Timeout (in duration) of a single CreateMachine call of the driver, unless overridden by the machine.sapcloud.io/create-machine-timeout annotation of the MachineClass. A timed out call is retried in the next sync. 0 leaves the call unbounded.")
+	fs.DurationVar(&s.SafetyOptions.DriverDeleteMachineTimeout.Duration, "driver-delete-machine-timeout", s.SafetyOptions.DriverDeleteMachineTimeout.Duration, "Timeout (in duration) of a single DeleteMachine call of the driver, unless overridden by the machine.sapcloud.io/delete-machine-timeout annotation of the MachineClass. A timed out call is retried in the next sync. 0 leaves the call unbounded.")
+	fs.DurationVar(&s.SafetyOptions.DriverGetMachineStatusTimeout.Duration, "driver-get-machine-status-timeout", s.SafetyOptions.DriverGetMachineStatusTimeout.Duration, "Timeout (in duration) of a single GetMachineStatus call of the driver, unless overridden by the machine.sapcloud.io/get-machine-status-timeout annotation of the MachineClass. A timed out call is retried in the next sync. 0 leaves the call unbounded.")
+	fs.StringVar(&s.SafetyOptions.MachineQuotaConfigMap, "machine-quota-configmap", s.SafetyOptions.MachineQuotaConfigMap, "Name of a ConfigMap in the control namespace whose data maps the names of MachineClasses to the maximum number of machines which may reference them. The creation of further machines is held in Pending. The machine.sapcloud.io/max-machines annotation of a MachineClass takes precedence.")
+	fs.DurationVar(&s.SafetyOptions.BootstrapTokenTTL.Duration, "bootstrap-token-ttl", s.SafetyOptions.BootstrapTokenTTL.Duration, "Lifetime (in duration) of the bootstrap tokens created for the nodes of new machines. A pending machine whose node didn't join before its token expired is marked Failed. 0 lets the tokens expire after the creation timeout of the machine.")
 	fs.DurationVar(&s.SafetyOptions.MachineSafetyAPIServerStatusCheckTimeout.Duration, "machine-safety-apiserver-statuscheck-timeout", s.SafetyOptions.MachineSafetyAPIServerStatusCheckTimeout.Duration, "Timeout (in duration) for which the APIServer can be down before declare the machine controller frozen by safety controller")
 
 	fs.DurationVar(&s.SafetyOptions.MachineSafetyOrphanVMsPeriod.Duration, "machine-safety-orphan-vms-period", s.SafetyOptions.MachineSafetyOrphanVMsPeriod.Duration, "Time period (in duration) used to poll for orphan VMs by safety controller.")
 	fs.DurationVar(&s.SafetyOptions.MachineSafetyAPIServerStatusCheckPeriod.Duration, "machine-safety-apiserver-statuscheck-period", s.SafetyOptions.MachineSafetyAPIServerStatusCheckPeriod.Duration, "Time period (in duration) used to poll for APIServer's health by safety controller")
 	fs.StringVar(&s.NodeConditions, "node-conditions", s.NodeConditions, "List of comma-separated/case-sensitive node-conditions which when set to True will change machine to a failed state after MachineHealthTimeout duration. It may further be replaced with a new machine if the machine is backed by a machine-set object.")
+	fs.StringVar(&s.ProviderConditions, "provider-conditions", s.ProviderConditions, "List of comma-separated/case-sensitive custom conditions reported by the driver which when set to True will change machine to a failed state after MachineHealthTimeout duration. The driver is queried for custom conditions during health checks only if this is set.")
+	fs.StringVar(&s.NodeTerminationConditionType, "node-termination-condition-type", s.NodeTerminationConditionType, "Type of the condition set on the node of a machine while it is being deleted.")
+	fs.StringVar(&s.NodeTerminationConditionReason, "node-termination-condition-reason", s.NodeTerminationConditionReason, "Reason of the condition set on the node of a machine while it is being deleted. If empty, the reason is Unhealthy for failed machines and ScaleDown otherwise.")
+	fs.BoolVar(&s.MachineQueueFairness, "machine-queue-fairness", s.MachineQueueFairness, "Dequeue machines round-robin across MachineClasses instead of strictly FIFO, so that the churn of one large MachineClass doesn't starve the others.")
+	fs.Var(machineconfig.NodeLabelTaintsVar{Val: &s.NodeLabelTaints}, "node-label-taint", "Mapping of the form <node-label-selector>:<key>[=<value>]:<effect> of a taint maintained by MCM on the nodes of machines matching the selector. The taint is removed again once the node no longer matches. Can be repeated.")
+	fs.StringSliceVar(&s.NodeLabelPropagationDenylist, "node-label-propagation-denylist", s.NodeLabelPropagationDenylist, "Comma-separated list of label key prefixes, e.g. node-role.kubernetes.io/, which are never added, modified or removed on nodes while propagating the labels of the node templates of machines.")
+	fs.StringSliceVar(&s.MachinePreDeletionHooks, "machine-pre-deletion-hooks", s.MachinePreDeletionHooks, "Comma-separated list of URLs of HTTP endpoints which are called in order with a POST of the machine before its VM is deleted. The VM deletion waits until each endpoint returned a 2xx status, retrying failed calls.")
+	fs.StringSliceVar(&s.NodeTaintsRemovedOnRecovery, "node-taints-removed-on-recovery", s.NodeTaintsRemovedOnRecovery, "Comma-separated list of keys of the taints which are removed from the node of a machine once it recovers from an unhealthy phase and is Running again, e.g. taints applied by a remediation tooling to the nodes of unhealthy machines. Empty, the default, disables the removal.")
+	fs.StringVar(&s.MachinePriorityComputer, "machine-priority-computer", s.MachinePriorityComputer, "Name of the computer used to set the priority annotation on newly created machines. One of: default, prefer-delete-spot-first")
 	fs.StringVar(&s.BootstrapTokenAuthExtraGroups, "bootstrap-token-auth-extra-groups", s.BootstrapTokenAuthExtraGroups, "Comma-separated list of groups to set bootstrap token's \"auth-extra-groups\" field to")
 
 	logs.AddFlags(fs) // adds --v flag for log level.
@@ -154,15 +218,47 @@ func (s *MCServer) Validate() error {
 	if s.ControllerStartInterval.Duration < 0 {
 		errs = append(errs, fmt.Errorf("controller start interval should be a non-negative value: got %v", s.ControllerStartInterval.Duration))
 	}
+	if s.NodeTerminationConditionType == "" {
+		errs = append(errs, fmt.Errorf("node termination condition type should not be empty"))
+	}
+	for _, prefix := range s.NodeLabelPropagationDenylist {
+		if prefix == "" {
+			errs = append(errs, fmt.Errorf("node label propagation denylist should not contain empty prefixes"))
+			break
+		}
+	}
+	for _, key := range s.NodeTaintsRemovedOnRecovery {
+		if key == "" {
+			errs = append(errs, fmt.Errorf("node taints removed on recovery should not contain empty keys"))
+			break
+		}
+	}
+	for _, hook := range s.MachinePreDeletionHooks {
+		if u, err := url.Parse(hook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("machine pre-deletion hooks should be absolute http(s) URLs: got %q", hook))
+		}
+	}
 	if s.SafetyOptions.MachineCreationTimeout.Duration < 0 {
 		errs = append(errs, fmt.Errorf("machine creation timeout should be a non-negative number: got %v", s.SafetyOptions.MachineCreationTimeout.Duration))
 	}
+	if s.SafetyOptions.MachineCriticalComponentsNotReadyTimeout.Duration < 0 {
+		errs = append(errs, fmt.Errorf("machine critical components not ready timeout should be a non-negative number: got %v", s.SafetyOptions.MachineCriticalComponentsNotReadyTimeout.Duration))
+	}
 	if s.SafetyOptions.MachineHealthTimeout.Duration < 0 {
 		errs = append(errs, fmt.Errorf("machine health timeout should be a non-negative number: got %v", s.SafetyOptions.MachineHealthTimeout.Duration))
 	}
 	if s.SafetyOptions.MachineDrainTimeout.Duration < 0 {
 		errs = append(errs, fmt.Errorf("machine drain timeout should be a non-negative number: got %v", s.SafetyOptions.MachineDrainTimeout.Duration))
 	}
+	if s.SafetyOptions.MachineDrainProgressUpdateInterval.Duration < 0 {
+		errs = append(errs, fmt.Errorf("machine drain progress update interval should be a non-negative number: got %v", s.SafetyOptions.MachineDrainProgressUpdateInterval.Duration))
+	}
+	if s.SafetyOptions.NodeTerminationConditionPropagationRetry.Duration < 0 {
+		errs = append(errs, fmt.Errorf("node termination condition propagation retry should be a non-negative number: got %v", s.SafetyOptions.NodeTerminationConditionPropagationRetry.Duration))
+	}
+	if t := s.SafetyOptions.NodeTerminationConditionTiming; t != machineutils.NodeTerminationConditionTimingBeforeDrain && t != machineutils.NodeTerminationConditionTimingAfterDrain {
+		errs = append(errs, fmt.Errorf("node termination condition timing should be one of %s, %s: got %q", machineutils.NodeTerminationConditionTimingBeforeDrain, machineutils.NodeTerminationConditionTimingAfterDrain, t))
+	}
 	if s.SafetyOptions.MachineInPlaceUpdateTimeout.Duration < 0 {
 		errs = append(errs, fmt.Errorf("machine in-place update timeout should be a non-negative number: got %v", s.SafetyOptions.MachineInPlaceUpdateTimeout.Duration))
 	}
@@ -175,6 +271,92 @@ func (s *MCServer) Validate() error {
 	if s.SafetyOptions.PvReattachTimeout.Duration < 0 {
 		errs = append(errs, fmt.Errorf("machine PV reattach timeout should be a non-negative number: got %v", s.SafetyOptions.PvReattachTimeout.Duration))
 	}
+	for _, m := range s.SafetyOptions.NodeNotReadyForceDrainTimeouts {
+		if m.Timeout.Duration < 0 {
+			errs = append(errs, fmt.Errorf("node not ready force drain timeout for selector %q should be a non-negative number: got %v", m.Selector, m.Timeout.Duration))
+		}
+	}
+	if _, err := labels.Parse(s.SafetyOptions.CriticalDaemonSetPodSelector); err != nil {
+		errs = append(errs, fmt.Errorf("critical DaemonSet pod selector %q cannot be parsed: %w", s.SafetyOptions.CriticalDaemonSetPodSelector, err))
+	}
+	if s.SafetyOptions.NodeDeletionQPS < 0 {
+		errs = append(errs, fmt.Errorf("node deletion QPS should not be a negative value: got %v", s.SafetyOptions.NodeDeletionQPS))
+	}
+	if s.SafetyOptions.MaxConcurrentPodEvictions < 0 {
+		errs = append(errs, fmt.Errorf("max concurrent pod evictions should not be a negative value: got %d", s.SafetyOptions.MaxConcurrentPodEvictions))
+	}
+	if s.SafetyOptions.NodePodDensityThreshold < 0 {
+		errs = append(errs, fmt.Errorf("node pod density threshold should not be a negative value: got %d", s.SafetyOptions.NodePodDensityThreshold))
+	}
+	if s.SafetyOptions.MaxConcurrentProviderOperationsPerClass < 0 {
+		errs = append(errs, fmt.Errorf("max concurrent provider operations per class should not be a negative value: got %d", s.SafetyOptions.MaxConcurrentProviderOperationsPerClass))
+	}
+	if s.SafetyOptions.MaxConcurrentInPlaceUpdateDrains < 0 {
+		errs = append(errs, fmt.Errorf("max concurrent in-place update drains should not be a negative value: got %d", s.SafetyOptions.MaxConcurrentInPlaceUpdateDrains))
+	}
+	if p := s.SafetyOptions.NodeLocalVolumePodDrainPolicy; p != drain.NodeLocalVolumePodPolicyEvict && p != drain.NodeLocalVolumePodPolicySkip && p != drain.NodeLocalVolumePodPolicyDeletePVC {
+		errs = append(errs, fmt.Errorf("node local volume pod drain policy should be one of %s, %s, %s: got %q", drain.NodeLocalVolumePodPolicyEvict, drain.NodeLocalVolumePodPolicySkip, drain.NodeLocalVolumePodPolicyDeletePVC, p))
+	}
+	if p := s.SafetyOptions.TerminalPodDrainPolicy; p != drain.TerminalPodPolicySkip && p != drain.TerminalPodPolicyEvict {
+		errs = append(errs, fmt.Errorf("terminal pod drain policy should be one of %s, %s: got %q", drain.TerminalPodPolicySkip, drain.TerminalPodPolicyEvict, p))
+	}
+	if s.SafetyOptions.MachineDeletionAuthFailureTimeout.Duration < 0 {
+		errs = append(errs, fmt.Errorf("machine deletion auth failure timeout should be a non-negative number: got %v", s.SafetyOptions.MachineDeletionAuthFailureTimeout.Duration))
+	}
+	if p := s.SafetyOptions.MachineDeletionAuthFailurePolicy; p != machineutils.DeletionAuthFailurePolicyHold && p != machineutils.DeletionAuthFailurePolicyForceRemoveFinalizer {
+		errs = append(errs, fmt.Errorf("machine deletion auth failure policy should be one of %s, %s: got %q", machineutils.DeletionAuthFailurePolicyHold, machineutils.DeletionAuthFailurePolicyForceRemoveFinalizer, p))
+	}
+	if s.SafetyOptions.MachineDeletionProviderUnreachableTimeout.Duration < 0 {
+		errs = append(errs, fmt.Errorf("machine deletion provider unreachable timeout should be a non-negative number: got %v", s.SafetyOptions.MachineDeletionProviderUnreachableTimeout.Duration))
+	}
+	if p := s.SafetyOptions.MachineDeletionProviderUnreachablePolicy; p != machineutils.DeletionProviderUnreachablePolicyStall && p != machineutils.DeletionProviderUnreachablePolicyForceRemoveFinalizer {
+		errs = append(errs, fmt.Errorf("machine deletion provider unreachable policy should be one of %s, %s: got %q", machineutils.DeletionProviderUnreachablePolicyStall, machineutils.DeletionProviderUnreachablePolicyForceRemoveFinalizer, p))
+	}
+	if p := s.SafetyOptions.NodeNotReadyPolicy; p != machineutils.NodeNotReadyPolicyReplace && p != machineutils.NodeNotReadyPolicyRebootBeforeReplace {
+		errs = append(errs, fmt.Errorf("node not ready policy should be one of %s, %s: got %q", machineutils.NodeNotReadyPolicyReplace, machineutils.NodeNotReadyPolicyRebootBeforeReplace, p))
+	}
+	if p := s.SafetyOptions.UnschedulableJoiningNodePolicy; p != machineutils.UnschedulableJoiningNodePolicyKeep && p != machineutils.UnschedulableJoiningNodePolicyUncordon {
+		errs = append(errs, fmt.Errorf("unschedulable joining node policy should be one of %s, %s: got %q", machineutils.UnschedulableJoiningNodePolicyKeep, machineutils.UnschedulableJoiningNodePolicyUncordon, p))
+	}
+	if p := s.SafetyOptions.NoExecuteTaintDrainPolicy; p != machineutils.NoExecuteTaintDrainPolicyDrain && p != machineutils.NoExecuteTaintDrainPolicyWait {
+		errs = append(errs, fmt.Errorf("noexecute taint drain policy should be one of %s, %s: got %q", machineutils.NoExecuteTaintDrainPolicyDrain, machineutils.NoExecuteTaintDrainPolicyWait, p))
+	}
+	if s.SafetyOptions.MachineConditionsUpdateInterval.Duration < 0 {
+		errs = append(errs, fmt.Errorf("machine conditions update interval should be a non-negative number: got %v", s.SafetyOptions.MachineConditionsUpdateInterval.Duration))
+	}
+	if s.SafetyOptions.MachineStatusCacheTTL.Duration < 0 {
+		errs = append(errs, fmt.Errorf("machine status cache TTL should be a non-negative number: got %v", s.SafetyOptions.MachineStatusCacheTTL.Duration))
+	}
+	if s.SafetyOptions.DeletionMachineStatusCacheTTL.Duration < 0 {
+		errs = append(errs, fmt.Errorf("deletion machine status cache TTL should be a non-negative number: got %v", s.SafetyOptions.DeletionMachineStatusCacheTTL.Duration))
+	}
+	if s.SafetyOptions.BootstrapTokenTTL.Duration < 0 {
+		errs = append(errs, fmt.Errorf("bootstrap token TTL should be a non-negative number: got %v", s.SafetyOptions.BootstrapTokenTTL.Duration))
+	}
+	if s.SafetyOptions.TargetClusterUnreachableRetryPeriod.Duration < 0 {
+		errs = append(errs, fmt.Errorf("target cluster unreachable retry period should be a non-negative number: got %v", s.SafetyOptions.TargetClusterUnreachableRetryPeriod.Duration))
+	}
+	if s.SafetyOptions.MachineUnreachableGracePeriod.Duration < 0 {
+		errs = append(errs, fmt.Errorf("machine unreachable grace period should be a non-negative number: got %v", s.SafetyOptions.MachineUnreachableGracePeriod.Duration))
+	}
+	if s.SafetyOptions.MachinePreDeletionHookTimeout.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("machine pre-deletion hook timeout should be greater than zero: got %v", s.SafetyOptions.MachinePreDeletionHookTimeout.Duration))
+	}
+	if s.SafetyOptions.PreDrainHookTimeout.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("pre-drain hook timeout should be greater than zero: got %v", s.SafetyOptions.PreDrainHookTimeout.Duration))
+	}
+	if s.SafetyOptions.MaxForceDeleteVMAttempts < 0 {
+		errs = append(errs, fmt.Errorf("max force delete VM attempts should be a non-negative number: got %d", s.SafetyOptions.MaxForceDeleteVMAttempts))
+	}
+	if s.SafetyOptions.DriverCreateMachineTimeout.Duration < 0 {
+		errs = append(errs, fmt.Errorf("driver create machine timeout should be a non-negative number: got %v", s.SafetyOptions.DriverCreateMachineTimeout.Duration))
+	}
+	if s.SafetyOptions.DriverDeleteMachineTimeout.Duration < 0 {
+		errs = append(errs, fmt.Errorf("driver delete machine timeout should be a non-negative number: got %v", s.SafetyOptions.DriverDeleteMachineTimeout.Duration))
+	}
+	if s.SafetyOptions.DriverGetMachineStatusTimeout.Duration < 0 {
+		errs = append(errs, fmt.Errorf("driver get machine status timeout should be a non-negative number: got %v", s.SafetyOptions.DriverGetMachineStatusTimeout.Duration))
+	}
 	if s.SafetyOptions.MachineSafetyAPIServerStatusCheckTimeout.Duration < 0 {
 		errs = append(errs, fmt.Errorf("machine safety APIServer status check timeout should be a non-negative number: got %v", s.SafetyOptions.MachineSafetyAPIServerStatusCheckTimeout.Duration))
 	}
@@ -45,8 +45,10 @@ import (
 	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	policyv1listers "k8s.io/client-go/listers/policy/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
 )
@@ -56,11 +58,13 @@ type Options struct {
 	client                       kubernetes.Interface
 	kubernetesVersion            *semver.Version
 	DeleteLocalData              bool
+	DeleteOnly                   bool
 	Driver                       driver.Driver
 	drainStartedOn               time.Time
 	drainEndedOn                 time.Time
 	ErrOut                       io.Writer
 	ForceDeletePods              bool
+	SkipCordon                   bool
 	GracePeriodSeconds           int
 	IgnorePodsWithoutControllers bool
 	IgnoreDaemonsets             bool
@@ -71,12 +75,59 @@ type Options struct {
 	Out                          io.Writer
 	pvcLister                    corelisters.PersistentVolumeClaimLister
 	pvLister                     corelisters.PersistentVolumeLister
+	storageClassLister           storagelisters.StorageClassLister
 	pdbLister                    policyv1listers.PodDisruptionBudgetLister
 	nodeLister                   corelisters.NodeLister
 	podLister                    corelisters.PodLister
 	volumeAttachmentHandler      *VolumeAttachmentHandler
 	Timeout                      time.Duration
 	podSynced                    cache.InformerSynced
+	// EvictionLimiter bounds the concurrent pod evictions across all drains sharing it – nil when not configured
+	EvictionLimiter *EvictionLimiter
+	// ProgressInterval is the interval at which ProgressFn is called while pods are evicted – 0 disables the reports
+	ProgressInterval time.Duration
+	// ProgressFn is called with the number of pods remaining on the node and the time elapsed since the drain started
+	ProgressFn func(remainingPods int, elapsed time.Duration)
+	// NodeLocalVolumePodPolicy is the handling of pods using node-local volumes of a StorageClass with the
+	// WaitForFirstConsumer binding mode – one of evict, skip, delete-pvc. An empty policy evicts them.
+	NodeLocalVolumePodPolicy string
+	// TerminalPodPolicy is the handling of pods in the Succeeded or Failed phase – one of skip, evict.
+	// An empty policy skips them.
+	TerminalPodPolicy string
+	// PriorityOrderedEviction evicts the pods in groups of ascending priority, waiting for each group to be drained
+	// before the next one, so that the pods of the highest priority are evicted last
+	PriorityOrderedEviction bool
+	// SkipPodSelector selects the pods which are left on the node by the drain, e.g. agents managed separately.
+	// It takes precedence over the handling of DaemonSet pods – nil skips no pods.
+	SkipPodSelector labels.Selector
+}
+
+// EvictionLimiter is a semaphore bounding the number of concurrent pod evictions and deletions
+// across all drains sharing it, so that draining many nodes at once doesn't overwhelm the API server
+type EvictionLimiter struct {
+	permits chan struct{}
+}
+
+// NewEvictionLimiter returns an EvictionLimiter permitting at most maxConcurrentEvictions concurrent
+// pod evictions, or nil if maxConcurrentEvictions isn't positive, i.e. the evictions aren't limited
+func NewEvictionLimiter(maxConcurrentEvictions int) *EvictionLimiter {
+	if maxConcurrentEvictions <= 0 {
+		return nil
+	}
+	return &EvictionLimiter{permits: make(chan struct{}, maxConcurrentEvictions)}
+}
+
+// acquire blocks until a permit is available or the context is done. The returned func releases the permit.
+func (l *EvictionLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.permits <- struct{}{}:
+		return func() { <-l.permits }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 // Takes a pod and returns a bool indicating whether or not to operate on the
@@ -156,6 +207,24 @@ const (
 	unmanagedFatal      = "pods not managed by ReplicationController, ReplicaSet, Job, DaemonSet or StatefulSet (use --force to override)"
 	unmanagedWarning    = "Deleting pods not managed by ReplicationController, ReplicaSet, Job, DaemonSet or StatefulSet"
 	reattachTimeoutErr  = "Timeout occurred while waiting for PV to reattach to a different node"
+
+	nodeLocalVolumeWarning = "Ignoring pods with node-local volumes bound on first consumer"
+
+	// NodeLocalVolumePodPolicyEvict is the policy which evicts the pods using node-local volumes bound on first
+	// consumer like any other pod, leaving them unable to reschedule on another node
+	NodeLocalVolumePodPolicyEvict = "evict"
+	// NodeLocalVolumePodPolicySkip is the policy which leaves the pods using node-local volumes bound on first
+	// consumer on the node, so that they don't block the drain
+	NodeLocalVolumePodPolicySkip = "skip"
+	// NodeLocalVolumePodPolicyDeletePVC is the policy which deletes the PersistentVolumeClaims of the node-local volumes
+	// bound on first consumer before evicting the pods using them, so that they are rebound on another node
+	NodeLocalVolumePodPolicyDeletePVC = "delete-pvc"
+
+	// TerminalPodPolicySkip is the policy which leaves the pods in the Succeeded or Failed phase on the node,
+	// as they don't run any containers which would have to be evicted
+	TerminalPodPolicySkip = "skip"
+	// TerminalPodPolicyEvict is the policy which evicts the pods in the Succeeded or Failed phase like any other pod
+	TerminalPodPolicyEvict = "evict"
 )
 
 var (
@@ -182,6 +251,7 @@ func NewDrainOptions(
 	driver driver.Driver,
 	pvcLister corelisters.PersistentVolumeClaimLister,
 	pvLister corelisters.PersistentVolumeLister,
+	storageClassLister storagelisters.StorageClassLister,
 	pdbLister policyv1listers.PodDisruptionBudgetLister,
 	nodeLister corelisters.NodeLister,
 	podLister corelisters.PodLister,
@@ -206,6 +276,7 @@ func NewDrainOptions(
 		Driver:                       driver,
 		pvcLister:                    pvcLister,
 		pvLister:                     pvLister,
+		storageClassLister:           storageClassLister,
 		pdbLister:                    pdbLister,
 		nodeLister:                   nodeLister,
 		podLister:                    podLister,
@@ -235,7 +306,9 @@ func (o *Options) RunDrain(ctx context.Context) error {
 		)
 	}()
 
-	if err := o.RunCordonOrUncordon(drainContext, true); err != nil {
+	if o.SkipCordon {
+		klog.V(3).Infof("Skipping cordon of node %q", o.nodeName)
+	} else if err := o.RunCordonOrUncordon(drainContext, true); err != nil {
 		klog.Errorf("Drain Error: Cordoning of node failed with error: %v", err)
 		return err
 	}
@@ -244,16 +317,103 @@ func (o *Options) RunDrain(ctx context.Context) error {
 		return err
 	}
 
+	stopProgressReports := o.startProgressReports(drainContext)
 	err := o.deleteOrEvictPodsSimple(drainContext)
+	stopProgressReports()
 	return err
 }
 
+// startProgressReports periodically calls the ProgressFn until the returned func is called, which
+// waits for a running call to return. It does nothing if the ProgressInterval or ProgressFn isn't set.
+func (o *Options) startProgressReports(ctx context.Context) func() {
+	if o.ProgressInterval <= 0 || o.ProgressFn == nil {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(o.ProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				remainingPods, err := o.countPodsForDeletion()
+				if err != nil {
+					klog.Warningf("Failed to count the pods remaining on node %q to report the drain progress: %v", o.nodeName, err)
+					continue
+				}
+				o.ProgressFn(remainingPods, time.Since(o.drainStartedOn))
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// countPodsForDeletion returns the number of pods on the node which are deleted by the drain. Unlike
+// getPodsForDeletion, it doesn't report pods which prevent the drain and doesn't write any warnings.
+func (o *Options) countPodsForDeletion() (int, error) {
+	podList, err := o.podLister.List(labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, pod := range podList {
+		if pod.Spec.NodeName != o.nodeName || o.skipsTerminalPod(pod) || o.skipsSelectedPod(pod) {
+			continue
+		}
+		podOk := true
+		for _, filt := range []podFilter{mirrorPodFilter, o.localStorageFilter, o.unreplicatedFilter, o.daemonsetFilter, o.nodeLocalVolumeFilter} {
+			filterOk, _, _ := filt(*pod)
+			podOk = podOk && filterOk
+		}
+		if podOk {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DryRun returns the pods on the node which a drain with the options the machine controller drains nodes with would
+// evict or delete, without evicting or deleting any of them.
+func DryRun(podLister corelisters.PodLister, nodeName string) ([]corev1.Pod, error) {
+	o := &Options{
+		IgnorePodsWithoutControllers: true,
+		IgnoreDaemonsets:             true,
+		DeleteLocalData:              true,
+		nodeName:                     nodeName,
+		podLister:                    podLister,
+		ErrOut:                       io.Discard,
+	}
+	return o.DryRun()
+}
+
+// DryRun returns the pods on the node which the drain with these options would evict or delete, without evicting or
+// deleting any of them.
+func (o *Options) DryRun() ([]corev1.Pod, error) {
+	return o.getPodsForDeletion()
+}
+
 func (o *Options) deleteOrEvictPodsSimple(ctx context.Context) error {
 	pods, err := o.getPodsForDeletion()
 	if err != nil {
 		return err
 	}
 
+	if err := o.deleteNodeLocalVolumePVCs(ctx, pods); err != nil {
+		return err
+	}
+
 	err = o.deleteOrEvictPods(ctx, pods)
 	if err != nil {
 		pendingPods, newErr := o.getPodsForDeletion()
@@ -334,6 +494,99 @@ func (o *Options) localStorageFilter(pod corev1.Pod) (bool, *warning, *fatal) {
 	return true, &warning{localStorageWarning}, nil
 }
 
+// skipsTerminalPod returns whether the pod is in the Succeeded or Failed phase and hence left on the node by the drain,
+// unless the TerminalPodPolicy is evict
+func (o *Options) skipsTerminalPod(pod *corev1.Pod) bool {
+	return o.TerminalPodPolicy != TerminalPodPolicyEvict && (pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed)
+}
+
+// skipsSelectedPod returns whether the pod is matched by the SkipPodSelector and hence left on the node by the drain
+func (o *Options) skipsSelectedPod(pod *corev1.Pod) bool {
+	return o.SkipPodSelector != nil && !o.SkipPodSelector.Empty() && o.SkipPodSelector.Matches(labels.Set(pod.Labels))
+}
+
+// nodeLocalVolumeFilter excludes the pods using node-local volumes bound on first consumer from the drain
+// if the NodeLocalVolumePodPolicy is skip
+func (o *Options) nodeLocalVolumeFilter(pod corev1.Pod) (bool, *warning, *fatal) {
+	if o.NodeLocalVolumePodPolicy != NodeLocalVolumePodPolicySkip || len(o.getNodeLocalVolumePVCNames(&pod)) == 0 {
+		return true, nil, nil
+	}
+	klog.V(3).Infof("Skipping pod %s/%s on node %q as it uses node-local volumes bound on first consumer", pod.Namespace, pod.Name, o.nodeName)
+	return false, &warning{nodeLocalVolumeWarning}, nil
+}
+
+// deleteNodeLocalVolumePVCs deletes the PersistentVolumeClaims of the node-local volumes bound on first consumer
+// used by the pods if the NodeLocalVolumePodPolicy is delete-pvc. The claims are only removed once the pods are
+// gone, which lets the pods recreated on another node bind new volumes.
+func (o *Options) deleteNodeLocalVolumePVCs(ctx context.Context, pods []corev1.Pod) error {
+	if o.NodeLocalVolumePodPolicy != NodeLocalVolumePodPolicyDeletePVC {
+		return nil
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		for _, pvcName := range o.getNodeLocalVolumePVCNames(pod) {
+			klog.V(3).Infof("Deleting PVC %s/%s of the node-local volume used by pod %s on node %q", pod.Namespace, pvcName, pod.Name, o.nodeName)
+			err := o.client.CoreV1().PersistentVolumeClaims(pod.Namespace).Delete(ctx, pvcName, metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete PVC %s/%s of pod %s: %w", pod.Namespace, pvcName, pod.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// getNodeLocalVolumePVCNames returns the names of the PersistentVolumeClaims of the pod which are bound to node-local
+// volumes of a StorageClass with the WaitForFirstConsumer binding mode, i.e. which can't be used on another node
+func (o *Options) getNodeLocalVolumePVCNames(pod *corev1.Pod) []string {
+	var pvcNames []string
+	for i := range pod.Spec.Volumes {
+		vol := &pod.Spec.Volumes[i]
+		if !volIsPvc(vol) {
+			continue
+		}
+
+		pvc, err := o.pvcLister.PersistentVolumeClaims(pod.Namespace).Get(vol.PersistentVolumeClaim.ClaimName)
+		if err != nil || pvc.Spec.VolumeName == "" || ptr.Deref(pvc.Spec.StorageClassName, "") == "" {
+			continue
+		}
+		storageClass, err := o.storageClassLister.Get(*pvc.Spec.StorageClassName)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.Warningf("Failed to get StorageClass %q of PVC %s/%s: %v", *pvc.Spec.StorageClassName, pvc.Namespace, pvc.Name, err)
+			}
+			continue
+		}
+		if ptr.Deref(storageClass.VolumeBindingMode, storagev1.VolumeBindingImmediate) != storagev1.VolumeBindingWaitForFirstConsumer {
+			continue
+		}
+		pv, err := o.pvLister.Get(pvc.Spec.VolumeName)
+		if err != nil || !isNodeLocalPV(pv) {
+			continue
+		}
+		pvcNames = append(pvcNames, pvc.Name)
+	}
+	return pvcNames
+}
+
+// isNodeLocalPV checks whether the PersistentVolume is only accessible from a single node
+func isNodeLocalPV(pv *corev1.PersistentVolume) bool {
+	if pv.Spec.Local != nil || pv.Spec.HostPath != nil {
+		return true
+	}
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return false
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == corev1.LabelHostname {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Map of status message to a list of pod names having that status.
 type podStatuses map[string][]string
 
@@ -359,13 +612,23 @@ func (o *Options) getPodsForDeletion() (pods []corev1.Pod, err error) {
 	}
 	ws := podStatuses{}
 	fs := podStatuses{}
+	skippedTerminalPods := 0
+	var skippedSelectedPods []string
 
 	for _, pod := range podList {
 		if pod.Spec.NodeName != o.nodeName {
 			continue
 		}
+		if o.skipsTerminalPod(pod) {
+			skippedTerminalPods++
+			continue
+		}
+		if o.skipsSelectedPod(pod) {
+			skippedSelectedPods = append(skippedSelectedPods, pod.Namespace+"/"+pod.Name)
+			continue
+		}
 		podOk := true
-		for _, filt := range []podFilter{mirrorPodFilter, o.localStorageFilter, o.unreplicatedFilter, o.daemonsetFilter} {
+		for _, filt := range []podFilter{mirrorPodFilter, o.localStorageFilter, o.unreplicatedFilter, o.daemonsetFilter, o.nodeLocalVolumeFilter} {
 			filterOk, w, f := filt(*pod)
 			podOk = podOk && filterOk
 			if w != nil {
@@ -380,6 +643,12 @@ func (o *Options) getPodsForDeletion() (pods []corev1.Pod, err error) {
 		}
 	}
 
+	if skippedTerminalPods > 0 {
+		klog.V(2).Infof("Skipping %d pod(s) in the Succeeded or Failed phase on node %q", skippedTerminalPods, o.nodeName)
+	}
+	if len(skippedSelectedPods) > 0 {
+		klog.V(2).Infof("Skipping pod(s) %v on node %q as they match the skip pod selector %q", skippedSelectedPods, o.nodeName, o.SkipPodSelector)
+	}
 	if len(fs) > 0 {
 		return []corev1.Pod{}, errors.New(fs.Message())
 	}
@@ -390,6 +659,12 @@ func (o *Options) getPodsForDeletion() (pods []corev1.Pod, err error) {
 }
 
 func (o *Options) deletePod(ctx context.Context, pod *corev1.Pod) error {
+	release, err := o.EvictionLimiter.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	deleteOptions := metav1.DeleteOptions{}
 	gracePeriodSeconds := int64(0)
 	deleteOptions.GracePeriodSeconds = &gracePeriodSeconds
@@ -398,7 +673,40 @@ func (o *Options) deletePod(ctx context.Context, pod *corev1.Pod) error {
 	return o.client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOptions)
 }
 
+// deletePodWithinDisruptionBudget deletes the pod without using the eviction subresource.
+// The PodDisruptionBudget of the pod is still respected by checking whether it allows a disruption,
+// in which case a TooManyRequests error is returned just like the eviction API would do.
+func (o *Options) deletePodWithinDisruptionBudget(ctx context.Context, pod *corev1.Pod) error {
+	if o.pdbLister != nil {
+		if pdb := getPdbForPod(o.pdbLister, pod); pdb != nil && pdb.Status.DisruptionsAllowed <= 0 {
+			return apierrors.NewTooManyRequests(
+				fmt.Sprintf("Cannot delete pod %s/%s as it would violate the pod's disruption budget %s/%s", pod.Namespace, pod.Name, pdb.Namespace, pdb.Name),
+				0,
+			)
+		}
+	}
+
+	deleteOptions := metav1.DeleteOptions{}
+	if o.GracePeriodSeconds >= 0 {
+		gracePeriodSeconds := int64(o.GracePeriodSeconds)
+		deleteOptions.GracePeriodSeconds = &gracePeriodSeconds
+	}
+
+	klog.V(3).Infof("Attempting to delete the pod:%q from node %q without eviction", pod.Name, o.nodeName)
+	return o.client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOptions)
+}
+
 func (o *Options) evictPod(ctx context.Context, pod *corev1.Pod, policyGroupVersion string) error {
+	release, err := o.EvictionLimiter.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if o.DeleteOnly {
+		return o.deletePodWithinDisruptionBudget(ctx, pod)
+	}
+
 	deleteOptions := &metav1.DeleteOptions{}
 	if o.GracePeriodSeconds >= 0 {
 		gracePeriodSeconds := int64(o.GracePeriodSeconds)
@@ -435,9 +743,47 @@ func (o *Options) deleteOrEvictPods(ctx context.Context, pods []corev1.Pod) erro
 		return o.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 	}
 
-	attemptEvict := !o.ForceDeletePods && len(policyGroupVersion) > 0
+	// With DeleteOnly, pods are deleted instead of evicted, so support for eviction isn't required
+	attemptEvict := !o.ForceDeletePods && (o.DeleteOnly || len(policyGroupVersion) > 0)
 
-	return o.evictPods(ctx, attemptEvict, pods, policyGroupVersion, getPodFn)
+	if !o.PriorityOrderedEviction {
+		return o.evictPods(ctx, attemptEvict, pods, policyGroupVersion, getPodFn)
+	}
+
+	for _, group := range groupPodsByPriority(pods) {
+		klog.V(3).Infof("Evicting %d pods of priority %d on the node: %q", len(group), getPodPriority(&group[0]), o.nodeName)
+		if err := o.evictPods(ctx, attemptEvict, group, policyGroupVersion, getPodFn); err != nil {
+			// the pods of higher priority are only evicted once all pods of lower priority are gone
+			return err
+		}
+	}
+	return nil
+}
+
+// getPodPriority returns the priority of the pod resolved from its PriorityClass, 0 if it has none
+func getPodPriority(pod *corev1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
+
+// groupPodsByPriority groups the pods by ascending priority, keeping the order of the pods within each group
+func groupPodsByPriority(pods []corev1.Pod) [][]corev1.Pod {
+	sorted := make([]corev1.Pod, len(pods))
+	copy(sorted, pods)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return getPodPriority(&sorted[i]) < getPodPriority(&sorted[j])
+	})
+
+	var groups [][]corev1.Pod
+	for i := range sorted {
+		if i == 0 || getPodPriority(&sorted[i]) != getPodPriority(&sorted[i-1]) {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], sorted[i])
+	}
+	return groups
 }
 
 func volIsPvc(vol *corev1.Volume) bool {
@@ -1061,7 +1407,7 @@ func (o *Options) evictPodWithoutPVInternal(ctx context.Context, attemptEvict bo
 			returnCh <- nil
 			return
 		} else if !attemptEvict || !apierrors.IsTooManyRequests(err) {
-			returnCh <- fmt.Errorf("error when evicting pod %q: %v scheduled on node %v", pod.Name, err, pod.Spec.NodeName)
+			returnCh <- fmt.Errorf("error when evicting pod %q: %w scheduled on node %v", pod.Name, err, pod.Spec.NodeName)
 			return
 		}
 		// Pod couldn't be evicted because of PDB violation
@@ -1209,3 +1555,41 @@ func isMisconfiguredPdb(pdb *policyv1.PodDisruptionBudget) bool {
 
 	return pdb.Status.ExpectedPods > 0 && pdb.Status.CurrentHealthy >= pdb.Status.ExpectedPods && pdb.Status.DisruptionsAllowed == 0
 }
+
+// webhookRejectionRegexp matches the message of the API server when an admission webhook denies a request
+var webhookRejectionRegexp = regexp.MustCompile(`admission webhook "([^"]+)" denied the request(?:: (.*))?`)
+
+// WebhookRejection is the denial of a pod eviction by an admission webhook
+type WebhookRejection struct {
+	// Webhook is the name of the admission webhook which denied the eviction
+	Webhook string
+	// Message is the reason given by the webhook – empty if it gave none
+	Message string
+}
+
+// GetWebhookRejection returns the first denial of a pod eviction by an admission webhook among the errors of a drain.
+// It returns false if the drain didn't fail due to an admission webhook.
+func GetWebhookRejection(err error) (*WebhookRejection, bool) {
+	if err == nil {
+		return nil, false
+	}
+	var aggregate utilerrors.Aggregate
+	if errors.As(err, &aggregate) {
+		for _, e := range aggregate.Errors() {
+			if rejection, ok := GetWebhookRejection(e); ok {
+				return rejection, true
+			}
+		}
+		return nil, false
+	}
+
+	var statusErr apierrors.APIStatus
+	if !errors.As(err, &statusErr) {
+		return nil, false
+	}
+	match := webhookRejectionRegexp.FindStringSubmatch(statusErr.Status().Message)
+	if match == nil {
+		return nil, false
+	}
+	return &WebhookRejection{Webhook: match[1], Message: match[2]}, true
+}
@@ -21,12 +21,18 @@ import (
 	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	coreinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
 	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
 
 	"github.com/gardener/machine-controller-manager/pkg/fakeclient"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
@@ -987,6 +993,423 @@ var _ = Describe("drain", func() {
 			))
 		})
 	})
+
+	DescribeTable("NodeLocalVolumePodPolicy",
+		func(policy string, expectPodDrained, expectPVCDeleted bool) {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			const nodeName = "node"
+			pod := getPodWithoutPV(testNamespace, "local-pod", nodeName, terminationGracePeriodShort, nil)
+			pod.Spec.Volumes = []corev1.Volume{{
+				Name: "data",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data-local-pod"},
+				},
+			}}
+			storageClass := &storagev1.StorageClass{
+				ObjectMeta:        metav1.ObjectMeta{Name: "local-storage"},
+				Provisioner:       "kubernetes.io/no-provisioner",
+				VolumeBindingMode: ptr.To(storagev1.VolumeBindingWaitForFirstConsumer),
+			}
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "data-local-pod", Namespace: testNamespace},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: ptr.To(storageClass.Name),
+					VolumeName:       "local-pv",
+				},
+			}
+			pv := &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "local-pv"},
+				Spec: corev1.PersistentVolumeSpec{
+					StorageClassName: storageClass.Name,
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						Local: &corev1.LocalVolumeSource{Path: "/mnt/disks/ssd1"},
+					},
+				},
+			}
+
+			targetCoreObjects := appendNodes(nil, []*corev1.Node{getNode(nodeName, nil)})
+			targetCoreObjects = appendPods(targetCoreObjects, []*corev1.Pod{pod})
+			targetCoreObjects = appendPVCs(targetCoreObjects, []*corev1.PersistentVolumeClaim{pvc})
+			targetCoreObjects = appendPVs(targetCoreObjects, []*corev1.PersistentVolume{pv})
+			targetCoreObjects = append(targetCoreObjects, storageClass)
+			storageClassIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			Expect(storageClassIndexer.Add(storageClass)).To(Succeed())
+
+			fakeTargetCoreClient, fakePVLister, fakePVCLister, fakeNodeLister, fakePodLister, pvcSynced, pvSynced, nodeSynced, podSynced, tracker := createFakeController(
+				stop, testNamespace, targetCoreObjects,
+			)
+			defer tracker.Stop()
+			Expect(cache.WaitForCacheSync(stop, pvcSynced, pvSynced, nodeSynced, podSynced)).To(BeTrue())
+
+			d := &Options{
+				client:                       fakeTargetCoreClient,
+				DeleteLocalData:              true,
+				Driver:                       &drainDriver{},
+				ErrOut:                       GinkgoWriter,
+				ForceDeletePods:              true,
+				GracePeriodSeconds:           30,
+				IgnorePodsWithoutControllers: true,
+				IgnoreDaemonsets:             true,
+				MaxEvictRetries:              3,
+				PvDetachTimeout:              30 * time.Second,
+				PvReattachTimeout:            1 * time.Millisecond,
+				nodeName:                     nodeName,
+				Out:                          GinkgoWriter,
+				pvcLister:                    fakePVCLister,
+				pvLister:                     fakePVLister,
+				storageClassLister:           storagelisters.NewStorageClassLister(storageClassIndexer),
+				nodeLister:                   fakeNodeLister,
+				podLister:                    fakePodLister,
+				Timeout:                      time.Minute,
+				podSynced:                    podSynced,
+				NodeLocalVolumePodPolicy:     policy,
+			}
+			Expect(d.RunDrain(context.TODO())).To(Succeed())
+
+			_, err := fakeTargetCoreClient.CoreV1().Pods(testNamespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+			if expectPodDrained {
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+			}
+			_, err = fakeTargetCoreClient.CoreV1().PersistentVolumeClaims(testNamespace).Get(context.TODO(), pvc.Name, metav1.GetOptions{})
+			if expectPVCDeleted {
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+			}
+		},
+		Entry("should evict the pod and keep its PVC if the policy is evict", NodeLocalVolumePodPolicyEvict, true, false),
+		Entry("should leave the pod on the node if the policy is skip", NodeLocalVolumePodPolicySkip, false, false),
+		Entry("should delete the PVC and evict the pod if the policy is delete-pvc", NodeLocalVolumePodPolicyDeletePVC, true, true),
+	)
+
+	DescribeTable("TerminalPodPolicy",
+		func(policy string, expectTerminalPodsDrained bool) {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			const nodeName = "node"
+			runningPod := getPodWithoutPV(testNamespace, "running-pod", nodeName, terminationGracePeriodShort, nil)
+			runningPod.Status.Phase = corev1.PodRunning
+			succeededPod := getPodWithoutPV(testNamespace, "succeeded-pod", nodeName, terminationGracePeriodShort, nil)
+			succeededPod.Status.Phase = corev1.PodSucceeded
+			failedPod := getPodWithoutPV(testNamespace, "failed-pod", nodeName, terminationGracePeriodShort, nil)
+			failedPod.Status.Phase = corev1.PodFailed
+
+			targetCoreObjects := appendNodes(nil, []*corev1.Node{getNode(nodeName, nil)})
+			targetCoreObjects = appendPods(targetCoreObjects, []*corev1.Pod{runningPod, succeededPod, failedPod})
+
+			fakeTargetCoreClient, fakePVLister, fakePVCLister, fakeNodeLister, fakePodLister, pvcSynced, pvSynced, nodeSynced, podSynced, tracker := createFakeController(
+				stop, testNamespace, targetCoreObjects,
+			)
+			defer tracker.Stop()
+			Expect(cache.WaitForCacheSync(stop, pvcSynced, pvSynced, nodeSynced, podSynced)).To(BeTrue())
+
+			d := &Options{
+				client:                       fakeTargetCoreClient,
+				DeleteLocalData:              true,
+				Driver:                       &drainDriver{},
+				ErrOut:                       GinkgoWriter,
+				ForceDeletePods:              true,
+				GracePeriodSeconds:           30,
+				IgnorePodsWithoutControllers: true,
+				IgnoreDaemonsets:             true,
+				MaxEvictRetries:              3,
+				PvDetachTimeout:              30 * time.Second,
+				PvReattachTimeout:            1 * time.Millisecond,
+				nodeName:                     nodeName,
+				Out:                          GinkgoWriter,
+				pvcLister:                    fakePVCLister,
+				pvLister:                     fakePVLister,
+				nodeLister:                   fakeNodeLister,
+				podLister:                    fakePodLister,
+				Timeout:                      time.Minute,
+				podSynced:                    podSynced,
+				TerminalPodPolicy:            policy,
+			}
+			Expect(d.RunDrain(context.TODO())).To(Succeed())
+
+			drainedPods := sets.New[string]()
+			for _, action := range fakeTargetCoreClient.(*fakeclient.Clientset).Actions() {
+				if action.GetResource().Resource != "pods" {
+					continue
+				}
+				if deleteAction, ok := action.(k8stesting.DeleteAction); ok {
+					drainedPods.Insert(deleteAction.GetName())
+				} else if createAction, ok := action.(k8stesting.CreateAction); ok && createAction.GetSubresource() == EvictionSubresource {
+					drainedPods.Insert(createAction.GetObject().(metav1.Object).GetName())
+				}
+			}
+			if expectTerminalPodsDrained {
+				Expect(sets.List(drainedPods)).To(ConsistOf(runningPod.Name, succeededPod.Name, failedPod.Name))
+			} else {
+				Expect(sets.List(drainedPods)).To(ConsistOf(runningPod.Name))
+			}
+		},
+		Entry("should skip the terminal pods by default", "", false),
+		Entry("should skip the terminal pods if the policy is skip", TerminalPodPolicySkip, false),
+		Entry("should drain the terminal pods like any other pod if the policy is evict", TerminalPodPolicyEvict, true),
+	)
+
+	DescribeTable("SkipPodSelector",
+		func(skipPodSelector labels.Selector, ignoreDaemonsets bool, expectDrainError bool, expectedDrainedPods ...string) {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			const nodeName = "node"
+			appPod := getPodWithoutPV(testNamespace, "app-pod", nodeName, terminationGracePeriodShort, map[string]string{"app": "app"})
+			agentPod := getPodWithoutPV(testNamespace, "agent-pod", nodeName, terminationGracePeriodShort, map[string]string{"app": "agent"})
+			daemonSetAgentPod := getPodWithoutPV(testNamespace, "daemonset-agent-pod", nodeName, terminationGracePeriodShort, map[string]string{"app": "agent"})
+			daemonSetAgentPod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "agent", Controller: ptr.To(true)}}
+
+			targetCoreObjects := appendNodes(nil, []*corev1.Node{getNode(nodeName, nil)})
+			targetCoreObjects = appendPods(targetCoreObjects, []*corev1.Pod{appPod, agentPod, daemonSetAgentPod})
+
+			fakeTargetCoreClient, fakePVLister, fakePVCLister, fakeNodeLister, fakePodLister, pvcSynced, pvSynced, nodeSynced, podSynced, tracker := createFakeController(
+				stop, testNamespace, targetCoreObjects,
+			)
+			defer tracker.Stop()
+			Expect(cache.WaitForCacheSync(stop, pvcSynced, pvSynced, nodeSynced, podSynced)).To(BeTrue())
+
+			d := &Options{
+				client:                       fakeTargetCoreClient,
+				DeleteLocalData:              true,
+				Driver:                       &drainDriver{},
+				ErrOut:                       GinkgoWriter,
+				ForceDeletePods:              true,
+				GracePeriodSeconds:           30,
+				IgnorePodsWithoutControllers: true,
+				IgnoreDaemonsets:             ignoreDaemonsets,
+				MaxEvictRetries:              3,
+				PvDetachTimeout:              30 * time.Second,
+				PvReattachTimeout:            1 * time.Millisecond,
+				nodeName:                     nodeName,
+				Out:                          GinkgoWriter,
+				pvcLister:                    fakePVCLister,
+				pvLister:                     fakePVLister,
+				nodeLister:                   fakeNodeLister,
+				podLister:                    fakePodLister,
+				Timeout:                      time.Minute,
+				podSynced:                    podSynced,
+				SkipPodSelector:              skipPodSelector,
+			}
+			err := d.RunDrain(context.TODO())
+			if expectDrainError {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			drainedPods := sets.New[string]()
+			for _, action := range fakeTargetCoreClient.(*fakeclient.Clientset).Actions() {
+				if action.GetResource().Resource != "pods" {
+					continue
+				}
+				if deleteAction, ok := action.(k8stesting.DeleteAction); ok {
+					drainedPods.Insert(deleteAction.GetName())
+				} else if createAction, ok := action.(k8stesting.CreateAction); ok && createAction.GetSubresource() == EvictionSubresource {
+					drainedPods.Insert(createAction.GetObject().(metav1.Object).GetName())
+				}
+			}
+			Expect(sets.List(drainedPods)).To(ConsistOf(expectedDrainedPods))
+		},
+		Entry("should drain all pods but the DaemonSet pods without a selector", nil, true, false, "app-pod", "agent-pod"),
+		Entry("should drain all pods but the DaemonSet pods with an empty selector", labels.Everything(), true, false, "app-pod", "agent-pod"),
+		Entry("should leave the pods matching the selector on the node", labels.SelectorFromSet(labels.Set{"app": "agent"}), true, false, "app-pod"),
+		Entry("should leave DaemonSet pods matching the selector on the node even if DaemonSet pods aren't ignored", labels.SelectorFromSet(labels.Set{"app": "agent"}), false, false, "app-pod"),
+		Entry("should fail on DaemonSet pods not matching the selector if DaemonSet pods aren't ignored", labels.SelectorFromSet(labels.Set{"app": "other"}), false, true),
+	)
+
+	Describe("PriorityOrderedEviction", func() {
+		newPodWithPriority := func(name string, priority *int32) *corev1.Pod {
+			pod := getPodWithoutPV(testNamespace, name, "node", terminationGracePeriodShort, nil)
+			pod.Spec.Priority = priority
+			return pod
+		}
+		priority := func(p int32) *int32 {
+			return &p
+		}
+
+		It("should evict the pods in groups of ascending priority", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			const nodeName = "node"
+			pods := []*corev1.Pod{
+				newPodWithPriority("high-pod", priority(1000)),
+				newPodWithPriority("low-pod", priority(10)),
+				newPodWithPriority("no-priority-pod", nil),
+				newPodWithPriority("zero-priority-pod", priority(0)),
+				newPodWithPriority("negative-priority-pod", priority(-10)),
+			}
+
+			targetCoreObjects := appendNodes(nil, []*corev1.Node{getNode(nodeName, nil)})
+			targetCoreObjects = appendPods(targetCoreObjects, pods)
+
+			fakeTargetCoreClient, fakePVLister, fakePVCLister, fakeNodeLister, fakePodLister, pvcSynced, pvSynced, nodeSynced, podSynced, tracker := createFakeController(
+				stop, testNamespace, targetCoreObjects,
+			)
+			defer tracker.Stop()
+			Expect(cache.WaitForCacheSync(stop, pvcSynced, pvSynced, nodeSynced, podSynced)).To(BeTrue())
+
+			d := &Options{
+				client:                       fakeTargetCoreClient,
+				DeleteLocalData:              true,
+				Driver:                       &drainDriver{},
+				ErrOut:                       GinkgoWriter,
+				ForceDeletePods:              true,
+				GracePeriodSeconds:           30,
+				IgnorePodsWithoutControllers: true,
+				IgnoreDaemonsets:             true,
+				MaxEvictRetries:              3,
+				PvDetachTimeout:              30 * time.Second,
+				PvReattachTimeout:            1 * time.Millisecond,
+				nodeName:                     nodeName,
+				Out:                          GinkgoWriter,
+				pvcLister:                    fakePVCLister,
+				pvLister:                     fakePVLister,
+				nodeLister:                   fakeNodeLister,
+				podLister:                    fakePodLister,
+				Timeout:                      time.Minute,
+				podSynced:                    podSynced,
+				PriorityOrderedEviction:      true,
+			}
+			Expect(d.RunDrain(context.TODO())).To(Succeed())
+
+			var drainedPods []string
+			for _, action := range fakeTargetCoreClient.(*fakeclient.Clientset).Actions() {
+				if deleteAction, ok := action.(k8stesting.DeleteAction); ok && action.GetResource().Resource == "pods" {
+					drainedPods = append(drainedPods, deleteAction.GetName())
+				}
+			}
+			Expect(drainedPods).To(HaveLen(len(pods)))
+			Expect(drainedPods[0]).To(Equal("negative-priority-pod"))
+			// pods without a PriorityClass are drained along with the pods of priority 0
+			Expect(drainedPods[1:3]).To(ConsistOf("no-priority-pod", "zero-priority-pod"))
+			Expect(drainedPods[3:]).To(Equal([]string{"low-pod", "high-pod"}))
+		})
+
+		It("should keep the order of the pods of the same priority", func() {
+			var pods []corev1.Pod
+			for _, pod := range []*corev1.Pod{
+				newPodWithPriority("b", priority(5)),
+				newPodWithPriority("a", nil),
+				newPodWithPriority("d", priority(5)),
+				newPodWithPriority("c", priority(0)),
+			} {
+				pods = append(pods, *pod)
+			}
+
+			var groups [][]string
+			for _, group := range groupPodsByPriority(pods) {
+				var names []string
+				for _, pod := range group {
+					names = append(names, pod.Name)
+				}
+				groups = append(groups, names)
+			}
+			Expect(groups).To(Equal([][]string{{"a", "c"}, {"b", "d"}}))
+		})
+	})
+
+	Describe("EvictionLimiter", func() {
+		It("should bound the concurrent pod evictions across all drains sharing it", func() {
+			const (
+				nNodes                 = 4
+				nPodsPerNode           = 5
+				maxConcurrentEvictions = 2
+			)
+
+			stop := make(chan struct{})
+			defer close(stop)
+
+			var targetCoreObjects []runtime.Object
+			for i := 0; i < nNodes; i++ {
+				nodeName := fmt.Sprintf("node-%d", i)
+				targetCoreObjects = appendNodes(targetCoreObjects, []*corev1.Node{getNode(nodeName, nil)})
+				targetCoreObjects = appendPods(targetCoreObjects, getPodsWithoutPV(nPodsPerNode, testNamespace, nodeName+"-pod-", nodeName, terminationGracePeriodShort, nil))
+			}
+
+			fakeTargetCoreClient, fakePVLister, fakePVCLister, fakeNodeLister, fakePodLister, pvcSynced, pvSynced, nodeSynced, podSynced, tracker := createFakeController(
+				stop, testNamespace, targetCoreObjects,
+			)
+			defer tracker.Stop()
+			Expect(cache.WaitForCacheSync(stop, pvcSynced, pvSynced, nodeSynced, podSynced)).To(BeTrue())
+
+			// the fake client serializes all calls, hence the concurrent pod deletions are tracked around it
+			client := &concurrentPodDeletionsClient{Interface: fakeTargetCoreClient, delay: 50 * time.Millisecond}
+			evictionLimiter := NewEvictionLimiter(maxConcurrentEvictions)
+
+			var wg sync.WaitGroup
+			drainErrs := make([]error, nNodes)
+			for i := 0; i < nNodes; i++ {
+				d := &Options{
+					client:                       client,
+					DeleteLocalData:              true,
+					Driver:                       &drainDriver{},
+					ErrOut:                       GinkgoWriter,
+					ForceDeletePods:              true,
+					GracePeriodSeconds:           30,
+					IgnorePodsWithoutControllers: true,
+					IgnoreDaemonsets:             true,
+					MaxEvictRetries:              3,
+					PvDetachTimeout:              30 * time.Second,
+					PvReattachTimeout:            1 * time.Millisecond,
+					nodeName:                     fmt.Sprintf("node-%d", i),
+					Out:                          GinkgoWriter,
+					pvcLister:                    fakePVCLister,
+					pvLister:                     fakePVLister,
+					nodeLister:                   fakeNodeLister,
+					podLister:                    fakePodLister,
+					Timeout:                      time.Minute,
+					podSynced:                    podSynced,
+					EvictionLimiter:              evictionLimiter,
+				}
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					drainErrs[i] = d.RunDrain(context.TODO())
+				}(i)
+			}
+			wg.Wait()
+
+			for _, err := range drainErrs {
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(client.deletions).To(Equal(nNodes * nPodsPerNode))
+			Expect(client.maxInFlight).To(BeNumerically(">", 0))
+			Expect(client.maxInFlight).To(BeNumerically("<=", maxConcurrentEvictions))
+		})
+
+		It("should not limit the pod evictions if no maximum is configured", func() {
+			Expect(NewEvictionLimiter(0)).To(BeNil())
+		})
+	})
+
+	DescribeTable("GetWebhookRejection",
+		func(err error, expected *WebhookRejection) {
+			rejection, ok := GetWebhookRejection(err)
+			Expect(ok).To(Equal(expected != nil))
+			Expect(rejection).To(Equal(expected))
+		},
+		Entry("no error", nil, nil),
+		Entry("an error not from the API server", fmt.Errorf("timed out waiting for the condition"), nil),
+		Entry("an API error not from a webhook", apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "pod-0", fmt.Errorf("not allowed")), nil),
+		Entry("a webhook rejection of an eviction among the errors of a drain",
+			utilerrors.NewAggregate([]error{
+				fmt.Errorf("error when waiting for pod %q terminating: timed out", "pod-0"),
+				fmt.Errorf("error when evicting pod %q: %w scheduled on node %v", "pod-1", apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "pod-1", fmt.Errorf(`admission webhook "guard.example.com" denied the request: pod-1 is protected`)), "node-0"),
+			}),
+			&WebhookRejection{Webhook: "guard.example.com", Message: "pod-1 is protected"},
+		),
+		Entry("a webhook rejection without a reason",
+			&apierrors.StatusError{ErrStatus: metav1.Status{Message: `admission webhook "guard.example.com" denied the request without explanation`}},
+			&WebhookRejection{Webhook: "guard.example.com"},
+		),
+	)
 })
 
 func getPodWithoutPV(ns, name, nodeName string, terminationGracePeriod time.Duration, labels map[string]string) *corev1.Pod {
@@ -1313,3 +1736,52 @@ func appendSuffixToVolumeHandles(pvs []*corev1.PersistentVolume, suffix string)
 	}
 	return pvs
 }
+
+// concurrentPodDeletionsClient tracks the maximum number of concurrent pod deletions,
+// each of which takes the given delay
+type concurrentPodDeletionsClient struct {
+	kubernetes.Interface
+	delay time.Duration
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	deletions   int
+}
+
+func (c *concurrentPodDeletionsClient) CoreV1() corev1client.CoreV1Interface {
+	return &concurrentPodDeletionsCoreV1{CoreV1Interface: c.Interface.CoreV1(), client: c}
+}
+
+type concurrentPodDeletionsCoreV1 struct {
+	corev1client.CoreV1Interface
+	client *concurrentPodDeletionsClient
+}
+
+func (c *concurrentPodDeletionsCoreV1) Pods(namespace string) corev1client.PodInterface {
+	return &concurrentPodDeletionsPods{PodInterface: c.CoreV1Interface.Pods(namespace), client: c.client}
+}
+
+type concurrentPodDeletionsPods struct {
+	corev1client.PodInterface
+	client *concurrentPodDeletionsClient
+}
+
+func (p *concurrentPodDeletionsPods) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	p.client.mu.Lock()
+	p.client.inFlight++
+	p.client.deletions++
+	if p.client.inFlight > p.client.maxInFlight {
+		p.client.maxInFlight = p.client.inFlight
+	}
+	p.client.mu.Unlock()
+
+	defer func() {
+		p.client.mu.Lock()
+		p.client.inFlight--
+		p.client.mu.Unlock()
+	}()
+
+	time.Sleep(p.client.delay)
+	return p.PodInterface.Delete(ctx, name, opts)
+}
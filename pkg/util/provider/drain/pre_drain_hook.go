@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package drain is used to drain nodes
+package drain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+)
+
+// PreDrainHook is invoked before the pods of a node are evicted during the deletion or the in-place update of its
+// machine, e.g. to quiesce an application or flush caches. The hooks are invoked on every attempt of the drain,
+// hence they have to be idempotent.
+type PreDrainHook interface {
+	// Execute runs the hook for the machine and its node, returning an error if the drain has to be retried
+	Execute(ctx context.Context, machine *v1alpha1.Machine, node *corev1.Node) error
+}
+
+// RunPreDrainHooks executes the hooks in order, stopping at the first hook returning an error. Each hook is bounded
+// by the timeout, unless it is 0.
+func RunPreDrainHooks(ctx context.Context, hooks []PreDrainHook, timeout time.Duration, machine *v1alpha1.Machine, node *corev1.Node) error {
+	for i, hook := range hooks {
+		if err := runPreDrainHook(ctx, hook, timeout, machine, node); err != nil {
+			return fmt.Errorf("pre-drain hook %d of %d failed: %w", i+1, len(hooks), err)
+		}
+	}
+	return nil
+}
+
+func runPreDrainHook(ctx context.Context, hook PreDrainHook, timeout time.Duration, machine *v1alpha1.Machine, node *corev1.Node) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return hook.Execute(ctx, machine, node)
+}
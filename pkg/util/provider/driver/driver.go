@@ -11,6 +11,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
 )
 
 // Driver is the common interface for creation/deletion of the VMs over different cloud-providers.
@@ -35,6 +37,50 @@ type Driver interface {
 	GetVolumeIDs(context.Context, *GetVolumeIDsRequest) (*GetVolumeIDsResponse, error)
 }
 
+// MachineSnapshotter is optionally implemented by drivers supporting snapshots of VMs.
+type MachineSnapshotter interface {
+	// SnapshotMachine call is responsible for taking a snapshot of the VM on the provider.
+	// This method is only invoked by the deletion flow after the node is drained and before the VM is deleted,
+	// if enabled on the MachineClass.
+	//
+	// In case of an error, this operation should return an error with one of the following status codes
+	//  - codes.Unimplemented if the provider does not support VM snapshots.
+	//  - codes.NotFound if VM instance was not found.
+	SnapshotMachine(context.Context, *SnapshotMachineRequest) (*SnapshotMachineResponse, error)
+}
+
+// MachineRebooter is optionally implemented by drivers supporting reboots of VMs.
+type MachineRebooter interface {
+	// RebootMachine call is responsible for rebooting the VM on the provider.
+	// This method is only invoked by the health check for a machine whose node is NotReady while its VM
+	// is still reported by the provider, if the reboot-before-replace node NotReady policy is configured.
+	//
+	// In case of an error, this operation should return an error with one of the following status codes
+	//  - codes.Unimplemented if the provider does not support rebooting VMs.
+	//  - codes.NotFound if VM instance was not found.
+	RebootMachine(context.Context, *RebootMachineRequest) (*RebootMachineResponse, error)
+}
+
+// SnapshotMachine takes a snapshot of the VM with the driver if it implements MachineSnapshotter,
+// otherwise it returns an error with codes.Unimplemented.
+func SnapshotMachine(ctx context.Context, d Driver, req *SnapshotMachineRequest) (*SnapshotMachineResponse, error) {
+	snapshotter, ok := d.(MachineSnapshotter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "driver does not support snapshots")
+	}
+	return snapshotter.SnapshotMachine(ctx, req)
+}
+
+// RebootMachine reboots the VM with the driver if it implements MachineRebooter,
+// otherwise it returns an error with codes.Unimplemented.
+func RebootMachine(ctx context.Context, d Driver, req *RebootMachineRequest) (*RebootMachineResponse, error) {
+	rebooter, ok := d.(MachineRebooter)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "driver does not support reboots")
+	}
+	return rebooter.RebootMachine(ctx, req)
+}
+
 // CreateMachineRequest is the create request for VM creation
 type CreateMachineRequest struct {
 	// Machine object from whom VM is to be created
@@ -45,6 +91,29 @@ type CreateMachineRequest struct {
 
 	//  Secret backing the machineClass object
 	Secret *corev1.Secret
+
+	// NodeLabels are the labels the node of the machine is intended to carry.
+	// Drivers may pass them to the kubelet (e.g. via its --node-labels flag), so that
+	// the node registers with them instead of being labelled only after joining.
+	NodeLabels map[string]string
+
+	// PreferredZone is the zone the VM is preferably created in to balance the machines
+	// of its MachineSet across zones. It is empty if there is no preference.
+	PreferredZone string
+
+	// PlacementGroup is the provider placement group, e.g. for low-latency clustering, the VM is created in.
+	// It is empty if the machine isn't placed in a placement group.
+	PlacementGroup string
+
+	// InstanceType is the instance type the VM is created with, overriding the one of the MachineClass.
+	// It is empty unless the creation is retried with a fallback instance type of the MachineClass
+	// after the provider reported the previous instance type as exhausted.
+	InstanceType string
+
+	// IdempotencyKey is a deterministic key of the machine derived from its UID. Drivers supporting it pass it
+	// to the provider, so that a repeated creation, e.g. after a restart of the controller before the ProviderID
+	// was recorded, doesn't create a duplicate VM. It is passed to GetMachineStatus as well to find such a VM.
+	IdempotencyKey string
 }
 
 // CreateMachineResponse is the create response for VM creation
@@ -59,6 +128,10 @@ type CreateMachineResponse struct {
 
 	// LastKnownState represents the last state of the VM during an creation/deletion error
 	LastKnownState string
+
+	// Capacity is the allocatable capacity of the VM as seen by the provider, e.g. cpu, memory and nvidia.com/gpu.
+	// It is optional and persisted in the machine status.
+	Capacity corev1.ResourceList
 }
 
 // InitializeMachineRequest encapsulates params for the VM Initialization operation (Driver.InitializeMachine).
@@ -94,6 +167,10 @@ type DeleteMachineRequest struct {
 
 	// Secret backing the machineClass object
 	Secret *corev1.Secret
+
+	// DryRun only plans the deletion of the machine, without calling DeleteMachine of the provider
+	// nor deleting the node or removing the finalizer of the machine
+	DryRun bool
 }
 
 // DeleteMachineResponse is the delete response for VM deletion
@@ -102,6 +179,39 @@ type DeleteMachineResponse struct {
 	LastKnownState string
 }
 
+// SnapshotMachineRequest is the request for taking a snapshot of the VM (Driver.SnapshotMachine)
+type SnapshotMachineRequest struct {
+	// Machine object whose VM is to be snapshotted
+	Machine *v1alpha1.Machine
+
+	// MachineClass backing the machine object
+	MachineClass *v1alpha1.MachineClass
+
+	// Secret backing the machineClass object
+	Secret *corev1.Secret
+}
+
+// SnapshotMachineResponse is the response for taking a snapshot of the VM (Driver.SnapshotMachine)
+type SnapshotMachineResponse struct {
+	// SnapshotID is the unique identification of the snapshot at the cloud provider
+	SnapshotID string
+}
+
+// RebootMachineRequest is the request for rebooting the VM (Driver.RebootMachine)
+type RebootMachineRequest struct {
+	// Machine object whose VM is to be rebooted
+	Machine *v1alpha1.Machine
+
+	// MachineClass backing the machine object
+	MachineClass *v1alpha1.MachineClass
+
+	// Secret backing the machineClass object
+	Secret *corev1.Secret
+}
+
+// RebootMachineResponse is the response for rebooting the VM (Driver.RebootMachine)
+type RebootMachineResponse struct{}
+
 // GetMachineStatusRequest is the get request for VM info
 type GetMachineStatusRequest struct {
 	// Machine object from whom VM status is to be fetched
@@ -112,6 +222,10 @@ type GetMachineStatusRequest struct {
 
 	//  Secret backing the machineClass object
 	Secret *corev1.Secret
+
+	// IdempotencyKey is the key the VM of the machine was created with, see CreateMachineRequest.
+	// Drivers supporting it may look up the VM by it if the ProviderID of the machine isn't recorded yet.
+	IdempotencyKey string
 }
 
 // GetMachineStatusResponse is the get response for VM info
@@ -123,6 +237,14 @@ type GetMachineStatusResponse struct {
 
 	// NodeName is the name of the node-object registered to kubernetes.
 	NodeName string
+
+	// Conditions are custom conditions of the VM which are not reflected in the node conditions,
+	// e.g. a scheduled maintenance of the VM. They are written onto the machine status conditions.
+	Conditions []corev1.NodeCondition
+
+	// Capacity is the allocatable capacity of the VM as seen by the provider, e.g. cpu, memory and nvidia.com/gpu.
+	// It is optional and persisted in the machine status.
+	Capacity corev1.ResourceList
 }
 
 // ListMachinesRequest is the request object to get a list of VMs belonging to a machineClass
@@ -7,6 +7,9 @@ package driver
 
 import (
 	"context"
+
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
 )
@@ -16,12 +19,18 @@ type VMs map[string]string
 
 // FakeDriver is a fake driver returned when none of the actual drivers match
 type FakeDriver struct {
-	VMExists       bool
-	ProviderID     string
-	NodeName       string
-	LastKnownState string
-	Err            error
-	fakeVMs        VMs
+	VMExists        bool
+	ProviderID      string
+	NodeName        string
+	LastKnownState  string
+	Err             error
+	Conditions      []corev1.NodeCondition
+	SnapshotID      string
+	RebootSupported bool
+	Rebooted        bool
+	Capacity        corev1.ResourceList
+	NodeLabels      map[string]string
+	fakeVMs         VMs
 }
 
 // NewFakeDriver returns a new fakedriver object
@@ -47,13 +56,16 @@ func (d *FakeDriver) AddMachine(machineID, machineName string) error {
 }
 
 // CreateMachine makes a call to the driver to create the machine.
-func (d *FakeDriver) CreateMachine(_ context.Context, _ *CreateMachineRequest) (*CreateMachineResponse, error) {
+// It records the node labels of the request on the driver.
+func (d *FakeDriver) CreateMachine(_ context.Context, req *CreateMachineRequest) (*CreateMachineResponse, error) {
+	d.NodeLabels = req.NodeLabels
 	if d.Err == nil {
 		d.VMExists = true
 		return &CreateMachineResponse{
 			ProviderID:     d.ProviderID,
 			NodeName:       d.NodeName,
 			LastKnownState: d.LastKnownState,
+			Capacity:       d.Capacity,
 		}, nil
 	}
 
@@ -90,6 +102,33 @@ func (d *FakeDriver) DeleteMachine(_ context.Context, deleteMachineRequest *Dele
 	}, d.Err
 }
 
+// SnapshotMachine makes a call to the driver to snapshot the VM of the machine.
+// It returns codes.Unimplemented unless a SnapshotID is set on the driver.
+func (d *FakeDriver) SnapshotMachine(_ context.Context, _ *SnapshotMachineRequest) (*SnapshotMachineResponse, error) {
+	if d.SnapshotID == "" {
+		return nil, status.Error(codes.Unimplemented, "Fake driver does not support snapshots")
+	}
+	if d.Err != nil {
+		return nil, d.Err
+	}
+	return &SnapshotMachineResponse{
+		SnapshotID: d.SnapshotID,
+	}, nil
+}
+
+// RebootMachine makes a call to the driver to reboot the VM of the machine.
+// It returns codes.Unimplemented unless RebootSupported is set on the driver.
+func (d *FakeDriver) RebootMachine(_ context.Context, _ *RebootMachineRequest) (*RebootMachineResponse, error) {
+	if !d.RebootSupported {
+		return nil, status.Error(codes.Unimplemented, "Fake driver does not support reboots")
+	}
+	if d.Err != nil {
+		return nil, d.Err
+	}
+	d.Rebooted = true
+	return &RebootMachineResponse{}, nil
+}
+
 // GetMachineStatus makes a gRPC call to the driver to check existance of machine
 func (d *FakeDriver) GetMachineStatus(_ context.Context, _ *GetMachineStatusRequest) (*GetMachineStatusResponse, error) {
 	if !d.VMExists {
@@ -99,6 +138,8 @@ func (d *FakeDriver) GetMachineStatus(_ context.Context, _ *GetMachineStatusRequ
 	return &GetMachineStatusResponse{
 		ProviderID: d.ProviderID,
 		NodeName:   d.NodeName,
+		Conditions: d.Conditions,
+		Capacity:   d.Capacity,
 	}, d.Err
 }
 
@@ -149,6 +149,11 @@ const (
 	// This is meant to be used by providers in implementation of
 	// [github.com/gardener/machine-controller-manager/pkg/util/provider/driver.Driver.GetMachineStatus]
 	Uninitialized Code = 17
+
+	// DeletionProtected indicates that the VM instance can't be deleted as deletion protection is enabled for it
+	// at the provider. This is meant to be used by providers in implementation of
+	// [github.com/gardener/machine-controller-manager/pkg/util/provider/driver.Driver.DeleteMachine]
+	DeletionProtected Code = 18
 )
 
 var strToCode = map[string]Code{
@@ -170,6 +175,7 @@ var strToCode = map[string]Code{
 	"DataLoss":           DataLoss,
 	"Unauthenticated":    Unauthenticated,
 	"Uninitialized":      Uninitialized,
+	"DeletionProtected":  DeletionProtected,
 }
 
 // StringToCode coverts string into the Code.
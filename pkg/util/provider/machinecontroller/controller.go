@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gardener/machine-controller-manager/pkg/handlers"
@@ -27,6 +28,9 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
@@ -39,7 +43,9 @@ import (
 	storagelisters "k8s.io/client-go/listers/storage/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
+	bootstraptokenapi "k8s.io/cluster-bootstrap/token/api"
 	"k8s.io/klog/v2"
 )
 
@@ -63,13 +69,26 @@ func NewController(
 	driver driver.Driver,
 	targetCoreInformerFactory kubernetesinformers.SharedInformerFactory,
 	secretInformer coreinformers.SecretInformer,
+	configMapInformer coreinformers.ConfigMapInformer,
 	machineClassInformer machineinformers.MachineClassInformer,
 	machineInformer machineinformers.MachineInformer,
+	machineSetInformer machineinformers.MachineSetInformer,
+	machineDeploymentInformer machineinformers.MachineDeploymentInformer,
 	recorder record.EventRecorder,
 	safetyOptions options.SafetyOptions,
 	nodeConditions string,
+	providerConditions string,
+	nodeTerminationConditionType string,
+	nodeTerminationConditionReason string,
+	nodeLabelTaints []options.NodeLabelTaint,
+	nodeLabelPropagationDenylist []string,
+	nodeTaintsRemovedOnRecovery []string,
+	machineQueueFairness bool,
 	bootstrapTokenAuthExtraGroups string,
 	targetKubernetesVersion *semver.Version,
+	machinePriorityComputer MachinePriorityComputer,
+	preDeletionHooks []PreDeletionHook,
+	preDrainHooks []drain.PreDrainHook,
 ) (Controller, error) {
 	const (
 		permitGiverStaleEntryTimeout = 1 * time.Hour
@@ -78,25 +97,44 @@ func NewController(
 	)
 
 	controller := &controller{
-		namespace:                     namespace,
-		controlMachineClient:          controlMachineClient,
-		controlCoreClient:             controlCoreClient,
-		targetCoreClient:              targetCoreClient,
-		recorder:                      recorder,
-		secretQueue:                   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "secret"),
-		nodeQueue:                     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "node"),
-		machineClassQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machineclass"),
-		machineQueue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machine"),
-		machineTerminationQueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinetermination"),
-		machineSafetyOrphanVMsQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyorphanvms"),
-		machineSafetyAPIServerQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyapiserver"),
-		safetyOptions:                 safetyOptions,
-		nodeConditions:                nodeConditions,
-		driver:                        driver,
-		bootstrapTokenAuthExtraGroups: bootstrapTokenAuthExtraGroups,
-		volumeAttachmentHandler:       nil,
-		permitGiver:                   permits.NewPermitGiver(permitGiverStaleEntryTimeout, janitorFreq),
-		targetKubernetesVersion:       targetKubernetesVersion,
+		namespace:                      namespace,
+		controlMachineClient:           controlMachineClient,
+		controlCoreClient:              controlCoreClient,
+		targetCoreClient:               targetCoreClient,
+		recorder:                       recorder,
+		secretQueue:                    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "secret"),
+		nodeQueue:                      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "node"),
+		machineClassQueue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machineclass"),
+		machineTerminationQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinetermination"),
+		machineSafetyOrphanVMsQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyorphanvms"),
+		machineSafetyAPIServerQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyapiserver"),
+		safetyOptions:                  safetyOptions,
+		nodeConditions:                 nodeConditions,
+		providerConditions:             providerConditions,
+		nodeTerminationConditionType:   v1.NodeConditionType(nodeTerminationConditionType),
+		nodeTerminationConditionReason: nodeTerminationConditionReason,
+		nodeLabelTaints:                nodeLabelTaints,
+		nodeLabelPropagationDenylist:   nodeLabelPropagationDenylist,
+		nodeTaintsRemovedOnRecovery:    nodeTaintsRemovedOnRecovery,
+		driver:                         newOperationTimeoutDriver(driver, safetyOptions),
+		bootstrapTokenAuthExtraGroups:  bootstrapTokenAuthExtraGroups,
+		volumeAttachmentHandler:        nil,
+		permitGiver:                    permits.NewPermitGiver(permitGiverStaleEntryTimeout, janitorFreq),
+		evictionLimiter:                drain.NewEvictionLimiter(safetyOptions.MaxConcurrentPodEvictions),
+		targetKubernetesVersion:        targetKubernetesVersion,
+		machinePriorityComputer:        machinePriorityComputer,
+		preDeletionHooks:               preDeletionHooks,
+		preDrainHooks:                  preDrainHooks,
+	}
+
+	controller.machineQueue = newMachineQueue(machineQueueFairness, controller.getMachineClassOfKey)
+
+	if controller.machinePriorityComputer == nil {
+		controller.machinePriorityComputer = defaultMachinePriorityComputer{}
+	}
+
+	if safetyOptions.NodeDeletionQPS > 0 {
+		controller.nodeDeletionRateLimiter = flowcontrol.NewTokenBucketRateLimiter(safetyOptions.NodeDeletionQPS, 1)
 	}
 
 	controller.internalExternalScheme = runtime.NewScheme()
@@ -118,26 +156,42 @@ func NewController(
 		controller.pvcLister = targetCoreInformerFactory.Core().V1().PersistentVolumeClaims().Lister()
 		controller.pvLister = targetCoreInformerFactory.Core().V1().PersistentVolumes().Lister()
 		controller.volumeAttachementLister = targetCoreInformerFactory.Storage().V1().VolumeAttachments().Lister()
+		controller.storageClassLister = targetCoreInformerFactory.Storage().V1().StorageClasses().Lister()
 		controller.nodeLister = targetCoreInformerFactory.Core().V1().Nodes().Lister()
 		controller.podLister = targetCoreInformerFactory.Core().V1().Pods().Lister()
 		controller.pdbLister = targetCoreInformerFactory.Policy().V1().PodDisruptionBudgets().Lister()
+		controller.bootstrapTokenSecretLister = corelisters.NewSecretLister(targetCoreInformerFactory.InformerFor(&v1.Secret{}, newBootstrapTokenSecretInformer).GetIndexer())
+
+		podInformer := targetCoreInformerFactory.Core().V1().Pods().Informer()
+		if err := podInformer.AddIndexers(cache.Indexers{podNodeNameIndex: podNodeNameIndexFunc}); err != nil {
+			return nil, err
+		}
+		controller.podIndexer = podInformer.GetIndexer()
 	}
 	controller.secretLister = secretInformer.Lister()
+	controller.configMapLister = configMapInformer.Lister()
 	controller.machineClassLister = machineClassInformer.Lister()
 	controller.machineLister = machineInformer.Lister()
+	controller.machineSetLister = machineSetInformer.Lister()
+	controller.machineDeploymentLister = machineDeploymentInformer.Lister()
 
 	// Controller syncs
 	if targetCoreInformerFactory != nil {
 		controller.pvcSynced = targetCoreInformerFactory.Core().V1().PersistentVolumeClaims().Informer().HasSynced
 		controller.pvSynced = targetCoreInformerFactory.Core().V1().PersistentVolumes().Informer().HasSynced
 		controller.volumeAttachementSynced = targetCoreInformerFactory.Storage().V1().VolumeAttachments().Informer().HasSynced
+		controller.storageClassSynced = targetCoreInformerFactory.Storage().V1().StorageClasses().Informer().HasSynced
 		controller.nodeSynced = targetCoreInformerFactory.Core().V1().Nodes().Informer().HasSynced
 		controller.podSynced = targetCoreInformerFactory.Core().V1().Pods().Informer().HasSynced
 		controller.pdbSynced = targetCoreInformerFactory.Policy().V1().PodDisruptionBudgets().Informer().HasSynced
+		controller.bootstrapTokenSecretSynced = targetCoreInformerFactory.InformerFor(&v1.Secret{}, newBootstrapTokenSecretInformer).HasSynced
 	}
 	controller.secretSynced = secretInformer.Informer().HasSynced
+	controller.configMapSynced = configMapInformer.Informer().HasSynced
 	controller.machineClassSynced = machineClassInformer.Informer().HasSynced
 	controller.machineSynced = machineInformer.Informer().HasSynced
+	controller.machineSetSynced = machineSetInformer.Informer().HasSynced
+	controller.machineDeploymentSynced = machineDeploymentInformer.Informer().HasSynced
 
 	// Secret Controller's Informers
 	_, _ = secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -209,6 +263,33 @@ func NewController(
 	return controller, nil
 }
 
+// newBootstrapTokenSecretInformer returns an informer for the bootstrap token secrets in the kube-system namespace
+// of the target cluster. It's registered for all secrets of the target informer factory, so that the other secrets
+// of the target cluster aren't cached.
+func newBootstrapTokenSecretInformer(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return coreinformers.NewFilteredSecretInformer(
+		client,
+		metav1.NamespaceSystem,
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("type", string(bootstraptokenapi.SecretTypeBootstrapToken)).String()
+		},
+	)
+}
+
+// podNodeNameIndex is the name of the index of the pods by the name of their node
+const podNodeNameIndex = "spec.nodeName"
+
+// podNodeNameIndexFunc indexes the pods by the name of their node, leaving out unscheduled pods
+func podNodeNameIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
+
 // Controller describes a controller for
 type Controller interface {
 	// Run runs the controller until the given stop channel can be read from.
@@ -219,8 +300,16 @@ type Controller interface {
 
 // controller is a concrete Controller.
 type controller struct {
-	namespace                     string
-	nodeConditions                string
+	namespace          string
+	nodeConditions     string
+	providerConditions string
+	// nodeTerminationConditionType and nodeTerminationConditionReason describe the condition set on nodes during drain
+	nodeTerminationConditionType   v1.NodeConditionType
+	nodeTerminationConditionReason string
+	nodeLabelTaints                []options.NodeLabelTaint
+	// nodeLabelPropagationDenylist are the prefixes of the label keys never propagated to nodes
+	nodeLabelPropagationDenylist  []string
+	nodeTaintsRemovedOnRecovery   []string
 	bootstrapTokenAuthExtraGroups string
 
 	// control clients
@@ -240,18 +329,53 @@ type controller struct {
 	// - lastAcquire time
 	// it is used to limit removal of `health timed out` machines
 	permitGiver permits.PermitGiver
+	// machinePriorityComputer computes the priority annotation set on newly created machines
+	machinePriorityComputer MachinePriorityComputer
+	// preDeletionHooks are run in order before the VM of a machine is deleted
+	preDeletionHooks []PreDeletionHook
+	// preDrainHooks are run in order before the pods of the node of a machine are evicted
+	preDrainHooks []drain.PreDrainHook
+	// nodeDeletionRateLimiter throttles deletion of node objects in the target cluster – nil when not configured
+	nodeDeletionRateLimiter flowcontrol.RateLimiter
+	// evictionLimiter bounds the concurrent pod evictions across all drains – nil when not configured
+	evictionLimiter *drain.EvictionLimiter
+	// lastConditionsUpdates stores per machine name the time its conditions were last written to its status
+	lastConditionsUpdates sync.Map
+	// machineStatusCache stores per machine UID the last result of the GetMachineStatus call of its health checks
+	machineStatusCache sync.Map
+	// deletionMachineStatusCache stores per provider ID the last result of the GetMachineStatus call of the deletion flow
+	deletionMachineStatusCache sync.Map
+	// machineClassCache stores per MachineClass name the MachineClass and secret data last validated for its machines,
+	// used to complete the deletion of machines whose MachineClass is gone
+	machineClassCache sync.Map
+	// futureTimestamps stores per machine UID and timestamp field the timestamps stored in the future and since when
+	// they are observed, used to measure timeouts despite clock skew
+	futureTimestamps sync.Map
+	// podDensityExceeded stores per machine UID whether its node exceeded the NodePodDensityThreshold on the last check,
+	// so that an event is only emitted once the threshold is crossed
+	podDensityExceeded sync.Map
+	// targetClusterReachability stores the result of the last probe of the reachability of the target cluster
+	targetClusterReachability atomic.Pointer[targetClusterProbe]
 
 	// control listers
-	secretLister       corelisters.SecretLister
-	machineClassLister machinelisters.MachineClassLister
-	machineLister      machinelisters.MachineLister
+	secretLister            corelisters.SecretLister
+	configMapLister         corelisters.ConfigMapLister
+	machineClassLister      machinelisters.MachineClassLister
+	machineLister           machinelisters.MachineLister
+	machineSetLister        machinelisters.MachineSetLister
+	machineDeploymentLister machinelisters.MachineDeploymentLister
 	// target listers – nil when running without a target cluster
 	pvcLister               corelisters.PersistentVolumeClaimLister
 	pvLister                corelisters.PersistentVolumeLister
 	nodeLister              corelisters.NodeLister
 	pdbLister               policyv1listers.PodDisruptionBudgetLister
 	volumeAttachementLister storagelisters.VolumeAttachmentLister
+	storageClassLister      storagelisters.StorageClassLister
 	podLister               corelisters.PodLister
+	// podIndexer indexes the pods by the name of their node with the podNodeNameIndex
+	podIndexer cache.Indexer
+	// bootstrapTokenSecretLister lists the bootstrap token secrets in the kube-system namespace
+	bootstrapTokenSecretLister corelisters.SecretLister
 	// queues
 	secretQueue                 workqueue.RateLimitingInterface
 	nodeQueue                   workqueue.RateLimitingInterface
@@ -264,12 +388,18 @@ type controller struct {
 	pvcSynced               cache.InformerSynced
 	pvSynced                cache.InformerSynced
 	secretSynced            cache.InformerSynced
+	configMapSynced         cache.InformerSynced
 	pdbSynced               cache.InformerSynced
 	volumeAttachementSynced cache.InformerSynced
+	storageClassSynced      cache.InformerSynced
 	nodeSynced              cache.InformerSynced
 	machineClassSynced      cache.InformerSynced
 	machineSynced           cache.InformerSynced
+	machineSetSynced        cache.InformerSynced
+	machineDeploymentSynced cache.InformerSynced
 	podSynced               cache.InformerSynced
+
+	bootstrapTokenSecretSynced cache.InformerSynced
 }
 
 func (dc *controller) Run(workers int, stopCh <-chan struct{}) {
@@ -290,12 +420,17 @@ func (dc *controller) Run(workers int, stopCh <-chan struct{}) {
 
 	syncedFuncs := []cache.InformerSynced{
 		dc.secretSynced,
+		dc.configMapSynced,
 		dc.pvcSynced,
 		dc.pvSynced,
 		dc.volumeAttachementSynced,
+		dc.storageClassSynced,
 		dc.nodeSynced,
+		dc.bootstrapTokenSecretSynced,
 		dc.machineClassSynced,
 		dc.machineSynced,
+		dc.machineSetSynced,
+		dc.machineDeploymentSynced,
 	}
 	if dc.targetKubernetesVersion != nil && k8sutils.ConstraintK8sGreaterEqual121.Check(dc.targetKubernetesVersion) {
 		syncedFuncs = append(syncedFuncs, dc.pdbSynced)
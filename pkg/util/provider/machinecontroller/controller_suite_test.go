@@ -23,6 +23,7 @@ import (
 
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/drain"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/options"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -34,6 +35,8 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	coreinformers "k8s.io/client-go/informers"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -548,6 +551,7 @@ func createController(
 
 	coreControlSharedInformers := coreControlInformerFactory.Core().V1()
 	secrets := coreControlSharedInformers.Secrets()
+	configMaps := coreControlSharedInformers.ConfigMaps()
 
 	controlMachineInformerFactory := machineinformers.NewFilteredSharedInformerFactory(
 		fakeControlMachineClient,
@@ -560,6 +564,8 @@ func createController(
 	machineSharedInformers := controlMachineInformerFactory.Machine().V1alpha1()
 	machineClass := machineSharedInformers.MachineClasses()
 	machines := machineSharedInformers.Machines()
+	machineSets := machineSharedInformers.MachineSets()
+	machineDeployments := machineSharedInformers.MachineDeployments()
 
 	internalExternalScheme := runtime.NewScheme()
 	Expect(machine_internal.AddToScheme(internalExternalScheme)).To(Succeed())
@@ -569,6 +575,7 @@ func createController(
 		MachineCreationTimeout:                   metav1.Duration{Duration: 20 * time.Minute},
 		MachineHealthTimeout:                     metav1.Duration{Duration: 10 * time.Minute},
 		MachineDrainTimeout:                      metav1.Duration{Duration: 5 * time.Minute},
+		MachineUnreachableGracePeriod:            metav1.Duration{Duration: 5 * time.Minute},
 		MachineSafetyOrphanVMsPeriod:             metav1.Duration{Duration: 30 * time.Minute},
 		MachineSafetyAPIServerStatusCheckPeriod:  metav1.Duration{Duration: 1 * time.Minute},
 		MachineSafetyAPIServerStatusCheckTimeout: metav1.Duration{Duration: 30 * time.Second},
@@ -576,27 +583,35 @@ func createController(
 	}
 
 	controller := &controller{
-		namespace:                   namespace,
-		nodeConditions:              "KernelDeadlock,ReadonlyFilesystem,DiskPressure,NetworkUnavailable",
-		driver:                      fakedriver,
-		safetyOptions:               safetyOptions,
-		machineClassLister:          machineClass.Lister(),
-		machineClassSynced:          machineClass.Informer().HasSynced,
-		controlCoreClient:           fakeControlCoreClient,
-		controlMachineClient:        fakeTypedMachineClient,
-		internalExternalScheme:      internalExternalScheme,
-		secretLister:                secrets.Lister(),
-		machineLister:               machines.Lister(),
-		machineSynced:               machines.Informer().HasSynced,
-		secretSynced:                secrets.Informer().HasSynced,
-		machineClassQueue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machineclass"),
-		secretQueue:                 workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "secret"),
-		nodeQueue:                   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "node"),
-		machineQueue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machine"),
-		machineTerminationQueue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinetermination"),
-		machineSafetyOrphanVMsQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyorphanvms"),
-		machineSafetyAPIServerQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyapiserver"),
-		recorder:                    record.NewBroadcaster().NewRecorder(nil, corev1.EventSource{Component: ""}),
+		namespace:                    namespace,
+		nodeConditions:               "KernelDeadlock,ReadonlyFilesystem,DiskPressure,NetworkUnavailable",
+		nodeTerminationConditionType: machineutils.NodeTerminationCondition,
+		driver:                       fakedriver,
+		safetyOptions:                safetyOptions,
+		machineClassLister:           machineClass.Lister(),
+		machineClassSynced:           machineClass.Informer().HasSynced,
+		controlCoreClient:            fakeControlCoreClient,
+		controlMachineClient:         fakeTypedMachineClient,
+		internalExternalScheme:       internalExternalScheme,
+		secretLister:                 secrets.Lister(),
+		configMapLister:              configMaps.Lister(),
+		machineLister:                machines.Lister(),
+		machineSynced:                machines.Informer().HasSynced,
+		machineSetLister:             machineSets.Lister(),
+		machineSetSynced:             machineSets.Informer().HasSynced,
+		machineDeploymentLister:      machineDeployments.Lister(),
+		machineDeploymentSynced:      machineDeployments.Informer().HasSynced,
+		secretSynced:                 secrets.Informer().HasSynced,
+		configMapSynced:              configMaps.Informer().HasSynced,
+		machineClassQueue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machineclass"),
+		secretQueue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "secret"),
+		nodeQueue:                    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "node"),
+		machineQueue:                 workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machine"),
+		machineTerminationQueue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinetermination"),
+		machineSafetyOrphanVMsQueue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyorphanvms"),
+		machineSafetyAPIServerQueue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "machinesafetyapiserver"),
+		recorder:                     record.NewFakeRecorder(100),
+		machinePriorityComputer:      defaultMachinePriorityComputer{},
 	}
 
 	if !noTargetCluster {
@@ -607,8 +622,16 @@ func createController(
 		controller.nodeSynced = coreTargetSharedInformers.Nodes().Informer().HasSynced
 		controller.pvcLister = coreTargetSharedInformers.PersistentVolumeClaims().Lister()
 		controller.pvLister = coreTargetSharedInformers.PersistentVolumes().Lister()
+		controller.storageClassLister = coreTargetInformerFactory.Storage().V1().StorageClasses().Lister()
+		controller.storageClassSynced = coreTargetInformerFactory.Storage().V1().StorageClasses().Informer().HasSynced
 		controller.podLister = coreTargetSharedInformers.Pods().Lister()
 		controller.podSynced = coreTargetSharedInformers.Pods().Informer().HasSynced
+		Expect(coreTargetSharedInformers.Pods().Informer().AddIndexers(toolscache.Indexers{podNodeNameIndex: podNodeNameIndexFunc})).To(Succeed())
+		controller.podIndexer = coreTargetSharedInformers.Pods().Informer().GetIndexer()
+		controller.pdbLister = coreTargetInformerFactory.Policy().V1().PodDisruptionBudgets().Lister()
+		bootstrapTokenSecrets := coreTargetInformerFactory.InformerFor(&corev1.Secret{}, newBootstrapTokenSecretInformer)
+		controller.bootstrapTokenSecretLister = corelisters.NewSecretLister(bootstrapTokenSecrets.GetIndexer())
+		controller.bootstrapTokenSecretSynced = bootstrapTokenSecrets.HasSynced
 	}
 
 	// controller.internalExternalScheme = runtime.NewScheme()
@@ -625,11 +648,14 @@ func waitForCacheSync(stop <-chan struct{}, controller *controller) {
 		stop,
 		controller.machineClassSynced,
 		controller.machineSynced,
+		controller.machineSetSynced,
+		controller.machineDeploymentSynced,
 		controller.secretSynced,
+		controller.configMapSynced,
 	)).To(BeTrue())
 
 	if controller.nodeLister != nil {
-		Expect(cache.WaitForCacheSync(stop, controller.nodeSynced)).To(BeTrue())
+		Expect(cache.WaitForCacheSync(stop, controller.nodeSynced, controller.podSynced, controller.storageClassSynced, controller.bootstrapTokenSecretSynced)).To(BeTrue())
 	}
 }
 
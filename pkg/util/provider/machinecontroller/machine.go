@@ -9,10 +9,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -25,10 +27,12 @@ import (
 	machineapi "github.com/gardener/machine-controller-manager/pkg/apis/machine"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/validation"
+	"github.com/gardener/machine-controller-manager/pkg/util/nodeops"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/metrics"
 )
 
 /*
@@ -60,10 +64,14 @@ func (c *controller) updateMachine(oldObj, newObj interface{}) {
 		return
 	}
 
-	if oldMachine.Generation == newMachine.Generation {
+	if oldMachine.Generation == newMachine.Generation &&
+		machineutils.IsMachineReconcilePaused(oldMachine) == machineutils.IsMachineReconcilePaused(newMachine) &&
+		machineutils.IsMachineDeletionDryRun(oldMachine) == machineutils.IsMachineDeletionDryRun(newMachine) &&
+		machineutils.IsMachineQuarantined(oldMachine) == machineutils.IsMachineQuarantined(newMachine) {
 		klog.V(3).Infof("Skipping non-spec updates for machine %s", oldMachine.Name)
 		return
 	}
+	c.machineStatusCache.Delete(newMachine.UID)
 
 	if newMachine.DeletionTimestamp != nil {
 		c.enqueueMachineTermination(newMachine, "handling terminating machine object UPDATE event")
@@ -85,6 +93,10 @@ func (c *controller) deleteMachine(obj interface{}) {
 			return
 		}
 	}
+	c.machineStatusCache.Delete(machine.UID)
+	c.deletionMachineStatusCache.Delete(machine.Spec.ProviderID)
+	c.podDensityExceeded.Delete(machine.UID)
+	c.forgetFutureTimestamps(machine)
 	c.enqueueMachineTermination(machine, "handling terminating machine object DELETE event")
 }
 
@@ -156,11 +168,20 @@ func (c *controller) reconcileClusterMachineKey(key string) error {
 		return nil
 	}
 
+	if machineutils.IsMachineReconcilePaused(machine) {
+		klog.V(2).Infof("Machine %q: Skipping reconcile as it is annotated with %q", machine.Name, machineutils.MachinePauseReconcile)
+		return nil
+	}
+
+	startTime := time.Now()
 	retryPeriod, err := c.reconcileClusterMachine(ctx, machine)
+	metrics.MachineReconcileDuration.WithLabelValues(machine.Spec.Class.Name).Observe(time.Since(startTime).Seconds())
 
 	var reEnqueReason = "periodic reconcile"
 	if err != nil {
 		reEnqueReason = err.Error()
+	} else {
+		c.updateLastSuccessfulReconcile(ctx, machine)
 	}
 
 	c.enqueueMachineAfter(machine, time.Duration(retryPeriod), reEnqueReason)
@@ -207,6 +228,11 @@ func (c *controller) reconcileClusterMachine(ctx context.Context, machine *v1alp
 	}
 
 	if machine.Labels[v1alpha1.NodeLabelKey] != "" && machine.Status.CurrentStatus.Phase != "" {
+		if machineutils.IsMachineQuarantined(machine) || machine.Status.CurrentStatus.Phase == v1alpha1.MachineQuarantined {
+			// Skip the health checks and other automatic actions on the machine while it is quarantined
+			return c.reconcileMachineQuarantine(ctx, machine)
+		}
+
 		// If reference to node object exists execute the below
 		retry, err := c.reconcileMachineHealth(ctx, machine)
 		if err != nil {
@@ -223,6 +249,11 @@ func (c *controller) reconcileClusterMachine(ctx context.Context, machine *v1alp
 			return retry, err
 		}
 
+		retry, err = c.syncNodeLabelTaints(ctx, machine)
+		if err != nil {
+			return retry, err
+		}
+
 		retry, err = c.updateNodeConditionBasedOnLabel(ctx, machine)
 		if err != nil {
 			return retry, err
@@ -232,6 +263,8 @@ func (c *controller) reconcileClusterMachine(ctx context.Context, machine *v1alp
 		if err != nil {
 			return retry, err
 		}
+
+		c.checkNodePodDensity(machine)
 	}
 
 	if machine.Spec.ProviderID == "" || machine.Status.CurrentStatus.Phase == "" || machine.Status.CurrentStatus.Phase == v1alpha1.MachineCrashLoopBackOff {
@@ -266,11 +299,16 @@ func (c *controller) reconcileClusterMachineTermination(key string) error {
 		return err
 	}
 
+	if machineutils.IsMachineReconcilePaused(machine) {
+		klog.V(2).Infof("Machine %q: Skipping termination reconcile as it is annotated with %q", machine.Name, machineutils.MachinePauseReconcile)
+		return nil
+	}
+
 	klog.V(2).Infof("reconcileClusterMachineTermination: Start for %q with phase:%q, description:%q",
 		machine.Name, machine.Status.CurrentStatus.Phase, machine.Status.LastOperation.Description)
 	defer klog.V(2).Infof("reconcileClusterMachineTermination: Stop for %q", machine.Name)
 
-	machineClass, secretData, retry, err := c.ValidateMachineClass(ctx, &machine.Spec.Class)
+	machineClass, secretData, retry, err := c.validateMachineClassForTermination(ctx, machine)
 	if err != nil {
 		klog.Errorf("cannot reconcile machine %q: %s", machine.Name, err)
 		c.enqueueMachineTerminationAfter(machine, time.Duration(retry), err.Error())
@@ -284,6 +322,7 @@ func (c *controller) reconcileClusterMachineTermination(key string) error {
 			Machine:      machine,
 			MachineClass: machineClass,
 			Secret:       &corev1.Secret{Data: secretData},
+			DryRun:       machineutils.IsMachineDeletionDryRun(machine),
 		},
 	)
 
@@ -385,6 +424,11 @@ func (c *controller) updateNodeToMachine(oldObj, newObj interface{}) {
 		return
 	}
 
+	if len(c.nodeLabelTaints) > 0 && !maps.Equal(oldNode.Labels, node.Labels) {
+		c.enqueueMachine(machine, fmt.Sprintf("handling node UPDATE event. labels of backing node %q have changed", getNodeName(machine)))
+		return
+	}
+
 	c.addNodeToMachine(newObj)
 }
 
@@ -485,6 +529,7 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 	var (
 		// Declarations
 		nodeName, providerID string
+		capacity             corev1.ResourceList
 
 		// Initializations
 		machine              = createMachineRequest.Machine
@@ -494,21 +539,32 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 
 	// we should avoid mutating Secret, since it goes all the way into the Informer's store
 	secretCopy := createMachineRequest.Secret.DeepCopy()
+	if err := c.renderUserDataTemplate(machine, createMachineRequest.MachineClass, secretCopy); err != nil {
+		recordReconcileError(reconcileFlowCreate, err)
+		return machineutils.ShortRetry, err
+	}
 	if err := c.addBootstrapTokenToUserData(ctx, machine, secretCopy); err != nil {
+		recordReconcileError(reconcileFlowCreate, err)
 		return machineutils.ShortRetry, err
 	}
 	if err := c.addMachineNameToUserData(machine, secretCopy); err != nil {
+		recordReconcileError(reconcileFlowCreate, err)
 		return machineutils.ShortRetry, err
 	}
 	createMachineRequest.Secret = secretCopy
+	createMachineRequest.NodeLabels = getIntendedNodeLabels(machine, c.nodeLabelPropagationDenylist)
+	createMachineRequest.PreferredZone = machine.Annotations[machineutils.MachinePreferredZone]
+	createMachineRequest.PlacementGroup = getPlacementGroup(machine, createMachineRequest.MachineClass)
+	createMachineRequest.IdempotencyKey = getIdempotencyKey(machine)
 
 	// Find out if VM exists on provider for this machine object
 	getMachineStatusResponse, err := c.driver.GetMachineStatus(
 		ctx,
 		&driver.GetMachineStatusRequest{
-			Machine:      machine,
-			MachineClass: createMachineRequest.MachineClass,
-			Secret:       createMachineRequest.Secret,
+			Machine:        machine,
+			MachineClass:   createMachineRequest.MachineClass,
+			Secret:         createMachineRequest.Secret,
+			IdempotencyKey: createMachineRequest.IdempotencyKey,
 		},
 	)
 	if err != nil {
@@ -517,6 +573,7 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 		if !ok {
 			// Error occurred with decoding machine error status, abort with retry.
 			klog.Errorf("Error occurred while decoding machine error for machine %q: %s", machine.Name, err)
+			recordReconcileError(reconcileFlowCreate, err)
 			return machineutils.MediumRetry, err
 		}
 		klog.Warningf("For machine %q, obtained VM error status as: %s", machineName, machineErr)
@@ -528,16 +585,51 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 			// In this case, invoke a CreateMachine() call
 			if _, present := machine.Labels[v1alpha1.NodeLabelKey]; !present {
 				// If node label is not present
+				unmetDependency, err := c.getUnmetMachineDependency(machine)
+				if err != nil {
+					recordReconcileError(reconcileFlowCreate, err)
+					return machineutils.ShortRetry, err
+				} else if unmetDependency != "" {
+					return c.holdMachineCreation(
+						ctx,
+						machine,
+						corev1.EventTypeNormal,
+						machineutils.MachineDependencyPendingReason,
+						unmetDependency,
+						fmt.Errorf("machine creation for %q is on hold until its dependencies are Running", machine.Name),
+					)
+				}
+				exceeded, quota, err := c.machineQuotaExceeded(machine, createMachineRequest.MachineClass)
+				if err != nil {
+					recordReconcileError(reconcileFlowCreate, err)
+					return machineutils.ShortRetry, err
+				} else if exceeded {
+					return c.holdMachineCreation(
+						ctx,
+						machine,
+						corev1.EventTypeWarning,
+						machineutils.MachineQuotaExceededReason,
+						fmt.Sprintf("Quota of %d machine(s) for MachineClass %q reached", quota, createMachineRequest.MachineClass.Name),
+						fmt.Errorf("machine creation for %q is on hold as quota of MachineClass %q is reached", machine.Name, createMachineRequest.MachineClass.Name),
+					)
+				}
 				klog.V(2).Infof("Creating a VM for machine %q, please wait!", machine.Name)
 				klog.V(2).Infof("The machine creation is triggered with timeout of %s", c.getEffectiveCreationTimeout(createMachineRequest.Machine).Duration)
-				createMachineResponse, err := c.driver.CreateMachine(ctx, createMachineRequest)
+				releasePermit, ok := c.acquireProviderOperationPermit(machine)
+				if !ok {
+					return machineutils.ShortRetry, fmt.Errorf("creation of machine %q is throttled as the maximum of concurrent provider operations for MachineClass %q is reached", machine.Name, machine.Spec.Class.Name)
+				}
+				createMachineResponse, err := c.createMachineWithFallbackInstanceTypes(ctx, createMachineRequest)
+				releasePermit()
 				if err != nil {
 					// Create call returned an error
 					klog.Errorf("Error while creating machine %s: %s", machine.Name, err.Error())
+					recordReconcileError(reconcileFlowCreate, err)
 					return c.machineCreateErrorHandler(ctx, machine, createMachineResponse, err)
 				}
 				nodeName = createMachineResponse.NodeName
 				providerID = createMachineResponse.ProviderID
+				capacity = createMachineResponse.Capacity
 				// Creation was successful
 				klog.V(2).Infof("Created new VM for machine: %q with ProviderID: %q and backing node: %q", machine.Name, providerID, nodeName)
 
@@ -564,6 +656,7 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 						}
 
 						_, err := c.driver.DeleteMachine(ctx, deleteMachineRequest)
+						c.deletionMachineStatusCache.Delete(providerID)
 
 						if err != nil {
 							klog.V(2).Infof("VM deletion in context of stale node obj failed for machine %q, will be retried. err=%q", machine.Name, err.Error())
@@ -609,8 +702,10 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 			//TODO@thiyyakat add a pointer to a boolean variable indicating whether initialization has happened successfully.
 			nodeName = getMachineStatusResponse.NodeName
 			providerID = getMachineStatusResponse.ProviderID
+			capacity = getMachineStatusResponse.Capacity
 
 		default:
+			recordReconcileError(reconcileFlowCreate, err)
 			return c.machineCreateErrorHandler(ctx, machine, nil, err)
 		}
 	} else {
@@ -619,10 +714,15 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 		}
 		nodeName = getMachineStatusResponse.NodeName
 		providerID = getMachineStatusResponse.ProviderID
+		capacity = getMachineStatusResponse.Capacity
 	}
 	//Update labels, providerID
 	var clone *v1alpha1.Machine
-	clone, err = c.updateLabels(ctx, createMachineRequest.Machine, nodeName, providerID)
+	clone, err = c.updateLabels(ctx, createMachineRequest.Machine, createMachineRequest.MachineClass, nodeName, providerID)
+	if len(capacity) > 0 && !apiequality.Semantic.DeepEqual(clone.Status.Capacity, capacity) {
+		clone = c.updateMachineCapacity(ctx, clone, capacity)
+		machine = clone
+	}
 	//initialize VM if not initialized
 	if uninitializedMachine {
 		var retryPeriod machineutils.RetryPeriod
@@ -635,9 +735,11 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 		return machineutils.ShortRetry, err
 	}
 	if err != nil {
+		recordReconcileError(reconcileFlowCreate, err)
 		return machineutils.ShortRetry, err
 	}
-	if machine.Status.CurrentStatus.Phase == "" || machine.Status.CurrentStatus.Phase == v1alpha1.MachineCrashLoopBackOff {
+	creationWasOnHold := machine.Status.CurrentStatus.Phase == v1alpha1.MachinePending && isMachineCreationHeld(machine)
+	if machine.Status.CurrentStatus.Phase == "" || machine.Status.CurrentStatus.Phase == v1alpha1.MachineCrashLoopBackOff || creationWasOnHold {
 		clone := machine.DeepCopy()
 		clone.Status.LastOperation = v1alpha1.LastOperation{
 			Description:    "Creating machine on cloud provider",
@@ -645,10 +747,20 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 			Type:           v1alpha1.MachineOperationCreate,
 			LastUpdateTime: metav1.Now(),
 		}
-		clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+		machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 			Phase:          v1alpha1.MachinePending,
 			TimeoutActive:  true,
 			LastUpdateTime: metav1.Now(),
+		})
+		clone.Status.FailureCategory = ""
+		if creationWasOnHold {
+			clone.Status.Conditions = nodeops.CloneAndAddCondition(clone.Status.Conditions, corev1.NodeCondition{
+				Type:               machineutils.MachineCreationHeld,
+				Status:             corev1.ConditionFalse,
+				Reason:             "CreationResumed",
+				Message:            "The VM of the machine was created",
+				LastTransitionTime: metav1.Now(),
+			})
 		}
 
 		// If running without a target cluster, set the Machine to Available immediately after a successful VM creation.
@@ -656,15 +768,20 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 		if c.targetCoreClient == nil {
 			clone.Status.LastOperation.Description = "Created machine on cloud provider"
 			clone.Status.LastOperation.State = v1alpha1.MachineStateSuccessful
-			clone.Status.CurrentStatus.Phase = v1alpha1.MachineAvailable
-			clone.Status.CurrentStatus.TimeoutActive = false
+			machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+				Phase:          v1alpha1.MachineAvailable,
+				TimeoutActive:  false,
+				LastUpdateTime: clone.Status.CurrentStatus.LastUpdateTime,
+			})
 		}
 
-		_, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
+		updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
 		if err != nil {
 			klog.Warningf("Machine/status UPDATE failed for %q. Retrying, error: %s", machine.Name, err)
+			recordReconcileError(reconcileFlowCreate, err)
 		} else {
 			klog.V(2).Infof("Machine/status UPDATE for %q during creation", machine.Name)
+			c.recordFlowStep(ctx, updatedMachine, clone.Status.LastOperation.Description)
 			// Return error even when machine object is updated
 			err = fmt.Errorf("machine creation in process. Machine/Status UPDATE successful")
 		}
@@ -673,7 +790,21 @@ func (c *controller) triggerCreationFlow(ctx context.Context, createMachineReque
 	return machineutils.LongRetry, nil
 }
 
-func (c *controller) updateLabels(ctx context.Context, machine *v1alpha1.Machine, nodeName, providerID string) (clone *v1alpha1.Machine, err error) {
+// updateMachineCapacity persists the capacity of the machine's VM reported by the driver in the machine status.
+// A failed update is only logged, as the capacity is reported again on the next reconciliation.
+func (c *controller) updateMachineCapacity(ctx context.Context, machine *v1alpha1.Machine, capacity corev1.ResourceList) *v1alpha1.Machine {
+	clone := machine.DeepCopy()
+	clone.Status.Capacity = capacity
+	updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Warningf("Machine/status UPDATE of capacity failed for %q. Retrying, error: %s", machine.Name, err)
+		return machine
+	}
+	klog.V(3).Infof("Machine/status UPDATE of capacity for %q", machine.Name)
+	return updatedMachine
+}
+
+func (c *controller) updateLabels(ctx context.Context, machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass, nodeName, providerID string) (clone *v1alpha1.Machine, err error) {
 	machineNodeLabelMissing := c.targetCoreClient != nil && !metav1.HasLabel(machine.ObjectMeta, v1alpha1.NodeLabelKey)
 	machinePriorityAnnotationPresent := metav1.HasAnnotation(machine.ObjectMeta, machineutils.MachinePriority)
 	clone = machine.DeepCopy()
@@ -687,7 +818,7 @@ func (c *controller) updateLabels(ctx context.Context, machine *v1alpha1.Machine
 			clone.Annotations = make(map[string]string)
 		}
 		if clone.Annotations[machineutils.MachinePriority] == "" {
-			clone.Annotations[machineutils.MachinePriority] = "3"
+			clone.Annotations[machineutils.MachinePriority] = c.machinePriorityComputer.ComputePriority(machine, machineClass)
 		}
 		clone.Spec.ProviderID = providerID
 		var updatedMachine *v1alpha1.Machine
@@ -723,6 +854,8 @@ func (c *controller) initializeMachine(ctx context.Context, machine *v1alpha1.Ma
 			return 0, nil
 		}
 		klog.Errorf("Error occurred while initializing VM instance for machine %q: %s", machine.Name, err)
+		machine = machine.DeepCopy()
+		machine.Status.FailureCategory = categorizeProvisioningFailure(err)
 		updateRetryPeriod, updateErr := c.machineStatusUpdate(
 			ctx,
 			machine,
@@ -758,8 +891,12 @@ func (c *controller) triggerDeletionFlow(ctx context.Context, deleteMachineReque
 	case !finalizers.Has(MCMFinalizerName):
 		// If Finalizers are not present on machine
 		err := fmt.Errorf("Machine %q is missing finalizers. Deletion cannot proceed", machine.Name)
+		recordReconcileError(reconcileFlowDelete, err)
 		return machineutils.LongRetry, err
 
+	case deleteMachineRequest.DryRun:
+		return c.planDeletionFlow(ctx, deleteMachineRequest)
+
 	case machine.Status.CurrentStatus.Phase != v1alpha1.MachineTerminating:
 		return c.setMachineTerminationStatus(ctx, deleteMachineRequest)
 
@@ -767,9 +904,10 @@ func (c *controller) triggerDeletionFlow(ctx context.Context, deleteMachineReque
 		return c.updateMachineStatusAndNodeLabel(
 			ctx,
 			&driver.GetMachineStatusRequest{
-				Machine:      deleteMachineRequest.Machine,
-				MachineClass: deleteMachineRequest.MachineClass,
-				Secret:       deleteMachineRequest.Secret,
+				Machine:        deleteMachineRequest.Machine,
+				MachineClass:   deleteMachineRequest.MachineClass,
+				Secret:         deleteMachineRequest.Secret,
+				IdempotencyKey: getIdempotencyKey(deleteMachineRequest.Machine),
 			})
 
 	case strings.Contains(machine.Status.LastOperation.Description, machineutils.InitiateDrain):
@@ -779,9 +917,15 @@ func (c *controller) triggerDeletionFlow(ctx context.Context, deleteMachineReque
 		return c.deleteNodeVolAttachments(ctx, deleteMachineRequest)
 
 	case strings.Contains(machine.Status.LastOperation.Description, machineutils.InitiateVMDeletion):
+		if retry, err := c.confirmMachineGeneration(ctx, machine, machineutils.InitiateVMDeletion); err != nil {
+			return retry, err
+		}
 		return c.deleteVM(ctx, deleteMachineRequest)
 
 	case strings.Contains(machine.Status.LastOperation.Description, machineutils.InitiateNodeDeletion):
+		if retry, err := c.confirmMachineGeneration(ctx, machine, machineutils.InitiateNodeDeletion); err != nil {
+			return retry, err
+		}
 		return c.deleteNodeObject(ctx, machine)
 
 	case strings.Contains(machine.Status.LastOperation.Description, machineutils.InitiateFinalizerRemoval):
@@ -789,6 +933,7 @@ func (c *controller) triggerDeletionFlow(ctx context.Context, deleteMachineReque
 		if err != nil {
 			// Keep retrying until update goes through
 			klog.Errorf("Machine finalizer REMOVAL failed for machine %q. Retrying, error: %s", machine.Name, err)
+			recordReconcileError(reconcileFlowDelete, err)
 			return machineutils.ShortRetry, err
 		}
 
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controller is used to provide the core functionalities of machine-controller-manager
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/drain"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// maxDeletionDryRunPods is the maximum number of pods named in the planned drain of a deletion dry run
+const maxDeletionDryRunPods = 10
+
+// planDeletionFlow records the remaining steps of the deletion flow of the machine in the description of its last
+// operation, without performing any of them. The transition of the machine to the Terminating phase is only described,
+// and the step the deletion flow is at is kept in the description, so that the deletion resumes from it once the dry
+// run is over.
+func (c *controller) planDeletionFlow(ctx context.Context, deleteMachineRequest *driver.DeleteMachineRequest) (machineutils.RetryPeriod, error) {
+	machine := deleteMachineRequest.Machine
+
+	steps, currentStep := c.getPlannedDeletionSteps(deleteMachineRequest)
+	description := fmt.Sprintf("%s: %s.", machineutils.DeletionDryRun, strings.Join(steps, ", then "))
+	if currentStep != "" {
+		description += " " + currentStep
+	}
+
+	if machine.Status.LastOperation.Description == description {
+		return machineutils.LongRetry, nil
+	}
+
+	clone := machine.DeepCopy()
+	clone.Status.LastOperation.Description = description
+	clone.Status.LastOperation.LastUpdateTime = metav1.Now()
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		klog.Warningf("Machine/status UPDATE failed for the deletion dry run of machine %q. Retrying, error: %s", machine.Name, err)
+		if apierrors.IsConflict(err) {
+			return machineutils.ConflictRetry, err
+		}
+		return machineutils.ShortRetry, err
+	}
+
+	klog.V(2).Infof("Deletion dry run of machine %q: %s", machine.Name, description)
+	return machineutils.LongRetry, nil
+}
+
+// getPlannedDeletionSteps returns the steps the deletion flow of the machine would perform from the step it is at,
+// along with that step if the machine is already Terminating
+func (c *controller) getPlannedDeletionSteps(deleteMachineRequest *driver.DeleteMachineRequest) ([]string, string) {
+	var (
+		machine     = deleteMachineRequest.Machine
+		description = machine.Status.LastOperation.Description
+		nodeName    = getNodeName(machine)
+		steps       []string
+		currentStep string
+	)
+
+	// the steps of the deletion flow in their order, starting with the steps following the transition to Terminating
+	flow := []struct {
+		step string
+		plan func() []string
+	}{
+		{machineutils.GetVMStatus, func() []string {
+			return []string{fmt.Sprintf("get the status of VM %q from the provider", getProviderID(machine))}
+		}},
+		{machineutils.InitiateDrain, func() []string {
+			return []string{c.planDrain(nodeName)}
+		}},
+		{machineutils.DelVolumesAttachments, func() []string {
+			return []string{fmt.Sprintf("delete the VolumeAttachments of node %q", nodeName)}
+		}},
+		{machineutils.InitiateVMDeletion, func() []string {
+			var plan []string
+			for _, hook := range c.getPendingPreDeletionHooks(machine) {
+				plan = append(plan, fmt.Sprintf("run pre-deletion hook %q", hook.Name()))
+			}
+			return append(plan, fmt.Sprintf("call DeleteMachine of the provider for VM %q", getProviderID(machine)))
+		}},
+		{machineutils.InitiateNodeDeletion, func() []string {
+			return []string{fmt.Sprintf("delete node %q", nodeName)}
+		}},
+		{machineutils.InitiateFinalizerRemoval, func() []string {
+			return []string{fmt.Sprintf("remove finalizer %q, after which the machine is gone", MCMFinalizerName)}
+		}},
+	}
+
+	start := 0
+	if machine.Status.CurrentStatus.Phase != v1alpha1.MachineTerminating {
+		steps = append(steps, fmt.Sprintf("set phase %q to %q", machine.Status.CurrentStatus.Phase, v1alpha1.MachineTerminating))
+	} else {
+		for i, s := range flow {
+			if strings.Contains(description, s.step) {
+				start, currentStep = i, s.step
+				break
+			}
+		}
+	}
+
+	for _, s := range flow[start:] {
+		steps = append(steps, s.plan()...)
+	}
+	return steps, currentStep
+}
+
+// planDrain describes the drain of the node, naming the pods it would evict or delete
+func (c *controller) planDrain(nodeName string) string {
+	if nodeName == "" || c.nodeLister == nil {
+		return "skip the drain as the machine has no node"
+	}
+	if _, err := c.nodeLister.Get(nodeName); err != nil {
+		return fmt.Sprintf("skip the drain as node %q isn't found", nodeName)
+	}
+
+	pods, err := drain.DryRun(c.podLister, nodeName)
+	if err != nil {
+		klog.Warningf("Failed to list the pods on node %q for the deletion dry run: %s", nodeName, err)
+		return fmt.Sprintf("drain node %q", nodeName)
+	}
+
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	slices.Sort(names)
+	if len(names) > maxDeletionDryRunPods {
+		names = append(names[:maxDeletionDryRunPods], "...")
+	}
+	return fmt.Sprintf("drain node %q evicting %d pods [%s]", nodeName, len(pods), strings.Join(names, " "))
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controller is used to provide the core functionalities of machine-controller-manager
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/options"
+	"k8s.io/klog/v2"
+)
+
+// operationTimeoutDriver bounds the CreateMachine, DeleteMachine and GetMachineStatus calls of the wrapped driver
+// by the timeout configured for the respective operation
+type operationTimeoutDriver struct {
+	driver.Driver
+	safetyOptions options.SafetyOptions
+}
+
+// newOperationTimeoutDriver wraps the driver, so that its calls are bounded by the timeouts of the safety options,
+// or of the annotations of the MachineClass of the calls overriding them
+func newOperationTimeoutDriver(d driver.Driver, safetyOptions options.SafetyOptions) driver.Driver {
+	return &operationTimeoutDriver{
+		Driver:        d,
+		safetyOptions: safetyOptions,
+	}
+}
+
+func (d *operationTimeoutDriver) CreateMachine(ctx context.Context, req *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, req.MachineClass, machineutils.MachineClassCreateMachineTimeout, d.safetyOptions.DriverCreateMachineTimeout.Duration)
+	defer cancel()
+	response, err := d.Driver.CreateMachine(ctx, req)
+	return response, toDeadlineExceededError(ctx, "CreateMachine", err)
+}
+
+func (d *operationTimeoutDriver) DeleteMachine(ctx context.Context, req *driver.DeleteMachineRequest) (*driver.DeleteMachineResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, req.MachineClass, machineutils.MachineClassDeleteMachineTimeout, d.safetyOptions.DriverDeleteMachineTimeout.Duration)
+	defer cancel()
+	response, err := d.Driver.DeleteMachine(ctx, req)
+	return response, toDeadlineExceededError(ctx, "DeleteMachine", err)
+}
+
+func (d *operationTimeoutDriver) GetMachineStatus(ctx context.Context, req *driver.GetMachineStatusRequest) (*driver.GetMachineStatusResponse, error) {
+	ctx, cancel := withOperationTimeout(ctx, req.MachineClass, machineutils.MachineClassGetMachineStatusTimeout, d.safetyOptions.DriverGetMachineStatusTimeout.Duration)
+	defer cancel()
+	response, err := d.Driver.GetMachineStatus(ctx, req)
+	return response, toDeadlineExceededError(ctx, "GetMachineStatus", err)
+}
+
+// SnapshotMachine forwards the call to the wrapped driver, which optionally supports snapshots
+func (d *operationTimeoutDriver) SnapshotMachine(ctx context.Context, req *driver.SnapshotMachineRequest) (*driver.SnapshotMachineResponse, error) {
+	return driver.SnapshotMachine(ctx, d.Driver, req)
+}
+
+// RebootMachine forwards the call to the wrapped driver, which optionally supports reboots
+func (d *operationTimeoutDriver) RebootMachine(ctx context.Context, req *driver.RebootMachineRequest) (*driver.RebootMachineResponse, error) {
+	return driver.RebootMachine(ctx, d.Driver, req)
+}
+
+// toDeadlineExceededError maps the error of a driver call whose context is done to a machine error with the
+// DeadlineExceeded code, as the raw context error of the driver carries no code the error handling relies upon
+func toDeadlineExceededError(ctx context.Context, operation string, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if machineErr, ok := status.FromError(err); ok && machineErr.Code() == codes.DeadlineExceeded {
+		return err
+	}
+	return status.Error(codes.DeadlineExceeded, fmt.Sprintf("%s call of the driver did not complete in time: %v", operation, err))
+}
+
+// withOperationTimeout returns the context of a driver call bounded by the timeout held by the given annotation of
+// the MachineClass, or by the default timeout in its absence. A timeout of 0 leaves the context unbounded.
+func withOperationTimeout(ctx context.Context, machineClass *v1alpha1.MachineClass, annotation string, defaultTimeout time.Duration) (context.Context, context.CancelFunc) {
+	timeout := defaultTimeout
+	if machineClass != nil {
+		if value, ok := machineClass.Annotations[annotation]; ok {
+			if parsed, err := time.ParseDuration(value); err != nil || parsed < 0 {
+				klog.Warningf("Ignoring invalid annotation %s=%q of MachineClass %q, it should be a non-negative duration", annotation, value, machineClass.Name)
+			} else {
+				timeout = parsed
+			}
+		}
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
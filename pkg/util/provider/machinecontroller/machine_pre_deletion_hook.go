@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controller is used to provide the core functionalities of machine-controller-manager
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// PreDeletionHook is invoked during the deletion of a machine after its node is drained and before its VM is deleted.
+// The VM deletion waits until the hook succeeds, retrying it on errors.
+type PreDeletionHook interface {
+	// Name identifies the hook in the status and the logs of the machine
+	Name() string
+	// Run runs the hook for the machine, returning an error if it has to be retried
+	Run(ctx context.Context, machine *v1alpha1.Machine) error
+}
+
+// NewHTTPPreDeletionHooks returns the PreDeletionHooks calling the given HTTP endpoints, in the same order
+func NewHTTPPreDeletionHooks(urls []string, timeout time.Duration) []PreDeletionHook {
+	hooks := make([]PreDeletionHook, 0, len(urls))
+	for _, url := range urls {
+		hooks = append(hooks, &httpPreDeletionHook{
+			url:    url,
+			client: &http.Client{Timeout: timeout},
+		})
+	}
+	return hooks
+}
+
+// httpPreDeletionHook posts the machine to an HTTP endpoint, succeeding on a 2xx response
+type httpPreDeletionHook struct {
+	url    string
+	client *http.Client
+}
+
+// httpPreDeletionHookRequest is the body posted by the httpPreDeletionHook
+type httpPreDeletionHookRequest struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	ProviderID string            `json:"providerID,omitempty"`
+	NodeName   string            `json:"nodeName,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+func (h *httpPreDeletionHook) Name() string {
+	return h.url
+}
+
+func (h *httpPreDeletionHook) Run(ctx context.Context, machine *v1alpha1.Machine) error {
+	body, err := json.Marshal(httpPreDeletionHookRequest{
+		Name:       machine.Name,
+		Namespace:  machine.Namespace,
+		ProviderID: machine.Spec.ProviderID,
+		NodeName:   getNodeName(machine),
+		Labels:     machine.Labels,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+	return nil
+}
+
+// getCompletedPreDeletionHooks returns the names of the pre-deletion hooks which already succeeded for the machine
+func getCompletedPreDeletionHooks(machine *v1alpha1.Machine) []string {
+	value, ok := machine.Annotations[machineutils.MachinePreDeletionHooksCompleted]
+	if !ok {
+		return nil
+	}
+	var completed []string
+	if err := json.Unmarshal([]byte(value), &completed); err != nil {
+		klog.Warningf("Ignoring invalid value %q of annotation %q of machine %q: %s", value, machineutils.MachinePreDeletionHooksCompleted, machine.Name, err)
+		return nil
+	}
+	return completed
+}
+
+// getPendingPreDeletionHooks returns the configured pre-deletion hooks, in order, which did not succeed yet for the machine
+func (c *controller) getPendingPreDeletionHooks(machine *v1alpha1.Machine) []PreDeletionHook {
+	completed := getCompletedPreDeletionHooks(machine)
+	var pending []PreDeletionHook
+	for _, hook := range c.preDeletionHooks {
+		if !slices.Contains(completed, hook.Name()) {
+			pending = append(pending, hook)
+		}
+	}
+	return pending
+}
+
+// runPreDeletionHook runs a pre-deletion hook of the machine which did not succeed yet.
+// Its success is recorded on the machine by its name, so that each hook succeeds once before the VM is deleted,
+// even if the configured hooks are reordered or extended meanwhile.
+func (c *controller) runPreDeletionHook(ctx context.Context, machine *v1alpha1.Machine, hook PreDeletionHook) (machineutils.RetryPeriod, error) {
+	if err := hook.Run(ctx, machine); err != nil {
+		description := fmt.Sprintf("Pre-deletion hook %q failed due to error: %s. Will retry in next sync. %s", hook.Name(), err, machineutils.InitiateVMDeletion)
+		klog.Warningf("%s for machine %q", description, machine.Name)
+		updateRetryPeriod, updateErr := c.machineStatusUpdate(
+			ctx,
+			machine,
+			v1alpha1.LastOperation{
+				Description:    description,
+				State:          v1alpha1.MachineStateProcessing,
+				Type:           v1alpha1.MachineOperationDelete,
+				LastUpdateTime: metav1.Now(),
+			},
+			machine.Status.CurrentStatus,
+			machine.Status.LastKnownState,
+		)
+		if updateErr != nil {
+			return updateRetryPeriod, updateErr
+		}
+
+		return machineutils.ShortRetry, fmt.Errorf("Machine deletion in process. Waiting for pre-deletion hook %q to succeed", hook.Name())
+	}
+
+	completed, err := json.Marshal(append(getCompletedPreDeletionHooks(machine), hook.Name()))
+	if err != nil {
+		return machineutils.ShortRetry, err
+	}
+	clone := machine.DeepCopy()
+	if clone.Annotations == nil {
+		clone.Annotations = make(map[string]string)
+	}
+	clone.Annotations[machineutils.MachinePreDeletionHooksCompleted] = string(completed)
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to record success of pre-deletion hook %q of machine %q: %s", hook.Name(), machine.Name, err)
+		return machineutils.ShortRetry, err
+	}
+
+	klog.V(2).Infof("Pre-deletion hook %q of machine %q succeeded", hook.Name(), machine.Name)
+
+	// Requeue so that the remaining hooks run, and the VM is deleted, after the success is recorded
+	return machineutils.ShortRetry, fmt.Errorf("Machine deletion in process. Pre-deletion hook %q succeeded", hook.Name())
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controller is used to provide the core functionalities of machine-controller-manager
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
+)
+
+const (
+	// DefaultMachinePriorityComputerName is the name of the MachinePriorityComputer
+	// which assigns the same priority to all machines
+	DefaultMachinePriorityComputerName = "default"
+	// SpotFirstMachinePriorityComputerName is the name of the MachinePriorityComputer
+	// which prefers deleting spot machines first on scale-down
+	SpotFirstMachinePriorityComputerName = "prefer-delete-spot-first"
+
+	// defaultMachinePriority is the priority assigned to a machine unless computed otherwise
+	defaultMachinePriority = "3"
+	// spotMachinePriority is the priority assigned to spot machines by the spotFirstMachinePriorityComputer
+	spotMachinePriority = "2"
+)
+
+// MachinePriorityComputer computes the value of the machineutils.MachinePriority annotation
+// set on a machine during its creation. The less the priority, the more likely the machine
+// is deleted first on scale-down.
+type MachinePriorityComputer interface {
+	ComputePriority(machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass) string
+}
+
+// NewMachinePriorityComputer returns the MachinePriorityComputer with the given name
+func NewMachinePriorityComputer(name string) (MachinePriorityComputer, error) {
+	switch name {
+	case "", DefaultMachinePriorityComputerName:
+		return defaultMachinePriorityComputer{}, nil
+	case SpotFirstMachinePriorityComputerName:
+		return spotFirstMachinePriorityComputer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown machine priority computer %q", name)
+	}
+}
+
+// defaultMachinePriorityComputer assigns the default priority to all machines
+type defaultMachinePriorityComputer struct{}
+
+func (defaultMachinePriorityComputer) ComputePriority(_ *v1alpha1.Machine, _ *v1alpha1.MachineClass) string {
+	return defaultMachinePriority
+}
+
+// spotFirstMachinePriorityComputer assigns a lower priority to machines labelled as spot machines,
+// either directly or via their MachineClass, so that they are deleted before on-demand machines
+type spotFirstMachinePriorityComputer struct{}
+
+func (spotFirstMachinePriorityComputer) ComputePriority(machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass) string {
+	if machine.Labels[machineutils.LabelKeySpotMachine] == "true" {
+		return spotMachinePriority
+	}
+	if machineClass != nil && machineClass.Labels[machineutils.LabelKeySpotMachine] == "true" {
+		return spotMachinePriority
+	}
+	return defaultMachinePriority
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package controller is used to provide the core functionalities of machine-controller-manager
+package controller
+
+import (
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/metrics"
+)
+
+// machineClassQueue is the underlying storage of the machine queue. It tracks the MachineClass of every
+// queued machine to expose the queue depth per MachineClass, and, if fair is set, dequeues machines
+// round-robin across MachineClasses, so that the churn of one large MachineClass doesn't starve the others.
+// It implements workqueue.Queue, whose methods are always called with the lock of the workqueue held.
+type machineClassQueue struct {
+	fair    bool
+	classOf func(item interface{}) string
+
+	// items holds the queued items in FIFO order if fair is not set
+	items []interface{}
+	// classItems holds the queued items per MachineClass in FIFO order if fair is set
+	classItems map[string][]interface{}
+	// classes holds the MachineClasses with queued items in round-robin order if fair is set
+	classes []string
+	// itemClasses holds the MachineClass of every queued item
+	itemClasses map[interface{}]string
+}
+
+var _ workqueue.Queue[any] = &machineClassQueue{}
+
+func newMachineClassQueue(fair bool, classOf func(item interface{}) string) *machineClassQueue {
+	return &machineClassQueue{
+		fair:        fair,
+		classOf:     classOf,
+		classItems:  make(map[string][]interface{}),
+		itemClasses: make(map[interface{}]string),
+	}
+}
+
+// newMachineQueue returns the rate limited machine queue, which dequeues machines
+// round-robin across MachineClasses if fair is set
+func newMachineQueue(fair bool, classOf func(item interface{}) string) workqueue.RateLimitingInterface {
+	const name = "machine"
+	return workqueue.NewRateLimitingQueueWithConfig(workqueue.DefaultControllerRateLimiter(), workqueue.RateLimitingQueueConfig{
+		Name: name,
+		DelayingQueue: workqueue.NewDelayingQueueWithConfig(workqueue.DelayingQueueConfig{
+			Name: name,
+			Queue: workqueue.NewWithConfig(workqueue.QueueConfig{
+				Name:  name,
+				Queue: newMachineClassQueue(fair, classOf),
+			}),
+		}),
+	})
+}
+
+// Touch is a no-op as the position of a queued item doesn't change when it's added again
+func (q *machineClassQueue) Touch(_ interface{}) {}
+
+// Push adds a new item
+func (q *machineClassQueue) Push(item interface{}) {
+	class := q.classOf(item)
+	q.itemClasses[item] = class
+	metrics.MachineQueueDepth.WithLabelValues(class).Inc()
+
+	if !q.fair {
+		q.items = append(q.items, item)
+		return
+	}
+	if len(q.classItems[class]) == 0 {
+		q.classes = append(q.classes, class)
+	}
+	q.classItems[class] = append(q.classItems[class], item)
+}
+
+// Len returns the number of queued items
+func (q *machineClassQueue) Len() int {
+	return len(q.itemClasses)
+}
+
+// Pop retrieves the next item, which is the oldest item of the next MachineClass in turn if fair is set
+func (q *machineClassQueue) Pop() interface{} {
+	var item interface{}
+	if !q.fair {
+		item = q.items[0]
+		q.items[0] = nil
+		q.items = q.items[1:]
+	} else {
+		class := q.classes[0]
+		q.classes = q.classes[1:]
+		items := q.classItems[class]
+		item = items[0]
+		if len(items) == 1 {
+			delete(q.classItems, class)
+		} else {
+			items[0] = nil
+			q.classItems[class] = items[1:]
+			// Move the MachineClass to the end of the round-robin order
+			q.classes = append(q.classes, class)
+		}
+	}
+
+	metrics.MachineQueueDepth.WithLabelValues(q.itemClasses[item]).Dec()
+	delete(q.itemClasses, item)
+	return item
+}
+
+// getMachineClassOfKey returns the name of the MachineClass of the machine with the given key,
+// or an empty string if the machine can't be found
+func (c *controller) getMachineClassOfKey(key interface{}) string {
+	keyString, ok := key.(string)
+	if !ok {
+		return ""
+	}
+	_, name, err := cache.SplitMetaNamespaceKey(keyString)
+	if err != nil {
+		return ""
+	}
+	machine, err := c.machineLister.Machines(c.namespace).Get(name)
+	if err != nil {
+		return ""
+	}
+	return machine.Spec.Class.Name
+}
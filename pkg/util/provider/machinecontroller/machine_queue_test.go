@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+
+	v1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("machine_queue", func() {
+
+	Describe("#newMachineQueue", func() {
+		newClassMachine := func(name, className string) *v1alpha1.Machine {
+			return &v1alpha1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: testNamespace,
+				},
+				Spec: v1alpha1.MachineSpec{
+					Class: v1alpha1.ClassSpec{
+						Kind: "MachineClass",
+						Name: className,
+					},
+				},
+			}
+		}
+
+		DescribeTable("##dequeue order",
+			func(fair bool, expectedOrder []string) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				// Machines of the large class-a are enqueued before those of class-b
+				controlMachineObjects := []runtime.Object{
+					newClassMachine("a-0", "class-a"),
+					newClassMachine("a-1", "class-a"),
+					newClassMachine("a-2", "class-a"),
+					newClassMachine("b-0", "class-b"),
+					newClassMachine("b-1", "class-b"),
+				}
+				c, trackers := createController(stop, testNamespace, controlMachineObjects, nil, nil, nil, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, c)
+
+				queue := newMachineQueue(fair, c.getMachineClassOfKey)
+				defer queue.ShutDown()
+				for _, obj := range controlMachineObjects {
+					queue.Add(fmt.Sprintf("%s/%s", testNamespace, obj.(*v1alpha1.Machine).Name))
+				}
+				Expect(queue.Len()).To(Equal(len(controlMachineObjects)))
+
+				var order []string
+				for queue.Len() > 0 {
+					key, _ := queue.Get()
+					order = append(order, key.(string))
+					queue.Done(key)
+				}
+				Expect(order).To(Equal(expectedOrder))
+			},
+			Entry("should dequeue machines strictly FIFO without fairness", false, []string{
+				testNamespace + "/a-0", testNamespace + "/a-1", testNamespace + "/a-2", testNamespace + "/b-0", testNamespace + "/b-1",
+			}),
+			Entry("should interleave machines of different MachineClasses with fairness", true, []string{
+				testNamespace + "/a-0", testNamespace + "/b-0", testNamespace + "/a-1", testNamespace + "/b-1", testNamespace + "/a-2",
+			}),
+		)
+
+		It("should keep a machine at its position when it is added again", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			controlMachineObjects := []runtime.Object{
+				newClassMachine("a-0", "class-a"),
+				newClassMachine("a-1", "class-a"),
+				newClassMachine("b-0", "class-b"),
+			}
+			c, trackers := createController(stop, testNamespace, controlMachineObjects, nil, nil, nil, false)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			queue := newMachineQueue(true, c.getMachineClassOfKey)
+			defer queue.ShutDown()
+			queue.Add(testNamespace + "/a-0")
+			queue.Add(testNamespace + "/a-1")
+			queue.Add(testNamespace + "/a-0")
+			queue.Add(testNamespace + "/b-0")
+			Expect(queue.Len()).To(Equal(3))
+
+			var order []string
+			for queue.Len() > 0 {
+				key, _ := queue.Get()
+				order = append(order, key.(string))
+				queue.Done(key)
+			}
+			Expect(order).To(Equal([]string{testNamespace + "/a-0", testNamespace + "/b-0", testNamespace + "/a-1"}))
+		})
+	})
+})
@@ -7,6 +7,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -75,22 +76,25 @@ func (c *controller) reconcileClusterMachineSafetyAPIServer(_ string) error {
 						return err
 					}
 
-					machine.Status.CurrentStatus = v1alpha1.CurrentStatus{
+					machineutils.SetCurrentStatus(&machine.Status, v1alpha1.CurrentStatus{
 						Phase:          v1alpha1.MachineRunning,
 						TimeoutActive:  false,
 						LastUpdateTime: metav1.Now(),
-					}
+					})
 					machine.Status.LastOperation = v1alpha1.LastOperation{
 						Description:    "Machine Health Timeout was reset due to APIServer being unreachable",
 						LastUpdateTime: metav1.Now(),
 						State:          v1alpha1.MachineStateSuccessful,
 						Type:           v1alpha1.MachineOperationHealthCheck,
 					}
-					_, err = c.controlMachineClient.Machines(c.namespace).UpdateStatus(ctx, machine, metav1.UpdateOptions{})
+					machine, err = c.controlMachineClient.Machines(c.namespace).UpdateStatus(ctx, machine, metav1.UpdateOptions{})
 					if err != nil {
 						klog.Error("SafetyController: Unable to UPDATE machine/status. Error:", err)
 						return err
 					}
+					if err := c.recordSafetyAction(ctx, machine, machineutils.SafetyReconcilerAPIServer); err != nil {
+						return err
+					}
 
 					klog.V(2).Infof("SafetyController: Reinitializing machine health check for machine: %q with backing node: %q and providerID: %q", machine.Name, getNodeName(machine), getProviderID(machine))
 				}
@@ -259,6 +263,7 @@ func (c *controller) checkMachineClass(ctx context.Context, machineClass *v1alph
 		}
 	}
 
+	var errs []error
 	for machineID, machineName := range listMachineResponse.MachineList {
 		machine, err := c.machineLister.Machines(c.namespace).Get(machineName)
 
@@ -275,7 +280,7 @@ func (c *controller) checkMachineClass(ctx context.Context, machineClass *v1alph
 			}
 
 			// Creating a dummy machine object to create deleteMachineRequest
-			machine = &v1alpha1.Machine{
+			dummyMachine := &v1alpha1.Machine{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      machineName,
 					Namespace: machineClass.Namespace,
@@ -286,14 +291,21 @@ func (c *controller) checkMachineClass(ctx context.Context, machineClass *v1alph
 			}
 
 			_, err := c.driver.DeleteMachine(ctx, &driver.DeleteMachineRequest{
-				Machine:      machine,
+				Machine:      dummyMachine,
 				MachineClass: machineClass,
 				Secret:       &corev1.Secret{Data: secretData},
 			})
+			c.deletionMachineStatusCache.Delete(machineID)
 			if err != nil {
 				klog.Errorf("SafetyController: Error while trying to DELETE VM on CP - %s. Shall retry in next safety controller sync.", err)
 			} else {
 				klog.V(2).Infof("SafetyController: Orphan VM found and terminated VM: %s, %s", machineName, machineID)
+				// the machine object of the MachineClass, if any, is backed by another VM
+				if machine != nil && machine.Spec.Class.Name == machineClass.Name {
+					if err := c.recordSafetyAction(ctx, machine, machineutils.SafetyReconcilerOrphanVMs); err != nil {
+						errs = append(errs, err)
+					}
+				}
 			}
 		} else {
 			// errors other than NotFound error
@@ -301,6 +313,9 @@ func (c *controller) checkMachineClass(ctx context.Context, machineClass *v1alph
 		}
 	}
 
+	if len(errs) > 0 {
+		return machineutils.ShortRetry, errors.Join(errs...)
+	}
 	return machineutils.LongRetry, nil
 }
 
@@ -6,6 +6,7 @@ package controller
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	v1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
@@ -184,6 +185,19 @@ var _ = Describe("safety_logic", func() {
 				_ = c.reconcileClusterMachineSafetyAPIServer("")
 
 				Expect(c.safetyOptions.MachineControllerFrozen).Should(Equal(postMachineControllerFrozen))
+
+				if controlAPIServerIsUp && targetAPIServerIsUp {
+					machine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), testMachine.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					if preMachineControllerIsFrozen {
+						// the health timeout of the Unknown machine is reset on unfreezing
+						Expect(machine.Status.CurrentStatus.Phase).To(Equal(v1alpha1.MachineRunning))
+						Expect(machine.Annotations).To(HaveKeyWithValue(machineutils.MachineLastSafetyAction, HavePrefix(machineutils.SafetyReconcilerAPIServer+"@")))
+					} else {
+						Expect(machine.Status.CurrentStatus.Phase).To(Equal(v1alpha1.MachineUnknown))
+						Expect(machine.Annotations).ToNot(HaveKey(machineutils.MachineLastSafetyAction))
+					}
+				}
 			},
 
 			// Both APIServers are reachable
@@ -253,6 +267,10 @@ var _ = Describe("safety_logic", func() {
 			//machineIds of machines which are expected to be deleted
 			toBeDeletedMachines []string
 			toBePresentMachines map[string]string
+			// names of machine objects on which the orphan VM reconciler is expected to be recorded
+			safetyActionMachines []string
+			// names of machine objects on which no safety action is expected to be recorded
+			noSafetyActionMachines []string
 		}
 		type data struct {
 			setup  setup
@@ -338,6 +356,21 @@ var _ = Describe("safety_logic", func() {
 			for machineID, machineName := range data.expect.toBePresentMachines {
 				Expect(listMachinesResponse.MachineList[machineID]).To(Equal(machineName))
 			}
+
+			for _, machineName := range data.expect.safetyActionMachines {
+				machine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machineName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Annotations).To(HaveKeyWithValue(machineutils.MachineLastSafetyAction, HavePrefix(machineutils.SafetyReconcilerOrphanVMs+"@")))
+				actedAt, err := time.Parse(time.RFC3339, strings.TrimPrefix(machine.Annotations[machineutils.MachineLastSafetyAction], machineutils.SafetyReconcilerOrphanVMs+"@"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(actedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			}
+
+			for _, machineName := range data.expect.noSafetyActionMachines {
+				machine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machineName, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Annotations).ToNot(HaveKey(machineutils.MachineLastSafetyAction))
+			}
 		},
 			Entry("machine object not found", &data{
 				setup: setup{
@@ -384,6 +417,9 @@ var _ = Describe("safety_logic", func() {
 								Name:      "testmachine_1",
 								Namespace: testNamespace,
 							},
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{Kind: machineutils.MachineClassKind, Name: "class-0"},
+							},
 							Status: v1alpha1.MachineStatus{
 								CurrentStatus: v1alpha1.CurrentStatus{
 									Phase: v1alpha1.MachineRunning,
@@ -396,8 +432,36 @@ var _ = Describe("safety_logic", func() {
 					},
 				},
 				expect: expect{
-					toBeDeletedMachines: []string{"testmachine-ip1"},
-					toBePresentMachines: nil,
+					toBeDeletedMachines:  []string{"testmachine-ip1"},
+					toBePresentMachines:  nil,
+					safetyActionMachines: []string{"testmachine_1"},
+				},
+			}),
+			Entry("machine object of another machine class carrying the name of the orphan VM shouldn't record the action", &data{
+				setup: setup{
+					machineObjects: []*v1alpha1.Machine{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "testmachine_1",
+								Namespace: testNamespace,
+							},
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{Kind: machineutils.MachineClassKind, Name: "other-class"},
+							},
+							Status: v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase: v1alpha1.MachineRunning,
+								},
+							},
+						},
+					},
+					machinesOnProvider: map[string]string{
+						"testmachine-ip1": "testmachine_1",
+					},
+				},
+				expect: expect{
+					toBeDeletedMachines:    []string{"testmachine-ip1"},
+					noSafetyActionMachines: []string{"testmachine_1"},
 				},
 			}),
 		)
@@ -617,7 +681,7 @@ var _ = Describe("safety_logic", func() {
 					err:   nil,
 				},
 			}),
-			Entry("Don't annotate node object when creation time is less than machineCreationTimeout (19mins old)", &data{
+			Entry("Don't annotate node object when creation time is less than machineCreationTimeout (15mins old)", &data{
 				setup: setup{
 					node: &corev1.Node{
 						TypeMeta: metav1.TypeMeta{
@@ -629,7 +693,7 @@ var _ = Describe("safety_logic", func() {
 							Annotations: map[string]string{
 								"anno1": "value1",
 							},
-							CreationTimestamp: metav1.NewTime(metav1.Now().Add(-19 * time.Minute)),
+							CreationTimestamp: metav1.NewTime(metav1.Now().Add(-15 * time.Minute)),
 						},
 					},
 				},
@@ -6,7 +6,10 @@ package controller
 
 import (
 	"context"
+	"time"
 
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -33,3 +36,19 @@ func (c *controller) updateNodeWithAnnotations(ctx context.Context, node *v1.Nod
 
 	return nil
 }
+
+// recordSafetyAction records the safety reconciler which acted on the machine, and when, in its MachineLastSafetyAction annotation
+func (c *controller) recordSafetyAction(ctx context.Context, machine *v1alpha1.Machine, reconciler string) error {
+	clone := machine.DeepCopy()
+	if clone.Annotations == nil {
+		clone.Annotations = make(map[string]string)
+	}
+	clone.Annotations[machineutils.MachineLastSafetyAction] = reconciler + "@" + time.Now().UTC().Format(time.RFC3339)
+
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to record the action of safety reconciler %q on machine %q due to error: %s", reconciler, machine.Name, err)
+		return err
+	}
+
+	return nil
+}
@@ -6,27 +6,42 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
 	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/utils/ptr"
 
 	machineapi "github.com/gardener/machine-controller-manager/pkg/apis/machine"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/validation"
 	fakemachineapi "github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/typed/machine/v1alpha1/fake"
 	customfake "github.com/gardener/machine-controller-manager/pkg/fakeclient"
+	"github.com/gardener/machine-controller-manager/pkg/util/permits"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/drain"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/options"
 )
 
 const testNamespace = "test"
@@ -106,6 +121,37 @@ var _ = Describe("machine", func() {
 			Entry("with NodeReady is False", corev1.NodeReady, corev1.ConditionFalse, false),
 			Entry("with NodeReady is Unknown", corev1.NodeReady, corev1.ConditionUnknown, false),
 		)
+
+		DescribeTable("Checking health of the machine with node conditions overridden by its MachineClass",
+			func(conditionType corev1.NodeConditionType, machineNodeConditions *string, expected bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineClass := &v1alpha1.MachineClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "machine-class",
+						Namespace:   testNamespace,
+						Annotations: map[string]string{machineutils.MachineClassNodeConditions: "KernelDeadlock,GPUUnhealthy"},
+					},
+				}
+				testMachine.Spec.Class = v1alpha1.ClassSpec{Kind: machineutils.MachineClassKind, Name: machineClass.Name}
+				if machineNodeConditions != nil {
+					testMachine.Spec.MachineConfiguration = &v1alpha1.MachineConfiguration{NodeConditions: machineNodeConditions}
+				}
+				testMachine.Status.Conditions = append(testMachine.Status.Conditions, corev1.NodeCondition{Type: conditionType, Status: corev1.ConditionTrue})
+
+				c, trackers := createController(stop, testNamespace, []runtime.Object{machineClass}, nil, nil, nil, false)
+				defer trackers.Stop()
+				c.nodeConditions = "ReadonlyFilesystem,KernelDeadlock,DiskPressure,NetworkUnavailable"
+				waitForCacheSync(stop, c)
+
+				Expect(c.isHealthy(&testMachine)).Should(BeIdenticalTo(expected))
+			},
+			Entry("with a condition of the MachineClass True", corev1.NodeConditionType("GPUUnhealthy"), nil, false),
+			Entry("with a condition of the MachineClass and the controller True", corev1.NodeConditionType("KernelDeadlock"), nil, false),
+			Entry("with a condition only of the controller True", corev1.NodeConditionType("ReadonlyFilesystem"), nil, true),
+			Entry("with a condition of the MachineClass True but overridden by the machine", corev1.NodeConditionType("GPUUnhealthy"), ptr.To("ReadonlyFilesystem"), true),
+		)
 	})
 
 	Describe("#criticalComponentsNotReadyTaintPresent", func() {
@@ -476,23 +522,161 @@ var _ = Describe("machine", func() {
 		)
 	})
 
+	Describe("#reconcileClusterMachineKey", func() {
+		objMeta := &metav1.ObjectMeta{
+			GenerateName: "machine",
+			Namespace:    testNamespace,
+		}
+
+		Context("when a machine is annotated to pause its reconciliation", func() {
+			It("should skip the paused machine while processing the others", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       "machine-0",
+							Namespace:  objMeta.Namespace,
+							Finalizers: []string{MCMFinalizerName},
+						},
+						SecretRef: newSecretReference(objMeta, 0),
+					},
+				}
+				for i, annotations := range []map[string]string{
+					{machineutils.MachinePauseReconcile: "true"},
+					nil,
+				} {
+					machine := newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, i),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
+							},
+						},
+					}, nil, nil, annotations, nil, true, metav1.Now())
+					machine.DeletionTimestamp = nil
+					machineObjects = append(machineObjects, machine)
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Data:       map[string][]byte{"userData": []byte("test")},
+					},
+				}
+
+				fakeDriver := driver.NewFakeDriver(false, "fakeID-0", "fakeNode-0", "", nil, nil)
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				for _, name := range []string{"machine-0", "machine-1"} {
+					Expect(controller.reconcileClusterMachineKey(objMeta.Namespace + "/" + name)).To(Succeed())
+				}
+
+				paused, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(paused.Spec.ProviderID).To(BeEmpty())
+				Expect(paused.Status.CurrentStatus.Phase).To(BeEmpty())
+
+				unpaused, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-1", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(unpaused.Spec.ProviderID).To(Equal("fakeID-0"))
+			})
+		})
+
+		Context("when recording the last successful reconcile", func() {
+			DescribeTable("##table",
+				func(machineClassName string, recorded *metav1.Time, expectRecorded bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machine := newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: machineClassName,
+							},
+							ProviderID: "fakeID-0",
+						},
+					}, &v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase:          v1alpha1.MachineAvailable,
+							LastUpdateTime: metav1.Now(),
+						},
+						LastSuccessfulReconcile: recorded,
+					}, nil, nil, nil, true, metav1.Now())
+					machine.DeletionTimestamp = nil
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:       "machine-0",
+								Namespace:  objMeta.Namespace,
+								Finalizers: []string{MCMFinalizerName},
+							},
+							SecretRef: newSecretReference(objMeta, 0),
+						},
+						machine,
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Data:       map[string][]byte{"userData": []byte("test")},
+						},
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID-0", "fakeNode-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, true)
+					defer trackers.Stop()
+					waitForCacheSync(stop, controller)
+
+					before := metav1.Now()
+					Expect(controller.reconcileClusterMachineKey(objMeta.Namespace + "/machine-0")).To(Succeed())
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					if expectRecorded {
+						Expect(machine.Status.LastSuccessfulReconcile).ToNot(BeNil())
+						Expect(machine.Status.LastSuccessfulReconcile.Time).ToNot(BeTemporally("<", before.Time.Truncate(time.Second)))
+					} else {
+						Expect(machine.Status.LastSuccessfulReconcile).To(Equal(recorded))
+					}
+				},
+				Entry("should record the time of a successful reconcile", "machine-0", nil, true),
+				Entry("should record the time of a successful reconcile if the recorded time is older than the update interval", "machine-0", &metav1.Time{Time: time.Now().Add(-machineutils.LastSuccessfulReconcileUpdateInterval - time.Minute)}, true),
+				Entry("should not record the time of a successful reconcile within the update interval", "machine-0", &metav1.Time{Time: time.Now().Add(-time.Minute).Truncate(time.Second)}, false),
+				Entry("should not record the time of a failing reconcile", "machine-missing", nil, false),
+			)
+		})
+	})
+
 	Describe("#triggerCreationFlow", func() {
 		type setup struct {
-			machineClasses      []*v1alpha1.MachineClass
-			machines            []*v1alpha1.Machine
-			secrets             []*corev1.Secret
-			nodes               []*corev1.Node
-			fakeResourceActions *customfake.ResourceActions
-			noTargetCluster     bool
+			machineClasses          []*v1alpha1.MachineClass
+			machines                []*v1alpha1.Machine
+			secrets                 []*corev1.Secret
+			nodes                   []*corev1.Node
+			fakeResourceActions     *customfake.ResourceActions
+			noTargetCluster         bool
+			machinePriorityComputer MachinePriorityComputer
+			configMaps              []*corev1.ConfigMap
+			machineQuotaConfigMap   string
 		}
 		type action struct {
 			machine    string
 			fakeDriver *driver.FakeDriver
 		}
 		type expect struct {
-			machine *v1alpha1.Machine
-			err     error
-			retry   machineutils.RetryPeriod
+			machine      *v1alpha1.Machine
+			err          error
+			retry        machineutils.RetryPeriod
+			event        string
+			priority     string
+			userData     string
+			nodeLabels   map[string]string
+			creationHeld bool
 		}
 		type data struct {
 			setup  setup
@@ -520,6 +704,9 @@ var _ = Describe("machine", func() {
 				for _, o := range data.setup.secrets {
 					controlCoreObjects = append(controlCoreObjects, o)
 				}
+				for _, o := range data.setup.configMaps {
+					controlCoreObjects = append(controlCoreObjects, o)
+				}
 
 				targetCoreObjects := []runtime.Object{}
 				for _, o := range data.setup.nodes {
@@ -539,6 +726,11 @@ var _ = Describe("machine", func() {
 
 				defer trackers.Stop()
 
+				if data.setup.machinePriorityComputer != nil {
+					controller.machinePriorityComputer = data.setup.machinePriorityComputer
+				}
+				controller.safetyOptions.MachineQuotaConfigMap = data.setup.machineQuotaConfigMap
+
 				waitForCacheSync(stop, controller)
 
 				action := data.action
@@ -551,14 +743,12 @@ var _ = Describe("machine", func() {
 				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
 				Expect(err).ToNot(HaveOccurred())
 
-				retry, err := controller.triggerCreationFlow(
-					context.TODO(),
-					&driver.CreateMachineRequest{
-						Machine:      machine,
-						MachineClass: machineClass,
-						Secret:       secret,
-					},
-				)
+				createMachineRequest := &driver.CreateMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				}
+				retry, err := controller.triggerCreationFlow(context.TODO(), createMachineRequest)
 
 				if data.expect.err != nil || err != nil {
 					Expect(err).To(HaveOccurred())
@@ -571,6 +761,11 @@ var _ = Describe("machine", func() {
 				Expect(actual.Finalizers).To(Equal(data.expect.machine.Finalizers))
 				Expect(retry).To(Equal(data.expect.retry))
 				Expect(actual.Status.CurrentStatus.Phase).To(Equal(data.expect.machine.Status.CurrentStatus.Phase))
+				if data.setup.noTargetCluster && machine.Status.CurrentStatus.Phase != v1alpha1.MachineAvailable && actual.Status.CurrentStatus.Phase == v1alpha1.MachineAvailable {
+					// the transition via Pending is recorded
+					Expect(actual.Status.PhaseTransitionTimes).To(HaveKey(v1alpha1.MachinePending))
+					Expect(actual.Status.PhaseTransitionTimes).To(HaveKey(v1alpha1.MachineAvailable))
+				}
 				if data.expect.machine.Labels == nil {
 					Expect(actual.Labels).To(BeNil())
 				} else {
@@ -584,6 +779,27 @@ var _ = Describe("machine", func() {
 				if data.expect.machine.Status.LastOperation.Description != "" {
 					Expect(actual.Status.LastOperation.Description).To(Equal(data.expect.machine.Status.LastOperation.Description))
 				}
+				if data.expect.machine.Status.FailureCategory != "" {
+					Expect(actual.Status.FailureCategory).To(Equal(data.expect.machine.Status.FailureCategory))
+				}
+				if data.expect.priority != "" {
+					Expect(actual.Annotations).To(HaveKeyWithValue(machineutils.MachinePriority, data.expect.priority))
+				}
+				if data.expect.userData != "" {
+					Expect(string(createMachineRequest.Secret.Data["userData"])).To(Equal(data.expect.userData))
+				}
+				if data.expect.nodeLabels != nil {
+					Expect(fakedriver.(*driver.FakeDriver).NodeLabels).To(Equal(data.expect.nodeLabels))
+				}
+				if data.expect.event != "" {
+					recorder := controller.recorder.(*record.FakeRecorder)
+					Expect(recorder.Events).To(Receive(ContainSubstring(data.expect.event)))
+				}
+				if data.expect.creationHeld {
+					// CreateMachine of the fake driver marks the VM as existing
+					Expect(fakedriver.(*driver.FakeDriver).VMExists).To(BeFalse())
+					Expect(isMachineCreationHeld(actual)).To(BeTrue())
+				}
 			},
 
 			Entry("Machine creation succeeds with object UPDATE", &data{
@@ -634,7 +850,7 @@ var _ = Describe("machine", func() {
 					retry: machineutils.ShortRetry,
 				},
 			}),
-			Entry("Machine creation succeeds with status UPDATE", &data{
+			Entry("Machine creation passes the intended node labels to the driver", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -648,168 +864,107 @@ var _ = Describe("machine", func() {
 							SecretRef:  newSecretReference(objMeta, 0),
 						},
 					},
-					machines: newMachines(
-						1,
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
+							},
+							NodeTemplateSpec: v1alpha1.NodeTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Labels: map[string]string{"worker.gardener.cloud/pool": "pool-0"},
 								},
-								ProviderID: "fakeID",
 							},
 						},
-						nil,
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
-						},
-						true,
-						metav1.Now(),
-					),
+					}, nil, nil, nil, map[string]string{machineutils.LabelKeyMachineDeployment: "machinedeployment-0"}, true, metav1.Now()),
 				},
 				action: action{
 					machine: "machine-0",
 					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
+						VMExists:   false,
 						ProviderID: "fakeID-0",
 						NodeName:   "fakeNode-0",
 						Err:        nil,
 					},
 				},
 				expect: expect{
-					machine: newMachine(
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
-								},
-								ProviderID: "fakeID",
-							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase: v1alpha1.MachinePending,
-							},
-						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+					machine: newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							ProviderID: "fakeID",
 						},
-						true,
-						metav1.Now(),
-					),
-					err:   fmt.Errorf("machine creation in process. Machine/Status UPDATE successful"),
+					}, nil, nil, nil, map[string]string{
+						v1alpha1.NodeLabelKey:                  "fakeNode-0",
+						machineutils.LabelKeyMachineDeployment: "machinedeployment-0",
+					}, true, metav1.Now()),
+					err:   fmt.Errorf("machine creation in process. Machine initialization (if required) is successful"),
 					retry: machineutils.ShortRetry,
+					nodeLabels: map[string]string{
+						"worker.gardener.cloud/pool":           "pool-0",
+						machineutils.LabelKeyMachineDeployment: "machinedeployment-0",
+					},
 				},
 			}),
-			Entry("Machine creation has already succeeded, so no update", &data{
+			Entry("Machine creation renders the user-data template if the MachineClass opts into it", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Data:       map[string][]byte{"userData": []byte("test")},
+							Data:       map[string][]byte{"userData": []byte("name={{ .MachineName }} zone={{ .Zone }}")},
 						},
 					},
 					machineClasses: []*v1alpha1.MachineClass{
 						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
-						},
-					},
-					machines: newMachines(
-						1,
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
-								},
-								ProviderID: "fakeID",
-							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachinePending,
-								LastUpdateTime: metav1.Now(),
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        "machine-0",
+								Namespace:   objMeta.Namespace,
+								Annotations: map[string]string{machineutils.MachineClassUserDataTemplating: "true"},
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    "Creating machine on cloud provider",
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationCreate,
-								LastUpdateTime: metav1.Now(),
+							SecretRef: newSecretReference(objMeta, 0),
+							NodeTemplate: &v1alpha1.NodeTemplate{
+								InstanceType: "m5.large",
+								Region:       "eu-west-1",
+								Zone:         "eu-west-1a",
 							},
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+					},
+					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
+							},
 						},
-						true,
-						metav1.Now(),
-					),
+					}, nil, nil, nil, nil, true, metav1.Now()),
 				},
 				action: action{
 					machine: "machine-0",
 					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
+						VMExists:   false,
 						ProviderID: "fakeID-0",
 						NodeName:   "fakeNode-0",
 						Err:        nil,
 					},
 				},
 				expect: expect{
-					machine: newMachine(
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
-								},
-								ProviderID: "fakeID",
-							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase: v1alpha1.MachinePending,
-
-								LastUpdateTime: metav1.Now(),
-							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    "Creating machine on cloud provider",
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationCreate,
-								LastUpdateTime: metav1.Now(),
+					machine: newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machineClass",
 							},
+							ProviderID: "fakeID",
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
-						},
-						true,
-						metav1.Now(),
-					),
-					err:   nil,
-					retry: machineutils.LongRetry,
+					}, nil, nil, nil, map[string]string{v1alpha1.NodeLabelKey: "fakeNode-0"}, true, metav1.Now()),
+					err:      fmt.Errorf("machine creation in process. Machine initialization (if required) is successful"),
+					retry:    machineutils.ShortRetry,
+					userData: "name=machine-0 zone=eu-west-1a",
 				},
 			}),
-			Entry("Machine creation fails with CrashLoopBackOff due to Internal error", &data{
+			Entry("Machine creation sets the priority computed by the machine priority computer", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -831,37 +986,31 @@ var _ = Describe("machine", func() {
 								Name: "machine-0",
 							},
 						},
-					}, nil, nil, nil, nil, true, metav1.Now()),
+					}, nil, nil, nil, map[string]string{machineutils.LabelKeySpotMachine: "true"}, true, metav1.Now()),
+					machinePriorityComputer: spotFirstMachinePriorityComputer{},
 				},
 				action: action{
 					machine: "machine-0",
 					fakeDriver: &driver.FakeDriver{
-						VMExists: false,
-						Err:      status.Error(codes.Internal, "Provider is returning error on create call"),
+						VMExists:   false,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
 					},
 				},
 				expect: expect{
 					machine: newMachine(&v1alpha1.MachineTemplateSpec{
 						ObjectMeta: *newObjectMeta(objMeta, 0),
 						Spec: v1alpha1.MachineSpec{
-							Class: v1alpha1.ClassSpec{
-								Kind: "MachineClass",
-								Name: "machineClass",
-							},
-						},
-					}, &v1alpha1.MachineStatus{
-						CurrentStatus: v1alpha1.CurrentStatus{
-							Phase: v1alpha1.MachineCrashLoopBackOff,
-						},
-						LastOperation: v1alpha1.LastOperation{
-							ErrorCode: codes.Internal.String(),
+							ProviderID: "fakeID",
 						},
-					}, nil, nil, nil, true, metav1.Now()),
-					err:   status.Error(codes.Internal, "Provider is returning error on create call"),
-					retry: machineutils.MediumRetry,
+					}, nil, nil, nil, map[string]string{machineutils.LabelKeySpotMachine: "true", v1alpha1.NodeLabelKey: "fakeNode-0"}, true, metav1.Now()),
+					err:      fmt.Errorf("machine creation in process. Machine initialization (if required) is successful"),
+					retry:    machineutils.ShortRetry,
+					priority: spotMachinePriority,
 				},
 			}),
-			Entry("Machine creation fails with CrashLoopBackOff due to resource exhaustion", &data{
+			Entry("Machine creation is held when the MachineClass quota is reached", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -871,25 +1020,46 @@ var _ = Describe("machine", func() {
 					},
 					machineClasses: []*v1alpha1.MachineClass{
 						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        "machine-0",
+								Namespace:   objMeta.Namespace,
+								Annotations: map[string]string{machineutils.MachineClassMaxMachines: "1"},
+							},
+							SecretRef: newSecretReference(objMeta, 0),
 						},
 					},
-					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
-						ObjectMeta: *newObjectMeta(objMeta, 0),
-						Spec: v1alpha1.MachineSpec{
-							Class: v1alpha1.ClassSpec{
-								Kind: "MachineClass",
-								Name: "machine-0",
+					machines: append(
+						newMachines(1, &v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
 							},
-						},
-					}, nil, nil, nil, nil, true, metav1.Now()),
+						}, nil, nil, nil, nil, true, metav1.Now()),
+						newMachine(&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-1",
+								Namespace: objMeta.Namespace,
+							},
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						}, nil, nil, nil, nil, true, metav1.Now()),
+					),
 				},
 				action: action{
 					machine: "machine-0",
 					fakeDriver: &driver.FakeDriver{
-						VMExists: false,
-						Err:      status.Error(codes.ResourceExhausted, "Provider does not have capacity to create VM"),
+						VMExists:   false,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
 					},
 				},
 				expect: expect{
@@ -898,23 +1068,35 @@ var _ = Describe("machine", func() {
 						Spec: v1alpha1.MachineSpec{
 							Class: v1alpha1.ClassSpec{
 								Kind: "MachineClass",
-								Name: "machineClass",
+								Name: "machine-0",
 							},
 						},
 					}, &v1alpha1.MachineStatus{
-						CurrentStatus: v1alpha1.CurrentStatus{
-							Phase: v1alpha1.MachineCrashLoopBackOff,
-						},
 						LastOperation: v1alpha1.LastOperation{
-							ErrorCode: codes.ResourceExhausted.String(),
+							Description: fmt.Sprintf("Quota of 1 machine(s) for MachineClass %q reached. %s", "machine-0", machineutils.MachineCreationOnHold),
+						},
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase: v1alpha1.MachinePending,
 						},
 					}, nil, nil, nil, true, metav1.Now()),
-					err:   status.Error(codes.ResourceExhausted, "Provider does not have capacity to create VM"),
-					retry: machineutils.LongRetry,
+					err:          fmt.Errorf("machine creation for %q is on hold as quota of MachineClass %q is reached", "machine-0", "machine-0"),
+					retry:        machineutils.MediumRetry,
+					event:        machineutils.MachineQuotaExceededReason,
+					creationHeld: true,
 				},
 			}),
-			Entry("Machine creation fails with Failure due to timeout", &data{
+			Entry("Machine creation is held when the MachineClass quota set by the quota ConfigMap is reached", &data{
 				setup: setup{
+					configMaps: []*corev1.ConfigMap{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-quota",
+								Namespace: objMeta.Namespace,
+							},
+							Data: map[string]string{"machine-0": "1"},
+						},
+					},
+					machineQuotaConfigMap: "machine-quota",
 					secrets: []*corev1.Secret{
 						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -923,25 +1105,45 @@ var _ = Describe("machine", func() {
 					},
 					machineClasses: []*v1alpha1.MachineClass{
 						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-0",
+								Namespace: objMeta.Namespace,
+							},
+							SecretRef: newSecretReference(objMeta, 0),
 						},
 					},
-					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
-						ObjectMeta: *newObjectMeta(objMeta, 0),
-						Spec: v1alpha1.MachineSpec{
-							Class: v1alpha1.ClassSpec{
-								Kind: "MachineClass",
-								Name: "machine-0",
+					machines: append(
+						newMachines(1, &v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
 							},
-						},
-					}, nil, nil, nil, nil, true, metav1.NewTime(metav1.Now().Add(-time.Hour))),
+						}, nil, nil, nil, nil, true, metav1.Now()),
+						newMachine(&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "machine-1",
+								Namespace: objMeta.Namespace,
+							},
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						}, nil, nil, nil, nil, true, metav1.Now()),
+					),
 				},
 				action: action{
 					machine: "machine-0",
 					fakeDriver: &driver.FakeDriver{
-						VMExists: false,
-						Err:      status.Error(codes.Internal, "Provider is returning error on create call"),
+						VMExists:   false,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
 					},
 				},
 				expect: expect{
@@ -950,22 +1152,24 @@ var _ = Describe("machine", func() {
 						Spec: v1alpha1.MachineSpec{
 							Class: v1alpha1.ClassSpec{
 								Kind: "MachineClass",
-								Name: "machineClass",
+								Name: "machine-0",
 							},
 						},
 					}, &v1alpha1.MachineStatus{
-						CurrentStatus: v1alpha1.CurrentStatus{
-							Phase: v1alpha1.MachineFailed,
-						},
 						LastOperation: v1alpha1.LastOperation{
-							ErrorCode: codes.Internal.String(),
+							Description: fmt.Sprintf("Quota of 1 machine(s) for MachineClass %q reached. %s", "machine-0", machineutils.MachineCreationOnHold),
 						},
-					}, nil, nil, nil, true, metav1.NewTime(metav1.Now().Add(-time.Hour))),
-					err:   status.Error(codes.Internal, "Provider is returning error on create call"),
-					retry: machineutils.MediumRetry,
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase: v1alpha1.MachinePending,
+						},
+					}, nil, nil, nil, true, metav1.Now()),
+					err:          fmt.Errorf("machine creation for %q is on hold as quota of MachineClass %q is reached", "machine-0", "machine-0"),
+					retry:        machineutils.MediumRetry,
+					event:        machineutils.MachineQuotaExceededReason,
+					creationHeld: true,
 				},
 			}),
-			Entry("Machine creation fails with Failure due to VM using stale node obj (case is for Provider AWS only)", &data{
+			Entry("Machine creation is held when the MachineClass quota is reached by a machine being created", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -975,26 +1179,41 @@ var _ = Describe("machine", func() {
 					},
 					machineClasses: []*v1alpha1.MachineClass{
 						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        "machine-0",
+								Namespace:   objMeta.Namespace,
+								Annotations: map[string]string{machineutils.MachineClassMaxMachines: "1"},
+							},
+							SecretRef: newSecretReference(objMeta, 0),
 						},
 					},
-					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
-						ObjectMeta: *newObjectMeta(objMeta, 0),
-						Spec: v1alpha1.MachineSpec{
-							Class: v1alpha1.ClassSpec{
-								Kind: "MachineClass",
-								Name: "machine-0",
+					machines: append(
+						newMachines(1, &v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
 							},
-						},
-					}, nil, nil, nil, nil, true, metav1.Now()),
-					nodes: []*corev1.Node{
-						{
+						}, nil, nil, nil, nil, true, metav1.Now()),
+						newMachine(&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: metav1.ObjectMeta{
-								Name: "fakeNode-0",
+								Name:      "machine-1",
+								Namespace: objMeta.Namespace,
 							},
-						},
-					},
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+							},
+						}, &v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase: v1alpha1.MachinePending,
+							},
+						}, nil, nil, nil, true, metav1.Now()),
+					),
 				},
 				action: action{
 					machine: "machine-0",
@@ -1011,19 +1230,24 @@ var _ = Describe("machine", func() {
 						Spec: v1alpha1.MachineSpec{
 							Class: v1alpha1.ClassSpec{
 								Kind: "MachineClass",
-								Name: "machineClass",
+								Name: "machine-0",
 							},
 						},
 					}, &v1alpha1.MachineStatus{
+						LastOperation: v1alpha1.LastOperation{
+							Description: fmt.Sprintf("Quota of 1 machine(s) for MachineClass %q reached. %s", "machine-0", machineutils.MachineCreationOnHold),
+						},
 						CurrentStatus: v1alpha1.CurrentStatus{
-							Phase: v1alpha1.MachineFailed,
+							Phase: v1alpha1.MachinePending,
 						},
 					}, nil, nil, nil, true, metav1.Now()),
-					err:   nil,
-					retry: machineutils.ShortRetry,
+					err:          fmt.Errorf("machine creation for %q is on hold as quota of MachineClass %q is reached", "machine-0", "machine-0"),
+					retry:        machineutils.MediumRetry,
+					event:        machineutils.MachineQuotaExceededReason,
+					creationHeld: true,
 				},
 			}),
-			Entry("CLBF machine turns to Pending if VM is present", &data{
+			Entry("Machine creation succeeds with status UPDATE", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -1049,11 +1273,7 @@ var _ = Describe("machine", func() {
 								ProviderID: "fakeID",
 							},
 						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase: v1alpha1.MachineCrashLoopBackOff,
-							},
-						},
+						nil,
 						nil,
 						map[string]string{
 							machineutils.MachinePriority: "3",
@@ -1105,7 +1325,7 @@ var _ = Describe("machine", func() {
 					retry: machineutils.ShortRetry,
 				},
 			}),
-			Entry("Machine initialization failed due to VM instance initialization error", &data{
+			Entry("Machine creation has already succeeded, so no update", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -1119,22 +1339,40 @@ var _ = Describe("machine", func() {
 							SecretRef:  newSecretReference(objMeta, 0),
 						},
 					},
-					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
-						ObjectMeta: *newObjectMeta(objMeta, 0),
-						Spec: v1alpha1.MachineSpec{
-							Class: v1alpha1.ClassSpec{
-								Kind: "MachineClass",
-								Name: "machine-0",
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
 							},
 						},
-					}, nil, nil, nil, nil, true, metav1.Now()),
-					nodes: []*corev1.Node{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "fakeNode-0",
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachinePending,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    "Creating machine on cloud provider",
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationCreate,
+								LastUpdateTime: metav1.Now(),
 							},
 						},
-					},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
 				},
 				action: action{
 					machine: "machine-0",
@@ -1142,90 +1380,207 @@ var _ = Describe("machine", func() {
 						VMExists:   true,
 						ProviderID: "fakeID-0",
 						NodeName:   "fakeNode-0",
-						Err:        status.Error(codes.Uninitialized, "VM instance could not be initialized"),
+						Err:        nil,
 					},
 				},
 				expect: expect{
-					machine: newMachine(&v1alpha1.MachineTemplateSpec{
-						ObjectMeta: *newObjectMeta(objMeta, 0),
-						Spec: v1alpha1.MachineSpec{
-							Class: v1alpha1.ClassSpec{
-								Kind: "MachineClass",
-								Name: "machineClass",
-							},
-							ProviderID: "fakeID",
-						},
-					}, &v1alpha1.MachineStatus{
-						CurrentStatus: v1alpha1.CurrentStatus{
-							Phase: v1alpha1.MachineCrashLoopBackOff,
-						},
-						LastOperation: v1alpha1.LastOperation{
-							Description: fmt.Sprintf("Provider error: %s. %s", status.Error(codes.Uninitialized, "VM instance could not be initialized").Error(), machineutils.InstanceInitialization),
-							ErrorCode:   codes.Uninitialized.String(),
-							State:       v1alpha1.MachineStateFailed,
-							Type:        v1alpha1.MachineOperationCreate,
-						},
-					}, nil, nil, map[string]string{v1alpha1.NodeLabelKey: "fakeNode-0"}, true, metav1.Now()),
-					err:   status.Error(codes.Uninitialized, "VM instance could not be initialized"),
-					retry: machineutils.ShortRetry,
-				},
-			}),
-			/*
-				Entry("Machine creation success even on temporary APIServer disruption", &data{
-					setup: setup{
-						secrets: []*corev1.Secret{
-							{
-								ObjectMeta: *newObjectMeta(objMeta, 0),
-							},
-						},
-						aws: []*v1alpha1.MachineClass{
-							{
-								ObjectMeta: *newObjectMeta(objMeta, 0),
-								SecretRef:  newSecretReference(objMeta, 0),
-							},
-						},
-						machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
 								Class: v1alpha1.ClassSpec{
-									Kind: "AWSMachineClass",
+									Kind: "MachineClass",
 									Name: "machine-0",
 								},
+								ProviderID: "fakeID",
 							},
-						}, nil, nil, nil, nil),
-						fakeResourceActions: &customfake.ResourceActions{
-							Machine: customfake.Actions{
-								Get: "Failed to GET machine",
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase: v1alpha1.MachinePending,
+
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    "Creating machine on cloud provider",
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationCreate,
+								LastUpdateTime: metav1.Now(),
 							},
 						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+					err:   nil,
+					retry: machineutils.LongRetry,
+				},
+			}),
+			Entry("Machine creation fails with CrashLoopBackOff due to Internal error", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Data:       map[string][]byte{"userData": []byte("test")},
+						},
 					},
-					action: action{
-						machine:        "machine-0",
-						fakeProviderID: "fakeID-0",
-						fakeNodeName:   "fakeNode-0",
-						fakeError:      nil,
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
 					},
-					expect: expect{
-						machine: newMachine(&v1alpha1.MachineTemplateSpec{
+					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
+							},
+						},
+					}, nil, nil, nil, nil, true, metav1.Now()),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists: false,
+						Err:      status.Error(codes.Internal, "Provider is returning error on create call"),
+					},
+				},
+				expect: expect{
+					machine: newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machineClass",
+							},
+						},
+					}, &v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase: v1alpha1.MachineCrashLoopBackOff,
+						},
+						LastOperation: v1alpha1.LastOperation{
+							ErrorCode: codes.Internal.String(),
+						},
+					}, nil, nil, nil, true, metav1.Now()),
+					err:   status.Error(codes.Internal, "Provider is returning error on create call"),
+					retry: machineutils.MediumRetry,
+				},
+			}),
+			Entry("Machine creation fails with CrashLoopBackOff due to resource exhaustion", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "AWSMachineClass",
-									Name: "machine-0",
-								},
-								ProviderID: "fakeID",
+							Data:       map[string][]byte{"userData": []byte("test")},
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
 							},
-						}, &v1alpha1.MachineStatus{
-							Node: "fakeNode",
-							//TODO conditions
-						}, nil, nil, nil),
-						err: false,
+						},
+					}, nil, nil, nil, nil, true, metav1.Now()),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists: false,
+						Err:      status.Error(codes.ResourceExhausted, "Provider does not have capacity to create VM"),
 					},
-				}),
-			*/
-			Entry("without target cluster: machine creation and initialization succeeds", &data{
+				},
+				expect: expect{
+					machine: newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machineClass",
+							},
+						},
+					}, &v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase: v1alpha1.MachineCrashLoopBackOff,
+						},
+						LastOperation: v1alpha1.LastOperation{
+							ErrorCode: codes.ResourceExhausted.String(),
+						},
+						FailureCategory: v1alpha1.MachineFailureCategoryQuota,
+					}, nil, nil, nil, true, metav1.Now()),
+					err:   status.Error(codes.ResourceExhausted, "Provider does not have capacity to create VM"),
+					retry: machineutils.LongRetry,
+				},
+			}),
+			Entry("Machine creation fails with Failure due to timeout", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Data:       map[string][]byte{"userData": []byte("test")},
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
+							},
+						},
+					}, nil, nil, nil, nil, true, metav1.NewTime(metav1.Now().Add(-time.Hour))),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists: false,
+						Err:      status.Error(codes.Internal, "Provider is returning error on create call"),
+					},
+				},
+				expect: expect{
+					machine: newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machineClass",
+							},
+						},
+					}, &v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase: v1alpha1.MachineFailed,
+						},
+						LastOperation: v1alpha1.LastOperation{
+							ErrorCode: codes.Internal.String(),
+						},
+					}, nil, nil, nil, true, metav1.NewTime(metav1.Now().Add(-time.Hour))),
+					err:   status.Error(codes.Internal, "Provider is returning error on create call"),
+					retry: machineutils.MediumRetry,
+				},
+			}),
+			Entry("Machine creation fails with Failure due to VM using stale node obj (case is for Provider AWS only)", &data{
 				setup: setup{
-					noTargetCluster: true,
 					secrets: []*corev1.Secret{
 						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -1247,6 +1602,13 @@ var _ = Describe("machine", func() {
 							},
 						},
 					}, nil, nil, nil, nil, true, metav1.Now()),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
+							},
+						},
+					},
 				},
 				action: action{
 					machine: "machine-0",
@@ -1265,16 +1627,18 @@ var _ = Describe("machine", func() {
 								Kind: "MachineClass",
 								Name: "machineClass",
 							},
-							ProviderID: "fakeID",
 						},
-					}, nil, nil, nil, map[string]string{}, true, metav1.Now()),
-					err:   fmt.Errorf("machine creation in process. Machine initialization (if required) is successful"),
+					}, &v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase: v1alpha1.MachineFailed,
+						},
+					}, nil, nil, nil, true, metav1.Now()),
+					err:   nil,
 					retry: machineutils.ShortRetry,
 				},
 			}),
-			Entry("without target cluster: machine transitions to Available", &data{
+			Entry("CLBF machine turns to Pending if VM is present", &data{
 				setup: setup{
-					noTargetCluster: true,
 					secrets: []*corev1.Secret{
 						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -1299,12 +1663,18 @@ var _ = Describe("machine", func() {
 								ProviderID: "fakeID",
 							},
 						},
-						nil,
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase: v1alpha1.MachineCrashLoopBackOff,
+							},
+						},
 						nil,
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
-						map[string]string{},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
 						true,
 						metav1.Now(),
 					),
@@ -1332,18 +1702,16 @@ var _ = Describe("machine", func() {
 						},
 						&v1alpha1.MachineStatus{
 							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase: v1alpha1.MachineAvailable,
-							},
-							LastOperation: v1alpha1.LastOperation{
-								State:       v1alpha1.MachineStateSuccessful,
-								Description: "Created machine on cloud provider",
+								Phase: v1alpha1.MachinePending,
 							},
 						},
 						nil,
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
-						map[string]string{}, // expect empty labels – no node label
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
 						true,
 						metav1.Now(),
 					),
@@ -1351,9 +1719,8 @@ var _ = Describe("machine", func() {
 					retry: machineutils.ShortRetry,
 				},
 			}),
-			Entry("without target cluster: Machine is already Available, so no update", &data{
+			Entry("Machine initialization failed due to VM instance initialization error", &data{
 				setup: setup{
-					noTargetCluster: true,
 					secrets: []*corev1.Secret{
 						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -1366,31 +1733,188 @@ var _ = Describe("machine", func() {
 							SecretRef:  newSecretReference(objMeta, 0),
 						},
 					},
-					machines: newMachines(
-						1,
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
-								},
-								ProviderID: "fakeID",
+					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
 							},
 						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineAvailable,
-								LastUpdateTime: metav1.Now(),
+					}, nil, nil, nil, nil, true, metav1.Now()),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    "Created machine on cloud provider",
-								State:          v1alpha1.MachineStateSuccessful,
-								Type:           v1alpha1.MachineOperationCreate,
-								LastUpdateTime: metav1.Now(),
+						},
+					},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        status.Error(codes.Uninitialized, "VM instance could not be initialized"),
+					},
+				},
+				expect: expect{
+					machine: newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machineClass",
+							},
+							ProviderID: "fakeID",
+						},
+					}, &v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase: v1alpha1.MachineCrashLoopBackOff,
+						},
+						LastOperation: v1alpha1.LastOperation{
+							Description: fmt.Sprintf("Provider error: %s. %s", status.Error(codes.Uninitialized, "VM instance could not be initialized").Error(), machineutils.InstanceInitialization),
+							ErrorCode:   codes.Uninitialized.String(),
+							State:       v1alpha1.MachineStateFailed,
+							Type:        v1alpha1.MachineOperationCreate,
+						},
+					}, nil, nil, map[string]string{v1alpha1.NodeLabelKey: "fakeNode-0"}, true, metav1.Now()),
+					err:   status.Error(codes.Uninitialized, "VM instance could not be initialized"),
+					retry: machineutils.ShortRetry,
+				},
+			}),
+			/*
+				Entry("Machine creation success even on temporary APIServer disruption", &data{
+					setup: setup{
+						secrets: []*corev1.Secret{
+							{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+							},
+						},
+						aws: []*v1alpha1.MachineClass{
+							{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								SecretRef:  newSecretReference(objMeta, 0),
+							},
+						},
+						machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "AWSMachineClass",
+									Name: "machine-0",
+								},
+							},
+						}, nil, nil, nil, nil),
+						fakeResourceActions: &customfake.ResourceActions{
+							Machine: customfake.Actions{
+								Get: "Failed to GET machine",
+							},
+						},
+					},
+					action: action{
+						machine:        "machine-0",
+						fakeProviderID: "fakeID-0",
+						fakeNodeName:   "fakeNode-0",
+						fakeError:      nil,
+					},
+					expect: expect{
+						machine: newMachine(&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "AWSMachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						}, &v1alpha1.MachineStatus{
+							Node: "fakeNode",
+							//TODO conditions
+						}, nil, nil, nil),
+						err: false,
+					},
+				}),
+			*/
+			Entry("without target cluster: machine creation and initialization succeeds", &data{
+				setup: setup{
+					noTargetCluster: true,
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Data:       map[string][]byte{"userData": []byte("test")},
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(1, &v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
+							},
+						},
+					}, nil, nil, nil, nil, true, metav1.Now()),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   false,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					machine: newMachine(&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machineClass",
+							},
+							ProviderID: "fakeID",
+						},
+					}, nil, nil, nil, map[string]string{}, true, metav1.Now()),
+					err:   fmt.Errorf("machine creation in process. Machine initialization (if required) is successful"),
+					retry: machineutils.ShortRetry,
+				},
+			}),
+			Entry("without target cluster: machine transitions to Available", &data{
+				setup: setup{
+					noTargetCluster: true,
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Data:       map[string][]byte{"userData": []byte("test")},
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
 							},
 						},
 						nil,
+						nil,
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
@@ -1423,7 +1947,54 @@ var _ = Describe("machine", func() {
 						&v1alpha1.MachineStatus{
 							CurrentStatus: v1alpha1.CurrentStatus{
 								Phase: v1alpha1.MachineAvailable,
-
+							},
+							LastOperation: v1alpha1.LastOperation{
+								State:       v1alpha1.MachineStateSuccessful,
+								Description: "Created machine on cloud provider",
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{}, // expect empty labels – no node label
+						true,
+						metav1.Now(),
+					),
+					err:   fmt.Errorf("machine creation in process. Machine/Status UPDATE successful"),
+					retry: machineutils.ShortRetry,
+				},
+			}),
+			Entry("without target cluster: Machine is already Available, so no update", &data{
+				setup: setup{
+					noTargetCluster: true,
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Data:       map[string][]byte{"userData": []byte("test")},
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineAvailable,
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
@@ -1441,176 +2012,6 @@ var _ = Describe("machine", func() {
 						true,
 						metav1.Now(),
 					),
-					err:   nil,
-					retry: machineutils.LongRetry,
-				},
-			}),
-		)
-	})
-
-	Describe("#triggerDeletionFlow", func() {
-		type setup struct {
-			secrets             []*corev1.Secret
-			machineClasses      []*v1alpha1.MachineClass
-			machines            []*v1alpha1.Machine
-			nodes               []*corev1.Node
-			fakeResourceActions *customfake.ResourceActions
-			noTargetCluster     bool
-		}
-		type action struct {
-			machine                 string
-			forceDeleteLabelPresent bool
-			fakeMachineStatus       *v1alpha1.MachineStatus
-			fakeDriver              *driver.FakeDriver
-		}
-		type expect struct {
-			machine                       *v1alpha1.Machine
-			err                           error
-			nodeTerminationConditionIsSet bool
-			nodeDeleted                   bool
-			retry                         machineutils.RetryPeriod
-		}
-		type data struct {
-			setup  setup
-			action action
-			expect expect
-		}
-		objMeta := &metav1.ObjectMeta{
-			GenerateName:      "machine",
-			Namespace:         "test",
-			CreationTimestamp: metav1.Now(),
-		}
-		DescribeTable("##table",
-			func(data *data) {
-				stop := make(chan struct{})
-				defer close(stop)
-
-				machineObjects := []runtime.Object{}
-				for _, o := range data.setup.machineClasses {
-					machineObjects = append(machineObjects, o)
-				}
-				for _, o := range data.setup.machines {
-					machineObjects = append(machineObjects, o)
-				}
-
-				controlCoreObjects := []runtime.Object{}
-				targetCoreObjects := []runtime.Object{}
-
-				for _, o := range data.setup.secrets {
-					controlCoreObjects = append(controlCoreObjects, o)
-				}
-				for _, o := range data.setup.nodes {
-					targetCoreObjects = append(targetCoreObjects, o)
-				}
-
-				fakeDriver := driver.NewFakeDriver(
-					data.action.fakeDriver.VMExists,
-					data.action.fakeDriver.ProviderID,
-					data.action.fakeDriver.NodeName,
-					data.action.fakeDriver.LastKnownState,
-					data.action.fakeDriver.Err,
-					nil,
-				)
-
-				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, data.setup.noTargetCluster)
-
-				defer trackers.Stop()
-				waitForCacheSync(stop, controller)
-
-				action := data.action
-				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), action.machine, metav1.GetOptions{})
-				Expect(err).ToNot(HaveOccurred())
-
-				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
-				Expect(err).ToNot(HaveOccurred())
-
-				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
-				Expect(err).ToNot(HaveOccurred())
-
-				if data.setup.fakeResourceActions != nil {
-					_ = trackers.TargetCore.SetFakeResourceActions(data.setup.fakeResourceActions, math.MaxInt32)
-				}
-
-				// Deletion of machine is triggered
-				retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
-					Machine:      machine,
-					MachineClass: machineClass,
-					Secret:       secret,
-				})
-				if err != nil || data.expect.err != nil {
-					Expect(err).To(Equal(data.expect.err))
-				}
-				Expect(retry).To(Equal(data.expect.retry))
-
-				machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), action.machine, metav1.GetOptions{})
-				Expect(err).ToNot(HaveOccurred())
-				Expect(machine.Spec).To(Equal(data.expect.machine.Spec))
-				Expect(machine.Status.CurrentStatus.Phase).To(Equal(data.expect.machine.Status.CurrentStatus.Phase))
-				Expect(machine.Status.LastOperation.State).To(Equal(data.expect.machine.Status.LastOperation.State))
-				Expect(machine.Status.LastOperation.Type).To(Equal(data.expect.machine.Status.LastOperation.Type))
-				Expect(machine.Status.LastOperation.Description).To(Equal(data.expect.machine.Status.LastOperation.Description))
-				Expect(machine.Finalizers).To(Equal(data.expect.machine.Finalizers))
-
-				if data.expect.nodeDeleted {
-					_, nodeErr := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), machine.Labels[v1alpha1.NodeLabelKey], metav1.GetOptions{})
-					Expect(nodeErr).To(HaveOccurred())
-				}
-				if data.expect.nodeTerminationConditionIsSet {
-					node, nodeErr := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), machine.Labels[v1alpha1.NodeLabelKey], metav1.GetOptions{})
-					Expect(nodeErr).To(Not(HaveOccurred()))
-					Expect(len(node.Status.Conditions)).To(Equal(1))
-					Expect(node.Status.Conditions[0].Type).To(Equal(machineutils.NodeTerminationCondition))
-					Expect(node.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
-				}
-
-			},
-			Entry("Do not process machine deletion for object without finalizer", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-						},
-					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
-						},
-					},
-					machines: newMachines(
-						1,
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
-								},
-								ProviderID: "fakeID",
-							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineRunning,
-								LastUpdateTime: metav1.Now(),
-							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    "Machine machine-0 successfully joined the cluster",
-								State:          v1alpha1.MachineStateSuccessful,
-								Type:           v1alpha1.MachineOperationCreate,
-								LastUpdateTime: metav1.Now(),
-							},
-						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
-						},
-						false,
-						metav1.Now(),
-					),
 				},
 				action: action{
 					machine: "machine-0",
@@ -1622,8 +2023,6 @@ var _ = Describe("machine", func() {
 					},
 				},
 				expect: expect{
-					err:   fmt.Errorf("Machine \"machine-0\" is missing finalizers. Deletion cannot proceed"),
-					retry: machineutils.LongRetry,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -1637,11 +2036,12 @@ var _ = Describe("machine", func() {
 						},
 						&v1alpha1.MachineStatus{
 							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineRunning,
+								Phase: v1alpha1.MachineAvailable,
+
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    "Machine machine-0 successfully joined the cluster",
+								Description:    "Created machine on cloud provider",
 								State:          v1alpha1.MachineStateSuccessful,
 								Type:           v1alpha1.MachineOperationCreate,
 								LastUpdateTime: metav1.Now(),
@@ -1651,29 +2051,91 @@ var _ = Describe("machine", func() {
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
-						},
-						false,
+						map[string]string{},
+						true,
 						metav1.Now(),
 					),
+					err:   nil,
+					retry: machineutils.LongRetry,
 				},
 			}),
-			Entry("Change machine phase to termination successfully", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-						},
+		)
+
+		DescribeTable("##failure categories",
+			func(createErr error, expectedCategory v1alpha1.MachineFailureCategory) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
 					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
+					newMachine(
+						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+							},
 						},
+						nil, nil, nil, nil, true, metav1.Now(),
+					),
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Data:       map[string][]byte{"userData": []byte("test")},
 					},
-					machines: newMachines(
-						1,
+				}
+
+				fakeDriver := driver.NewFakeDriver(false, "", "", "", createErr, nil)
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = controller.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+				Expect(err).To(Equal(createErr))
+
+				actual, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(actual.Status.LastOperation.State).To(Equal(v1alpha1.MachineStateFailed))
+				Expect(actual.Status.FailureCategory).To(Equal(expectedCategory))
+			},
+			Entry("should categorize exhausted resources as quota failure", status.Error(codes.ResourceExhausted, "Provider does not have capacity to create VM"), v1alpha1.MachineFailureCategoryQuota),
+			Entry("should categorize quota hints as quota failure", status.Error(codes.Internal, "QuotaExceeded: cores quota of region exceeded"), v1alpha1.MachineFailureCategoryQuota),
+			Entry("should categorize missing images as image-not-found failure", status.Error(codes.InvalidArgument, "The image id 'ami-123' does not exist"), v1alpha1.MachineFailureCategoryImageNotFound),
+			Entry("should categorize network hints as network failure", status.Error(codes.Internal, "The subnet ID 'subnet-123' does not exist"), v1alpha1.MachineFailureCategoryNetwork),
+			Entry("should categorize unauthenticated errors as auth failure", status.Error(codes.Unauthenticated, "invalid token"), v1alpha1.MachineFailureCategoryAuth),
+			Entry("should categorize auth hints as auth failure", status.Error(codes.Internal, "AuthFailure: credentials could not be validated"), v1alpha1.MachineFailureCategoryAuth),
+			Entry("should categorize unavailable providers as transient failure", status.Error(codes.Unavailable, "service temporarily unavailable"), v1alpha1.MachineFailureCategoryTransient),
+			Entry("should categorize other errors as unknown failure", status.Error(codes.Internal, "something went wrong"), v1alpha1.MachineFailureCategoryUnknown),
+		)
+
+		Context("when the machine depends on other machines", func() {
+			It("should hold the creation until its dependencies are Running", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
+					},
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -1681,47 +2143,20 @@ var _ = Describe("machine", func() {
 									Kind: "MachineClass",
 									Name: "machine-0",
 								},
-								ProviderID: "fakeID",
-							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineRunning,
-								LastUpdateTime: metav1.Now(),
-							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    "Machine machine-0 successfully joined the cluster",
-								State:          v1alpha1.MachineStateSuccessful,
-								Type:           v1alpha1.MachineOperationCreate,
-								LastUpdateTime: metav1.Now(),
 							},
 						},
 						nil,
+						nil,
 						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+							machineutils.MachineDependsOn: "pool=control",
 						},
+						nil,
 						true,
 						metav1.Now(),
 					),
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
-					},
-				},
-				expect: expect{
-					err:   fmt.Errorf("Machine deletion in process. Phase set to termination"),
-					retry: machineutils.ShortRetry,
-					machine: newMachine(
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
+							ObjectMeta: *newObjectMeta(objMeta, 1),
 							Spec: v1alpha1.MachineSpec{
 								Class: v1alpha1.ClassSpec{
 									Kind: "MachineClass",
@@ -1732,30 +2167,2104 @@ var _ = Describe("machine", func() {
 						},
 						&v1alpha1.MachineStatus{
 							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
-							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.GetVMStatus,
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
+								Phase:          v1alpha1.MachinePending,
 								LastUpdateTime: metav1.Now(),
 							},
 						},
 						nil,
+						nil,
 						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+							"pool": "control",
 						},
 						true,
 						metav1.Now(),
 					),
-				},
-			}),
-			Entry("Checking existance of VM at provider successfully", &data{
-				setup: setup{
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Data:       map[string][]byte{"userData": []byte("test")},
+					},
+				}
+
+				fakeDriver := driver.NewFakeDriver(false, "fakeID-0", "fakeNode-0", "", nil, nil)
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				triggerCreation := func() (machineutils.RetryPeriod, error) {
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					return controller.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+				}
+
+				retry, err := triggerCreation()
+				Expect(err).To(Equal(fmt.Errorf("machine creation for %q is on hold until its dependencies are Running", "machine-0")))
+				Expect(retry).To(Equal(machineutils.MediumRetry))
+				Expect(fakeDriver.(*driver.FakeDriver).VMExists).To(BeFalse())
+				Expect(controller.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(machineutils.MachineDependencyPendingReason)))
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Status.CurrentStatus.Phase).To(Equal(v1alpha1.MachinePending))
+				Expect(machine.Status.LastOperation.Description).To(Equal(fmt.Sprintf("Machines [machine-1] of dependency selector %q are not Running. %s", "pool=control", machineutils.MachineCreationOnHold)))
+
+				dependency, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-1", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				dependency.Status.CurrentStatus.Phase = v1alpha1.MachineRunning
+				_, err = controller.controlMachineClient.Machines(objMeta.Namespace).UpdateStatus(context.TODO(), dependency, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Eventually(func() v1alpha1.MachinePhase {
+					m, _ := controller.machineLister.Machines(objMeta.Namespace).Get("machine-1")
+					return m.Status.CurrentStatus.Phase
+				}).Should(Equal(v1alpha1.MachineRunning))
+
+				_, err = triggerCreation()
+				Expect(err).To(HaveOccurred())
+				Expect(fakeDriver.(*driver.FakeDriver).VMExists).To(BeTrue())
+			})
+		})
+
+		Context("when the driver reports the capacity of the VM", func() {
+			DescribeTable("##table",
+				func(vmExists bool, machineStatus *v1alpha1.MachineStatus, machineLabels map[string]string) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					capacity := corev1.ResourceList{
+						corev1.ResourceCPU:                    resource.MustParse("4"),
+						corev1.ResourceMemory:                 resource.MustParse("16Gi"),
+						corev1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+					}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+								},
+							},
+							machineStatus,
+							nil,
+							map[string]string{
+								machineutils.MachinePriority: "3",
+							},
+							machineLabels,
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Data:       map[string][]byte{"userData": []byte("test")},
+						},
+					}
+
+					fakeDriver := driver.NewFakeDriver(vmExists, "fakeID-0", "fakeNode-0", "", nil, nil).(*driver.FakeDriver)
+					fakeDriver.Capacity = capacity
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+					defer trackers.Stop()
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, _ = controller.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(fakeDriver.VMExists).To(BeTrue())
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.Capacity).To(HaveLen(len(capacity)))
+					for name, quantity := range capacity {
+						Expect(machine.Status.Capacity).To(HaveKey(name))
+						Expect(machine.Status.Capacity[name].Equal(quantity)).To(BeTrue())
+					}
+				},
+				Entry("should store the capacity reported on VM creation in the machine status", false, nil, nil),
+				Entry("should store the capacity reported by GetMachineStatus in the machine status", true, &v1alpha1.MachineStatus{
+					CurrentStatus: v1alpha1.CurrentStatus{
+						Phase:          v1alpha1.MachineRunning,
+						LastUpdateTime: metav1.Now(),
+					},
+				}, map[string]string{
+					v1alpha1.NodeLabelKey: "fakeNode-0",
+				}),
+			)
+		})
+
+		Context("when the controller crashes after the VM creation", func() {
+			It("should recover the created VM by the idempotency key instead of creating a duplicate VM", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newMachine(
+					&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
+							},
+						},
+					},
+					nil,
+					nil,
+					nil,
+					nil,
+					true,
+					metav1.Now(),
+				)
+				machine.DeletionTimestamp = nil
+				machine.UID = "machine-0-uid"
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
+					},
+					machine,
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Data:       map[string][]byte{"userData": []byte("test")},
+					},
+				}
+
+				fakeDriver := &idempotentDriver{
+					FakeDriver: driver.NewFakeDriver(false, "fakeID-machine-0", "machine-0", "", nil, nil).(*driver.FakeDriver),
+					vms:        map[string]string{},
+				}
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				// The crash is simulated by failing to record the ProviderID of the created VM once
+				crashed := false
+				controller.controlMachineClient.(*fakemachineapi.FakeMachineV1alpha1).PrependReactor("update", "machines", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					if crashed || action.GetSubresource() != "" {
+						return false, nil, nil
+					}
+					crashed = true
+					return true, nil, fmt.Errorf("controller crashed")
+				})
+
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				triggerCreation := func() (machineutils.RetryPeriod, error) {
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					return controller.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+				}
+
+				_, err = triggerCreation()
+				Expect(err).To(HaveOccurred())
+				Expect(crashed).To(BeTrue())
+				Expect(fakeDriver.creations).To(Equal(1))
+				machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Spec.ProviderID).To(BeEmpty())
+
+				_, _ = triggerCreation()
+				Expect(fakeDriver.creations).To(Equal(1))
+				Expect(fakeDriver.vms).To(HaveLen(1))
+				machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Spec.ProviderID).To(Equal("fakeID-machine-0"))
+				Expect(machine.Labels).To(HaveKeyWithValue(v1alpha1.NodeLabelKey, "machine-0"))
+			})
+		})
+	})
+
+	Describe("#acquireProviderOperationPermit", func() {
+		It("should limit the concurrent VM creations and deletions of a MachineClass combined", func() {
+			const (
+				nCreations    = 3
+				nDeletions    = 3
+				maxOperations = 2
+			)
+
+			stop := make(chan struct{})
+			defer close(stop)
+
+			objMeta := &metav1.ObjectMeta{GenerateName: "machine", Namespace: testNamespace}
+			machineClass := &v1alpha1.MachineClass{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+				SecretRef:  newSecretReference(objMeta, 0),
+			}
+			machineObjects := []runtime.Object{machineClass}
+			for i := 0; i < nCreations+nDeletions; i++ {
+				spec := v1alpha1.MachineSpec{Class: v1alpha1.ClassSpec{Kind: "MachineClass", Name: machineClass.Name}}
+				machineStatus := &v1alpha1.MachineStatus{}
+				if i >= nCreations {
+					spec.ProviderID = fmt.Sprintf("fakeID-%d", i)
+					machineStatus.CurrentStatus = v1alpha1.CurrentStatus{Phase: v1alpha1.MachineTerminating, LastUpdateTime: metav1.Now()}
+				}
+				machineObjects = append(machineObjects, newMachine(
+					&v1alpha1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(objMeta, i), Spec: spec},
+					machineStatus, nil, nil, nil, true, metav1.Now(),
+				))
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+				Data:       map[string][]byte{"userData": []byte("test")},
+			}
+
+			fakeDriver := &concurrentProviderOperationsDriver{
+				FakeDriver: driver.NewFakeDriver(false, "", "", "", nil, nil).(*driver.FakeDriver),
+				delay:      50 * time.Millisecond,
+			}
+			c, trackers := createController(stop, testNamespace, machineObjects, []runtime.Object{secret}, nil, fakeDriver, false)
+			defer trackers.Stop()
+			c.permitGiver = permits.NewPermitGiver(5*time.Second, 1*time.Second)
+			defer c.permitGiver.Close()
+			c.safetyOptions.MaxConcurrentProviderOperationsPerClass = maxOperations
+			waitForCacheSync(stop, c)
+
+			var wg sync.WaitGroup
+			for i := 0; i < nCreations+nDeletions; i++ {
+				machine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), fmt.Sprintf("machine-%d", i), metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					if i < nCreations {
+						_, _ = c.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{Machine: machine, MachineClass: machineClass, Secret: secret})
+					} else {
+						_, _ = c.deleteVM(context.TODO(), &driver.DeleteMachineRequest{Machine: machine, MachineClass: machineClass, Secret: secret})
+					}
+				}(i)
+			}
+			wg.Wait()
+
+			Expect(fakeDriver.creations).To(Equal(nCreations))
+			Expect(fakeDriver.deletions).To(Equal(nDeletions))
+			Expect(fakeDriver.maxInFlight).To(BeNumerically(">", 0))
+			Expect(fakeDriver.maxInFlight).To(BeNumerically("<=", maxOperations))
+		})
+	})
+
+	Describe("#createMachineWithFallbackInstanceTypes", func() {
+		DescribeTable("##table",
+			func(fallbackInstanceTypes string, exhaustedInstanceTypes []string, expectedInstanceTypes []string, expectCreated bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				objMeta := &metav1.ObjectMeta{GenerateName: "machine", Namespace: testNamespace}
+				machineClass := &v1alpha1.MachineClass{
+					ObjectMeta: *newObjectMeta(objMeta, 0),
+					SecretRef:  newSecretReference(objMeta, 0),
+				}
+				if fallbackInstanceTypes != "" {
+					machineClass.Annotations = map[string]string{machineutils.MachineClassFallbackInstanceTypes: fallbackInstanceTypes}
+				}
+				machineObjects := []runtime.Object{
+					machineClass,
+					newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec:       v1alpha1.MachineSpec{Class: v1alpha1.ClassSpec{Kind: "MachineClass", Name: machineClass.Name}},
+						},
+						&v1alpha1.MachineStatus{}, nil, nil, nil, true, metav1.Now(),
+					),
+				}
+				secret := &corev1.Secret{
+					ObjectMeta: *newObjectMeta(objMeta, 0),
+					Data:       map[string][]byte{"userData": []byte("test")},
+				}
+
+				fakeDriver := &fallbackInstanceTypesDriver{
+					FakeDriver:             driver.NewFakeDriver(false, "", "", "", nil, nil).(*driver.FakeDriver),
+					exhaustedInstanceTypes: exhaustedInstanceTypes,
+				}
+				c, trackers := createController(stop, testNamespace, machineObjects, []runtime.Object{secret}, nil, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, c)
+
+				machine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				_, _ = c.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{Machine: machine, MachineClass: machineClass, Secret: secret})
+
+				Expect(fakeDriver.requestedInstanceTypes).To(Equal(expectedInstanceTypes))
+
+				machine, err = c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				if expectCreated {
+					Expect(machine.Spec.ProviderID).To(Equal("fakeID-machine-0"))
+				} else {
+					Expect(machine.Spec.ProviderID).To(BeEmpty())
+					Expect(machine.Status.CurrentStatus.Phase).To(Equal(v1alpha1.MachineCrashLoopBackOff))
+				}
+			},
+			Entry("should create the VM with the fallback instance type if the primary one is exhausted",
+				"fallback-0, fallback-1", []string{""}, []string{"", "fallback-0"}, true),
+			Entry("should try the fallback instance types in their order",
+				"fallback-0,fallback-1", []string{"", "fallback-0"}, []string{"", "fallback-0", "fallback-1"}, true),
+			Entry("should give up once all fallback instance types are exhausted",
+				"fallback-0,fallback-1", []string{"", "fallback-0", "fallback-1"}, []string{"", "fallback-0", "fallback-1"}, false),
+			Entry("should give up right away if no fallback instance types are configured",
+				"", []string{""}, []string{""}, false),
+		)
+	})
+
+	Describe("#getPlacementGroup", func() {
+		DescribeTable("##table",
+			func(machineClassPlacementGroup, machinePlacementGroup, expectedPlacementGroup string) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				objMeta := &metav1.ObjectMeta{GenerateName: "machine", Namespace: testNamespace}
+				machineClass := &v1alpha1.MachineClass{
+					ObjectMeta: *newObjectMeta(objMeta, 0),
+					SecretRef:  newSecretReference(objMeta, 0),
+				}
+				if machineClassPlacementGroup != "" {
+					machineClass.Annotations = map[string]string{machineutils.MachinePlacementGroup: machineClassPlacementGroup}
+				}
+				machine := newMachine(
+					&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec:       v1alpha1.MachineSpec{Class: v1alpha1.ClassSpec{Kind: "MachineClass", Name: machineClass.Name}},
+					},
+					&v1alpha1.MachineStatus{}, nil, nil, nil, true, metav1.Now(),
+				)
+				if machinePlacementGroup != "" {
+					machine.Annotations = map[string]string{machineutils.MachinePlacementGroup: machinePlacementGroup}
+				}
+				secret := &corev1.Secret{
+					ObjectMeta: *newObjectMeta(objMeta, 0),
+					Data:       map[string][]byte{"userData": []byte("test")},
+				}
+
+				fakeDriver := &placementGroupDriver{
+					FakeDriver: driver.NewFakeDriver(false, "", "", "", nil, nil).(*driver.FakeDriver),
+				}
+				c, trackers := createController(stop, testNamespace, []runtime.Object{machineClass, machine}, []runtime.Object{secret}, nil, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, c)
+
+				machine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				_, _ = c.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{Machine: machine, MachineClass: machineClass, Secret: secret})
+
+				Expect(fakeDriver.requestedPlacementGroups).To(Equal([]string{expectedPlacementGroup}))
+			},
+			Entry("should create the VM without placement group if none is annotated",
+				"", "", ""),
+			Entry("should create the VM in the placement group of the MachineClass",
+				"class-group", "", "class-group"),
+			Entry("should create the VM in the placement group of the machine",
+				"", "machine-group", "machine-group"),
+			Entry("should prefer the placement group of the machine over the one of the MachineClass",
+				"class-group", "machine-group", "machine-group"),
+		)
+	})
+
+	Describe("#triggerDeletionFlow", func() {
+		type setup struct {
+			secrets                        []*corev1.Secret
+			machineClasses                 []*v1alpha1.MachineClass
+			machines                       []*v1alpha1.Machine
+			nodes                          []*corev1.Node
+			fakeResourceActions            *customfake.ResourceActions
+			noTargetCluster                bool
+			nodeNotReadyForceDrainTimeouts []options.NodeSelectorTimeout
+			machineUnreachableGracePeriod  *metav1.Duration
+			nodeTerminationConditionType   corev1.NodeConditionType
+			nodeTerminationConditionReason string
+		}
+		type action struct {
+			machine                 string
+			forceDeleteLabelPresent bool
+			fakeMachineStatus       *v1alpha1.MachineStatus
+			fakeDriver              *driver.FakeDriver
+		}
+		type expect struct {
+			machine                        *v1alpha1.Machine
+			err                            error
+			nodeTerminationConditionIsSet  bool
+			nodeTerminationConditionReason string
+			nodeDeleted                    bool
+			retry                          machineutils.RetryPeriod
+			event                          string
+		}
+		type data struct {
+			setup  setup
+			action action
+			expect expect
+		}
+		objMeta := &metav1.ObjectMeta{
+			GenerateName:      "machine",
+			Namespace:         "test",
+			CreationTimestamp: metav1.Now(),
+		}
+		DescribeTable("##table",
+			func(data *data) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{}
+				for _, o := range data.setup.machineClasses {
+					machineObjects = append(machineObjects, o)
+				}
+				for _, o := range data.setup.machines {
+					machineObjects = append(machineObjects, o)
+				}
+
+				controlCoreObjects := []runtime.Object{}
+				targetCoreObjects := []runtime.Object{}
+
+				for _, o := range data.setup.secrets {
+					controlCoreObjects = append(controlCoreObjects, o)
+				}
+				for _, o := range data.setup.nodes {
+					targetCoreObjects = append(targetCoreObjects, o)
+				}
+
+				fakeDriver := driver.NewFakeDriver(
+					data.action.fakeDriver.VMExists,
+					data.action.fakeDriver.ProviderID,
+					data.action.fakeDriver.NodeName,
+					data.action.fakeDriver.LastKnownState,
+					data.action.fakeDriver.Err,
+					nil,
+				)
+
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, data.setup.noTargetCluster)
+
+				defer trackers.Stop()
+				controller.safetyOptions.NodeNotReadyForceDrainTimeouts = data.setup.nodeNotReadyForceDrainTimeouts
+				if data.setup.machineUnreachableGracePeriod != nil {
+					controller.safetyOptions.MachineUnreachableGracePeriod = *data.setup.machineUnreachableGracePeriod
+				}
+				if data.setup.nodeTerminationConditionType != "" {
+					controller.nodeTerminationConditionType = data.setup.nodeTerminationConditionType
+				}
+				controller.nodeTerminationConditionReason = data.setup.nodeTerminationConditionReason
+				waitForCacheSync(stop, controller)
+
+				action := data.action
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), action.machine, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				if data.setup.fakeResourceActions != nil {
+					_ = trackers.TargetCore.SetFakeResourceActions(data.setup.fakeResourceActions, math.MaxInt32)
+				}
+
+				// Deletion of machine is triggered
+				retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+				if err != nil || data.expect.err != nil {
+					Expect(err).To(Equal(data.expect.err))
+				}
+				Expect(retry).To(Equal(data.expect.retry))
+
+				machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), action.machine, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Spec).To(Equal(data.expect.machine.Spec))
+				Expect(machine.Status.CurrentStatus.Phase).To(Equal(data.expect.machine.Status.CurrentStatus.Phase))
+				Expect(machine.Status.LastOperation.State).To(Equal(data.expect.machine.Status.LastOperation.State))
+				Expect(machine.Status.LastOperation.Type).To(Equal(data.expect.machine.Status.LastOperation.Type))
+				Expect(machine.Status.LastOperation.Description).To(Equal(data.expect.machine.Status.LastOperation.Description))
+				Expect(machine.Finalizers).To(Equal(data.expect.machine.Finalizers))
+
+				if data.expect.nodeDeleted {
+					_, nodeErr := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), machine.Labels[v1alpha1.NodeLabelKey], metav1.GetOptions{})
+					Expect(nodeErr).To(HaveOccurred())
+				}
+				if data.expect.nodeTerminationConditionIsSet {
+					node, nodeErr := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), machine.Labels[v1alpha1.NodeLabelKey], metav1.GetOptions{})
+					Expect(nodeErr).To(Not(HaveOccurred()))
+					Expect(len(node.Status.Conditions)).To(Equal(1))
+					Expect(node.Status.Conditions[0].Type).To(Equal(controller.nodeTerminationConditionType))
+					Expect(node.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+					if data.expect.nodeTerminationConditionReason != "" {
+						Expect(node.Status.Conditions[0].Reason).To(Equal(data.expect.nodeTerminationConditionReason))
+					}
+				}
+				if data.expect.event != "" {
+					recorder := controller.recorder.(*record.FakeRecorder)
+					Expect(recorder.Events).To(Receive(ContainSubstring(data.expect.event)))
+				}
+
+			},
+			Entry("Do not process machine deletion for object without finalizer", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineRunning,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    "Machine machine-0 successfully joined the cluster",
+								State:          v1alpha1.MachineStateSuccessful,
+								Type:           v1alpha1.MachineOperationCreate,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						false,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("Machine \"machine-0\" is missing finalizers. Deletion cannot proceed"),
+					retry: machineutils.LongRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineRunning,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    "Machine machine-0 successfully joined the cluster",
+								State:          v1alpha1.MachineStateSuccessful,
+								Type:           v1alpha1.MachineOperationCreate,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						false,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Change machine phase to termination successfully", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineRunning,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    "Machine machine-0 successfully joined the cluster",
+								State:          v1alpha1.MachineStateSuccessful,
+								Type:           v1alpha1.MachineOperationCreate,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("Machine deletion in process. Phase set to termination"),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.GetVMStatus,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Checking existance of VM at provider successfully", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.GetVMStatus,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("machine deletion in process. VM with matching ID found"),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Drain machine successfully", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeNode-0",
+						},
+						true,
+						metav1.Now(),
+					),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
+							},
+						},
+					},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:                           fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
+					retry:                         machineutils.ShortRetry,
+					nodeTerminationConditionIsSet: true,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Drain machine successfully and set the configured termination condition on the node", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeNode-0",
+						},
+						true,
+						metav1.Now(),
+					),
+					nodeTerminationConditionType:   "ToBeDeleted",
+					nodeTerminationConditionReason: "MachineDeletion",
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
+							},
+						},
+					},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:                            fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
+					retry:                          machineutils.ShortRetry,
+					nodeTerminationConditionIsSet:  true,
+					nodeTerminationConditionReason: "MachineDeletion",
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Drain skipping as nodeName is not valid", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               corev1.NodeReady,
+									Status:             corev1.ConditionUnknown,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("Skipping drain as nodeName is not a valid one for machine. Initiate VM deletion"),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    "Skipping drain as nodeName is not a valid one for machine. Initiate VM deletion",
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Force Drain as machine is NotReady for a long time (5 minutes)", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               corev1.NodeReady,
+									Status:             corev1.ConditionUnknown,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("%s", fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Force Drain as machine is NotReady for longer than the configured unreachable grace period (2 minutes)", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               corev1.NodeReady,
+									Status:             corev1.ConditionUnknown,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-3 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+					machineUnreachableGracePeriod: &metav1.Duration{Duration: 2 * time.Minute},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("%s", fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("No Force Drain as machine is NotReady for less than the configured unreachable grace period (10 minutes)", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               corev1.NodeReady,
+									Status:             corev1.ConditionUnknown,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+					machineUnreachableGracePeriod: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Force Drain as machine is in ReadonlyFilesystem for longer than the configured unreachable grace period (2 minutes)", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               "ReadonlyFilesystem",
+									Status:             corev1.ConditionTrue,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-3 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+					machineUnreachableGracePeriod: &metav1.Duration{Duration: 2 * time.Minute},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("%s", fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("No Force Drain as machine is NotReady for less than the timeout configured for its node's labels", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               corev1.NodeReady,
+									Status:             corev1.ConditionUnknown,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   "fakeID-0",
+								Labels: map[string]string{"accelerator": "gpu"},
+							},
+						},
+					},
+					nodeNotReadyForceDrainTimeouts: []options.NodeSelectorTimeout{
+						{
+							Selector: "accelerator=gpu",
+							Timeout:  metav1.Duration{Duration: 10 * time.Minute},
+						},
+					},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Force Drain as machine is in ReadonlyFilesystem for a long time (5 minutes)", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               "ReadonlyFilesystem",
+									Status:             corev1.ConditionTrue,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("%s", fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Force Drain as machine is NotReady for a long time(5 min) ,also ReadonlyFilesystem is true for a long time (5 minutes)", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               "ReadonlyFilesystem",
+									Status:             corev1.ConditionTrue,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+								},
+								{
+									Type:               corev1.NodeReady,
+									Status:             corev1.ConditionFalse,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("%s", fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("No Drain skipping as ReadonlyFilesystem is true for a short time(<5min)", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               "ReadonlyFilesystem",
+									Status:             corev1.ConditionTrue,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("No Drain skipping as ReadonlyFilesystem is false", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               "ReadonlyFilesystem",
+									Status:             corev1.ConditionFalse,
+									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+								},
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Drain machine failure, but since force deletion label is present deletion continues", &data{
+				setup: setup{
 					secrets: []*corev1.Secret{
 						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -1785,9 +4294,175 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.GetVMStatus,
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+							"force-deletion":      "True",
+						},
+						true,
+						metav1.Now(),
+					),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeID-0",
+							},
+						},
+					},
+					fakeResourceActions: &customfake.ResourceActions{
+						Node: customfake.Actions{
+							Update: "Failed to update node",
+						},
+					},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("Failed to update node"),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Drain failed due to - Failed to update node. However, since it's a force deletion shall continue deletion of VM. %s", machineutils.DelVolumesAttachments),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+			Entry("Drain machine failure before drain timeout, hence deletion fails", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.NewTime(time.Now().Add(-3 * time.Minute)),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.NewTime(time.Now().Add(-3 * time.Minute)),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.NewTime(time.Now().Add(-3*time.Minute)),
+					),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeID-0",
+							},
+						},
+					},
+					fakeResourceActions: &customfake.ResourceActions{
+						Node: customfake.Actions{
+							Update: "Failed to update node",
+						},
+					},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
+					},
+				},
+				expect: expect{
+					err:   fmt.Errorf("failed to create update conditions for node \"fakeID-0\": Failed to update node"),
+					retry: machineutils.ShortRetry,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Drain failed due to failure in update of node conditions - %s. Will retry in next sync. %s", "failed to create update conditions for node \"fakeID-0\": Failed to update node", machineutils.InitiateDrain),
+								State:          v1alpha1.MachineStateFailed,
+								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
 						},
@@ -1802,6 +4477,67 @@ var _ = Describe("machine", func() {
 						metav1.Now(),
 					),
 				},
+			}),
+			Entry("Drain machine failure after drain timeout, hence deletion continues", &data{
+				setup: setup{
+					secrets: []*corev1.Secret{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					},
+					machineClasses: []*v1alpha1.MachineClass{
+						{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+					},
+					machines: newMachines(
+						1,
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeID-0",
+						},
+						true,
+						metav1.NewTime(time.Now().Add(-3*time.Hour)),
+					),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeID-0",
+							},
+						},
+					},
+					fakeResourceActions: &customfake.ResourceActions{
+						Node: customfake.Actions{
+							Update: "Failed to update node",
+						},
+					},
+				},
 				action: action{
 					machine: "machine-0",
 					fakeDriver: &driver.FakeDriver{
@@ -1812,7 +4548,7 @@ var _ = Describe("machine", func() {
 					},
 				},
 				expect: expect{
-					err:   fmt.Errorf("machine deletion in process. VM with matching ID found"),
+					err:   fmt.Errorf("Failed to update node"),
 					retry: machineutils.ShortRetry,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
@@ -1831,7 +4567,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    fmt.Sprintf("Drain failed due to - Failed to update node. However, since it's a force deletion shall continue deletion of VM. %s", machineutils.DelVolumesAttachments),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -1849,7 +4585,7 @@ var _ = Describe("machine", func() {
 					),
 				},
 			}),
-			Entry("Drain machine successfully", &data{
+			Entry("Drain machine failure due to node update failure", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -1903,20 +4639,24 @@ var _ = Describe("machine", func() {
 							},
 						},
 					},
+					fakeResourceActions: &customfake.ResourceActions{
+						Node: customfake.Actions{
+							Update: "Failed to update node",
+						},
+					},
 				},
 				action: action{
 					machine: "machine-0",
 					fakeDriver: &driver.FakeDriver{
 						VMExists:   true,
-						ProviderID: "fakeID",
+						ProviderID: "fakeID-0",
 						NodeName:   "fakeNode-0",
 						Err:        nil,
 					},
 				},
 				expect: expect{
-					err:                           fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
-					retry:                         machineutils.ShortRetry,
-					nodeTerminationConditionIsSet: true,
+					err:   fmt.Errorf("failed to create update conditions for node \"fakeNode-0\": Failed to update node"),
+					retry: machineutils.ShortRetry,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -1934,8 +4674,8 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
-								State:          v1alpha1.MachineStateProcessing,
+								Description:    fmt.Sprintf("Drain failed due to failure in update of node conditions - %s. Will retry in next sync. %s", "failed to create update conditions for node \"fakeNode-0\": Failed to update node", machineutils.InitiateDrain),
+								State:          v1alpha1.MachineStateFailed,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
@@ -1952,7 +4692,7 @@ var _ = Describe("machine", func() {
 					),
 				},
 			}),
-			Entry("Drain skipping as nodeName is not valid", &data{
+			Entry("Delete VM successfully", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -1983,25 +4723,18 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
-							Conditions: []corev1.NodeCondition{
-								{
-									Type:               corev1.NodeReady,
-									Status:             corev1.ConditionUnknown,
-									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
-								},
-							},
 						},
 						nil,
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
 						map[string]string{
-							v1alpha1.NodeLabelKey: "",
+							v1alpha1.NodeLabelKey: "fakeID-0",
 						},
 						true,
 						metav1.Now(),
@@ -2012,12 +4745,12 @@ var _ = Describe("machine", func() {
 					fakeDriver: &driver.FakeDriver{
 						VMExists:   true,
 						ProviderID: "fakeID-0",
-						NodeName:   "",
+						NodeName:   "fakeNode-0",
 						Err:        nil,
 					},
 				},
 				expect: expect{
-					err:   fmt.Errorf("Skipping drain as nodeName is not a valid one for machine. Initiate VM deletion"),
+					err:   fmt.Errorf("Machine deletion in process. VM deletion was successful. " + machineutils.InitiateNodeDeletion),
 					retry: machineutils.ShortRetry,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
@@ -2036,7 +4769,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    "Skipping drain as nodeName is not a valid one for machine. Initiate VM deletion",
+								Description:    fmt.Sprintf("VM deletion was successful. %s", machineutils.InitiateNodeDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2047,14 +4780,14 @@ var _ = Describe("machine", func() {
 							machineutils.MachinePriority: "3",
 						},
 						map[string]string{
-							v1alpha1.NodeLabelKey: "",
+							v1alpha1.NodeLabelKey: "fakeID-0",
 						},
 						true,
 						metav1.Now(),
 					),
 				},
 			}),
-			Entry("Force Drain as machine is NotReady for a long time (5 minutes)", &data{
+			Entry("Hold VM deletion as nodes of other machines share the providerID", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -2067,47 +4800,88 @@ var _ = Describe("machine", func() {
 							SecretRef:  newSecretReference(objMeta, 0),
 						},
 					},
-					machines: newMachines(
-						1,
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+					machines: append(
+						newMachines(
+							1,
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
 								},
-								ProviderID: "fakeID",
 							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+							nil,
+							map[string]string{
+								machineutils.MachinePriority: "3",
 							},
-							Conditions: []corev1.NodeCondition{
-								{
-									Type:               corev1.NodeReady,
-									Status:             corev1.ConditionUnknown,
-									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-0",
+							},
+							true,
+							metav1.Now(),
+						),
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      "machine-1",
+									Namespace: objMeta.Namespace,
+								},
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
 								},
 							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineRunning,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							nil,
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-1",
+							},
+							true,
+							metav1.Now(),
+						),
+					),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeID-0",
+							},
+							Spec: corev1.NodeSpec{
+								ProviderID: "fakeID-0",
+							},
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeID-1",
+							},
+							Spec: corev1.NodeSpec{
+								ProviderID: "fakeID-0",
+							},
 						},
-						true,
-						metav1.Now(),
-					),
+					},
 				},
 				action: action{
 					machine: "machine-0",
@@ -2119,8 +4893,9 @@ var _ = Describe("machine", func() {
 					},
 				},
 				expect: expect{
-					err:   fmt.Errorf("%s", fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
-					retry: machineutils.ShortRetry,
+					err:   fmt.Errorf("VM deletion for machine %q is on hold due to conflicting providerID %q", "machine-0", "fakeID-0"),
+					retry: machineutils.MediumRetry,
+					event: machineutils.ProviderIDConflictReason,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -2138,8 +4913,8 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments),
-								State:          v1alpha1.MachineStateProcessing,
+								Description:    fmt.Sprintf("Nodes [fakeID-0 fakeID-1] of machines [machine-0 machine-1] share the providerID %q. VM deletion is on hold until the conflict is resolved. %s", "fakeID-0", machineutils.InitiateVMDeletion),
+								State:          v1alpha1.MachineStateFailed,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
@@ -2156,7 +4931,7 @@ var _ = Describe("machine", func() {
 					),
 				},
 			}),
-			Entry("Force Drain as machine is in ReadonlyFilesystem for a long time (5 minutes)", &data{
+			Entry("Delete node object successfully", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -2187,18 +4962,11 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    fmt.Sprintf("VM deletion was successful. %s", machineutils.InitiateNodeDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
-							Conditions: []corev1.NodeCondition{
-								{
-									Type:               "ReadonlyFilesystem",
-									Status:             corev1.ConditionTrue,
-									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
-								},
-							},
 						},
 						nil,
 						map[string]string{
@@ -2210,6 +4978,13 @@ var _ = Describe("machine", func() {
 						true,
 						metav1.Now(),
 					),
+					nodes: []*corev1.Node{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeID-0",
+							},
+						},
+					},
 				},
 				action: action{
 					machine: "machine-0",
@@ -2221,8 +4996,9 @@ var _ = Describe("machine", func() {
 					},
 				},
 				expect: expect{
-					err:   fmt.Errorf("%s", fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
-					retry: machineutils.ShortRetry,
+					err:         fmt.Errorf("Machine deletion in process. Deletion of node object was successful"),
+					retry:       machineutils.ShortRetry,
+					nodeDeleted: true,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -2240,7 +5016,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments),
+								Description:    fmt.Sprintf("Deletion of Node Object %q is successful. %s", "fakeID-0", machineutils.InitiateFinalizerRemoval),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2258,7 +5034,7 @@ var _ = Describe("machine", func() {
 					),
 				},
 			}),
-			Entry("Force Drain as machine is NotReady for a long time(5 min) ,also ReadonlyFilesystem is true for a long time (5 minutes)", &data{
+			Entry("Delete machine finalizer successfully", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -2289,23 +5065,11 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    fmt.Sprintf("Deletion of Node Object %q is successful. %s", "fakeID-0", machineutils.InitiateFinalizerRemoval),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
-							Conditions: []corev1.NodeCondition{
-								{
-									Type:               "ReadonlyFilesystem",
-									Status:             corev1.ConditionTrue,
-									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
-								},
-								{
-									Type:               corev1.NodeReady,
-									Status:             corev1.ConditionFalse,
-									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
-								},
-							},
 						},
 						nil,
 						map[string]string{
@@ -2328,8 +5092,7 @@ var _ = Describe("machine", func() {
 					},
 				},
 				expect: expect{
-					err:   fmt.Errorf("%s", fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
-					retry: machineutils.ShortRetry,
+					retry: machineutils.LongRetry,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -2347,7 +5110,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments),
+								Description:    fmt.Sprintf("Deletion of Node Object %q is successful. %s", "fakeID-0", machineutils.InitiateFinalizerRemoval),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2360,12 +5123,12 @@ var _ = Describe("machine", func() {
 						map[string]string{
 							v1alpha1.NodeLabelKey: "fakeID-0",
 						},
-						true,
+						false,
 						metav1.Now(),
 					),
 				},
 			}),
-			Entry("No Drain skipping as ReadonlyFilesystem is true for a short time(<5min)", &data{
+			Entry("Unable to decode deletion flow state for machine", &data{
 				setup: setup{
 					secrets: []*corev1.Secret{
 						{
@@ -2396,18 +5159,11 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
-								State:          v1alpha1.MachineStateProcessing,
+								Description:    "Some random last op description",
+								State:          v1alpha1.MachineStateFailed,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
-							Conditions: []corev1.NodeCondition{
-								{
-									Type:               "ReadonlyFilesystem",
-									Status:             corev1.ConditionTrue,
-									LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
-								},
-							},
 						},
 						nil,
 						map[string]string{
@@ -2430,7 +5186,7 @@ var _ = Describe("machine", func() {
 					},
 				},
 				expect: expect{
-					err:   fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
+					err:   fmt.Errorf("Machine deletion in process. Phase set to termination"),
 					retry: machineutils.ShortRetry,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
@@ -2449,7 +5205,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+								Description:    machineutils.GetVMStatus,
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2467,8 +5223,9 @@ var _ = Describe("machine", func() {
 					),
 				},
 			}),
-			Entry("No Drain skipping as ReadonlyFilesystem is false", &data{
+			Entry("without target cluster: checking existence of VM, then jump to VM deletion", &data{
 				setup: setup{
+					noTargetCluster: true,
 					secrets: []*corev1.Secret{
 						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -2498,26 +5255,17 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    machineutils.GetVMStatus,
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
-							Conditions: []corev1.NodeCondition{
-								{
-									Type:               "ReadonlyFilesystem",
-									Status:             corev1.ConditionFalse,
-									LastTransitionTime: metav1.NewTime(time.Now().Add(-6 * time.Minute)),
-								},
-							},
 						},
 						nil,
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
-						},
+						map[string]string{}, // no node label
 						true,
 						metav1.Now(),
 					),
@@ -2532,7 +5280,6 @@ var _ = Describe("machine", func() {
 					},
 				},
 				expect: expect{
-					err:   fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion),
 					retry: machineutils.ShortRetry,
 					machine: newMachine(
 						&v1alpha1.MachineTemplateSpec{
@@ -2551,7 +5298,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+								Description:    "Running without target cluster, skipping node drain and volume attachment deletion. " + machineutils.InitiateVMDeletion,
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2561,16 +5308,15 @@ var _ = Describe("machine", func() {
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
-						},
+						map[string]string{}, // no node label
 						true,
 						metav1.Now(),
 					),
 				},
 			}),
-			Entry("Drain machine failure, but since force deletion label is present deletion continues", &data{
+			Entry("without target cluster: Delete node object successfully", &data{
 				setup: setup{
+					noTargetCluster: true,
 					secrets: []*corev1.Secret{
 						{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
@@ -2600,7 +5346,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    machineutils.InitiateNodeDeletion,
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2610,10 +5356,7 @@ var _ = Describe("machine", func() {
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
-							"force-deletion":      "True",
-						},
+						map[string]string{}, // no node label
 						true,
 						metav1.Now(),
 					),
@@ -2624,33 +5367,79 @@ var _ = Describe("machine", func() {
 							},
 						},
 					},
-					fakeResourceActions: &customfake.ResourceActions{
-						Node: customfake.Actions{
-							Update: "Failed to update node",
-						},
+				},
+				action: action{
+					machine: "machine-0",
+					fakeDriver: &driver.FakeDriver{
+						VMExists:   true,
+						ProviderID: "fakeID-0",
+						NodeName:   "fakeNode-0",
+						Err:        nil,
 					},
 				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
+				expect: expect{
+					err:         fmt.Errorf("Machine deletion in process. No node object found"),
+					retry:       machineutils.ShortRetry,
+					nodeDeleted: false,
+					machine: newMachine(
+						&v1alpha1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							Spec: v1alpha1.MachineSpec{
+								Class: v1alpha1.ClassSpec{
+									Kind: "MachineClass",
+									Name: "machine-0",
+								},
+								ProviderID: "fakeID",
+							},
+						},
+						&v1alpha1.MachineStatus{
+							CurrentStatus: v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineTerminating,
+								LastUpdateTime: metav1.Now(),
+							},
+							LastOperation: v1alpha1.LastOperation{
+								Description:    fmt.Sprintf("Label %q not present on machine %q or no associated node object found, continuing deletion flow. %s", v1alpha1.NodeLabelKey, "machine-0", machineutils.InitiateFinalizerRemoval),
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationDelete,
+								LastUpdateTime: metav1.Now(),
+							},
+						},
+						nil,
+						map[string]string{
+							machineutils.MachinePriority: "3",
+						},
+						map[string]string{}, // no node label
+						true,
+						metav1.Now(),
+					),
+				},
+			}),
+		)
+
+		Context("when node deletions are rate-limited", func() {
+			It("should delete only as many node objects as permitted and defer the rest", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				const machineCount = 3
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
 					},
-				},
-				expect: expect{
-					err:   fmt.Errorf("Failed to update node"),
-					retry: machineutils.ShortRetry,
-					machine: newMachine(
+				}
+				targetCoreObjects := []runtime.Object{}
+				for i := 0; i < machineCount; i++ {
+					nodeName := fmt.Sprintf("fakeID-%d", i)
+					machineObjects = append(machineObjects, newMachine(
 						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
+							ObjectMeta: *newObjectMeta(objMeta, i),
 							Spec: v1alpha1.MachineSpec{
 								Class: v1alpha1.ClassSpec{
 									Kind: "MachineClass",
 									Name: "machine-0",
 								},
-								ProviderID: "fakeID",
+								ProviderID: nodeName,
 							},
 						},
 						&v1alpha1.MachineStatus{
@@ -2659,7 +5448,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Drain failed due to - Failed to update node. However, since it's a force deletion shall continue deletion of VM. %s", machineutils.DelVolumesAttachments),
+								Description:    fmt.Sprintf("VM deletion was successful. %s", machineutils.InitiateNodeDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2670,28 +5459,381 @@ var _ = Describe("machine", func() {
 							machineutils.MachinePriority: "3",
 						},
 						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+							v1alpha1.NodeLabelKey: nodeName,
 						},
 						true,
 						metav1.Now(),
-					),
-				},
-			}),
-			Entry("Drain machine failure before drain timeout, hence deletion fails", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
+					))
+					targetCoreObjects = append(targetCoreObjects, &corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: nodeName,
 						},
+					})
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
 					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
+				}
+
+				fakeDriver := driver.NewFakeDriver(true, "", "", "", nil, nil)
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				// permit a single node deletion for the duration of the test
+				controller.nodeDeletionRateLimiter = flowcontrol.NewTokenBucketRateLimiter(0.001, 1)
+
+				for i := 0; i < machineCount; i++ {
+					machineName := fmt.Sprintf("machine-%d", i)
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), machineName, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					_, nodeErr := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), machine.Labels[v1alpha1.NodeLabelKey], metav1.GetOptions{})
+					actual, getErr := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), machineName, metav1.GetOptions{})
+					Expect(getErr).ToNot(HaveOccurred())
+					if i == 0 {
+						Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. Deletion of node object was successful")))
+						Expect(nodeErr).To(HaveOccurred())
+						Expect(actual.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateFinalizerRemoval))
+					} else {
+						Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. Deletion of node object is throttled")))
+						Expect(nodeErr).ToNot(HaveOccurred())
+						Expect(actual.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateNodeDeletion))
+					}
+				}
+			})
+		})
+
+		Context("when the MachineClass requires the node to be deleted before the VM", func() {
+			DescribeTable("##table",
+				func(nodePresent bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        "machine-0",
+								Namespace:   objMeta.Namespace,
+								Annotations: map[string]string{machineutils.MachineClassDeleteNodeBeforeVM: "true"},
+							},
+							SecretRef: newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							map[string]string{
+								machineutils.MachinePriority: "3",
+							},
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-0",
+							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+					targetCoreObjects := []runtime.Object{}
+					if nodePresent {
+						targetCoreObjects = append(targetCoreObjects, &corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeID-0",
+							},
+						})
+					}
+
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, nil, false)
+					defer trackers.Stop()
+					fakeDriver := &nodeTrackingDriver{
+						Driver:     driver.NewFakeDriver(true, "fakeID-0", "fakeNode-0", "", nil, nil),
+						nodeClient: controller.targetCoreClient,
+						nodeName:   "fakeID-0",
+					}
+					controller.driver = fakeDriver
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. VM deletion was successful. " + machineutils.InitiateNodeDeletion)))
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					Expect(fakeDriver.deleteMachineCalled).To(BeTrue())
+					Expect(fakeDriver.nodePresentOnDeleteMachine).To(BeFalse())
+					_, err = controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeID-0", metav1.GetOptions{})
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				},
+				Entry("should delete the node object before calling DeleteMachine", true),
+				Entry("should continue with the VM deletion if the node object is already gone", false),
+			)
+		})
+
+		Context("when the machine requests its node to be deleted while its VM deletion is pending", func() {
+			DescribeTable("##table",
+				func(annotations map[string]string, expectNodeDeleted bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							SecretRef:  newSecretReference(objMeta, 0),
 						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    fmt.Sprintf("VM deletion failed due to - stuck. However, will re-try in the next resync. %s", machineutils.InitiateVMDeletion),
+									State:          v1alpha1.MachineStateFailed,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							annotations,
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeNode-0",
+							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+					targetCoreObjects := []runtime.Object{
+						&corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
+							},
+						},
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", status.Error(codes.Unknown, "stuck"), nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(HaveOccurred())
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					// the VM deletion is still pending and retried
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateVMDeletion))
+					Expect(machine.Status.LastOperation.State).To(Equal(v1alpha1.MachineStateFailed))
+
+					_, err = controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeNode-0", metav1.GetOptions{})
+					events := controller.recorder.(*record.FakeRecorder).Events
+					if expectNodeDeleted {
+						Expect(apierrors.IsNotFound(err)).To(BeTrue())
+						Expect(events).To(Receive(ContainSubstring(machineutils.MachineForcedNodeDeletionReason)))
+					} else {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(events).ToNot(Receive())
+					}
+				},
+				Entry("should delete the node object while the VM deletion is retried", map[string]string{machineutils.MachineForceNodeDeletion: "true"}, true),
+				Entry("should keep the node object until the VM is deleted without the annotation", nil, false),
+			)
+		})
+
+		Context("when the MachineClass requires a snapshot of the VM before its deletion", func() {
+			DescribeTable("##table",
+				func(snapshotID string) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:        "machine-0",
+								Namespace:   objMeta.Namespace,
+								Annotations: map[string]string{machineutils.MachineClassSnapshotBeforeDeletion: "true"},
+							},
+							SecretRef: newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							map[string]string{
+								machineutils.MachinePriority: "3",
+							},
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-0",
+							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, nil, false)
+					defer trackers.Stop()
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil).(*driver.FakeDriver)
+					fakeDriver.SnapshotID = snapshotID
+					controller.driver = fakeDriver
+					waitForCacheSync(stop, controller)
+
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					triggerDeletionFlow := func() (machineutils.RetryPeriod, error) {
+						machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						return controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+							Machine:      machine,
+							MachineClass: machineClass,
+							Secret:       secret,
+						})
+					}
+
+					if snapshotID != "" {
+						retry, err := triggerDeletionFlow()
+						Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. Snapshot %q of VM taken", snapshotID)))
+						Expect(retry).To(Equal(machineutils.ShortRetry))
+						Expect(fakeDriver.VMExists).To(BeTrue())
+
+						machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(machine.Annotations).To(HaveKeyWithValue(machineutils.MachineSnapshotID, snapshotID))
+					}
+
+					retry, err := triggerDeletionFlow()
+					Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. VM deletion was successful. " + machineutils.InitiateNodeDeletion)))
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+					Expect(fakeDriver.VMExists).To(BeFalse())
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					if snapshotID != "" {
+						Expect(machine.Annotations).To(HaveKeyWithValue(machineutils.MachineSnapshotID, snapshotID))
+					} else {
+						Expect(machine.Annotations).ToNot(HaveKey(machineutils.MachineSnapshotID))
+					}
+				},
+				Entry("should record the snapshot ID before deleting the VM", "fake-snapshot-0"),
+				Entry("should delete the VM without a snapshot if the driver does not support snapshots", ""),
+			)
+		})
+
+		Context("when pre-deletion hooks are configured", func() {
+			var (
+				stop       chan struct{}
+				controller *controller
+				trackers   *customfake.FakeObjectTrackers
+				fakeDriver *driver.FakeDriver
+				calls      []string
+			)
+
+			setup := func(annotations map[string]string, hooks ...PreDeletionHook) {
+				stop = make(chan struct{})
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
 					},
-					machines: newMachines(
-						1,
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -2705,51 +5847,126 @@ var _ = Describe("machine", func() {
 						&v1alpha1.MachineStatus{
 							CurrentStatus: v1alpha1.CurrentStatus{
 								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.NewTime(time.Now().Add(-3 * time.Minute)),
+								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.NewTime(time.Now().Add(-3 * time.Minute)),
+								LastUpdateTime: metav1.Now(),
 							},
 						},
 						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
+						annotations,
 						map[string]string{
 							v1alpha1.NodeLabelKey: "fakeID-0",
 						},
 						true,
-						metav1.NewTime(time.Now().Add(-3*time.Minute)),
+						metav1.Now(),
 					),
-					nodes: []*corev1.Node{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "fakeID-0",
-							},
-						},
-					},
-					fakeResourceActions: &customfake.ResourceActions{
-						Node: customfake.Actions{
-							Update: "Failed to update node",
-						},
-					},
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
 					},
-				},
-				expect: expect{
-					err:   fmt.Errorf("failed to create update conditions for node \"fakeID-0\": Failed to update node"),
-					retry: machineutils.ShortRetry,
-					machine: newMachine(
+				}
+
+				controller, trackers = createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, nil, false)
+				fakeDriver = driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil).(*driver.FakeDriver)
+				controller.driver = fakeDriver
+				calls = nil
+				controller.preDeletionHooks = hooks
+				waitForCacheSync(stop, controller)
+			}
+
+			AfterEach(func() {
+				trackers.Stop()
+				close(stop)
+			})
+
+			triggerDeletionFlow := func() (machineutils.RetryPeriod, error) {
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				return controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+			}
+
+			It("should run the hooks in order and wait for their success before deleting the VM", func() {
+				setup(map[string]string{machineutils.MachinePriority: "3"},
+					&stubPreDeletionHook{name: "cmdb", failures: 1, calls: &calls},
+					&stubPreDeletionHook{name: "dns", calls: &calls},
+				)
+
+				retry, err := triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. Waiting for pre-deletion hook %q to succeed", "cmdb")))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				Expect(fakeDriver.VMExists).To(BeTrue())
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Status.LastOperation.State).To(Equal(v1alpha1.MachineStateProcessing))
+				Expect(machine.Status.LastOperation.Description).To(HaveSuffix(machineutils.InitiateVMDeletion))
+				Expect(machine.Annotations).ToNot(HaveKey(machineutils.MachinePreDeletionHooksCompleted))
+
+				retry, err = triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. Pre-deletion hook %q succeeded", "cmdb")))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				Expect(fakeDriver.VMExists).To(BeTrue())
+
+				retry, err = triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. Pre-deletion hook %q succeeded", "dns")))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				Expect(fakeDriver.VMExists).To(BeTrue())
+				machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Annotations).To(HaveKeyWithValue(machineutils.MachinePreDeletionHooksCompleted, `["cmdb","dns"]`))
+
+				retry, err = triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. VM deletion was successful. " + machineutils.InitiateNodeDeletion)))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				Expect(fakeDriver.VMExists).To(BeFalse())
+				Expect(calls).To(Equal([]string{"cmdb", "cmdb", "dns"}))
+			})
+
+			It("should only run the hooks which did not succeed yet after the hooks were reordered and extended", func() {
+				setup(map[string]string{machineutils.MachinePreDeletionHooksCompleted: `["cmdb"]`},
+					&stubPreDeletionHook{name: "dns", calls: &calls},
+					&stubPreDeletionHook{name: "cmdb", calls: &calls},
+					&stubPreDeletionHook{name: "ipam", calls: &calls},
+				)
+
+				retry, err := triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. Pre-deletion hook %q succeeded", "dns")))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+
+				retry, err = triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. Pre-deletion hook %q succeeded", "ipam")))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Annotations).To(HaveKeyWithValue(machineutils.MachinePreDeletionHooksCompleted, `["cmdb","dns","ipam"]`))
+
+				retry, err = triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. VM deletion was successful. " + machineutils.InitiateNodeDeletion)))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				Expect(fakeDriver.VMExists).To(BeFalse())
+				Expect(calls).To(Equal([]string{"dns", "ipam"}))
+			})
+		})
+
+		Context("when a drain timeout is set on the machine", func() {
+			DescribeTable("##table",
+				func(drainTimeout *metav1.Duration, deletedSince time.Duration, expectedDescription string) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machine := newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -2758,6 +5975,9 @@ var _ = Describe("machine", func() {
 									Name: "machine-0",
 								},
 								ProviderID: "fakeID",
+								MachineConfiguration: &v1alpha1.MachineConfiguration{
+									MachineDrainTimeout: drainTimeout,
+								},
 							},
 						},
 						&v1alpha1.MachineStatus{
@@ -2766,8 +5986,8 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Drain failed due to failure in update of node conditions - %s. Will retry in next sync. %s", "failed to create update conditions for node \"fakeID-0\": Failed to update node", machineutils.InitiateDrain),
-								State:          v1alpha1.MachineStateFailed,
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
@@ -2781,24 +6001,74 @@ var _ = Describe("machine", func() {
 						},
 						true,
 						metav1.Now(),
-					),
-				},
-			}),
-			Entry("Drain machine failure after drain timeout, hence deletion continues", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
+					)
+					machine.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-deletedSince)}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
+						machine,
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
-					machines: newMachines(
-						1,
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+					defer trackers.Stop()
+					waitForCacheSync(stop, controller)
+					// the global drain timeout of the suite is 5 minutes
+					Expect(controller.safetyOptions.MachineDrainTimeout.Duration).To(Equal(5 * time.Minute))
+
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(Equal(fmt.Errorf("%s", expectedDescription)))
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+				},
+				Entry("should force the drain once the drain timeout of the machine elapsed, before the global one",
+					&metav1.Duration{Duration: 2 * time.Minute}, 3*time.Minute, fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
+				Entry("should not force the drain before the drain timeout of the machine elapsed, after the global one",
+					&metav1.Duration{Duration: 30 * time.Minute}, 10*time.Minute, fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion)),
+				Entry("should force the drain once the global drain timeout elapsed if the machine has none",
+					nil, 10*time.Minute, fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)),
+				Entry("should not force the drain before the global drain timeout elapsed if the machine has none",
+					nil, 3*time.Minute, fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion)),
+			)
+		})
+
+		Context("when the MachineClass of the machine is deleted", func() {
+			var (
+				stop       chan struct{}
+				controller *controller
+				trackers   *customfake.FakeObjectTrackers
+				fakeDriver *driver.FakeDriver
+			)
+
+			BeforeEach(func() {
+				stop = make(chan struct{})
+				machineClass := &v1alpha1.MachineClass{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "machine.sapcloud.io/v1alpha1",
+						Kind:       "MachineClass",
+					},
+					ObjectMeta: *newObjectMeta(objMeta, 0),
+					SecretRef:  newSecretReference(objMeta, 0),
+				}
+				machineClass.Finalizers = []string{MCMFinalizerName}
+				machineObjects := []runtime.Object{
+					machineClass,
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -2812,51 +6082,339 @@ var _ = Describe("machine", func() {
 						&v1alpha1.MachineStatus{
 							CurrentStatus: v1alpha1.CurrentStatus{
 								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+								LastUpdateTime: metav1.Now(),
 							},
 						},
 						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
+						nil,
 						map[string]string{
 							v1alpha1.NodeLabelKey: "fakeID-0",
 						},
 						true,
-						metav1.NewTime(time.Now().Add(-3*time.Hour)),
+						metav1.Now(),
 					),
-					nodes: []*corev1.Node{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "fakeID-0",
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Data:       map[string][]byte{"userData": []byte("test")},
+					},
+				}
+
+				fakeDriver = driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil).(*driver.FakeDriver)
+				controller, trackers = createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+				waitForCacheSync(stop, controller)
+			})
+
+			AfterEach(func() {
+				trackers.Stop()
+				close(stop)
+			})
+
+			deleteMachineClass := func() {
+				Expect(controller.controlMachineClient.MachineClasses(objMeta.Namespace).Delete(context.TODO(), "machine-0", metav1.DeleteOptions{})).To(Succeed())
+				Eventually(func() bool {
+					_, err := controller.machineClassLister.MachineClasses(objMeta.Namespace).Get("machine-0")
+					return apierrors.IsNotFound(err)
+				}).Should(BeTrue())
+			}
+
+			It("should complete the deletion of the machine with the MachineClass last validated", func() {
+				_, _, _, err := controller.ValidateMachineClass(context.TODO(), &v1alpha1.ClassSpec{Kind: "MachineClass", Name: "machine-0"})
+				Expect(err).ToNot(HaveOccurred())
+				deleteMachineClass()
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				for i := 0; i < 5 && len(machine.Finalizers) > 0; i++ {
+					machineClass, secretData, _, err := controller.validateMachineClassForTermination(context.TODO(), machine)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machineClass.Name).To(Equal("machine-0"))
+					Expect(secretData).To(HaveKeyWithValue("userData", []byte("test")))
+
+					_, _ = controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       &corev1.Secret{Data: secretData},
+					})
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+				}
+				Expect(machine.Finalizers).To(BeEmpty())
+				Expect(fakeDriver.VMExists).To(BeFalse())
+			})
+
+			It("should not continue the deletion of the machine if its MachineClass was never validated", func() {
+				deleteMachineClass()
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				_, _, _, err = controller.validateMachineClassForTermination(context.TODO(), machine)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				Expect(fakeDriver.VMExists).To(BeTrue())
+			})
+		})
+
+		Context("when the final steps of the deletion complete", func() {
+			It("should emit the events of the steps in order with the UID of the machine as correlation ID", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newMachine(
+					&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
 							},
+							ProviderID: "fakeID",
 						},
 					},
-					fakeResourceActions: &customfake.ResourceActions{
-						Node: customfake.Actions{
-							Update: "Failed to update node",
+					&v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase:          v1alpha1.MachineTerminating,
+							LastUpdateTime: metav1.Now(),
+						},
+						LastOperation: v1alpha1.LastOperation{
+							Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+							State:          v1alpha1.MachineStateProcessing,
+							Type:           v1alpha1.MachineOperationDelete,
+							LastUpdateTime: metav1.Now(),
 						},
 					},
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
+					nil,
+					nil,
+					map[string]string{
+						v1alpha1.NodeLabelKey: "fakeID-0",
 					},
-				},
-				expect: expect{
-					err:   fmt.Errorf("Failed to update node"),
-					retry: machineutils.ShortRetry,
-					machine: newMachine(
+					true,
+					metav1.Now(),
+				)
+				machine.UID = "machine-0-uid"
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
+					},
+					machine,
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+					},
+				}
+				targetCoreObjects := []runtime.Object{
+					newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{}),
+				}
+
+				fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil).(*driver.FakeDriver)
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				for i := 0; i < 5 && len(machine.Finalizers) > 0; i++ {
+					_, _ = controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       &corev1.Secret{},
+					})
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+				}
+				Expect(machine.Finalizers).To(BeEmpty())
+				_, err = controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeID-0", metav1.GetOptions{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+				var auditEvents []string
+				events := controller.recorder.(*record.FakeRecorder).Events
+				for len(events) > 0 {
+					event := <-events
+					if strings.Contains(event, "correlation ID") {
+						auditEvents = append(auditEvents, event)
+					}
+				}
+				Expect(auditEvents).To(HaveLen(3))
+				for i, reason := range []string{machineutils.MachineVMDeletedReason, machineutils.MachineNodeDeletedReason, machineutils.MachineFinalizerRemovedReason} {
+					Expect(auditEvents[i]).To(HavePrefix(corev1.EventTypeNormal + " " + reason + " "))
+					Expect(auditEvents[i]).To(ContainSubstring("(correlation ID machine-0-uid)"))
+					Expect(auditEvents[i]).To(ContainSubstring(machineutils.MachineDeletionCorrelationID + ":machine-0-uid"))
+				}
+			})
+		})
+
+		Context("when the deletion is a dry run", func() {
+			var (
+				controller *controller
+				fakeDriver *driver.FakeDriver
+				trackers   *customfake.FakeObjectTrackers
+				stop       chan struct{}
+			)
+
+			setup := func(phase v1alpha1.MachinePhase, description string) *v1alpha1.Machine {
+				stop = make(chan struct{})
+				isController := true
+				machine := newMachine(
+					&v1alpha1.MachineTemplateSpec{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						Spec: v1alpha1.MachineSpec{
+							Class: v1alpha1.ClassSpec{
+								Kind: "MachineClass",
+								Name: "machine-0",
+							},
+							ProviderID: "fakeID",
+						},
+					},
+					&v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase:          phase,
+							LastUpdateTime: metav1.Now(),
+						},
+						LastOperation: v1alpha1.LastOperation{
+							Description:    description,
+							State:          v1alpha1.MachineStateProcessing,
+							Type:           v1alpha1.MachineOperationDelete,
+							LastUpdateTime: metav1.Now(),
+						},
+					},
+					nil,
+					nil,
+					map[string]string{
+						v1alpha1.NodeLabelKey: "node-0",
+					},
+					true,
+					metav1.Now(),
+				)
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
+					},
+					machine,
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+					},
+				}
+				targetCoreObjects := []runtime.Object{
+					newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{}),
+					&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:            "pod-0",
+							Namespace:       testNamespace,
+							OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "rs-0", Controller: &isController}},
+						},
+						Spec: corev1.PodSpec{NodeName: "node-0"},
+					},
+				}
+
+				fakeDriver = driver.NewFakeDriver(true, "fakeID", "node-0", "", nil, nil).(*driver.FakeDriver)
+				controller, trackers = createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+				waitForCacheSync(stop, controller)
+				return machine
+			}
+
+			AfterEach(func() {
+				trackers.Stop()
+				close(stop)
+			})
+
+			triggerDeletionFlow := func(machine *v1alpha1.Machine, dryRun bool) (*v1alpha1.Machine, machineutils.RetryPeriod, error) {
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       &corev1.Secret{},
+					DryRun:       dryRun,
+				})
+				updatedMachine, getErr := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(getErr).ToNot(HaveOccurred())
+				return updatedMachine, retry, err
+			}
+
+			It("should describe the whole deletion flow without persisting the transition to Terminating", func() {
+				machine := setup(v1alpha1.MachineRunning, "Machine machine-0 successfully joined the cluster")
+
+				updatedMachine, retry, err := triggerDeletionFlow(machine, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(retry).To(Equal(machineutils.LongRetry))
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(v1alpha1.MachineRunning))
+				Expect(updatedMachine.Status.LastOperation.Description).To(Equal(machineutils.DeletionDryRun + `: set phase "Running" to "Terminating", ` +
+					`then get the status of VM "fakeID-0" from the provider, then drain node "node-0" evicting 1 pods [test/pod-0], ` +
+					`then delete the VolumeAttachments of node "node-0", then call DeleteMachine of the provider for VM "fakeID-0", ` +
+					`then delete node "node-0", then remove finalizer "machine.sapcloud.io/machine-controller-manager", after which the machine is gone.`))
+				Expect(fakeDriver.VMExists).To(BeTrue())
+			})
+
+			It("should describe the remaining steps without deleting the VM, the node or the finalizer", func() {
+				machine := setup(v1alpha1.MachineTerminating, fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion))
+
+				for i := 0; i < 3; i++ {
+					updatedMachine, retry, err := triggerDeletionFlow(machine, true)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(retry).To(Equal(machineutils.LongRetry))
+					machine = updatedMachine
+				}
+				Expect(machine.Status.CurrentStatus.Phase).To(Equal(v1alpha1.MachineTerminating))
+				Expect(machine.Status.LastOperation.Description).To(HavePrefix(machineutils.DeletionDryRun + `: call DeleteMachine of the provider for VM "fakeID-0", then delete node "node-0"`))
+				// the step of the deletion flow is kept, so that the deletion resumes from it
+				Expect(machine.Status.LastOperation.Description).To(HaveSuffix(machineutils.InitiateVMDeletion))
+				Expect(machine.Finalizers).ToNot(BeEmpty())
+				Expect(fakeDriver.VMExists).To(BeTrue())
+				_, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "node-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				machine, _, _ = triggerDeletionFlow(machine, false)
+				Expect(fakeDriver.VMExists).To(BeFalse())
+				Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateNodeDeletion))
+			})
+
+			It("should only describe the pre-deletion hooks which did not succeed yet", func() {
+				machine := setup(v1alpha1.MachineTerminating, fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion))
+				var calls []string
+				controller.preDeletionHooks = []PreDeletionHook{
+					&stubPreDeletionHook{name: "dns", calls: &calls},
+					&stubPreDeletionHook{name: "cmdb", calls: &calls},
+				}
+				machine.Annotations = map[string]string{machineutils.MachinePreDeletionHooksCompleted: `["cmdb"]`}
+
+				machine, _, err := triggerDeletionFlow(machine, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Status.LastOperation.Description).To(HavePrefix(machineutils.DeletionDryRun + `: run pre-deletion hook "dns", then call DeleteMachine of the provider for VM "fakeID-0"`))
+				Expect(calls).To(BeEmpty())
+			})
+
+			It("should delete the VM if the deletion is not a dry run", func() {
+				machine := setup(v1alpha1.MachineTerminating, fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion))
+
+				machine, _, _ = triggerDeletionFlow(machine, false)
+				Expect(fakeDriver.VMExists).To(BeFalse())
+				Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateNodeDeletion))
+				Expect(machine.Status.LastOperation.Description).ToNot(ContainSubstring(machineutils.DeletionDryRun))
+			})
+		})
+
+		Context("when the VM deletion waits for critical DaemonSet pods", func() {
+			It("should hold the VM deletion until the CSI DaemonSet pod on the node is safe to terminate", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
+					},
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -2873,7 +6431,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Drain failed due to - Failed to update node. However, since it's a force deletion shall continue deletion of VM. %s", machineutils.DelVolumesAttachments),
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2889,23 +6447,105 @@ var _ = Describe("machine", func() {
 						true,
 						metav1.Now(),
 					),
-				},
-			}),
-			Entry("Drain machine failure due to node update failure", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+					},
+				}
+				csiPod := &corev1.Pod{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "v1",
+						Kind:       "Pod",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "csi-driver-node-0",
+						Namespace: objMeta.Namespace,
+						Labels:    map[string]string{"app": "csi-driver-node"},
+						OwnerReferences: []metav1.OwnerReference{
+							{
+								APIVersion: "apps/v1",
+								Kind:       "DaemonSet",
+								Name:       "csi-driver-node",
+								Controller: ptr.To(true),
+							},
+						},
+					},
+					Spec: corev1.PodSpec{
+						NodeName: "fakeID-0",
+					},
+					Status: corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					},
+				}
+				targetCoreObjects := []runtime.Object{
+					&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "fakeID-0",
 						},
 					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
-						},
+					csiPod,
+				}
+
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, nil, false)
+				defer trackers.Stop()
+				fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil).(*driver.FakeDriver)
+				controller.driver = fakeDriver
+				controller.safetyOptions.CriticalDaemonSetPodSelector = "app=csi-driver-node"
+				waitForCacheSync(stop, controller)
+
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				triggerDeletionFlow := func() (machineutils.RetryPeriod, error) {
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					return controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+				}
+
+				retry, err := triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. VM deletion is waiting for the critical DaemonSet pods [test/csi-driver-node-0] to be safe to terminate")))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				Expect(fakeDriver.VMExists).To(BeTrue())
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Status.LastOperation.Description).To(Equal(fmt.Sprintf("VM deletion is waiting for the critical DaemonSet pods [test/csi-driver-node-0] to be safe to terminate. %s", machineutils.InitiateVMDeletion)))
+
+				csiPod.Status.Conditions = []corev1.PodCondition{
+					{
+						Type:   machineutils.PodSafeToTerminateCondition,
+						Status: corev1.ConditionTrue,
+					},
+				}
+				_, err = controller.targetCoreClient.CoreV1().Pods(csiPod.Namespace).UpdateStatus(context.TODO(), csiPod, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Eventually(func() bool {
+					pod, err := controller.podLister.Pods(csiPod.Namespace).Get(csiPod.Name)
+					return err == nil && isPodSafeToTerminate(pod)
+				}).Should(BeTrue())
+
+				retry, err = triggerDeletionFlow()
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. VM deletion was successful. " + machineutils.InitiateNodeDeletion)))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				Expect(fakeDriver.VMExists).To(BeFalse())
+			})
+
+			It("should not hold the VM deletion once the drain timeout elapsed", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
 					},
-					machines: newMachines(
-						1,
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -2922,7 +6562,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateDrain,
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -2933,181 +6573,820 @@ var _ = Describe("machine", func() {
 							machineutils.MachinePriority: "3",
 						},
 						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeNode-0",
+							v1alpha1.NodeLabelKey: "fakeID-0",
 						},
 						true,
-						metav1.Now(),
+						metav1.NewTime(time.Now().Add(-time.Hour)),
 					),
-					nodes: []*corev1.Node{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "fakeNode-0",
-							},
-						},
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
 					},
-					fakeResourceActions: &customfake.ResourceActions{
-						Node: customfake.Actions{
-							Update: "Failed to update node",
+				}
+				targetCoreObjects := []runtime.Object{
+					&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "fakeID-0",
 						},
 					},
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
+					&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "csi-driver-node-0",
+							Namespace: objMeta.Namespace,
+							Labels:    map[string]string{"app": "csi-driver-node"},
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "apps/v1",
+									Kind:       "DaemonSet",
+									Name:       "csi-driver-node",
+									Controller: ptr.To(true),
+								},
+							},
+						},
+						Spec: corev1.PodSpec{
+							NodeName: "fakeID-0",
+						},
+						Status: corev1.PodStatus{
+							Phase: corev1.PodRunning,
+						},
 					},
-				},
-				expect: expect{
-					err:   fmt.Errorf("failed to create update conditions for node \"fakeNode-0\": Failed to update node"),
-					retry: machineutils.ShortRetry,
-					machine: newMachine(
-						&v1alpha1.MachineTemplateSpec{
+				}
+
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, nil, false)
+				defer trackers.Stop()
+				fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil).(*driver.FakeDriver)
+				controller.driver = fakeDriver
+				controller.safetyOptions.CriticalDaemonSetPodSelector = "app=csi-driver-node"
+				waitForCacheSync(stop, controller)
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+				Expect(err).To(Equal(fmt.Errorf("Machine deletion in process. VM deletion was successful. " + machineutils.InitiateNodeDeletion)))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+				Expect(fakeDriver.VMExists).To(BeFalse())
+			})
+		})
+
+		Context("when the VM deletion fails due to authentication errors", func() {
+			type data struct {
+				policy              string
+				failingSince        *time.Time
+				confirmed           bool
+				expectedRetry       machineutils.RetryPeriod
+				expectedState       v1alpha1.MachineState
+				expectedDescription []string
+			}
+
+			DescribeTable("##table",
+				func(data *data) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					annotations := map[string]string{
+						machineutils.MachinePriority: "3",
+					}
+					if data.failingSince != nil {
+						annotations[machineutils.MachineDeletionAuthFailureSince] = data.failingSince.Format(time.RFC3339)
+					}
+					if data.confirmed {
+						annotations[machineutils.MachineForceFinalizerRemoval] = "true"
+					}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
 								},
-								ProviderID: "fakeID",
 							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Drain failed due to failure in update of node conditions - %s. Will retry in next sync. %s", "failed to create update conditions for node \"fakeNode-0\": Failed to update node", machineutils.InitiateDrain),
-								State:          v1alpha1.MachineStateFailed,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+							nil,
+							annotations,
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-0",
 							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", status.Error(codes.Unauthenticated, "invalid credentials"), nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.MachineDeletionAuthFailureTimeout = metav1.Duration{Duration: 30 * time.Minute}
+					controller.safetyOptions.MachineDeletionAuthFailurePolicy = data.policy
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(HaveOccurred())
+					Expect(retry).To(Equal(data.expectedRetry))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Annotations).To(HaveKey(machineutils.MachineDeletionAuthFailureSince))
+					if data.failingSince != nil {
+						Expect(machine.Annotations[machineutils.MachineDeletionAuthFailureSince]).To(Equal(data.failingSince.Format(time.RFC3339)))
+					}
+					Expect(machine.Status.LastOperation.ErrorCode).To(Equal(codes.Unauthenticated.String()))
+					Expect(machine.Status.LastOperation.State).To(Equal(data.expectedState))
+					for _, description := range data.expectedDescription {
+						Expect(machine.Status.LastOperation.Description).To(ContainSubstring(description))
+					}
+				},
+				Entry("should record the start of the failures and retry on the first failure", &data{
+					policy:              machineutils.DeletionAuthFailurePolicyHold,
+					expectedRetry:       machineutils.ShortRetry,
+					expectedState:       v1alpha1.MachineStateFailed,
+					expectedDescription: []string{"will re-try in the next resync", machineutils.InitiateVMDeletion},
+				}),
+				Entry("should hold the deletion once the failures persist with the Hold policy", &data{
+					policy:              machineutils.DeletionAuthFailurePolicyHold,
+					failingSince:        ptr.To(time.Now().Add(-time.Hour)),
+					confirmed:           true,
+					expectedRetry:       machineutils.LongRetry,
+					expectedState:       v1alpha1.MachineStateFailed,
+					expectedDescription: []string{"VM deletion is on hold", machineutils.InitiateVMDeletion},
+				}),
+				Entry("should hold the deletion until confirmed by an operator with the ForceRemoveFinalizer policy", &data{
+					policy:              machineutils.DeletionAuthFailurePolicyForceRemoveFinalizer,
+					failingSince:        ptr.To(time.Now().Add(-time.Hour)),
+					expectedRetry:       machineutils.LongRetry,
+					expectedState:       v1alpha1.MachineStateFailed,
+					expectedDescription: []string{"VM deletion is on hold", machineutils.MachineForceFinalizerRemoval, machineutils.InitiateVMDeletion},
+				}),
+				Entry("should continue the deletion without deleting the VM once confirmed with the ForceRemoveFinalizer policy", &data{
+					policy:              machineutils.DeletionAuthFailurePolicyForceRemoveFinalizer,
+					failingSince:        ptr.To(time.Now().Add(-time.Hour)),
+					confirmed:           true,
+					expectedRetry:       machineutils.ShortRetry,
+					expectedState:       v1alpha1.MachineStateProcessing,
+					expectedDescription: []string{"without deleting the VM", machineutils.InitiateNodeDeletion},
+				}),
+				Entry("should retry the deletion while the failures don't persist beyond the timeout", &data{
+					policy:              machineutils.DeletionAuthFailurePolicyForceRemoveFinalizer,
+					failingSince:        ptr.To(time.Now().Add(-time.Minute)),
+					confirmed:           true,
+					expectedRetry:       machineutils.ShortRetry,
+					expectedState:       v1alpha1.MachineStateFailed,
+					expectedDescription: []string{"will re-try in the next resync", machineutils.InitiateVMDeletion},
+				}),
+			)
+		})
+
+		Context("when the VM deletion of a machine keeps failing", func() {
+			type data struct {
+				forceDeletion    bool
+				maxAttempts      int32
+				previousAttempts string
+				expectedAttempts string
+				abandoned        bool
+			}
+
+			DescribeTable("##table",
+				func(data *data) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					annotations := map[string]string{}
+					if data.previousAttempts != "" {
+						annotations[machineutils.MachineForceDeleteVMAttempts] = data.previousAttempts
+					}
+					labels := map[string]string{
+						v1alpha1.NodeLabelKey: "fakeID-0",
+					}
+					if data.forceDeletion {
+						labels["force-deletion"] = "true"
+					}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
 						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							annotations,
+							labels,
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
 						},
-						true,
-						metav1.Now(),
-					),
+					}
+					targetCoreObjects := []runtime.Object{
+						newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{}),
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", status.Error(codes.Unknown, "provider is stuck"), nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.MaxForceDeleteVMAttempts = data.maxAttempts
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					deleteMachineRequest := func() *driver.DeleteMachineRequest {
+						return &driver.DeleteMachineRequest{
+							Machine:      machine,
+							MachineClass: machineClass,
+							Secret:       &corev1.Secret{},
+						}
+					}
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), deleteMachineRequest())
+					Expect(err).To(HaveOccurred())
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					if data.expectedAttempts == "" {
+						Expect(machine.Annotations).ToNot(HaveKey(machineutils.MachineForceDeleteVMAttempts))
+					} else {
+						Expect(machine.Annotations).To(HaveKeyWithValue(machineutils.MachineForceDeleteVMAttempts, data.expectedAttempts))
+					}
+
+					if !data.abandoned {
+						Expect(machine.Status.LastOperation.State).To(Equal(v1alpha1.MachineStateFailed))
+						Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateVMDeletion))
+						Expect(machine.Finalizers).ToNot(BeEmpty())
+						return
+					}
+
+					Expect(machine.Status.LastOperation.State).To(Equal(v1alpha1.MachineStateProcessing))
+					Expect(machine.Status.LastOperation.Description).To(ContainSubstring("without confirmation of the VM deletion"))
+					Expect(machine.Status.LastOperation.Description).To(ContainSubstring("data may be leaked or lost"))
+					Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateNodeDeletion))
+
+					for i := 0; i < 5 && len(machine.Finalizers) > 0; i++ {
+						_, _ = controller.triggerDeletionFlow(context.TODO(), deleteMachineRequest())
+						machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+					}
+					Expect(machine.Finalizers).To(BeEmpty())
+					_, err = controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeID-0", metav1.GetOptions{})
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
 				},
-			}),
-			Entry("Delete VM successfully", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
+				Entry("should count the failed attempt and retry the VM deletion of a machine labeled force-deletion", &data{
+					forceDeletion:    true,
+					maxAttempts:      3,
+					expectedAttempts: "1",
+				}),
+				Entry("should delete the node and remove the finalizer of a machine labeled force-deletion once the attempts are exhausted", &data{
+					forceDeletion:    true,
+					maxAttempts:      3,
+					previousAttempts: "2",
+					expectedAttempts: "3",
+					abandoned:        true,
+				}),
+				Entry("should keep retrying the VM deletion of a machine not labeled force-deletion", &data{
+					maxAttempts:      3,
+					previousAttempts: "5",
+					expectedAttempts: "5",
+				}),
+				Entry("should keep retrying the VM deletion of a machine labeled force-deletion without max attempts", &data{
+					forceDeletion: true,
+				}),
+			)
+		})
+
+		Context("when the VM deletion fails as the provider is unreachable", func() {
+			type data struct {
+				policy              string
+				failingSince        *time.Time
+				confirmed           bool
+				expectedRetry       machineutils.RetryPeriod
+				expectedState       v1alpha1.MachineState
+				expectedDescription []string
+			}
+
+			DescribeTable("##table",
+				func(data *data) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					annotations := map[string]string{
+						machineutils.MachinePriority: "3",
+					}
+					if data.failingSince != nil {
+						annotations[machineutils.MachineDeletionProviderUnreachableSince] = data.failingSince.Format(time.RFC3339)
+					}
+					if data.confirmed {
+						annotations[machineutils.MachineForceFinalizerRemoval] = "true"
+					}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							annotations,
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-0",
+							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", status.Error(codes.Unavailable, "provider unreachable"), nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.MachineDeletionProviderUnreachableTimeout = metav1.Duration{Duration: 30 * time.Minute}
+					controller.safetyOptions.MachineDeletionProviderUnreachablePolicy = data.policy
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(HaveOccurred())
+					Expect(retry).To(Equal(data.expectedRetry))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Annotations).To(HaveKey(machineutils.MachineDeletionProviderUnreachableSince))
+					if data.failingSince != nil {
+						Expect(machine.Annotations[machineutils.MachineDeletionProviderUnreachableSince]).To(Equal(data.failingSince.Format(time.RFC3339)))
+					}
+					Expect(machine.Status.LastOperation.ErrorCode).To(Equal(codes.Unavailable.String()))
+					Expect(machine.Status.LastOperation.State).To(Equal(data.expectedState))
+					for _, description := range data.expectedDescription {
+						Expect(machine.Status.LastOperation.Description).To(ContainSubstring(description))
+					}
+				},
+				Entry("should record the start of the failures and retry on the first failure", &data{
+					policy:              machineutils.DeletionProviderUnreachablePolicyStall,
+					expectedRetry:       machineutils.ShortRetry,
+					expectedState:       v1alpha1.MachineStateFailed,
+					expectedDescription: []string{"will re-try in the next resync", machineutils.InitiateVMDeletion},
+				}),
+				Entry("should stall the deletion once the failures persist with the Stall policy", &data{
+					policy:              machineutils.DeletionProviderUnreachablePolicyStall,
+					failingSince:        ptr.To(time.Now().Add(-time.Hour)),
+					confirmed:           true,
+					expectedRetry:       machineutils.LongRetry,
+					expectedState:       v1alpha1.MachineStateDeletionStalled,
+					expectedDescription: []string{"VM deletion is stalled", machineutils.InitiateVMDeletion},
+				}),
+				Entry("should stall the deletion until confirmed by an operator with the ForceRemoveFinalizer policy", &data{
+					policy:              machineutils.DeletionProviderUnreachablePolicyForceRemoveFinalizer,
+					failingSince:        ptr.To(time.Now().Add(-time.Hour)),
+					expectedRetry:       machineutils.LongRetry,
+					expectedState:       v1alpha1.MachineStateDeletionStalled,
+					expectedDescription: []string{"VM deletion is stalled", machineutils.MachineForceFinalizerRemoval, machineutils.InitiateVMDeletion},
+				}),
+				Entry("should continue the deletion without deleting the VM once confirmed with the ForceRemoveFinalizer policy", &data{
+					policy:              machineutils.DeletionProviderUnreachablePolicyForceRemoveFinalizer,
+					failingSince:        ptr.To(time.Now().Add(-time.Hour)),
+					confirmed:           true,
+					expectedRetry:       machineutils.ShortRetry,
+					expectedState:       v1alpha1.MachineStateProcessing,
+					expectedDescription: []string{"without deleting the VM", machineutils.InitiateNodeDeletion},
+				}),
+				Entry("should retry the deletion while the failures don't persist beyond the timeout", &data{
+					policy:              machineutils.DeletionProviderUnreachablePolicyForceRemoveFinalizer,
+					failingSince:        ptr.To(time.Now().Add(-time.Minute)),
+					confirmed:           true,
+					expectedRetry:       machineutils.ShortRetry,
+					expectedState:       v1alpha1.MachineStateFailed,
+					expectedDescription: []string{"will re-try in the next resync", machineutils.InitiateVMDeletion},
+				}),
+			)
+		})
+
+		Context("when the VM deletion recovers from persistent failures", func() {
+			DescribeTable("##table",
+				func(deleteErr error, expectedAnnotations []string) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					failingSince := time.Now().Add(-time.Minute).Format(time.RFC3339)
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
-					machines: newMachines(
-						1,
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
 								},
-								ProviderID: "fakeID",
 							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+							nil,
+							map[string]string{
+								machineutils.MachinePriority:                         "3",
+								machineutils.MachineDeletionProviderUnreachableSince: failingSince,
+								machineutils.MachineDeletionAuthFailureSince:         failingSince,
+							},
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-0",
 							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", deleteErr, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.MachineDeletionProviderUnreachableTimeout = metav1.Duration{Duration: 30 * time.Minute}
+					controller.safetyOptions.MachineDeletionAuthFailureTimeout = metav1.Duration{Duration: 30 * time.Minute}
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(HaveOccurred())
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					for _, annotation := range []string{machineutils.MachineDeletionProviderUnreachableSince, machineutils.MachineDeletionAuthFailureSince} {
+						if slices.Contains(expectedAnnotations, annotation) {
+							Expect(machine.Annotations).To(HaveKeyWithValue(annotation, failingSince))
+						} else {
+							Expect(machine.Annotations).ToNot(HaveKey(annotation))
+						}
+					}
+				},
+				Entry("should clear the start of the failures once the VM is deleted", nil, nil),
+				Entry("should only keep the start of the failures as the provider is unreachable while it is unreachable", status.Error(codes.Unavailable, "provider unreachable"),
+					[]string{machineutils.MachineDeletionProviderUnreachableSince}),
+				Entry("should only keep the start of the authentication failures while the authentication fails", status.Error(codes.Unauthenticated, "invalid credentials"),
+					[]string{machineutils.MachineDeletionAuthFailureSince}),
+				Entry("should clear the start of the failures once the deletion fails for another cause", status.Error(codes.Internal, "quota exceeded"), nil),
+			)
+		})
+
+		Context("when the VM is deletion protected at the provider", func() {
+			DescribeTable("##table",
+				func(deleteErr error, previouslyProtected bool, expectedRetry machineutils.RetryPeriod, expectedConditionStatus corev1.ConditionStatus, expectedDescription string) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineStatus := &v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase:          v1alpha1.MachineTerminating,
+							LastUpdateTime: metav1.Now(),
 						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+						LastOperation: v1alpha1.LastOperation{
+							Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+							State:          v1alpha1.MachineStateProcessing,
+							Type:           v1alpha1.MachineOperationDelete,
+							LastUpdateTime: metav1.Now(),
 						},
-						true,
-						metav1.Now(),
-					),
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
-					},
-				},
-				expect: expect{
-					err:   fmt.Errorf("Machine deletion in process. VM deletion was successful. " + machineutils.InitiateNodeDeletion),
-					retry: machineutils.ShortRetry,
-					machine: newMachine(
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
-								},
-								ProviderID: "fakeID",
+					}
+					if previouslyProtected {
+						machineStatus.Conditions = []corev1.NodeCondition{
+							{
+								Type:   machineutils.MachineDeletionProtected,
+								Status: corev1.ConditionTrue,
+								Reason: machineutils.MachineDeletionProtectedReason,
 							},
+						}
+					}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
 						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("VM deletion was successful. %s", machineutils.InitiateNodeDeletion),
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+							machineStatus,
+							nil,
+							nil,
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-0",
 							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", deleteErr, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+					defer trackers.Stop()
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(HaveOccurred())
+					Expect(retry).To(Equal(expectedRetry))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.LastOperation.Description).To(ContainSubstring(expectedDescription))
+					condition := getMachineCondition(machine, machineutils.MachineDeletionProtected)
+					Expect(condition).ToNot(BeNil())
+					Expect(condition.Status).To(Equal(expectedConditionStatus))
+
+					if expectedConditionStatus == corev1.ConditionTrue {
+						Expect(machine.Status.LastOperation.ErrorCode).To(Equal(codes.DeletionProtected.String()))
+						Expect(machine.Status.LastOperation.State).To(Equal(v1alpha1.MachineStateFailed))
+						Expect(controller.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(machineutils.MachineDeletionProtectedReason)))
+					}
+				},
+				Entry("should set the DeletionProtected condition and hold the deletion", status.Error(codes.DeletionProtected, "deletion protection is enabled"), false,
+					machineutils.MediumRetry, corev1.ConditionTrue, "VM deletion is on hold as deletion protection is enabled"),
+				Entry("should keep holding the deletion while the protection is enabled", status.Error(codes.DeletionProtected, "deletion protection is enabled"), true,
+					machineutils.MediumRetry, corev1.ConditionTrue, machineutils.InitiateVMDeletion),
+				Entry("should continue the deletion and clear the condition once the protection is disabled", nil, true,
+					machineutils.ShortRetry, corev1.ConditionFalse, machineutils.InitiateNodeDeletion),
+			)
+		})
+
+		Context("when nodes share the providerID of the machine", func() {
+			conflict := fmt.Sprintf("Nodes [fakeID-0 fakeID-1] of machines [machine-0] share the providerID %q", "fakeID-0")
+
+			DescribeTable("##table",
+				func(sharedProviderID bool, previouslyHeld bool, expectedRetry machineutils.RetryPeriod, expectedConditionStatus corev1.ConditionStatus, expectEvent bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineStatus := &v1alpha1.MachineStatus{
+						CurrentStatus: v1alpha1.CurrentStatus{
+							Phase:          v1alpha1.MachineTerminating,
+							LastUpdateTime: metav1.Now(),
 						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+						LastOperation: v1alpha1.LastOperation{
+							Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
+							State:          v1alpha1.MachineStateProcessing,
+							Type:           v1alpha1.MachineOperationDelete,
+							LastUpdateTime: metav1.Now(),
 						},
-						true,
-						metav1.Now(),
-					),
-				},
-			}),
-			Entry("Delete node object successfully", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
+					}
+					if previouslyHeld {
+						machineStatus.Conditions = []corev1.NodeCondition{
+							{
+								Type:    machineutils.MachineProviderIDConflict,
+								Status:  corev1.ConditionTrue,
+								Reason:  machineutils.ProviderIDConflictReason,
+								Message: conflict,
+							},
+						}
+						machineStatus.LastOperation.Description = fmt.Sprintf("%s. VM deletion is on hold until the conflict is resolved. %s", conflict, machineutils.InitiateVMDeletion)
+						machineStatus.LastOperation.State = v1alpha1.MachineStateFailed
+					}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							machineStatus,
+							nil,
+							nil,
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeID-0",
+							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
-					machines: newMachines(
-						1,
+					}
+					otherProviderID := "fakeID-1"
+					if sharedProviderID {
+						otherProviderID = "fakeID-0"
+					}
+					targetCoreObjects := []runtime.Object{
+						&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "fakeID-0"}, Spec: corev1.NodeSpec{ProviderID: "fakeID-0"}},
+						&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "fakeID-1"}, Spec: corev1.NodeSpec{ProviderID: otherProviderID}},
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(HaveOccurred())
+					Expect(retry).To(Equal(expectedRetry))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					condition := getMachineCondition(machine, machineutils.MachineProviderIDConflict)
+					Expect(condition).ToNot(BeNil())
+					Expect(condition.Status).To(Equal(expectedConditionStatus))
+					if expectedConditionStatus == corev1.ConditionTrue {
+						Expect(condition.Message).To(Equal(conflict))
+						Expect(machine.Status.LastOperation.Description).To(HavePrefix(conflict))
+						Expect(machine.Status.LastOperation.State).To(Equal(v1alpha1.MachineStateFailed))
+					} else {
+						Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateNodeDeletion))
+					}
+					var events []string
+					for len(controller.recorder.(*record.FakeRecorder).Events) > 0 {
+						events = append(events, <-controller.recorder.(*record.FakeRecorder).Events)
+					}
+					if expectEvent {
+						Expect(events).To(ContainElement(ContainSubstring(machineutils.ProviderIDConflictReason)))
+					} else {
+						Expect(events).ToNot(ContainElement(ContainSubstring(machineutils.ProviderIDConflictReason)))
+					}
+				},
+				Entry("should set the ProviderIDConflict condition, hold the deletion and record an event", true, false,
+					machineutils.MediumRetry, corev1.ConditionTrue, true),
+				Entry("should keep holding the deletion without recording another event while the conflict persists", true, true,
+					machineutils.MediumRetry, corev1.ConditionTrue, false),
+				Entry("should continue the deletion and clear the condition once the conflict is resolved", false, true,
+					machineutils.ShortRetry, corev1.ConditionFalse, false),
+			)
+		})
+
+		Context("when the generation of the machine advanced since its deletion started", func() {
+			DescribeTable("##table",
+				func(generation, observedGeneration int64, expectDeferral bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machine := newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -3124,44 +7403,93 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("VM deletion was successful. %s", machineutils.InitiateNodeDeletion),
+								Description:    fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion),
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
+							ObservedGeneration: observedGeneration,
 						},
 						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
+						nil,
 						map[string]string{
 							v1alpha1.NodeLabelKey: "fakeID-0",
 						},
 						true,
 						metav1.Now(),
-					),
-					nodes: []*corev1.Node{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "fakeID-0",
-							},
+					)
+					// The generation is advanced by a concurrent update of the spec of the machine
+					machine.Generation = generation
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
+						machine,
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeID-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+					defer trackers.Stop()
+					waitForCacheSync(stop, controller)
+
+					deleteMachine := func() (machineutils.RetryPeriod, error) {
+						machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+
+						return controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+							Machine:      machine,
+							MachineClass: machineClass,
+							Secret:       secret,
+						})
+					}
+
+					retry, err := deleteMachine()
+					Expect(err).To(HaveOccurred())
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.ObservedGeneration).To(Equal(generation))
+					if !expectDeferral {
+						Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateNodeDeletion))
+						return
+					}
+					Expect(machine.Status.LastOperation.Description).To(ContainSubstring("deferring the step"))
+					Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateVMDeletion))
+
+					// The deferred step is performed once the latest generation was observed
+					_, err = deleteMachine()
+					Expect(err).To(HaveOccurred())
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.InitiateNodeDeletion))
 				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
+				Entry("should defer the VM deletion if the generation advanced", int64(2), int64(1), true),
+				Entry("should delete the VM if the generation didn't advance", int64(1), int64(1), false),
+			)
+		})
+
+		Context("when the machine's drain eviction policy is delete-only", func() {
+			It("should delete the pods on the node instead of evicting them", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
 					},
-				},
-				expect: expect{
-					err:         fmt.Errorf("Machine deletion in process. Deletion of node object was successful"),
-					retry:       machineutils.ShortRetry,
-					nodeDeleted: true,
-					machine: newMachine(
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -3178,7 +7506,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Deletion of Node Object %q is successful. %s", "fakeID-0", machineutils.InitiateFinalizerRemoval),
+								Description:    machineutils.InitiateDrain,
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -3186,31 +7514,107 @@ var _ = Describe("machine", func() {
 						},
 						nil,
 						map[string]string{
-							machineutils.MachinePriority: "3",
+							machineutils.MachinePriority:            "3",
+							machineutils.MachineDrainEvictionPolicy: machineutils.DrainEvictionPolicyDeleteOnly,
 						},
 						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+							v1alpha1.NodeLabelKey: "fakeNode-0",
 						},
 						true,
 						metav1.Now(),
 					),
-				},
-			}),
-			Entry("Delete machine finalizer successfully", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+					},
+				}
+				targetCoreObjects := []runtime.Object{
+					&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "fakeNode-0",
 						},
 					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
+				}
+				for i := 0; i < 2; i++ {
+					targetCoreObjects = append(targetCoreObjects, &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fmt.Sprintf("pod-%d", i),
+							Namespace: objMeta.Namespace,
+						},
+						Spec: corev1.PodSpec{
+							NodeName: "fakeNode-0",
 						},
+					})
+				}
+
+				fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				// announce support for eviction, so that pods would be evicted if not for the policy
+				fakeTargetCoreClient := controller.targetCoreClient.(*customfake.Clientset)
+				fakeTargetCoreClient.FakeDiscovery.Resources = []*metav1.APIResourceList{
+					{
+						GroupVersion: "policy/v1",
 					},
-					machines: newMachines(
-						1,
+					{
+						GroupVersion: "v1",
+						APIResources: []metav1.APIResource{
+							{
+								Name: drain.EvictionSubresource,
+								Kind: drain.EvictionKind,
+							},
+						},
+					},
+				}
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+				Expect(err).To(Equal(fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion)))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+
+				var evictedPods, deletedPods []string
+				for _, a := range fakeTargetCoreClient.Actions() {
+					switch {
+					case a.GetResource().Resource != "pods":
+					case a.GetVerb() == "create" && a.GetSubresource() == drain.EvictionSubresource:
+						evictedPods = append(evictedPods, a.(k8stesting.CreateAction).GetObject().(metav1.Object).GetName())
+					case a.GetVerb() == "delete":
+						deletedPods = append(deletedPods, a.(k8stesting.DeleteAction).GetName())
+					}
+				}
+				Expect(evictedPods).To(BeEmpty())
+				Expect(deletedPods).To(ConsistOf("pod-0", "pod-1"))
+
+				pods, err := controller.targetCoreClient.CoreV1().Pods(objMeta.Namespace).List(context.TODO(), metav1.ListOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pods.Items).To(BeEmpty())
+			})
+		})
+
+		Context("when an admission webhook denies the eviction of a pod", func() {
+			It("should set the DrainBlockedByWebhook condition naming the webhook", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
+					},
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -3227,7 +7631,7 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Deletion of Node Object %q is successful. %s", "fakeID-0", machineutils.InitiateFinalizerRemoval),
+								Description:    machineutils.InitiateDrain,
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
@@ -3238,73 +7642,498 @@ var _ = Describe("machine", func() {
 							machineutils.MachinePriority: "3",
 						},
 						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+							v1alpha1.NodeLabelKey: "fakeNode-0",
 						},
 						true,
 						metav1.Now(),
 					),
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+					},
+				}
+				targetCoreObjects := []runtime.Object{
+					&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "fakeNode-0",
+						},
+					},
+					&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "pod-0",
+							Namespace: objMeta.Namespace,
+						},
+						Spec: corev1.PodSpec{
+							NodeName: "fakeNode-0",
+						},
+					},
+				}
+
+				fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, controller)
+
+				fakeTargetCoreClient := controller.targetCoreClient.(*customfake.Clientset)
+				fakeTargetCoreClient.FakeDiscovery.Resources = []*metav1.APIResourceList{
+					{
+						GroupVersion: "policy/v1",
+					},
+					{
+						GroupVersion: "v1",
+						APIResources: []metav1.APIResource{
+							{
+								Name: drain.EvictionSubresource,
+								Kind: drain.EvictionKind,
+							},
+						},
 					},
+				}
+				fakeTargetCoreClient.PrependReactor("post", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+					if action.GetSubresource() != "eviction" {
+						return false, nil, nil
+					}
+					return true, nil, &apierrors.StatusError{ErrStatus: metav1.Status{
+						Status:  metav1.StatusFailure,
+						Code:    403,
+						Reason:  metav1.StatusReasonForbidden,
+						Message: `admission webhook "pod-guard.example.com" denied the request: pod-0 is protected`,
+					}}
+				})
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("pod-guard.example.com"))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+
+				machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Status.LastOperation.Description).To(HaveSuffix(machineutils.InitiateDrain))
+				cond := getMachineCondition(machine, machineutils.MachineDrainBlockedByWebhook)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+				Expect(cond.Reason).To(Equal(machineutils.MachineDrainBlockedByWebhookReason))
+				Expect(cond.Message).To(Equal(`Admission webhook "pod-guard.example.com" denied the eviction of a pod: pod-0 is protected`))
+			})
+		})
+
+		Context("when the node has a NoExecute taint of another controller", func() {
+			DescribeTable("##table",
+				func(policy string, tolerations []corev1.Toleration, nodeLabelTaints []options.NodeLabelTaint, expectedDescription string, expectDrained bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    machineutils.InitiateDrain,
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							map[string]string{
+								machineutils.MachinePriority: "3",
+							},
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeNode-0",
+							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+					targetCoreObjects := []runtime.Object{
+						&corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
+							},
+							Spec: corev1.NodeSpec{
+								Taints: []corev1.Taint{
+									{
+										Key:    "remediation.example.com/unhealthy",
+										Effect: corev1.TaintEffectNoExecute,
+									},
+								},
+							},
+						},
+						&corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:              "pod-0",
+								Namespace:         objMeta.Namespace,
+								DeletionTimestamp: ptr.To(metav1.Now()),
+							},
+							Spec: corev1.PodSpec{
+								NodeName:    "fakeNode-0",
+								Tolerations: tolerations,
+							},
+						},
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.NoExecuteTaintDrainPolicy = policy
+					controller.nodeLabelTaints = nodeLabelTaints
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(Equal(fmt.Errorf("%s", expectedDescription)))
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.LastOperation.Description).To(Equal(expectedDescription))
+
+					node, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeNode-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(node.Spec.Unschedulable).To(Equal(expectDrained))
+
+					var deletedPods []string
+					for _, a := range controller.targetCoreClient.(*customfake.Clientset).Actions() {
+						if a.GetResource().Resource == "pods" && a.GetVerb() == "delete" {
+							deletedPods = append(deletedPods, a.(k8stesting.DeleteAction).GetName())
+						}
+					}
+					if expectDrained {
+						Expect(deletedPods).To(ConsistOf("pod-0"))
+					} else {
+						Expect(deletedPods).To(BeEmpty())
+					}
 				},
-				expect: expect{
-					retry: machineutils.LongRetry,
-					machine: newMachine(
-						&v1alpha1.MachineTemplateSpec{
+				Entry("should hold the drain while the pods not tolerating the taint are evicted if the policy is wait",
+					machineutils.NoExecuteTaintDrainPolicyWait, nil, nil,
+					fmt.Sprintf("%s %q, 1 pod(s) remaining. Will retry in next sync. %s", machineutils.NoExecuteTaintEvictionInProgress, "remediation.example.com/unhealthy", machineutils.InitiateDrain), false),
+				Entry("should drain the node if the pods tolerate the taint",
+					machineutils.NoExecuteTaintDrainPolicyWait, []corev1.Toleration{{Key: "remediation.example.com/unhealthy", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute}}, nil,
+					fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), true),
+				Entry("should drain the node regardless of the taint if the policy is drain",
+					machineutils.NoExecuteTaintDrainPolicyDrain, nil, nil,
+					fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), true),
+				Entry("should drain the node if the taint is one of the node label taints",
+					machineutils.NoExecuteTaintDrainPolicyWait, nil, []options.NodeLabelTaint{{Selector: "pool=gpu", Taint: corev1.Taint{Key: "remediation.example.com/unhealthy", Effect: corev1.TaintEffectNoExecute}}},
+					fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), true),
+			)
+		})
+
+		Context("when pre-drain hooks are registered", func() {
+			DescribeTable("##table",
+				func(hookErr error, forceDeletion bool, expectedDescription string, expectedState v1alpha1.MachineState, expectedCalls []string, expectDrained bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					labels := map[string]string{
+						v1alpha1.NodeLabelKey: "fakeNode-0",
+					}
+					if forceDeletion {
+						labels["force-deletion"] = "True"
+					}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    machineutils.InitiateDrain,
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							map[string]string{
+								machineutils.MachinePriority: "3",
+							},
+							labels,
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+					targetCoreObjects := []runtime.Object{
+						&corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
+							},
+						},
+						&corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:              "pod-0",
+								Namespace:         objMeta.Namespace,
+								DeletionTimestamp: ptr.To(metav1.Now()),
+							},
+							Spec: corev1.PodSpec{
+								NodeName: "fakeNode-0",
+							},
+						},
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					var calls []string
+					controller.preDrainHooks = []drain.PreDrainHook{
+						&fakePreDrainHook{name: "quiesce", err: hookErr, calls: &calls},
+						&fakePreDrainHook{name: "flush", calls: &calls},
+					}
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       &corev1.Secret{},
+					})
+					Expect(err).To(Equal(fmt.Errorf("%s", expectedDescription)))
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+					Expect(calls).To(Equal(expectedCalls))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.LastOperation.Description).To(Equal(expectedDescription))
+					Expect(machine.Status.LastOperation.State).To(Equal(expectedState))
+
+					node, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeNode-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(node.Spec.Unschedulable).To(Equal(expectDrained))
+				},
+				Entry("should run the hooks in order before draining the node", nil, false,
+					fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), v1alpha1.MachineStateProcessing,
+					[]string{"quiesce/fakeNode-0", "flush/fakeNode-0"}, true),
+				Entry("should hold the drain and surface the error if a hook fails", errors.New("application is still busy"), false,
+					fmt.Sprintf("%s - pre-drain hook 1 of 2 failed: application is still busy. Will retry in next sync. %s", machineutils.PreDrainHookFailed, machineutils.InitiateDrain), v1alpha1.MachineStateProcessing,
+					[]string{"quiesce/fakeNode-0"}, false),
+				Entry("should drain the node of a force deleted machine even if a hook fails", errors.New("application is still busy"), true,
+					fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments), v1alpha1.MachineStateProcessing,
+					[]string{"quiesce/fakeNode-0"}, true),
+			)
+		})
+
+		Context("when the node only runs DaemonSet pods", func() {
+			DescribeTable("##table",
+				func(fastPath bool, skipPodSelector string, expectedDescription string, expectCordoned bool, expectedPods int) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
+							ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{
+								GenerateName: objMeta.GenerateName,
+								Namespace:    objMeta.Namespace,
+								Annotations:  map[string]string{machineutils.MachineClassDrainSkipPodSelector: skipPodSelector},
+							}, 0),
+							SecretRef: newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
+								},
+							},
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    machineutils.InitiateDrain,
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
+							},
+							nil,
+							map[string]string{
+								machineutils.MachinePriority: "3",
+							},
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeNode-0",
+							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+					targetCoreObjects := []runtime.Object{
+						&corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
+							},
+						},
+					}
+					for i := 0; i < 2; i++ {
+						targetCoreObjects = append(targetCoreObjects, &corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      fmt.Sprintf("daemon-pod-%d", i),
+								Namespace: objMeta.Namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									{
+										APIVersion: "apps/v1",
+										Kind:       "DaemonSet",
+										Name:       fmt.Sprintf("daemon-%d", i),
+										Controller: ptr.To(true),
+									},
 								},
-								ProviderID: "fakeID",
 							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
+							Spec: corev1.PodSpec{
+								NodeName: "fakeNode-0",
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Deletion of Node Object %q is successful. %s", "fakeID-0", machineutils.InitiateFinalizerRemoval),
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+						})
+					}
+
+					// a pod of a Deployment, evicted by the drain unless it's matched by the skip pod selector
+					targetCoreObjects = append(targetCoreObjects, &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "agent-pod",
+							Namespace: objMeta.Namespace,
+							Labels:    map[string]string{"app": "agent"},
+							OwnerReferences: []metav1.OwnerReference{
+								{
+									APIVersion: "apps/v1",
+									Kind:       "ReplicaSet",
+									Name:       "agent",
+									Controller: ptr.To(true),
+								},
 							},
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+						Spec: corev1.PodSpec{
+							NodeName: "fakeNode-0",
 						},
-						false,
-						metav1.Now(),
-					),
+					})
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.DrainFastPathForNodesWithoutEvictablePods = fastPath
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(Equal(fmt.Errorf("%s", expectedDescription)))
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.LastOperation.Description).To(Equal(expectedDescription))
+
+					node, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeNode-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(node.Spec.Unschedulable).To(Equal(expectCordoned))
+
+					pods, err := controller.targetCoreClient.CoreV1().Pods(objMeta.Namespace).List(context.TODO(), metav1.ListOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(pods.Items).To(HaveLen(expectedPods))
 				},
-			}),
-			Entry("Unable to decode deletion flow state for machine", &data{
-				setup: setup{
-					secrets: []*corev1.Secret{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-						},
-					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							SecretRef:  newSecretReference(objMeta, 0),
-						},
+				Entry("should cordon the node, skip the eviction and proceed to the VM deletion right away if the fast path is enabled",
+					true, "app=agent", fmt.Sprintf("%s. %s", machineutils.DrainSkippedNoEvictablePods, machineutils.InitiateVMDeletion), true, 3),
+				Entry("should drain the node if the fast path is enabled but a pod isn't skipped by the drain",
+					true, "", fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), true, 2),
+				Entry("should drain the node if the fast path is disabled",
+					false, "app=agent", fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), true, 3),
+			)
+		})
+
+		Context("when the drain progress updates are enabled", func() {
+			It("should report the progress of a multi-pod drain in the machine status", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineObjects := []runtime.Object{
+					&v1alpha1.MachineClass{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
+						SecretRef:  newSecretReference(objMeta, 0),
 					},
-					machines: newMachines(
-						1,
+					newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -3321,8 +8150,8 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    "Some random last op description",
-								State:          v1alpha1.MachineStateFailed,
+								Description:    machineutils.InitiateDrain,
+								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
@@ -3332,166 +8161,452 @@ var _ = Describe("machine", func() {
 							machineutils.MachinePriority: "3",
 						},
 						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+							v1alpha1.NodeLabelKey: "fakeNode-0",
 						},
 						true,
 						metav1.Now(),
 					),
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
+				}
+				controlCoreObjects := []runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: *newObjectMeta(objMeta, 0),
 					},
-				},
-				expect: expect{
-					err:   fmt.Errorf("Machine deletion in process. Phase set to termination"),
-					retry: machineutils.ShortRetry,
-					machine: newMachine(
-						&v1alpha1.MachineTemplateSpec{
+				}
+				targetCoreObjects := []runtime.Object{
+					&corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "fakeNode-0",
+						},
+					},
+				}
+				for i := 0; i < 3; i++ {
+					targetCoreObjects = append(targetCoreObjects, &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      fmt.Sprintf("pod-%d", i),
+							Namespace: objMeta.Namespace,
+						},
+						Spec: corev1.PodSpec{
+							NodeName: "fakeNode-0",
+						},
+					})
+				}
+
+				fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+				controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+				defer trackers.Stop()
+				controller.safetyOptions.MachineDrainProgressUpdateInterval = metav1.Duration{Duration: 20 * time.Millisecond}
+				waitForCacheSync(stop, controller)
+
+				// slow down the deletion of the pods, so that the drain lasts several progress update intervals
+				fakeTargetCoreClient := controller.targetCoreClient.(*customfake.Clientset)
+				fakeTargetCoreClient.PrependReactor("delete", "pods", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+					time.Sleep(100 * time.Millisecond)
+					return false, nil, nil
+				})
+
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+				Expect(err).To(Equal(fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion)))
+				Expect(retry).To(Equal(machineutils.ShortRetry))
+
+				var progressDescriptions []string
+				for _, a := range controller.controlMachineClient.(*fakemachineapi.FakeMachineV1alpha1).Actions() {
+					if a.GetVerb() != "update" || a.GetSubresource() != "status" {
+						continue
+					}
+					description := a.(k8stesting.UpdateAction).GetObject().(*v1alpha1.Machine).Status.LastOperation.Description
+					if strings.HasPrefix(description, "Drain in progress") {
+						progressDescriptions = append(progressDescriptions, description)
+					}
+				}
+				Expect(len(progressDescriptions)).To(BeNumerically(">=", 2))
+				Expect(progressDescriptions[0]).To(ContainSubstring("pod(s) remaining after"))
+				Expect(progressDescriptions[0]).To(ContainSubstring(machineutils.InitiateDrain))
+
+				machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Status.LastOperation.Description).To(Equal(fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion)))
+			})
+		})
+
+		Context("when the APIServer of the target cluster is unreachable", func() {
+			DescribeTable("##table",
+				func(retryPeriod time.Duration, expectHold bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
 								},
-								ProviderID: "fakeID",
 							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    machineutils.InitiateDrain,
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.GetVMStatus,
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+							nil,
+							map[string]string{
+								machineutils.MachinePriority: "3",
+							},
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeNode-0",
 							},
+							true,
+							// the drain timeout elapsed, which would force the drain
+							metav1.NewTime(time.Now().Add(-time.Hour)),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
+					}
+					targetCoreObjects := []runtime.Object{
+						&corev1.Node{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fakeNode-0",
+							},
 						},
-						map[string]string{
-							v1alpha1.NodeLabelKey: "fakeID-0",
+						&corev1.Pod{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "pod-0",
+								Namespace: objMeta.Namespace,
+							},
+							Spec: corev1.PodSpec{
+								NodeName: "fakeNode-0",
+							},
 						},
-						true,
-						metav1.Now(),
-					),
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.TargetClusterUnreachableRetryPeriod = metav1.Duration{Duration: retryPeriod}
+					waitForCacheSync(stop, controller)
+
+					fakeTargetCoreClient := controller.targetCoreClient.(*customfake.Clientset)
+					fakeTargetCoreClient.PrependReactor("*", "nodes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+						return true, nil, apierrors.NewServiceUnavailable("target cluster is down")
+					})
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(HaveOccurred())
+
+					machine, getErr := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(getErr).ToNot(HaveOccurred())
+					Expect(machine.Status.CurrentStatus.Phase).To(Equal(v1alpha1.MachineTerminating))
+					if expectHold {
+						Expect(retry).To(Equal(machineutils.RetryPeriod(retryPeriod)))
+						Expect(machine.Status.LastOperation.State).To(Equal(v1alpha1.MachineStateProcessing))
+						Expect(machine.Status.LastOperation.Description).To(Equal(fmt.Sprintf("%s. Will retry in next sync. %s", machineutils.TargetClusterUnreachable, machineutils.InitiateDrain)))
+
+						for _, a := range fakeTargetCoreClient.Actions() {
+							if a.GetResource().Resource == "pods" {
+								Expect(a.GetVerb()).ToNot(BeElementOf("create", "delete"), "no pod should be drained while the target cluster is unreachable")
+							}
+						}
+					} else {
+						// the drain is forced as its timeout elapsed
+						Expect(machine.Status.LastOperation.Description).To(ContainSubstring(machineutils.DelVolumesAttachments))
+					}
 				},
-			}),
-			Entry("without target cluster: checking existence of VM, then jump to VM deletion", &data{
-				setup: setup{
-					noTargetCluster: true,
-					secrets: []*corev1.Secret{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-						},
-					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
+				Entry("should hold the drain instead of forcing it", 30*time.Second, true),
+				Entry("should force the drain if the hold is disabled", time.Duration(0), false),
+			)
+		})
+
+		Context("when the node termination condition is set before the drain", func() {
+			DescribeTable("##table",
+				func(conditionPresent bool, propagationRetry time.Duration, expectConditionUpdate bool, expectedDescription string, expectedRetry machineutils.RetryPeriod) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
-					machines: newMachines(
-						1,
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
 								},
-								ProviderID: "fakeID",
 							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    machineutils.InitiateDrain,
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.GetVMStatus,
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+							nil,
+							nil,
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeNode-0",
 							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
+					}
+					node := &corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "fakeNode-0",
 						},
-						map[string]string{}, // no node label
-						true,
-						metav1.Now(),
-					),
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
-					},
+					}
+					if conditionPresent {
+						node.Status.Conditions = []corev1.NodeCondition{
+							{
+								Type:   machineutils.NodeTerminationCondition,
+								Status: corev1.ConditionTrue,
+								Reason: machineutils.NodeScaledDown,
+							},
+						}
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, []runtime.Object{node}, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.NodeTerminationConditionPropagationRetry = metav1.Duration{Duration: propagationRetry}
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(Equal(fmt.Errorf("%s", expectedDescription)))
+					Expect(retry).To(Equal(expectedRetry))
+
+					conditionUpdates := 0
+					for _, a := range controller.targetCoreClient.(*customfake.Clientset).Actions() {
+						if a.GetResource().Resource == "nodes" && a.GetVerb() == "update" && a.GetSubresource() == "status" {
+							conditionUpdates++
+						}
+					}
+					if expectConditionUpdate {
+						Expect(conditionUpdates).To(Equal(1))
+					} else {
+						Expect(conditionUpdates).To(BeZero())
+					}
+
+					updatedNode, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeNode-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(updatedNode.Status.Conditions).To(HaveLen(1))
+					Expect(updatedNode.Status.Conditions[0].Type).To(Equal(corev1.NodeConditionType(machineutils.NodeTerminationCondition)))
+					Expect(updatedNode.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
+
+					machine, err = controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(machine.Status.LastOperation.Description).To(Equal(expectedDescription))
 				},
-				expect: expect{
-					retry: machineutils.ShortRetry,
-					machine: newMachine(
-						&v1alpha1.MachineTemplateSpec{
+				Entry("should not re-apply the condition already present in the cache and drain the node", true, time.Duration(0), false,
+					fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), machineutils.ShortRetry),
+				Entry("should not wait for the propagation of the condition already present in the cache", true, 2*time.Second, false,
+					fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), machineutils.ShortRetry),
+				Entry("should set the condition and drain the node right away if the propagation retry is disabled", false, time.Duration(0), true,
+					fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion), machineutils.ShortRetry),
+				Entry("should set the condition and requeue for its propagation before the drain", false, 2*time.Second, true,
+					fmt.Sprintf("%s. %s", machineutils.NodeTerminationConditionSet, machineutils.InitiateDrain), machineutils.RetryPeriod(2*time.Second)),
+			)
+		})
+
+		Context("when the timing of the node termination condition is configured", func() {
+			DescribeTable("##table",
+				func(timing string, propagationRetry time.Duration, expectConditionBeforeCordon bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+							SecretRef:  newSecretReference(objMeta, 0),
+						},
+						newMachine(
+							&v1alpha1.MachineTemplateSpec{
+								ObjectMeta: *newObjectMeta(objMeta, 0),
+								Spec: v1alpha1.MachineSpec{
+									Class: v1alpha1.ClassSpec{
+										Kind: "MachineClass",
+										Name: "machine-0",
+									},
+									ProviderID: "fakeID",
 								},
-								ProviderID: "fakeID",
 							},
-						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
+							&v1alpha1.MachineStatus{
+								CurrentStatus: v1alpha1.CurrentStatus{
+									Phase:          v1alpha1.MachineTerminating,
+									LastUpdateTime: metav1.Now(),
+								},
+								LastOperation: v1alpha1.LastOperation{
+									Description:    machineutils.InitiateDrain,
+									State:          v1alpha1.MachineStateProcessing,
+									Type:           v1alpha1.MachineOperationDelete,
+									LastUpdateTime: metav1.Now(),
+								},
 							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    "Running without target cluster, skipping node drain and volume attachment deletion. " + machineutils.InitiateVMDeletion,
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+							nil,
+							nil,
+							map[string]string{
+								v1alpha1.NodeLabelKey: "fakeNode-0",
 							},
+							true,
+							metav1.Now(),
+						),
+					}
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
+					}
+					node := &corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "fakeNode-0",
 						},
-						map[string]string{}, // no node label
-						true,
-						metav1.Now(),
-					),
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, []runtime.Object{node}, fakeDriver, false)
+					defer trackers.Stop()
+					controller.safetyOptions.NodeTerminationConditionTiming = timing
+					controller.safetyOptions.NodeTerminationConditionPropagationRetry = metav1.Duration{Duration: propagationRetry}
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(Equal(fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion)))
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					// the cordon is the first step of the drain, hence the order of the node updates reflects the timing of the condition
+					var nodeUpdates []string
+					for _, a := range controller.targetCoreClient.(*customfake.Clientset).Actions() {
+						if a.GetResource().Resource == "nodes" && a.GetVerb() == "update" {
+							if a.GetSubresource() == "status" {
+								nodeUpdates = append(nodeUpdates, "condition")
+							} else {
+								nodeUpdates = append(nodeUpdates, "cordon")
+							}
+						}
+					}
+					if expectConditionBeforeCordon {
+						Expect(nodeUpdates).To(Equal([]string{"condition", "cordon"}))
+					} else {
+						Expect(nodeUpdates).To(Equal([]string{"cordon", "condition"}))
+					}
+
+					updatedNode, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeNode-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+					Expect(updatedNode.Status.Conditions).To(HaveLen(1))
+					Expect(updatedNode.Status.Conditions[0].Type).To(Equal(corev1.NodeConditionType(machineutils.NodeTerminationCondition)))
+					Expect(updatedNode.Status.Conditions[0].Status).To(Equal(corev1.ConditionTrue))
 				},
-			}),
-			Entry("without target cluster: Delete node object successfully", &data{
-				setup: setup{
-					noTargetCluster: true,
-					secrets: []*corev1.Secret{
-						{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
+				Entry("should set the condition before the drain by default", "", time.Duration(0), true),
+				Entry("should set the condition before the drain", machineutils.NodeTerminationConditionTimingBeforeDrain, time.Duration(0), true),
+				Entry("should set the condition only after the drain", machineutils.NodeTerminationConditionTimingAfterDrain, time.Duration(0), false),
+				Entry("should not wait for the propagation of the condition set after the drain", machineutils.NodeTerminationConditionTimingAfterDrain, 2*time.Second, false),
+			)
+		})
+
+		Context("when the machine is the sole member of its pool", func() {
+			DescribeTable("##table",
+				func(machineDeployment *v1alpha1.MachineDeployment, machineSetReplicas int32, expectCordon bool) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machineSet := &v1alpha1.MachineSet{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "machineset-0",
+							Namespace: objMeta.Namespace,
 						},
-					},
-					machineClasses: []*v1alpha1.MachineClass{
-						{
+						Spec: v1alpha1.MachineSetSpec{
+							Replicas: machineSetReplicas,
+						},
+					}
+					machineObjects := []runtime.Object{
+						&v1alpha1.MachineClass{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							SecretRef:  newSecretReference(objMeta, 0),
 						},
-					},
-					machines: newMachines(
-						1,
+					}
+					if machineDeployment != nil {
+						machineDeployment.Name = "machinedeployment-0"
+						machineDeployment.Namespace = objMeta.Namespace
+						machineSet.OwnerReferences = []metav1.OwnerReference{{Kind: "MachineDeployment", Name: machineDeployment.Name, Controller: ptr.To(true)}}
+						machineObjects = append(machineObjects, machineDeployment)
+					}
+					machineObjects = append(machineObjects, machineSet, newMachine(
 						&v1alpha1.MachineTemplateSpec{
 							ObjectMeta: *newObjectMeta(objMeta, 0),
 							Spec: v1alpha1.MachineSpec{
@@ -3508,75 +8623,239 @@ var _ = Describe("machine", func() {
 								LastUpdateTime: metav1.Now(),
 							},
 							LastOperation: v1alpha1.LastOperation{
-								Description:    machineutils.InitiateNodeDeletion,
+								Description:    machineutils.InitiateDrain,
 								State:          v1alpha1.MachineStateProcessing,
 								Type:           v1alpha1.MachineOperationDelete,
 								LastUpdateTime: metav1.Now(),
 							},
 						},
-						nil,
+						&metav1.OwnerReference{Kind: "MachineSet", Name: machineSet.Name, Controller: ptr.To(true)},
 						map[string]string{
 							machineutils.MachinePriority: "3",
 						},
-						map[string]string{}, // no node label
+						map[string]string{
+							v1alpha1.NodeLabelKey: "fakeNode-0",
+						},
 						true,
 						metav1.Now(),
-					),
-					nodes: []*corev1.Node{
-						{
+					))
+					controlCoreObjects := []runtime.Object{
+						&corev1.Secret{
+							ObjectMeta: *newObjectMeta(objMeta, 0),
+						},
+					}
+					targetCoreObjects := []runtime.Object{
+						&corev1.Node{
 							ObjectMeta: metav1.ObjectMeta{
-								Name: "fakeID-0",
+								Name: "fakeNode-0",
 							},
 						},
-					},
-				},
-				action: action{
-					machine: "machine-0",
-					fakeDriver: &driver.FakeDriver{
-						VMExists:   true,
-						ProviderID: "fakeID-0",
-						NodeName:   "fakeNode-0",
-						Err:        nil,
-					},
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode-0", "", nil, nil)
+					controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					waitForCacheSync(stop, controller)
+
+					machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+
+					retry, err := controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+						Machine:      machine,
+						MachineClass: machineClass,
+						Secret:       secret,
+					})
+					Expect(err).To(Equal(fmt.Errorf("Drain successful. %s", machineutils.InitiateVMDeletion)))
+					Expect(retry).To(Equal(machineutils.ShortRetry))
+
+					node, err := controller.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "fakeNode-0", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(node.Spec.Unschedulable).To(Equal(expectCordon))
 				},
-				expect: expect{
-					err:         fmt.Errorf("Machine deletion in process. No node object found"),
-					retry:       machineutils.ShortRetry,
-					nodeDeleted: false,
-					machine: newMachine(
-						&v1alpha1.MachineTemplateSpec{
-							ObjectMeta: *newObjectMeta(objMeta, 0),
-							Spec: v1alpha1.MachineSpec{
-								Class: v1alpha1.ClassSpec{
-									Kind: "MachineClass",
-									Name: "machine-0",
+				Entry("should skip the cordon if the MachineDeployment has a single replica and no surge", &v1alpha1.MachineDeployment{
+					Spec: v1alpha1.MachineDeploymentSpec{
+						Replicas: 1,
+						Strategy: v1alpha1.MachineDeploymentStrategy{
+							Type: v1alpha1.RollingUpdateMachineDeploymentStrategyType,
+							RollingUpdate: &v1alpha1.RollingUpdateMachineDeployment{
+								UpdateConfiguration: v1alpha1.UpdateConfiguration{
+									MaxSurge:       ptr.To(intstr.FromInt32(0)),
+									MaxUnavailable: ptr.To(intstr.FromInt32(1)),
 								},
-								ProviderID: "fakeID",
 							},
 						},
-						&v1alpha1.MachineStatus{
-							CurrentStatus: v1alpha1.CurrentStatus{
-								Phase:          v1alpha1.MachineTerminating,
-								LastUpdateTime: metav1.Now(),
-							},
-							LastOperation: v1alpha1.LastOperation{
-								Description:    fmt.Sprintf("Label %q not present on machine %q or no associated node object found, continuing deletion flow. %s", v1alpha1.NodeLabelKey, "machine-0", machineutils.InitiateFinalizerRemoval),
-								State:          v1alpha1.MachineStateProcessing,
-								Type:           v1alpha1.MachineOperationDelete,
-								LastUpdateTime: metav1.Now(),
+					},
+				}, int32(1), false),
+				Entry("should cordon the node if the MachineDeployment allows a surge", &v1alpha1.MachineDeployment{
+					Spec: v1alpha1.MachineDeploymentSpec{
+						Replicas: 1,
+						Strategy: v1alpha1.MachineDeploymentStrategy{
+							Type: v1alpha1.RollingUpdateMachineDeploymentStrategyType,
+							RollingUpdate: &v1alpha1.RollingUpdateMachineDeployment{
+								UpdateConfiguration: v1alpha1.UpdateConfiguration{
+									MaxSurge:       ptr.To(intstr.FromInt32(1)),
+									MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+								},
 							},
 						},
-						nil,
-						map[string]string{
-							machineutils.MachinePriority: "3",
-						},
-						map[string]string{}, // no node label
-						true,
-						metav1.Now(),
-					),
+					},
+				}, int32(1), true),
+				Entry("should cordon the node if the MachineDeployment has several replicas", &v1alpha1.MachineDeployment{
+					Spec: v1alpha1.MachineDeploymentSpec{
+						Replicas: 3,
+					},
+				}, int32(3), true),
+				Entry("should skip the cordon if the machine is owned by a single replica MachineSet without MachineDeployment", nil, int32(1), false),
+			)
+		})
+	})
+
+	Describe("#recordFlowStep", func() {
+		objMeta := &metav1.ObjectMeta{
+			GenerateName: "machine",
+			Namespace:    "test",
+		}
+
+		It("should accumulate the creation and deletion flow steps in the flow history annotation", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			machineObjects := []runtime.Object{
+				&v1alpha1.MachineClass{
+					ObjectMeta: *newObjectMeta(objMeta, 0),
+					SecretRef:  newSecretReference(objMeta, 0),
 				},
-			}),
-		)
+			}
+			machineObjects = append(machineObjects, newMachines(1, &v1alpha1.MachineTemplateSpec{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+				Spec: v1alpha1.MachineSpec{
+					Class: v1alpha1.ClassSpec{
+						Kind: "MachineClass",
+						Name: "machine-0",
+					},
+				},
+			}, nil, nil, map[string]string{machineutils.MachineFlowHistoryEnabled: "true"}, nil, true, metav1.Now())[0])
+			controlCoreObjects := []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: *newObjectMeta(objMeta, 0),
+					Data:       map[string][]byte{"userData": []byte("test")},
+				},
+			}
+
+			fakeDriver := driver.NewFakeDriver(false, "fakeID-0", "fakeNode-0", "", nil, nil)
+			controller, trackers := createController(stop, objMeta.Namespace, machineObjects, controlCoreObjects, nil, fakeDriver, false)
+			defer trackers.Stop()
+			waitForCacheSync(stop, controller)
+
+			getRequestObjects := func() (*v1alpha1.Machine, *v1alpha1.MachineClass, *corev1.Secret) {
+				machine, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), "machine-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				machineClass, err := controller.controlMachineClient.MachineClasses(objMeta.Namespace).Get(context.TODO(), machine.Spec.Class.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				secret, err := controller.controlCoreClient.CoreV1().Secrets(objMeta.Namespace).Get(context.TODO(), machineClass.SecretRef.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				return machine, machineClass, secret
+			}
+
+			// the first pass creates the VM, the second pass moves the machine to Pending
+			for range 2 {
+				machine, machineClass, secret := getRequestObjects()
+				_, _ = controller.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+			}
+
+			// walk through the deletion flow until the finalizer removal is initiated
+			for range 8 {
+				machine, machineClass, secret := getRequestObjects()
+				if strings.Contains(machine.Status.LastOperation.Description, machineutils.InitiateFinalizerRemoval) {
+					break
+				}
+				_, _ = controller.triggerDeletionFlow(context.TODO(), &driver.DeleteMachineRequest{
+					Machine:      machine,
+					MachineClass: machineClass,
+					Secret:       secret,
+				})
+			}
+
+			machine, _, _ := getRequestObjects()
+			var history []flowStep
+			Expect(json.Unmarshal([]byte(machine.Annotations[machineutils.MachineFlowHistory]), &history)).To(Succeed())
+			Expect(len(history)).To(BeNumerically("<=", machineutils.MaxMachineFlowHistoryEntries))
+
+			steps := make([]string, 0, len(history))
+			for _, entry := range history {
+				Expect(entry.Time.IsZero()).To(BeFalse())
+				steps = append(steps, entry.Step)
+			}
+			Expect(steps[0]).To(Equal("Creating machine on cloud provider"))
+			Expect(steps[1]).To(Equal(machineutils.GetVMStatus))
+			Expect(steps[2]).To(ContainSubstring(machineutils.InitiateDrain))
+			Expect(steps[len(steps)-1]).To(ContainSubstring(machineutils.InitiateFinalizerRemoval))
+		})
+
+		It("should retain only the most recent flow steps", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			machine := newMachine(&v1alpha1.MachineTemplateSpec{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+			}, nil, nil, map[string]string{machineutils.MachineFlowHistoryEnabled: "true"}, nil, true, metav1.Now())
+			controller, trackers := createController(stop, objMeta.Namespace, []runtime.Object{machine}, nil, nil, nil, false)
+			defer trackers.Stop()
+			waitForCacheSync(stop, controller)
+
+			for i := range machineutils.MaxMachineFlowHistoryEntries + 5 {
+				current, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				controller.recordFlowStep(context.TODO(), current, fmt.Sprintf("step-%d", i))
+			}
+
+			actual, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			var history []flowStep
+			Expect(json.Unmarshal([]byte(actual.Annotations[machineutils.MachineFlowHistory]), &history)).To(Succeed())
+			Expect(history).To(HaveLen(machineutils.MaxMachineFlowHistoryEntries))
+			Expect(history[0].Step).To(Equal("step-5"))
+			Expect(history[len(history)-1].Step).To(Equal(fmt.Sprintf("step-%d", machineutils.MaxMachineFlowHistoryEntries+4)))
+		})
+
+		It("should not update the machine for a repeated flow step", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			machine := newMachine(&v1alpha1.MachineTemplateSpec{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+			}, nil, nil, map[string]string{machineutils.MachineFlowHistoryEnabled: "true"}, nil, true, metav1.Now())
+			controller, trackers := createController(stop, objMeta.Namespace, []runtime.Object{machine}, nil, nil, nil, false)
+			defer trackers.Stop()
+			waitForCacheSync(stop, controller)
+
+			updates := 0
+			controller.controlMachineClient.(*fakemachineapi.FakeMachineV1alpha1).PrependReactor("update", "machines", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+				updates++
+				return false, nil, nil
+			})
+
+			for range 3 {
+				current, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				controller.recordFlowStep(context.TODO(), current, "step")
+			}
+			Expect(updates).To(Equal(1))
+
+			actual, err := controller.controlMachineClient.Machines(objMeta.Namespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			var history []flowStep
+			Expect(json.Unmarshal([]byte(actual.Annotations[machineutils.MachineFlowHistory]), &history)).To(Succeed())
+			Expect(history).To(HaveLen(1))
+		})
 	})
 
 	/*
@@ -4046,3 +9325,146 @@ var _ = Describe("machine", func() {
 		})
 	*/
 })
+
+// nodeTrackingDriver records whether the node object was still present when DeleteMachine was called
+type nodeTrackingDriver struct {
+	driver.Driver
+	nodeClient                 kubernetes.Interface
+	nodeName                   string
+	deleteMachineCalled        bool
+	nodePresentOnDeleteMachine bool
+}
+
+func (d *nodeTrackingDriver) DeleteMachine(ctx context.Context, req *driver.DeleteMachineRequest) (*driver.DeleteMachineResponse, error) {
+	d.deleteMachineCalled = true
+	_, err := d.nodeClient.CoreV1().Nodes().Get(ctx, d.nodeName, metav1.GetOptions{})
+	d.nodePresentOnDeleteMachine = err == nil
+	return d.Driver.DeleteMachine(ctx, req)
+}
+
+// concurrentProviderOperationsDriver tracks the number of concurrent create and delete operations of the provider
+type concurrentProviderOperationsDriver struct {
+	*driver.FakeDriver
+	delay time.Duration
+
+	lock        sync.Mutex
+	inFlight    int
+	maxInFlight int
+	creations   int
+	deletions   int
+}
+
+func (d *concurrentProviderOperationsDriver) track(counter *int) func() {
+	d.lock.Lock()
+	*counter++
+	d.inFlight++
+	d.maxInFlight = max(d.maxInFlight, d.inFlight)
+	d.lock.Unlock()
+
+	time.Sleep(d.delay)
+	return func() {
+		d.lock.Lock()
+		d.inFlight--
+		d.lock.Unlock()
+	}
+}
+
+func (d *concurrentProviderOperationsDriver) CreateMachine(_ context.Context, req *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
+	defer d.track(&d.creations)()
+	return &driver.CreateMachineResponse{ProviderID: "fakeID-" + req.Machine.Name, NodeName: req.Machine.Name}, nil
+}
+
+func (d *concurrentProviderOperationsDriver) DeleteMachine(_ context.Context, _ *driver.DeleteMachineRequest) (*driver.DeleteMachineResponse, error) {
+	defer d.track(&d.deletions)()
+	return &driver.DeleteMachineResponse{}, nil
+}
+
+// fallbackInstanceTypesDriver reports the instance types as exhausted on creation, the empty instance type being the one of the MachineClass
+type fallbackInstanceTypesDriver struct {
+	*driver.FakeDriver
+	exhaustedInstanceTypes []string
+
+	requestedInstanceTypes []string
+}
+
+func (d *fallbackInstanceTypesDriver) CreateMachine(_ context.Context, req *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
+	d.requestedInstanceTypes = append(d.requestedInstanceTypes, req.InstanceType)
+	if slices.Contains(d.exhaustedInstanceTypes, req.InstanceType) {
+		return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("instance type %q is exhausted", req.InstanceType))
+	}
+	return &driver.CreateMachineResponse{ProviderID: "fakeID-" + req.Machine.Name, NodeName: req.Machine.Name}, nil
+}
+
+// placementGroupDriver records the placement groups requested on VM creation
+type placementGroupDriver struct {
+	*driver.FakeDriver
+
+	requestedPlacementGroups []string
+}
+
+func (d *placementGroupDriver) CreateMachine(_ context.Context, req *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
+	d.requestedPlacementGroups = append(d.requestedPlacementGroups, req.PlacementGroup)
+	return &driver.CreateMachineResponse{ProviderID: "fakeID-" + req.Machine.Name, NodeName: req.Machine.Name}, nil
+}
+
+// idempotentDriver keeps its VMs by the idempotency key of their creation, like a provider deduping creations by it
+type idempotentDriver struct {
+	*driver.FakeDriver
+	vms map[string]string
+
+	creations int
+}
+
+func (d *idempotentDriver) CreateMachine(_ context.Context, req *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
+	d.creations++
+	if _, ok := d.vms[req.IdempotencyKey]; !ok {
+		d.vms[req.IdempotencyKey] = "fakeID-" + req.Machine.Name
+	}
+	return &driver.CreateMachineResponse{ProviderID: d.vms[req.IdempotencyKey], NodeName: req.Machine.Name}, nil
+}
+
+func (d *idempotentDriver) GetMachineStatus(_ context.Context, req *driver.GetMachineStatusRequest) (*driver.GetMachineStatusResponse, error) {
+	providerID, ok := d.vms[req.IdempotencyKey]
+	if !ok || req.IdempotencyKey == "" {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("no VM with idempotency key %q", req.IdempotencyKey))
+	}
+	return &driver.GetMachineStatusResponse{ProviderID: providerID, NodeName: req.Machine.Name}, nil
+}
+
+// stubPreDeletionHook fails the given number of times before succeeding, recording its name on each call
+type stubPreDeletionHook struct {
+	name     string
+	failures int
+	calls    *[]string
+}
+
+func (h *stubPreDeletionHook) Name() string {
+	return h.name
+}
+
+func (h *stubPreDeletionHook) Run(_ context.Context, _ *v1alpha1.Machine) error {
+	*h.calls = append(*h.calls, h.name)
+	if h.failures > 0 {
+		h.failures--
+		return fmt.Errorf("hook %q is not ready yet", h.name)
+	}
+	return nil
+}
+
+// fakePreDrainHook fails with the given error, if any, recording its name and the node on each call. A blocking
+// hook only returns once its context is done.
+type fakePreDrainHook struct {
+	name  string
+	err   error
+	block bool
+	calls *[]string
+}
+
+func (h *fakePreDrainHook) Execute(ctx context.Context, _ *v1alpha1.Machine, node *corev1.Node) error {
+	*h.calls = append(*h.calls, h.name+"/"+node.Name)
+	if h.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return h.err
+}
@@ -29,7 +29,10 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
 	"runtime"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -52,10 +55,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	storageclient "k8s.io/client-go/kubernetes/typed/storage/v1"
 	storagelisters "k8s.io/client-go/listers/storage/v1"
+	clientretry "k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 )
@@ -63,8 +70,10 @@ import (
 // emptyMap is a dummy emptyMap to compare with
 var emptyMap = make(map[string]string)
 var (
-	errSuccessfulALTsync     = errors.New("machine ALTs have been reconciled")
-	errSuccessfulPhaseUpdate = errors.New("machine creation is successful. Machine Phase/Conditions have been UPDATED")
+	errSuccessfulALTsync             = errors.New("machine ALTs have been reconciled")
+	errSuccessfulNodeLabelTaintsSync = errors.New("node label taints have been reconciled")
+	errSuccessfulPhaseUpdate         = errors.New("machine creation is successful. Machine Phase/Conditions have been UPDATED")
+	errMachineRecreationTriggered    = errors.New("machine creation timed out. Machine has been marked for deletion to be recreated")
 )
 
 const (
@@ -72,6 +81,14 @@ const (
 	pollInterval       = 100 * time.Millisecond
 	lockAcquireTimeout = 1 * time.Second
 	cacheUpdateTimeout = 1 * time.Second
+	// providerOperationPermitTimeout is the time waited for a permit for a create or delete operation of the provider
+	// before the machine is requeued
+	providerOperationPermitTimeout = 1 * time.Second
+	// inPlaceUpdateDrainPermitTimeout is the time waited for a permit for the drain of a machine selected for an
+	// in-place update before the drain is deferred
+	inPlaceUpdateDrainPermitTimeout = 1 * time.Second
+	// inPlaceUpdateDrainPermitKey is the key of the permits for the drains of machines selected for an in-place update
+	inPlaceUpdateDrainPermitKey = "in-place-update-drains"
 )
 
 // ValidateMachineClass validates the machine class.
@@ -116,9 +133,38 @@ func (c *controller) ValidateMachineClass(_ context.Context, classSpec *v1alpha1
 		return nil, nil, machineutils.ShortRetry, err
 	}
 
+	c.machineClassCache.Store(machineClass.Name, &cachedMachineClass{
+		machineClass: machineClass,
+		secretData:   secretData,
+	})
+
 	return machineClass, secretData, retry, nil
 }
 
+// cachedMachineClass is a MachineClass and its secret data as last validated
+type cachedMachineClass struct {
+	machineClass *v1alpha1.MachineClass
+	secretData   map[string][]byte
+}
+
+// validateMachineClassForTermination validates the MachineClass of a machine being deleted. If the MachineClass is
+// gone, e.g. as it was deleted before its finalizer was added, the MachineClass and secret data last validated
+// for it are used instead, so that the deletion of the machine can still complete.
+func (c *controller) validateMachineClassForTermination(ctx context.Context, machine *v1alpha1.Machine) (*v1alpha1.MachineClass, map[string][]byte, machineutils.RetryPeriod, error) {
+	machineClass, secretData, retry, err := c.ValidateMachineClass(ctx, &machine.Spec.Class)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return machineClass, secretData, retry, err
+	}
+
+	cached, ok := c.machineClassCache.Load(machine.Spec.Class.Name)
+	if !ok {
+		return nil, nil, retry, err
+	}
+	klog.Warningf("MachineClass %q of machine %q not found, continuing its deletion with the MachineClass last validated", machine.Spec.Class.Name, machine.Name)
+	entry := cached.(*cachedMachineClass)
+	return entry.machineClass, entry.secretData, retry, nil
+}
+
 func (c *controller) getSecretData(machineClassName string, secretRefs ...*v1.SecretReference) (map[string][]byte, error) {
 	var secretData map[string][]byte
 
@@ -373,6 +419,7 @@ func (c *controller) inPlaceUpdate(ctx context.Context, machine *v1alpha1.Machin
 			// Don't return error so that other steps can be executed.
 			return machineutils.LongRetry, nil
 		}
+		recordReconcileError(reconcileFlowInPlace, err)
 		return machineutils.ShortRetry, err
 	}
 
@@ -382,7 +429,12 @@ func (c *controller) inPlaceUpdate(ctx context.Context, machine *v1alpha1.Machin
 
 	// if the condition is present and the reason is selected for update then drain the node
 	if cond.Reason == v1alpha1.SelectedForUpdate {
+		releasePermit, ok := c.acquireInPlaceUpdateDrainPermit(machine)
+		if !ok {
+			return machineutils.ShortRetry, fmt.Errorf("drain of machine %q for its in-place update is deferred as the maximum of concurrent in-place update drains is reached", machine.Name)
+		}
 		retry, err := c.drainNodeForInPlace(ctx, machine)
+		releasePermit()
 		if err != nil {
 			return retry, err
 		}
@@ -390,6 +442,7 @@ func (c *controller) inPlaceUpdate(ctx context.Context, machine *v1alpha1.Machin
 		// if the node is drained successfully then fetch the node condition again
 		cond, err = nodeops.GetNodeCondition(ctx, c.targetCoreClient, getNodeName(machine), v1alpha1.NodeInPlaceUpdate)
 		if err != nil {
+			recordReconcileError(reconcileFlowInPlace, err)
 			return machineutils.ShortRetry, err
 		}
 	}
@@ -405,6 +458,7 @@ func (c *controller) inPlaceUpdate(ctx context.Context, machine *v1alpha1.Machin
 		cond.LastTransitionTime = metav1.Now()
 		cond.Message = "Node is ready for in-place update"
 		if err := nodeops.AddOrUpdateConditionsOnNode(ctx, c.targetCoreClient, getNodeName(machine), *cond); err != nil {
+			recordReconcileError(reconcileFlowInPlace, err)
 			return machineutils.ShortRetry, err
 		}
 		// give machine time for update to get applied
@@ -443,10 +497,10 @@ func (c *controller) updateMachineStatusAndNodeCondition(ctx context.Context, ma
 	description = fmt.Sprintf("Machine %s is undergoing an in-place update", machine.Name)
 	klog.V(2).Infof("%s with backing node %q is undergoing an in-place update", description, getNodeName(machine))
 
-	machine.Status.CurrentStatus = v1alpha1.CurrentStatus{
+	machineutils.SetCurrentStatus(&machine.Status, v1alpha1.CurrentStatus{
 		Phase:          v1alpha1.MachineInPlaceUpdating,
 		LastUpdateTime: metav1.Now(),
-	}
+	})
 	machine.Status.LastOperation = v1alpha1.LastOperation{
 		Description:    description,
 		State:          v1alpha1.MachineStateProcessing,
@@ -525,7 +579,7 @@ func (c *controller) syncMachineNodeTemplates(ctx context.Context, machine *v1al
 	}
 
 	annotationsChanged := SyncMachineAnnotations(machine, nodeCopy, lastAppliedALT.Annotations)
-	labelsChanged := SyncMachineLabels(machine, nodeCopy, lastAppliedALT.Labels)
+	labelsChanged := SyncMachineLabels(machine, nodeCopy, lastAppliedALT.Labels, c.nodeLabelPropagationDenylist)
 	taintsChanged := SyncMachineTaints(machine, nodeCopy, lastAppliedALT.Spec.Taints)
 
 	// Update node-object with latest nodeTemplate elements if elements have changed.
@@ -606,12 +660,46 @@ func SyncMachineAnnotations(
 	return toBeUpdated
 }
 
+// getIntendedNodeLabels returns the labels the node of a machine is intended to carry, i.e. the labels of its
+// node template not matching the denylist and the name of its MachineDeployment, if any. It returns nil if there are none.
+func getIntendedNodeLabels(machine *v1alpha1.Machine, denylist []string) map[string]string {
+	var nodeLabels map[string]string
+	if len(machine.Spec.NodeTemplateSpec.Labels) > 0 {
+		nodeLabels = make(map[string]string, len(machine.Spec.NodeTemplateSpec.Labels)+1)
+		for key, value := range machine.Spec.NodeTemplateSpec.Labels {
+			if isDenylistedNodeLabel(key, denylist) {
+				continue
+			}
+			nodeLabels[key] = value
+		}
+	}
+	if machineDeploymentName, ok := machine.Labels[machineutils.LabelKeyMachineDeployment]; ok {
+		if nodeLabels == nil {
+			nodeLabels = make(map[string]string, 1)
+		}
+		nodeLabels[machineutils.LabelKeyMachineDeployment] = machineDeploymentName
+	}
+	return nodeLabels
+}
+
+// isDenylistedNodeLabel returns true if the label key starts with any of the prefixes of the denylist
+func isDenylistedNodeLabel(key string, denylist []string) bool {
+	for _, prefix := range denylist {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // SyncMachineLabels syncs the labels of the machine with node-objects.
+// Labels whose keys start with any of the prefixes of the denylist are never added, modified or removed.
 // It returns true if update is needed else false.
 func SyncMachineLabels(
 	machine *v1alpha1.Machine,
 	node *v1.Node,
 	lastAppliedLabels map[string]string,
+	denylist []string,
 ) bool {
 	toBeUpdated := false
 	mLabels, nLabels := machine.Spec.NodeTemplateSpec.Labels, node.Labels
@@ -628,6 +716,9 @@ func SyncMachineLabels(
 
 	// Delete any labels that existed in the past but has been deleted now
 	for lastAppliedLabelKey := range lastAppliedLabels {
+		if isDenylistedNodeLabel(lastAppliedLabelKey, denylist) {
+			continue
+		}
 		if _, exists := mLabels[lastAppliedLabelKey]; !exists {
 			delete(nLabels, lastAppliedLabelKey)
 			toBeUpdated = true
@@ -636,6 +727,9 @@ func SyncMachineLabels(
 
 	// Add/Update any key that doesn't exist or whose value as changed
 	for mKey, mValue := range mLabels {
+		if isDenylistedNodeLabel(mKey, denylist) {
+			continue
+		}
 		if nValue, exists := nLabels[mKey]; !exists || mValue != nValue {
 			nLabels[mKey] = mValue
 			toBeUpdated = true
@@ -719,6 +813,126 @@ func SyncMachineTaints(
 	return toBeUpdated
 }
 
+// syncNodeLabelTaints ensures that the taints configured via nodeLabelTaints are present on the node of the machine
+// if its labels match the corresponding selector, and removes the taints applied earlier whose selector no longer matches.
+// Taints on the node-object which have not been applied by MCM are not touched.
+func (c *controller) syncNodeLabelTaints(ctx context.Context, machine *v1alpha1.Machine) (machineutils.RetryPeriod, error) {
+	var lastAppliedTaints []v1.Taint
+
+	node, err := c.nodeLister.Get(getNodeName(machine))
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Don't return error so that other steps can be executed.
+			return machineutils.LongRetry, nil
+		}
+		klog.Errorf("Error occurred while trying to fetch node object - err: %s", err)
+		return machineutils.ShortRetry, err
+	}
+
+	lastAppliedTaintsJSONString, exists := node.Annotations[machineutils.LastAppliedNodeLabelTaintsAnnotation]
+	if !exists && len(c.nodeLabelTaints) == 0 {
+		return machineutils.LongRetry, nil
+	} else if exists {
+		if err := json.Unmarshal([]byte(lastAppliedTaintsJSONString), &lastAppliedTaints); err != nil {
+			klog.Errorf("Error occurred while syncing node label taints: %s", err)
+			return machineutils.ShortRetry, err
+		}
+	}
+
+	// Collect the taints of all matching selectors, the first taint for a [key, effect] wins
+	var desiredTaints []v1.Taint
+	desiredTaintsMap := make(map[taintKeyEffect]v1.Taint)
+	for _, nodeLabelTaint := range c.nodeLabelTaints {
+		selector, err := labels.Parse(nodeLabelTaint.Selector)
+		if err != nil {
+			klog.Errorf("Invalid node label selector %q: %s", nodeLabelTaint.Selector, err)
+			continue
+		}
+		taintKE := taintKeyEffect{Key: nodeLabelTaint.Taint.Key, Effect: nodeLabelTaint.Taint.Effect}
+		if _, exists := desiredTaintsMap[taintKE]; exists || !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		desiredTaintsMap[taintKE] = nodeLabelTaint.Taint
+		desiredTaints = append(desiredTaints, nodeLabelTaint.Taint)
+	}
+
+	lastAppliedTaintsSet := sets.New[taintKeyEffect]()
+	for _, taint := range lastAppliedTaints {
+		lastAppliedTaintsSet.Insert(taintKeyEffect{Key: taint.Key, Effect: taint.Effect})
+	}
+
+	var (
+		taints        []v1.Taint
+		appliedTaints []v1.Taint
+		toBeUpdated   bool
+	)
+	presentTaintsSet := sets.New[taintKeyEffect]()
+	for _, taint := range node.Spec.Taints {
+		taintKE := taintKeyEffect{Key: taint.Key, Effect: taint.Effect}
+		desiredTaint, desired := desiredTaintsMap[taintKE]
+		if lastAppliedTaintsSet.Has(taintKE) {
+			if !desired {
+				// Remove the taint applied earlier as the node labels no longer match
+				toBeUpdated = true
+				continue
+			}
+			if taint.Value != desiredTaint.Value {
+				taint.Value = desiredTaint.Value
+				toBeUpdated = true
+			}
+		}
+		taints = append(taints, taint)
+		presentTaintsSet.Insert(taintKE)
+	}
+	for _, desiredTaint := range desiredTaints {
+		taintKE := taintKeyEffect{Key: desiredTaint.Key, Effect: desiredTaint.Effect}
+		if !presentTaintsSet.Has(taintKE) {
+			taints = append(taints, desiredTaint)
+			toBeUpdated = true
+		} else if !lastAppliedTaintsSet.Has(taintKE) {
+			// Taint is already present on the node but not applied by MCM, hence it is left untouched
+			continue
+		}
+		appliedTaints = append(appliedTaints, desiredTaint)
+	}
+
+	appliedTaintsJSONByte, err := json.Marshal(appliedTaints)
+	if err != nil {
+		klog.Errorf("Error occurred while syncing node label taints: %s", err)
+		return machineutils.ShortRetry, err
+	}
+	annotationUpToDate := (len(appliedTaints) == 0 && !exists) || (exists && lastAppliedTaintsJSONString == string(appliedTaintsJSONByte))
+	if !toBeUpdated && annotationUpToDate {
+		return machineutils.LongRetry, nil
+	}
+
+	klog.V(2).Infof("Updating node label taints for machine: %q with providerID: %q and backing node: %q", machine.Name, getProviderID(machine), getNodeName(machine))
+	nodeCopy := node.DeepCopy()
+	nodeCopy.Spec.Taints = taints
+	if len(appliedTaints) == 0 {
+		delete(nodeCopy.Annotations, machineutils.LastAppliedNodeLabelTaintsAnnotation)
+	} else {
+		if nodeCopy.Annotations == nil {
+			nodeCopy.Annotations = make(map[string]string)
+		}
+		nodeCopy.Annotations[machineutils.LastAppliedNodeLabelTaintsAnnotation] = string(appliedTaintsJSONByte)
+	}
+
+	_, err = c.targetCoreClient.CoreV1().Nodes().Update(ctx, nodeCopy, metav1.UpdateOptions{})
+	if err != nil {
+		// Keep retrying until update goes through
+		klog.Errorf("Update of node label taints failed for node object of machine %q. Retrying, error: %q", machine.Name, err)
+	} else {
+		// Return error to continue in next reconcile
+		err = errSuccessfulNodeLabelTaintsSync
+	}
+
+	if apierrors.IsConflict(err) {
+		return machineutils.ConflictRetry, err
+	}
+	return machineutils.ShortRetry, err
+}
+
 // machineCreateErrorHandler updates the machine status based on
 // CreateMachineResponse and the error during the machine creation
 func (c *controller) machineCreateErrorHandler(ctx context.Context, machine *v1alpha1.Machine, createMachineResponse *driver.CreateMachineResponse, err error) (machineutils.RetryPeriod, error) {
@@ -742,6 +956,11 @@ func (c *controller) machineCreateErrorHandler(ctx context.Context, machine *v1a
 		lastKnownState = createMachineResponse.LastKnownState
 	}
 
+	// the failure category isn't considered to decide whether the status is updated, it is
+	// only persisted along with the rest of the failure
+	machine = machine.DeepCopy()
+	machine.Status.FailureCategory = categorizeProvisioningFailure(err)
+
 	updateRetryPeriod, updateErr := c.machineStatusUpdate(
 		ctx,
 		machine,
@@ -766,6 +985,49 @@ func (c *controller) machineCreateErrorHandler(ctx context.Context, machine *v1a
 	return retryRequired, err
 }
 
+// failureCategoryHints are the substrings of provider error messages hinting at the category of
+// provisioning failures, checked in order if the error code doesn't determine the category
+var failureCategoryHints = []struct {
+	category v1alpha1.MachineFailureCategory
+	hints    []string
+}{
+	{v1alpha1.MachineFailureCategoryQuota, []string{"quota", "limitexceeded", "limit exceeded", "insufficient capacity", "insufficientinstancecapacity"}},
+	{v1alpha1.MachineFailureCategoryImageNotFound, []string{"image"}},
+	{v1alpha1.MachineFailureCategoryAuth, []string{"unauthorized", "forbidden", "credential", "authentication", "authorization", "permission"}},
+	{v1alpha1.MachineFailureCategoryNetwork, []string{"network", "subnet", "vpc", "security group", "securitygroup", "ip address"}},
+}
+
+// categorizeProvisioningFailure maps the error returned by the driver while provisioning a machine to a failure
+// category, using the code of the error and the hints in its message
+func categorizeProvisioningFailure(err error) v1alpha1.MachineFailureCategory {
+	machineErr, ok := status.FromError(err)
+	if !ok {
+		return v1alpha1.MachineFailureCategoryUnknown
+	}
+
+	switch machineErr.Code() {
+	case codes.ResourceExhausted:
+		return v1alpha1.MachineFailureCategoryQuota
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return v1alpha1.MachineFailureCategoryAuth
+	}
+
+	message := strings.ToLower(machineErr.Message())
+	for _, c := range failureCategoryHints {
+		for _, hint := range c.hints {
+			if strings.Contains(message, hint) {
+				return c.category
+			}
+		}
+	}
+
+	switch machineErr.Code() {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Aborted, codes.Unavailable:
+		return v1alpha1.MachineFailureCategoryTransient
+	}
+	return v1alpha1.MachineFailureCategoryUnknown
+}
+
 func (c *controller) machineStatusUpdate(
 	ctx context.Context,
 	machine *v1alpha1.Machine,
@@ -775,7 +1037,7 @@ func (c *controller) machineStatusUpdate(
 ) (machineutils.RetryPeriod, error) {
 	clone := machine.DeepCopy()
 	clone.Status.LastOperation = lastOperation
-	clone.Status.CurrentStatus = currentStatus
+	machineutils.SetCurrentStatus(&clone.Status, currentStatus)
 	clone.Status.LastKnownState = lastKnownState
 
 	if isMachineStatusSimilar(clone.Status, machine.Status) {
@@ -783,12 +1045,15 @@ func (c *controller) machineStatusUpdate(
 		return machineutils.ShortRetry, nil
 	}
 
-	_, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
+	updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
 	if err != nil {
 		// Keep retrying until update goes through
 		klog.Warningf("Machine/status UPDATE failed for machine %q. Retrying, error: %s", machine.Name, err)
 	} else {
 		klog.V(2).Infof("Machine/status UPDATE for %q", machine.Name)
+		if lastOperation.Type == v1alpha1.MachineOperationCreate || lastOperation.Type == v1alpha1.MachineOperationDelete {
+			c.recordFlowStep(ctx, updatedMachine, lastOperation.Description)
+		}
 	}
 
 	if apierrors.IsConflict(err) {
@@ -798,6 +1063,69 @@ func (c *controller) machineStatusUpdate(
 	return machineutils.ShortRetry, err
 }
 
+// updateLastSuccessfulReconcile records the current time as the last successful reconcile in the status of the reconciled
+// machine, unless it was recorded within the LastSuccessfulReconcileUpdateInterval. Failures are only logged, as the
+// time is recorded again on the next successful reconcile.
+func (c *controller) updateLastSuccessfulReconcile(ctx context.Context, machine *v1alpha1.Machine) {
+	if last := machine.Status.LastSuccessfulReconcile; last != nil && time.Since(last.Time) < machineutils.LastSuccessfulReconcileUpdateInterval {
+		return
+	}
+
+	clone := machine.DeepCopy()
+	now := metav1.Now()
+	clone.Status.LastSuccessfulReconcile = &now
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		if apierrors.IsConflict(err) {
+			// The reconcile updated the machine, the time is recorded on its next reconcile
+			klog.V(4).Infof("Machine %q changed during its reconcile, not recording its last successful reconcile", machine.Name)
+			return
+		}
+		klog.Warningf("Failed to record the last successful reconcile of machine %q: %s", machine.Name, err)
+	}
+}
+
+// flowStep is an entry of the MachineFlowHistory annotation
+type flowStep struct {
+	Time metav1.Time `json:"time"`
+	Step string      `json:"step"`
+}
+
+// recordFlowStep appends the step to the MachineFlowHistory annotation of the machine if enabled,
+// retaining only the last MaxMachineFlowHistoryEntries steps. The machine is only updated if the step differs
+// from the last recorded one, so that retries of a step don't cost an update each. Failures are only logged
+// as the history is used for diagnostics only.
+func (c *controller) recordFlowStep(ctx context.Context, machine *v1alpha1.Machine, step string) {
+	if machine.Annotations[machineutils.MachineFlowHistoryEnabled] != "true" {
+		return
+	}
+
+	var history []flowStep
+	if value, ok := machine.Annotations[machineutils.MachineFlowHistory]; ok {
+		if err := json.Unmarshal([]byte(value), &history); err != nil {
+			klog.Warningf("Resetting flow history of machine %q as it could not be decoded: %s", machine.Name, err)
+			history = nil
+		}
+	}
+	if len(history) > 0 && history[len(history)-1].Step == step {
+		return
+	}
+	history = append(history, flowStep{Time: metav1.Now(), Step: step})
+	if len(history) > machineutils.MaxMachineFlowHistoryEntries {
+		history = history[len(history)-machineutils.MaxMachineFlowHistoryEntries:]
+	}
+	value, err := json.Marshal(history)
+	if err != nil {
+		klog.Warningf("Failed to encode flow history of machine %q: %s", machine.Name, err)
+		return
+	}
+
+	clone := machine.DeepCopy()
+	metav1.SetMetaDataAnnotation(&clone.ObjectMeta, machineutils.MachineFlowHistory, string(value))
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		klog.Warningf("Failed to record flow step %q for machine %q: %s", step, machine.Name, err)
+	}
+}
+
 // isMachineStatusSimilar checks if the status of 2 machines is similar or not.
 func isMachineStatusSimilar(s1, s2 v1alpha1.MachineStatus) bool {
 	s1Copy, s2Copy := s1.DeepCopy(), s2.DeepCopy()
@@ -824,8 +1152,8 @@ func isMachineStatusSimilar(s1, s2 v1alpha1.MachineStatus) bool {
 // getCreateFailurePhase gets the effective creation timeout
 func (c *controller) getCreateFailurePhase(machine *v1alpha1.Machine) v1alpha1.MachinePhase {
 	timeOutDuration := c.getEffectiveCreationTimeout(machine).Duration
-	// Timeout value obtained by subtracting last operation with expected time out period
-	timeOut := metav1.Now().Add(-timeOutDuration).Sub(machine.CreationTimestamp.Time)
+	// Timeout value obtained by subtracting the expected time out period from the time since the creation
+	timeOut := c.timeSinceStoredTimestamp(machine, "CreationTimestamp", machine.CreationTimestamp.Time) - timeOutDuration
 
 	if timeOut > 0 {
 		// Machine creation timeout occured while joining of machine
@@ -837,14 +1165,155 @@ func (c *controller) getCreateFailurePhase(machine *v1alpha1.Machine) v1alpha1.M
 	return v1alpha1.MachineCrashLoopBackOff
 }
 
+// machineQuotaExceeded checks whether creating a VM for the machine would exceed the machine quota of its
+// MachineClass, set by its annotation or the quota ConfigMap. Machines backed by a VM and machines whose
+// creation is in flight count towards the quota. It returns the quota if it has been reached.
+func (c *controller) machineQuotaExceeded(machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass) (bool, int, error) {
+	quotaValue, source, err := c.getMachineQuota(machineClass)
+	if err != nil || quotaValue == "" {
+		return false, 0, err
+	}
+	quota, err := strconv.Atoi(quotaValue)
+	if err != nil || quota < 0 {
+		klog.Warningf("Ignoring invalid machine quota %q of MachineClass %q set by %s", quotaValue, machineClass.Name, source)
+		return false, 0, nil
+	}
+
+	machines, err := c.machineLister.Machines(c.namespace).List(labels.Everything())
+	if err != nil {
+		return false, 0, err
+	}
+	count := 0
+	for _, m := range machines {
+		if m.Name != machine.Name && m.Spec.Class.Name == machineClass.Name && isMachineCountedTowardsQuota(m) {
+			count++
+		}
+	}
+	return count >= quota, quota, nil
+}
+
+// getMachineQuota returns the machine quota of the MachineClass and where it is set, or an empty quota if there is none
+func (c *controller) getMachineQuota(machineClass *v1alpha1.MachineClass) (string, string, error) {
+	if quotaValue, ok := machineClass.Annotations[machineutils.MachineClassMaxMachines]; ok {
+		return quotaValue, fmt.Sprintf("annotation %q", machineutils.MachineClassMaxMachines), nil
+	}
+	if c.safetyOptions.MachineQuotaConfigMap == "" {
+		return "", "", nil
+	}
+	configMap, err := c.configMapLister.ConfigMaps(c.namespace).Get(c.safetyOptions.MachineQuotaConfigMap)
+	if apierrors.IsNotFound(err) {
+		return "", "", nil
+	} else if err != nil {
+		return "", "", err
+	}
+	return configMap.Data[machineClass.Name], fmt.Sprintf("ConfigMap %q", configMap.Name), nil
+}
+
+// isMachineCountedTowardsQuota checks whether the machine is backed by a VM or its creation is in flight.
+// Machines whose creation is held don't count, so that they don't block each other.
+func isMachineCountedTowardsQuota(machine *v1alpha1.Machine) bool {
+	if machine.Spec.ProviderID != "" {
+		return true
+	}
+	if isMachineCreationHeld(machine) {
+		return false
+	}
+	return machine.Status.CurrentStatus.Phase == "" || machine.Status.CurrentStatus.Phase == v1alpha1.MachinePending
+}
+
+// isMachineCreationHeld checks whether the creation of the machine is held back
+func isMachineCreationHeld(machine *v1alpha1.Machine) bool {
+	cond := getMachineCondition(machine, machineutils.MachineCreationHeld)
+	return cond != nil && cond.Status == v1.ConditionTrue
+}
+
+// getUnmetMachineDependency checks whether the machines selected by the MachineDependsOn annotation
+// of the machine are Running. It returns a description of the unmet dependency, if any.
+func (c *controller) getUnmetMachineDependency(machine *v1alpha1.Machine) (string, error) {
+	selectorValue, ok := machine.Annotations[machineutils.MachineDependsOn]
+	if !ok {
+		return "", nil
+	}
+	selector, err := labels.Parse(selectorValue)
+	if err != nil {
+		klog.Warningf("Ignoring invalid value %q of annotation %q on machine %q: %s", selectorValue, machineutils.MachineDependsOn, machine.Name, err)
+		return "", nil
+	}
+
+	machines, err := c.machineLister.Machines(c.namespace).List(selector)
+	if err != nil {
+		return "", err
+	}
+	var dependencies, notRunning []string
+	for _, m := range machines {
+		if m.Name == machine.Name {
+			continue
+		}
+		dependencies = append(dependencies, m.Name)
+		if m.Status.CurrentStatus.Phase != v1alpha1.MachineRunning {
+			notRunning = append(notRunning, m.Name)
+		}
+	}
+	if len(dependencies) == 0 {
+		return fmt.Sprintf("No machines found for dependency selector %q", selectorValue), nil
+	}
+	if len(notRunning) > 0 {
+		sort.Strings(notRunning)
+		return fmt.Sprintf("Machines %v of dependency selector %q are not Running", notRunning, selectorValue), nil
+	}
+	return "", nil
+}
+
+// holdMachineCreation keeps the machine in Pending phase and records an event
+// as long as the reason described by cause does not permit creating a new VM
+func (c *controller) holdMachineCreation(ctx context.Context, machine *v1alpha1.Machine, eventType, reason, cause string, holdErr error) (machineutils.RetryPeriod, error) {
+	description := fmt.Sprintf("%s. %s", cause, machineutils.MachineCreationOnHold)
+	c.recorder.Eventf(machine, eventType, reason, description)
+
+	clone := machine.DeepCopy()
+	clone.Status.Conditions = nodeops.CloneAndAddCondition(clone.Status.Conditions, v1.NodeCondition{
+		Type:               machineutils.MachineCreationHeld,
+		Status:             v1.ConditionTrue,
+		Reason:             reason,
+		Message:            cause,
+		LastTransitionTime: metav1.Now(),
+	})
+	clone.Status.LastOperation = v1alpha1.LastOperation{
+		Description:    description,
+		State:          v1alpha1.MachineStateProcessing,
+		Type:           v1alpha1.MachineOperationCreate,
+		LastUpdateTime: metav1.Now(),
+	}
+	machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+		Phase:          v1alpha1.MachinePending,
+		LastUpdateTime: metav1.Now(),
+	})
+	if isMachineStatusSimilar(clone.Status, machine.Status) && apiequality.Semantic.DeepEqual(clone.Status.Conditions, machine.Status.Conditions) {
+		klog.V(3).Infof("Not updating the status of the machine object %q, as the content is similar", clone.Name)
+		return machineutils.MediumRetry, holdErr
+	}
+
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		klog.Warningf("Machine/status UPDATE failed for machine %q. Retrying, error: %s", machine.Name, err)
+		if apierrors.IsConflict(err) {
+			return machineutils.ConflictRetry, err
+		}
+		return machineutils.ShortRetry, err
+	}
+
+	return machineutils.MediumRetry, holdErr
+}
+
 // reconcileMachineHealth updates the machine object with
 // any change in node conditions or health
 func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alpha1.Machine) (machineutils.RetryPeriod, error) {
 	var (
-		cloneDirty        = false
-		clone             = machine.DeepCopy()
-		description       string
-		lastOperationType v1alpha1.MachineOperationType
+		cloneDirty            = false
+		clone                 = machine.DeepCopy()
+		description           string
+		lastOperationType     v1alpha1.MachineOperationType
+		conditionsChanged     = false
+		conditionsUpdateDelay time.Duration
 	)
 
 	node, err := c.nodeLister.Get(machine.Labels[v1alpha1.NodeLabelKey])
@@ -852,6 +1321,7 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 		if !apierrors.IsNotFound(err) {
 			// Any other types of errors while fetching node object
 			klog.Errorf("Could not fetch node object for machine %q", machine.Name)
+			recordReconcileError(reconcileFlowHealth, err)
 			return machineutils.ShortRetry, err
 		}
 		// Node object is not found
@@ -866,10 +1336,10 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 			)
 			klog.Warning(description)
 
-			clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+			machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 				Phase:          v1alpha1.MachineUnknown,
 				LastUpdateTime: metav1.Now(),
-			}
+			})
 			clone.Status.LastOperation = v1alpha1.LastOperation{
 				Description:    description,
 				State:          v1alpha1.MachineStateProcessing,
@@ -879,12 +1349,27 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 			cloneDirty = true
 		}
 	} else {
-		populatedConditions, removedConditions, isChanged := nodeConditionsHaveChanged(machine.Status.Conditions, node.Status.Conditions)
+		providerConditions, err := c.getProviderConditions(ctx, machine)
+		if err != nil {
+			// Retain the last known provider conditions, which are the ones not reported by the node
+			klog.Warningf("Could not fetch provider conditions of machine %q, retaining the last known ones: %s", machine.Name, err)
+			providerConditions = machine.Status.Conditions
+		}
+		conditions := mergeProviderConditions(node.Status.Conditions, providerConditions)
+		conditions = setNodeExternallyCordonedCondition(machine, node, conditions)
+
+		populatedConditions, removedConditions, isChanged := nodeConditionsHaveChanged(machine.Status.Conditions, conditions)
 		if isChanged {
-			clone.Status.Conditions = node.Status.Conditions
+			// The changed conditions are always evaluated below, only their write is debounced
+			clone.Status.Conditions = conditions
+			conditionsChanged = true
 
 			klog.V(3).Infof("Conditions of node %q backing machine %q with providerID %q have changed.\nAdded/Updated Conditions:\n\n%s\nRemoved Conditions:\n\n%s\n", getNodeName(machine), machine.Name, getProviderID(machine), getFormattedNodeConditions(populatedConditions), getFormattedNodeConditions(removedConditions))
-			cloneDirty = true
+			if conditionsUpdateDelay = c.getConditionsUpdateDelay(machine); conditionsUpdateDelay > 0 {
+				klog.V(3).Infof("Debouncing update of conditions of machine %q for %s", machine.Name, conditionsUpdateDelay)
+			} else {
+				cloneDirty = true
+			}
 		}
 
 		// During the period when the machine is undergoing an in-place update or has failed to update,
@@ -893,37 +1378,75 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 		if machine.Status.CurrentStatus.Phase != v1alpha1.MachineInPlaceUpdating && machine.Status.CurrentStatus.Phase != v1alpha1.MachineInPlaceUpdateFailed {
 			if c.isHealthy(clone) {
 				if clone.Status.CurrentStatus.Phase != v1alpha1.MachineRunning && !isPendingMachineWithCriticalComponentsNotReadyTaint(clone, node) {
-					if clone.Status.LastOperation.Type == v1alpha1.MachineOperationCreate &&
-						clone.Status.LastOperation.State != v1alpha1.MachineStateSuccessful {
-						// When machine creation went through
-						description = fmt.Sprintf("Machine %s successfully joined the cluster", clone.Name)
-						lastOperationType = v1alpha1.MachineOperationCreate
-
-						// Delete the bootstrap token
-						err = c.deleteBootstrapToken(ctx, clone.Name)
-						if err != nil {
-							klog.Warning(err)
+					if verificationCondition, pending := c.isMachineVerificationPending(clone, node); pending {
+						if clone.Status.CurrentStatus.Phase != v1alpha1.MachineVerifying {
+							// Machine joined the cluster, but has to pass its verification before it is marked Running
+							description = fmt.Sprintf("Machine %s joined the cluster, waiting for node condition %q to verify it", clone.Name, verificationCondition)
+							klog.V(2).Infof("%s with backing node %q and providerID %q", description, getNodeName(clone), getProviderID(clone))
+
+							clone.Status.LastOperation = v1alpha1.LastOperation{
+								Description:    description,
+								State:          v1alpha1.MachineStateProcessing,
+								Type:           v1alpha1.MachineOperationCreate,
+								LastUpdateTime: metav1.Now(),
+							}
+							creationStart := clone.Status.CurrentStatus.LastUpdateTime
+							machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+								Phase:          v1alpha1.MachineVerifying,
+								TimeoutActive:  true,
+								LastUpdateTime: metav1.Now(),
+							})
+							// The verification counts against the creation timeout, so it keeps running from the original start
+							if !creationStart.IsZero() {
+								clone.Status.CurrentStatus.LastUpdateTime = creationStart
+							}
+							cloneDirty = true
 						}
 					} else {
-						// Machine rejoined the cluster after a health-check
-						description = fmt.Sprintf("Machine %s successfully re-joined the cluster", clone.Name)
-						lastOperationType = v1alpha1.MachineOperationHealthCheck
-					}
-					klog.V(2).Infof("%s with backing node %q and providerID %q", description, getNodeName(clone), getProviderID(clone))
-
-					// Machine is ready and has joined/re-joined the cluster
-					clone.Status.LastOperation = v1alpha1.LastOperation{
-						Description:    description,
-						State:          v1alpha1.MachineStateSuccessful,
-						Type:           lastOperationType,
-						LastUpdateTime: metav1.Now(),
-					}
-					clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
-						Phase: v1alpha1.MachineRunning,
-						// TimeoutActive:  false,
-						LastUpdateTime: metav1.Now(),
+						if clone.Status.LastOperation.Type == v1alpha1.MachineOperationCreate &&
+							clone.Status.LastOperation.State != v1alpha1.MachineStateSuccessful {
+							// When machine creation went through
+							description = fmt.Sprintf("Machine %s successfully joined the cluster", clone.Name)
+							lastOperationType = v1alpha1.MachineOperationCreate
+
+							if err := c.uncordonJoiningNode(ctx, clone, node); err != nil {
+								klog.Errorf("Could not uncordon node %q of newly created machine %q: %s", node.Name, clone.Name, err)
+								recordReconcileError(reconcileFlowHealth, err)
+								return machineutils.ShortRetry, err
+							}
+
+							// Delete the bootstrap token
+							err = c.deleteBootstrapToken(ctx, clone.Name)
+							if err != nil {
+								klog.Warning(err)
+							}
+						} else {
+							// Machine rejoined the cluster after a health-check
+							description = fmt.Sprintf("Machine %s successfully re-joined the cluster", clone.Name)
+							lastOperationType = v1alpha1.MachineOperationHealthCheck
+
+							if err := c.removeNodeTaintsOnRecovery(ctx, clone, node); err != nil {
+								klog.Errorf("Could not remove taints from node %q of recovered machine %q: %s", node.Name, clone.Name, err)
+								recordReconcileError(reconcileFlowHealth, err)
+								return machineutils.ShortRetry, err
+							}
+						}
+						klog.V(2).Infof("%s with backing node %q and providerID %q", description, getNodeName(clone), getProviderID(clone))
+
+						// Machine is ready and has joined/re-joined the cluster
+						clone.Status.LastOperation = v1alpha1.LastOperation{
+							Description:    description,
+							State:          v1alpha1.MachineStateSuccessful,
+							Type:           lastOperationType,
+							LastUpdateTime: metav1.Now(),
+						}
+						machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+							Phase: v1alpha1.MachineRunning,
+							// TimeoutActive:  false,
+							LastUpdateTime: metav1.Now(),
+						})
+						cloneDirty = true
 					}
-					cloneDirty = true
 				}
 			} else {
 				if clone.Status.CurrentStatus.Phase == v1alpha1.MachineRunning {
@@ -932,11 +1455,11 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 					description = fmt.Sprintf("Machine %s is unhealthy - changing MachinePhase to Unknown. Node conditions: %+v", clone.Name, clone.Status.Conditions)
 					klog.Warning(description)
 
-					clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+					machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 						Phase: v1alpha1.MachineUnknown,
 						// TimeoutActive:  true,
 						LastUpdateTime: metav1.Now(),
-					}
+					})
 					clone.Status.LastOperation = v1alpha1.LastOperation{
 						Description:    description,
 						State:          v1alpha1.MachineStateProcessing,
@@ -956,10 +1479,10 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 			if node.Labels[v1alpha1.LabelKeyNodeUpdateResult] == v1alpha1.LabelValueNodeUpdateSuccessful && clone.Status.CurrentStatus.Phase != v1alpha1.MachineInPlaceUpdateSuccessful {
 				description = fmt.Sprintf("Machine %s successfully updated dependencies", machine.Name)
 				klog.V(2).Infof("%s with backing node %q and providerID %q sucessfully update the dependencies", description, getNodeName(machine), getProviderID(machine))
-				clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+				machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 					Phase:          v1alpha1.MachineInPlaceUpdateSuccessful,
 					LastUpdateTime: metav1.Now(),
-				}
+				})
 				clone.Status.LastOperation = v1alpha1.LastOperation{
 					Description:    description,
 					State:          v1alpha1.MachineStateSuccessful,
@@ -970,10 +1493,10 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 			} else if node.Labels[v1alpha1.LabelKeyNodeUpdateResult] == v1alpha1.LabelValueNodeUpdateFailed && clone.Status.CurrentStatus.Phase != v1alpha1.MachineInPlaceUpdateFailed {
 				description = fmt.Sprintf("Machine %s failed to update dependencies: %s", machine.Name, node.Annotations[v1alpha1.AnnotationKeyMachineUpdateFailedReason])
 				klog.V(2).Infof("%s with backing node %q and providerID %q failed to update dependencies", description, getNodeName(machine), getProviderID(machine))
-				clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+				machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 					Phase:          v1alpha1.MachineInPlaceUpdateFailed,
 					LastUpdateTime: metav1.Now(),
-				}
+				})
 				clone.Status.LastOperation = v1alpha1.LastOperation{
 					Description:    description,
 					State:          v1alpha1.MachineStateFailed,
@@ -986,7 +1509,7 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 	}
 
 	if !cloneDirty &&
-		(machine.Status.CurrentStatus.Phase == v1alpha1.MachinePending ||
+		(machine.Status.CurrentStatus.Phase == v1alpha1.MachinePending || machine.Status.CurrentStatus.Phase == v1alpha1.MachineVerifying ||
 			machine.Status.CurrentStatus.Phase == v1alpha1.MachineUnknown || machine.Status.CurrentStatus.Phase == v1alpha1.MachineInPlaceUpdating ||
 			machine.Status.CurrentStatus.Phase == v1alpha1.MachineInPlaceUpdateFailed) {
 		var (
@@ -994,7 +1517,7 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 			timeOutDuration time.Duration
 		)
 
-		isMachinePending := machine.Status.CurrentStatus.Phase == v1alpha1.MachinePending
+		isMachinePending := machine.Status.CurrentStatus.Phase == v1alpha1.MachinePending || machine.Status.CurrentStatus.Phase == v1alpha1.MachineVerifying
 		isMachineInPlaceUpdating := machine.Status.CurrentStatus.Phase == v1alpha1.MachineInPlaceUpdating
 		disableHealthTimeout := machine.Spec.MachineConfiguration != nil && ptr.Deref(machine.Spec.DisableHealthTimeout, false)
 		sleepTime := 1 * time.Minute
@@ -1007,28 +1530,128 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 			timeOutDuration = c.getEffectiveHealthTimeout(machine).Duration
 		}
 
-		// Timeout value obtained by subtracting last operation with expected time out period
-		timeOut := metav1.Now().Add(-timeOutDuration).Sub(machine.Status.CurrentStatus.LastUpdateTime.Time)
-		if timeOut > 0 {
-			// Machine health timeout occurred while joining or rejoining of machine
+		// Timeout value obtained by subtracting the expected time out period from the time since the last status update
+		timeOut := c.timeSinceStoredTimestamp(machine, "LastUpdateTime of the status", machine.Status.CurrentStatus.LastUpdateTime.Time) - timeOutDuration
+		if isMachinePending && c.criticalComponentsNotReadyTimeoutOccurred(node) {
+			// Node joined the cluster, but its critical components never became ready.
+			// Machine set controller would replace this machine with a new one as phase is failed.
+			description = fmt.Sprintf(
+				"Machine %s joined the cluster, but taint %q wasn't removed from node %q in %s.",
+				machine.Name,
+				machineutils.TaintNodeCriticalComponentsNotReady,
+				node.Name,
+				c.safetyOptions.MachineCriticalComponentsNotReadyTimeout.Duration,
+			)
+			klog.Error(description)
 
-			if !isMachinePending && !isMachineInPlaceUpdating && !disableHealthTimeout {
-				// Timeout occurred due to machine being unhealthy for too long
-				description = fmt.Sprintf(
-					"Machine %s health checks failing since last %s minutes. Updating machine phase to Failed. Node Conditions: %+v",
-					machine.Name,
-					timeOutDuration,
-					machine.Status.Conditions,
-				)
+			clone.Status.LastOperation = v1alpha1.LastOperation{
+				Description:    description,
+				State:          v1alpha1.MachineStateFailed,
+				Type:           machine.Status.LastOperation.Type,
+				LastUpdateTime: metav1.Now(),
+			}
+			machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+				Phase:          v1alpha1.MachineFailed,
+				LastUpdateTime: metav1.Now(),
+			})
+			cloneDirty = true
+		} else if isMachinePending && timeOut <= 0 && c.bootstrapTokenExpired(machine, node) {
+			// Node can't join the cluster anymore without a valid bootstrap token.
+			// Machine set controller would replace this machine with a new one as phase is failed.
+			description = fmt.Sprintf(
+				"Bootstrap token of machine %s expired before its node joined the cluster. Changing phase to Failed.",
+				machine.Name,
+			)
+			klog.Error(description)
+			c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.BootstrapTokenExpiredReason, "Bootstrap token expired before the node joined the cluster, the machine is replaced")
 
-				machineDeployName := getMachineDeploymentName(machine)
-				// creating lock for machineDeployment, if not allocated
-				c.permitGiver.RegisterPermits(machineDeployName, 1)
-				return c.tryMarkingMachineFailed(ctx, machine, clone, machineDeployName, description, lockAcquireTimeout)
+			clone.Status.LastOperation = v1alpha1.LastOperation{
+				Description:    description,
+				State:          v1alpha1.MachineStateFailed,
+				Type:           machine.Status.LastOperation.Type,
+				LastUpdateTime: metav1.Now(),
 			}
+			machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+				Phase:          v1alpha1.MachineFailed,
+				LastUpdateTime: metav1.Now(),
+			})
+			cloneDirty = true
+		} else if timeOut > 0 && c.isTargetClusterUnreachable(ctx) {
+			// The node of the machine can't be observed, hence the machine is held instead of being marked Failed
+			holdPeriod := c.safetyOptions.TargetClusterUnreachableRetryPeriod.Duration
+			klog.Warningf("Creation/Health timeout of machine %q occurred, but the APIServer of the target cluster is unreachable. Re-evaluating it after %s", machine.Name, holdPeriod)
+			c.enqueueMachineAfter(machine, holdPeriod, "target cluster unreachable")
+		} else if timeOut > 0 && !isMachinePending && !isMachineInPlaceUpdating && !disableHealthTimeout && c.healthTimeoutExtendedForCriticalComponents(node) {
+			// The node is still initializing, hence the health timeout is restarted instead of marking the machine Failed
+			description = fmt.Sprintf(
+				"Machine %s health checks failing since last %s minutes, but taint %q is still present on node %q. %s",
+				machine.Name,
+				timeOutDuration,
+				machineutils.TaintNodeCriticalComponentsNotReady,
+				node.Name,
+				machineutils.MachineHealthTimeoutExtended,
+			)
+			klog.Warning(description)
 
-			if isMachineInPlaceUpdating {
-				description = fmt.Sprintf(
+			clone.Status.LastOperation = v1alpha1.LastOperation{
+				Description:    description,
+				State:          v1alpha1.MachineStateProcessing,
+				Type:           v1alpha1.MachineOperationHealthCheck,
+				LastUpdateTime: metav1.Now(),
+			}
+			machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+				Phase:          v1alpha1.MachineUnknown,
+				LastUpdateTime: metav1.Now(),
+			})
+			cloneDirty = true
+		} else if timeOut > 0 {
+			// Machine health timeout occurred while joining or rejoining of machine
+
+			if !isMachinePending && !isMachineInPlaceUpdating && !disableHealthTimeout && c.shouldRebootMachine(machine, node) {
+				if err := c.rebootMachine(ctx, machine); err != nil {
+					klog.Warningf("Could not reboot VM of machine %q with NotReady node %q, replacing the machine instead: %v", machine.Name, getNodeName(machine), err)
+				} else {
+					// Restart the health timeout, so that the machine is only replaced if its node doesn't become Ready after the reboot
+					description = fmt.Sprintf(
+						"Machine %s health checks failing since last %s minutes, but its VM is healthy. %s",
+						machine.Name,
+						timeOutDuration,
+						machineutils.MachineRebootTriggered,
+					)
+					klog.Warning(description)
+					c.recorder.Eventf(machine, v1.EventTypeNormal, machineutils.MachineRebootedReason, "Rebooted VM of machine as its node %q is NotReady", getNodeName(machine))
+
+					clone.Status.LastOperation = v1alpha1.LastOperation{
+						Description:    description,
+						State:          v1alpha1.MachineStateProcessing,
+						Type:           v1alpha1.MachineOperationHealthCheck,
+						LastUpdateTime: metav1.Now(),
+					}
+					machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+						Phase:          v1alpha1.MachineUnknown,
+						LastUpdateTime: metav1.Now(),
+					})
+					cloneDirty = true
+				}
+			}
+
+			if !isMachinePending && !isMachineInPlaceUpdating && !disableHealthTimeout && !cloneDirty {
+				// Timeout occurred due to machine being unhealthy for too long
+				description = fmt.Sprintf(
+					"Machine %s health checks failing since last %s minutes. Updating machine phase to Failed. Node Conditions: %+v",
+					machine.Name,
+					timeOutDuration,
+					machine.Status.Conditions,
+				)
+
+				machineDeployName := getMachineDeploymentName(machine)
+				// creating lock for machineDeployment, if not allocated
+				c.permitGiver.RegisterPermits(machineDeployName, 1)
+				return c.tryMarkingMachineFailed(ctx, machine, clone, machineDeployName, description, lockAcquireTimeout)
+			}
+
+			if isMachineInPlaceUpdating {
+				description = fmt.Sprintf(
 					"Machine %s failed to in-place update in %s minutes.",
 					machine.Name,
 					timeOutDuration,
@@ -1042,10 +1665,10 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 					Type:           v1alpha1.MachineOperationInPlaceUpdate,
 					LastUpdateTime: metav1.Now(),
 				}
-				clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+				machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 					Phase:          v1alpha1.MachineInPlaceUpdateFailed,
 					LastUpdateTime: metav1.Now(),
-				}
+				})
 				cloneDirty = true
 			} else if isMachinePending {
 				// Timeout occurred while machine creation
@@ -1054,19 +1677,34 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 					machine.Name,
 					timeOutDuration,
 				)
+				if machine.Status.CurrentStatus.Phase == v1alpha1.MachineVerifying {
+					description = fmt.Sprintf(
+						"Machine %s failed to pass its verification in %s minutes.",
+						machine.Name,
+						timeOutDuration,
+					)
+				}
 				// Log the error message for machine failure
 				klog.Error(description)
 
+				if c.getOnCreationTimeoutPolicy(machine) == machineutils.OnCreationTimeoutPolicyRecreate {
+					if metav1.GetControllerOf(machine) != nil {
+						return c.recreateMachineOnCreationTimeout(ctx, machine, description)
+					}
+					// a machine without a controlling MachineSet wouldn't be recreated once deleted
+					klog.Warningf("Marking machine %q Failed instead of recreating it after its creation timeout, as it isn't controlled by a MachineSet", machine.Name)
+				}
+
 				clone.Status.LastOperation = v1alpha1.LastOperation{
 					Description:    description,
 					State:          v1alpha1.MachineStateFailed,
 					Type:           machine.Status.LastOperation.Type,
 					LastUpdateTime: metav1.Now(),
 				}
-				clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+				machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 					Phase:          v1alpha1.MachineFailed,
 					LastUpdateTime: metav1.Now(),
-				}
+				})
 				cloneDirty = true
 			}
 		} else {
@@ -1082,11 +1720,15 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 		if err != nil {
 			// Keep retrying across reconciles until update goes through
 			klog.Errorf("Update of Phase/Conditions failed for machine %q. Retrying, error: %q", machine.Name, err)
+			recordReconcileError(reconcileFlowHealth, err)
 			if apierrors.IsConflict(err) {
 				return machineutils.ConflictRetry, err
 			}
 		} else {
 			klog.V(2).Infof("Machine Phase/Conditions have been updated for %q with providerID %q and are in sync with backing node %q", machine.Name, getProviderID(machine), getNodeName(machine))
+			if conditionsChanged {
+				c.lastConditionsUpdates.Store(machine.Name, time.Now())
+			}
 			// Return error to end the reconcile
 			err = errSuccessfulPhaseUpdate
 		}
@@ -1094,9 +1736,284 @@ func (c *controller) reconcileMachineHealth(ctx context.Context, machine *v1alph
 		return machineutils.ShortRetry, err
 	}
 
+	if conditionsUpdateDelay > 0 {
+		c.enqueueMachineAfter(machine, conditionsUpdateDelay, "debounced update of conditions")
+	}
+
 	return machineutils.LongRetry, nil
 }
 
+// getConditionsUpdateDelay returns for how long the update of changed conditions of the machine has to be delayed,
+// so that they are written at most once per MachineConditionsUpdateInterval.
+func (c *controller) getConditionsUpdateDelay(machine *v1alpha1.Machine) time.Duration {
+	interval := c.safetyOptions.MachineConditionsUpdateInterval.Duration
+	if interval <= 0 {
+		return 0
+	}
+	lastUpdate, ok := c.lastConditionsUpdates.Load(machine.Name)
+	if !ok {
+		return 0
+	}
+	return max(interval-time.Since(lastUpdate.(time.Time)), 0)
+}
+
+// futureTimestampKey identifies a timestamp field of a machine in the futureTimestamps
+type futureTimestampKey struct {
+	uid   types.UID
+	field string
+}
+
+// futureTimestamp is a timestamp stored in the future and the local time at which it was first observed
+type futureTimestamp struct {
+	timestamp  time.Time
+	observedAt time.Time
+}
+
+// timeSinceStoredTimestamp returns the time elapsed since the timestamp stored in the given field of the machine,
+// to be compared with a timeout. A timestamp in the future points to clock skew between the control-plane nodes,
+// e.g. as it was written by a component whose clock is ahead. It is logged, and the time elapsed is measured from
+// when it was first observed instead, so that the timeout is required to last for its duration on the local clock
+// rather than being delayed until the local clock catches up.
+func (c *controller) timeSinceStoredTimestamp(machine *v1alpha1.Machine, field string, timestamp time.Time) time.Duration {
+	var (
+		key = futureTimestampKey{uid: machine.UID, field: field}
+		now = time.Now()
+	)
+
+	if observed, ok := c.futureTimestamps.Load(key); ok {
+		entry := observed.(*futureTimestamp)
+		if entry.timestamp.Equal(timestamp) {
+			return max(now.Sub(timestamp), now.Sub(entry.observedAt))
+		}
+		c.futureTimestamps.Delete(key)
+	}
+
+	if timestamp.After(now) {
+		klog.Warningf("%s %s of machine %q is %s in the future, possibly due to clock skew. Measuring its timeouts from now on", field, timestamp.Format(time.RFC3339), machine.Name, timestamp.Sub(now))
+		c.futureTimestamps.Store(key, &futureTimestamp{timestamp: timestamp, observedAt: now})
+		return 0
+	}
+	return now.Sub(timestamp)
+}
+
+// forgetFutureTimestamps removes the timestamps of the machine observed in the future
+func (c *controller) forgetFutureTimestamps(machine *v1alpha1.Machine) {
+	c.futureTimestamps.Range(func(key, _ any) bool {
+		if key.(futureTimestampKey).uid == machine.UID {
+			c.futureTimestamps.Delete(key)
+		}
+		return true
+	})
+}
+
+// reconcileMachineQuarantine puts the machine into the Quarantined phase and cordons its node while it has the
+// machineutils.MachineQuarantine annotation, and moves it back to the Unknown phase once the annotation is removed, so
+// that the health checks restart from scratch. The node is uncordoned on release only if it was cordoned for the quarantine.
+func (c *controller) reconcileMachineQuarantine(ctx context.Context, machine *v1alpha1.Machine) (machineutils.RetryPeriod, error) {
+	var (
+		quarantined = machineutils.IsMachineQuarantined(machine)
+		clone       = machine.DeepCopy()
+		description string
+		eventReason string
+	)
+
+	if err := c.updateQuarantineCordon(ctx, machine, quarantined); err != nil {
+		klog.Errorf("Could not update schedulability of node %q of quarantined machine %q: %s", getNodeName(machine), machine.Name, err)
+		recordReconcileError(reconcileFlowHealth, err)
+		return machineutils.ShortRetry, err
+	}
+
+	if quarantined {
+		if machine.Status.CurrentStatus.Phase == v1alpha1.MachineQuarantined {
+			return machineutils.LongRetry, nil
+		}
+		description = fmt.Sprintf("Machine %s is quarantined - its node is cordoned and health based actions are suspended", machine.Name)
+		eventReason = machineutils.MachineQuarantinedReason
+		machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+			Phase:          v1alpha1.MachineQuarantined,
+			LastUpdateTime: metav1.Now(),
+		})
+	} else {
+		description = fmt.Sprintf("Machine %s was released from quarantine - changing MachinePhase to Unknown to restart its health checks", machine.Name)
+		eventReason = machineutils.MachineQuarantineReleasedReason
+		machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
+			Phase:          v1alpha1.MachineUnknown,
+			LastUpdateTime: metav1.Now(),
+		})
+	}
+	clone.Status.LastOperation = v1alpha1.LastOperation{
+		Description:    description,
+		State:          v1alpha1.MachineStateSuccessful,
+		Type:           v1alpha1.MachineOperationHealthCheck,
+		LastUpdateTime: metav1.Now(),
+	}
+
+	_, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("Update of quarantine phase failed for machine %q. Retrying, error: %q", machine.Name, err)
+		recordReconcileError(reconcileFlowHealth, err)
+		if apierrors.IsConflict(err) {
+			return machineutils.ConflictRetry, err
+		}
+		return machineutils.ShortRetry, err
+	}
+	klog.V(2).Infof("%s with backing node %q and providerID %q", description, getNodeName(machine), getProviderID(machine))
+	c.recorder.Event(machine, v1.EventTypeNormal, eventReason, description)
+
+	// Return error to end the reconcile
+	return machineutils.ShortRetry, errSuccessfulPhaseUpdate
+}
+
+// updateQuarantineCordon cordons the node of a quarantined machine and marks it with the
+// machineutils.NodeCordonedForQuarantine annotation, unless the node is cordoned already. Once the machine is released,
+// only a node carrying the annotation is uncordoned, restoring the schedulability it had before the quarantine.
+func (c *controller) updateQuarantineCordon(ctx context.Context, machine *v1alpha1.Machine, quarantined bool) error {
+	node, err := c.nodeLister.Get(getNodeName(machine))
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	clone := node.DeepCopy()
+	_, cordonedForQuarantine := node.Annotations[machineutils.NodeCordonedForQuarantine]
+	switch {
+	case quarantined && !node.Spec.Unschedulable:
+		clone.Spec.Unschedulable = true
+		metav1.SetMetaDataAnnotation(&clone.ObjectMeta, machineutils.NodeCordonedForQuarantine, "true")
+	case !quarantined && cordonedForQuarantine:
+		clone.Spec.Unschedulable = false
+		delete(clone.Annotations, machineutils.NodeCordonedForQuarantine)
+	case !quarantined && node.Spec.Unschedulable:
+		klog.V(2).Infof("Not uncordoning node %q of machine %q released from quarantine, as it was cordoned before the quarantine", node.Name, machine.Name)
+		return nil
+	default:
+		return nil
+	}
+
+	_, err = c.targetCoreClient.CoreV1().Nodes().Update(ctx, clone, metav1.UpdateOptions{})
+	return err
+}
+
+// isNodeExternallyCordoned checks whether the node of the machine is cordoned by another actor than MCM. MCM cordons
+// the nodes of quarantined machines, and of machines being updated in-place, which carry the in-place update labels.
+// The nodes of machines which didn't join the cluster yet are left to the UnschedulableJoiningNodePolicy.
+func isNodeExternallyCordoned(machine *v1alpha1.Machine, node *v1.Node) bool {
+	if !node.Spec.Unschedulable || machineutils.IsMachineQuarantined(machine) {
+		return false
+	}
+	switch machine.Status.CurrentStatus.Phase {
+	case "", v1alpha1.MachinePending, v1alpha1.MachineVerifying, v1alpha1.MachineQuarantined, v1alpha1.MachineTerminating:
+		return false
+	}
+	for _, label := range []string{v1alpha1.LabelKeyNodeCandidateForUpdate, v1alpha1.LabelKeyNodeSelectedForUpdate, v1alpha1.LabelKeyNodeUpdateResult} {
+		if metav1.HasLabel(node.ObjectMeta, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// setNodeExternallyCordonedCondition returns the conditions of the machine with the machineutils.NodeExternallyCordoned
+// condition if its node is cordoned by another actor than MCM, keeping the transition time of the condition
+func setNodeExternallyCordonedCondition(machine *v1alpha1.Machine, node *v1.Node, conditions []v1.NodeCondition) []v1.NodeCondition {
+	conditions = slices.DeleteFunc(slices.Clone(conditions), func(condition v1.NodeCondition) bool {
+		return condition.Type == machineutils.NodeExternallyCordoned
+	})
+	if !isNodeExternallyCordoned(machine, node) {
+		return conditions
+	}
+
+	condition := v1.NodeCondition{
+		Type:               machineutils.NodeExternallyCordoned,
+		Status:             v1.ConditionTrue,
+		Reason:             "NodeUnschedulable",
+		Message:            fmt.Sprintf("Node %s is cordoned by another actor than MCM, which doesn't uncordon it", node.Name),
+		LastHeartbeatTime:  metav1.Now(),
+		LastTransitionTime: metav1.Now(),
+	}
+	if previous := getNodeExternallyCordonedCondition(machine); previous != nil {
+		condition.LastHeartbeatTime = previous.LastHeartbeatTime
+		condition.LastTransitionTime = previous.LastTransitionTime
+	}
+	return append(conditions, condition)
+}
+
+// getNodeExternallyCordonedCondition returns the machineutils.NodeExternallyCordoned condition of the machine, if its
+// node was last observed cordoned by another actor than MCM
+func getNodeExternallyCordonedCondition(machine *v1alpha1.Machine) *v1.NodeCondition {
+	for i := range machine.Status.Conditions {
+		if condition := &machine.Status.Conditions[i]; condition.Type == machineutils.NodeExternallyCordoned && condition.Status == v1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
+// uncordonJoiningNode uncordons the node of a newly created machine if it joined the cluster cordoned, e.g. by a
+// bootstrap flow keeping it unschedulable until it is ready, and the UnschedulableJoiningNodePolicy is uncordon.
+// The nodes of quarantined machines are kept cordoned.
+func (c *controller) uncordonJoiningNode(ctx context.Context, machine *v1alpha1.Machine, node *v1.Node) error {
+	if c.safetyOptions.UnschedulableJoiningNodePolicy != machineutils.UnschedulableJoiningNodePolicyUncordon ||
+		!node.Spec.Unschedulable || machineutils.IsMachineQuarantined(machine) {
+		return nil
+	}
+	klog.V(2).Infof("Uncordoning node %q of machine %q which joined the cluster cordoned", node.Name, machine.Name)
+	return c.setNodeUnschedulable(ctx, node.Name, false)
+}
+
+// removeNodeTaintsOnRecovery removes the taints with the nodeTaintsRemovedOnRecovery keys, e.g. the ones applied by
+// a remediation tooling while the machine was unhealthy, from the node of a machine which recovered. Other taints are
+// not touched. The node is re-fetched and the removal retried if the cached node is outdated.
+func (c *controller) removeNodeTaintsOnRecovery(ctx context.Context, machine *v1alpha1.Machine, node *v1.Node) error {
+	if len(c.nodeTaintsRemovedOnRecovery) == 0 {
+		return nil
+	}
+
+	firstTry := true
+	return clientretry.RetryOnConflict(nodeops.Backoff, func() error {
+		if !firstTry {
+			latestNode, err := c.targetCoreClient.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			node = latestNode
+		}
+		firstTry = false
+
+		taints := slices.DeleteFunc(slices.Clone(node.Spec.Taints), func(taint v1.Taint) bool {
+			return slices.Contains(c.nodeTaintsRemovedOnRecovery, taint.Key)
+		})
+		if len(taints) == len(node.Spec.Taints) {
+			return nil
+		}
+
+		klog.V(2).Infof("Removing %d taints from node %q of recovered machine %q", len(node.Spec.Taints)-len(taints), node.Name, machine.Name)
+		clone := node.DeepCopy()
+		clone.Spec.Taints = taints
+		_, err := c.targetCoreClient.CoreV1().Nodes().Update(ctx, clone, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// setNodeUnschedulable cordons or uncordons the node, if it exists
+func (c *controller) setNodeUnschedulable(ctx context.Context, nodeName string, unschedulable bool) error {
+	node, err := c.nodeLister.Get(nodeName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+
+	clone := node.DeepCopy()
+	clone.Spec.Unschedulable = unschedulable
+	_, err = c.targetCoreClient.CoreV1().Nodes().Update(ctx, clone, metav1.UpdateOptions{})
+	return err
+}
+
 func getFormattedNodeConditions(conditions []v1.NodeCondition) string {
 	var result string
 	if len(conditions) == 0 {
@@ -1149,13 +2066,31 @@ func (c *controller) deleteMachineFinalizers(ctx context.Context, machine *v1alp
 			return machineutils.ShortRetry, err
 		}
 
+		c.lastConditionsUpdates.Delete(machine.Name)
 		klog.V(2).Infof("Removed finalizer to machine %q with providerID %q and backing node %q", machine.Name, getProviderID(machine), getNodeName(machine))
+		c.recordDeletionAuditEvent(machine, machineutils.MachineFinalizerRemovedReason, fmt.Sprintf("Finalizer %q of the machine removed", MCMFinalizerName))
 		return machineutils.LongRetry, nil
 	}
 
 	return machineutils.LongRetry, nil
 }
 
+// recordDeletionAuditEvent emits an event for a final step of the deletion of the machine, i.e. the deletion of its VM
+// and node and the removal of its finalizer. As the steps run one after the other, so are their events emitted.
+// They are correlated by the UID of the machine, which is set as annotation and in the message of the event.
+func (c *controller) recordDeletionAuditEvent(machine *v1alpha1.Machine, reason, message string) {
+	correlationID := string(machine.UID)
+	c.recorder.AnnotatedEventf(
+		machine,
+		map[string]string{machineutils.MachineDeletionCorrelationID: correlationID},
+		v1.EventTypeNormal,
+		reason,
+		"%s (correlation ID %s)",
+		message,
+		correlationID,
+	)
+}
+
 /*
 SECTION
 Helper Functions
@@ -1180,11 +2115,141 @@ func (c *controller) isHealthy(machine *v1alpha1.Machine) bool {
 				return false
 			}
 		}
+
+		if c.providerConditions != "" {
+			for _, conditionType := range strings.Split(c.providerConditions, ",") {
+				if string(condition.Type) == conditionType && condition.Status == v1.ConditionTrue {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// getProviderConditions fetches the custom conditions of the machine's VM reported by the driver.
+// The driver is queried only if provider conditions are configured to be considered for the machine's health, and at
+// most once per ProviderConditionsRefreshInterval, or the MachineStatusCacheTTL if longer.
+func (c *controller) getProviderConditions(ctx context.Context, machine *v1alpha1.Machine) ([]v1.NodeCondition, error) {
+	if c.providerConditions == "" {
+		return nil, nil
+	}
+
+	machineClass, secretData, _, err := c.ValidateMachineClass(ctx, &machine.Spec.Class)
+	if err != nil {
+		return nil, err
+	}
+	ttl := max(c.safetyOptions.MachineStatusCacheTTL.Duration, machineutils.ProviderConditionsRefreshInterval)
+	response, err := c.getMachineStatusCached(ctx, &driver.GetMachineStatusRequest{
+		Machine:      machine,
+		MachineClass: machineClass,
+		Secret:       &v1.Secret{Data: secretData},
+	}, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return response.Conditions, nil
+}
+
+// cachedMachineStatus is the result of a GetMachineStatus call for a generation of a machine
+type cachedMachineStatus struct {
+	response   *driver.GetMachineStatusResponse
+	generation int64
+	fetchedAt  time.Time
+}
+
+// getMachineStatusCached returns the result of the GetMachineStatus call for the machine from the cache if it was
+// fetched for the current generation of the machine within the ttl, and calls the driver otherwise.
+// Errors returned by the driver are not cached.
+func (c *controller) getMachineStatusCached(ctx context.Context, request *driver.GetMachineStatusRequest, ttl time.Duration) (*driver.GetMachineStatusResponse, error) {
+	if ttl <= 0 {
+		return c.driver.GetMachineStatus(ctx, request)
+	}
+
+	machine := request.Machine
+	if cached, ok := c.machineStatusCache.Load(machine.UID); ok {
+		entry := cached.(*cachedMachineStatus)
+		if entry.generation == machine.Generation && time.Since(entry.fetchedAt) < ttl {
+			klog.V(4).Infof("Using the cached status of machine %q fetched at %s", machine.Name, entry.fetchedAt)
+			return entry.response, nil
+		}
+	}
+
+	response, err := c.driver.GetMachineStatus(ctx, request)
+	if err != nil {
+		c.machineStatusCache.Delete(machine.UID)
+		return nil, err
 	}
+	c.machineStatusCache.Store(machine.UID, &cachedMachineStatus{
+		response:   response,
+		generation: machine.Generation,
+		fetchedAt:  time.Now(),
+	})
+	return response, nil
+}
 
+// shouldRebootMachine returns true if the reboot-before-replace node NotReady policy is configured and the node of
+// the Unknown machine is NotReady, unless its VM was already rebooted since the machine became Unknown
+func (c *controller) shouldRebootMachine(machine *v1alpha1.Machine, node *v1.Node) bool {
+	if c.safetyOptions.NodeNotReadyPolicy != machineutils.NodeNotReadyPolicyRebootBeforeReplace ||
+		machine.Status.CurrentStatus.Phase != v1alpha1.MachineUnknown || node == nil {
+		return false
+	}
+	if strings.Contains(machine.Status.LastOperation.Description, machineutils.MachineRebootTriggered) {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status != v1.ConditionTrue
+		}
+	}
 	return true
 }
 
+// rebootMachine reboots the VM of the machine via the driver if the provider still reports the VM
+func (c *controller) rebootMachine(ctx context.Context, machine *v1alpha1.Machine) error {
+	machineClass, secretData, _, err := c.ValidateMachineClass(ctx, &machine.Spec.Class)
+	if err != nil {
+		return err
+	}
+	secret := &v1.Secret{Data: secretData}
+
+	if _, err := c.driver.GetMachineStatus(ctx, &driver.GetMachineStatusRequest{
+		Machine:      machine,
+		MachineClass: machineClass,
+		Secret:       secret,
+	}); err != nil {
+		return fmt.Errorf("VM isn't healthy: %w", err)
+	}
+
+	_, err = driver.RebootMachine(ctx, c.driver, &driver.RebootMachineRequest{
+		Machine:      machine,
+		MachineClass: machineClass,
+		Secret:       secret,
+	})
+	return err
+}
+
+// mergeProviderConditions returns the node conditions along with the provider conditions of types not reported by the node
+func mergeProviderConditions(nodeConditions, providerConditions []v1.NodeCondition) []v1.NodeCondition {
+	if len(providerConditions) == 0 {
+		return nodeConditions
+	}
+
+	nodeConditionTypes := sets.New[v1.NodeConditionType]()
+	for _, condition := range nodeConditions {
+		nodeConditionTypes.Insert(condition.Type)
+	}
+	conditions := append([]v1.NodeCondition{}, nodeConditions...)
+	for _, condition := range providerConditions {
+		if !nodeConditionTypes.Has(condition.Type) {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
 func criticalComponentsNotReadyTaintPresent(node *v1.Node) bool {
 	for _, taint := range node.Spec.Taints {
 		if taint.Key == machineutils.TaintNodeCriticalComponentsNotReady && taint.Effect == v1.TaintEffectNoSchedule {
@@ -1194,6 +2259,52 @@ func criticalComponentsNotReadyTaintPresent(node *v1.Node) bool {
 	return false
 }
 
+// criticalComponentsNotReadyTimeoutOccurred checks whether the critical-components-not-ready taint
+// is still present on the node longer than the MachineCriticalComponentsNotReadyTimeout after it joined.
+// A timeout of 0 disables the check, leaving such machines to the creation timeout.
+func (c *controller) criticalComponentsNotReadyTimeoutOccurred(node *v1.Node) bool {
+	timeOutDuration := c.safetyOptions.MachineCriticalComponentsNotReadyTimeout.Duration
+	if node == nil || timeOutDuration <= 0 || !criticalComponentsNotReadyTaintPresent(node) {
+		return false
+	}
+	return utiltime.HasTimeOutOccurred(node.CreationTimestamp, timeOutDuration)
+}
+
+// healthTimeoutExtendedForCriticalComponents checks whether the health timeout of a machine is extended as its node
+// still has the critical-components-not-ready taint, i.e. is still initializing. The extension ends once the taint is
+// present longer than the MachineCriticalComponentsNotReadyTimeout after the node joined, hence a timeout of 0
+// disables it.
+func (c *controller) healthTimeoutExtendedForCriticalComponents(node *v1.Node) bool {
+	return node != nil &&
+		c.safetyOptions.MachineCriticalComponentsNotReadyTimeout.Duration > 0 &&
+		criticalComponentsNotReadyTaintPresent(node) &&
+		!c.criticalComponentsNotReadyTimeoutOccurred(node)
+}
+
+// isMachineVerificationPending checks whether the newly joined machine still has to pass the verification configured
+// on its MachineClass, i.e. the node condition named by the machineutils.MachineClassVerificationCondition annotation
+// isn't True yet. It returns the type of the node condition.
+func (c *controller) isMachineVerificationPending(machine *v1alpha1.Machine, node *v1.Node) (string, bool) {
+	if machine.Status.LastOperation.Type != v1alpha1.MachineOperationCreate || machine.Status.LastOperation.State == v1alpha1.MachineStateSuccessful {
+		return "", false
+	}
+	machineClass, err := c.machineClassLister.MachineClasses(machine.Namespace).Get(machine.Spec.Class.Name)
+	if err != nil {
+		klog.V(4).Infof("Could not fetch MachineClass %q of machine %q to check for its verification: %s", machine.Spec.Class.Name, machine.Name, err)
+		return "", false
+	}
+	verificationCondition := machineClass.Annotations[machineutils.MachineClassVerificationCondition]
+	if verificationCondition == "" {
+		return "", false
+	}
+	for _, condition := range node.Status.Conditions {
+		if string(condition.Type) == verificationCondition && condition.Status == v1.ConditionTrue {
+			return verificationCondition, false
+		}
+	}
+	return verificationCondition, true
+}
+
 func isPendingMachineWithCriticalComponentsNotReadyTaint(clone *v1alpha1.Machine, node *v1.Node) bool {
 	if clone.Status.CurrentStatus.Phase == v1alpha1.MachinePending && criticalComponentsNotReadyTaintPresent(node) {
 		klog.V(3).Infof("Critical component taint %q still present on node %q for machine %q", machineutils.TaintNodeCriticalComponentsNotReady, getNodeName(clone), clone.Name)
@@ -1216,18 +2327,20 @@ func (c *controller) setMachineTerminationStatus(ctx context.Context, deleteMach
 		Type:           v1alpha1.MachineOperationDelete,
 		LastUpdateTime: metav1.Now(),
 	}
-	clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+	machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 		Phase: v1alpha1.MachineTerminating,
 		// TimeoutActive:  false,
 		LastUpdateTime: metav1.Now(),
-	}
+	})
+	clone.Status.ObservedGeneration = clone.Generation
 
-	_, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
+	updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
 	if err != nil {
 		// Keep retrying until update goes through
 		klog.Errorf("Machine/status UPDATE failed for machine %q. Retrying, error: %s", deleteMachineRequest.Machine.Name, err)
 	} else {
 		klog.V(2).Infof("Machine %q status updated to terminating ", deleteMachineRequest.Machine.Name)
+		c.recordFlowStep(ctx, updatedMachine, clone.Status.LastOperation.Description)
 		// Return error even when machine object is updated to ensure reconcilation is restarted
 		err = fmt.Errorf("Machine deletion in process. Phase set to termination")
 	}
@@ -1385,11 +2498,11 @@ func (c *controller) drainNodeForInPlace(ctx context.Context, machine *v1alpha1.
 		klog.Warningf("(drainNode) Node %q for machine %q doesn't exist, so drain will finish instantly", nodeName, machine.Name)
 	}
 
-	if !isConditionEmpty(nodeReadyCondition) && (nodeReadyCondition.Status != v1.ConditionTrue) && (time.Since(nodeReadyCondition.LastTransitionTime.Time) > nodeNotReadyDuration) {
+	if !isConditionEmpty(nodeReadyCondition) && (nodeReadyCondition.Status != v1.ConditionTrue) && (c.timeSinceStoredTimestamp(machine, "LastTransitionTime of the Ready condition", nodeReadyCondition.LastTransitionTime.Time) > nodeNotReadyDuration) {
 		message := "Setting forceDeletePods to true for drain as machine is NotReady for over 5min"
 		forceDeletePods = true
 		printLogInitError(message, &err, &description, machine, true)
-	} else if !isConditionEmpty(readOnlyFileSystemCondition) && (readOnlyFileSystemCondition.Status != v1.ConditionFalse) && (time.Since(readOnlyFileSystemCondition.LastTransitionTime.Time) > nodeNotReadyDuration) {
+	} else if !isConditionEmpty(readOnlyFileSystemCondition) && (readOnlyFileSystemCondition.Status != v1.ConditionFalse) && (c.timeSinceStoredTimestamp(machine, "LastTransitionTime of the ReadonlyFilesystem condition", readOnlyFileSystemCondition.LastTransitionTime.Time) > nodeNotReadyDuration) {
 		message := "Setting forceDeletePods to true for drain as machine is in ReadonlyFilesystem for over 5min"
 		forceDeletePods = true
 		printLogInitError(message, &err, &description, machine, true)
@@ -1426,6 +2539,18 @@ func (c *controller) drainNodeForInPlace(ctx context.Context, machine *v1alpha1.
 		)
 	}
 
+	if hookErr := c.runPreDrainHooks(ctx, machine, nodeName); hookErr != nil {
+		if forceDeletePods {
+			klog.Warningf("(drainNode) Pre-drain hook failed for machine %q: %v. However, since it's a force drain shall continue the drain.", machine.Name, hookErr)
+		} else {
+			klog.Warningf("(drainNode) Holding the in-place update drain of machine %q as a pre-drain hook failed: %v", machine.Name, hookErr)
+
+			description = fmt.Sprintf("%s - %s. Will retry in next sync. %s", machineutils.PreDrainHookFailed, hookErr, machineutils.InitiateDrain)
+			recordReconcileError(reconcileFlowInPlace, hookErr)
+			return c.updateMachineStatusAndNodeCondition(ctx, machine, description, v1alpha1.MachineStateProcessing, hookErr)
+		}
+	}
+
 	buf := bytes.NewBuffer([]byte{})
 	errBuf := bytes.NewBuffer([]byte{})
 
@@ -1447,12 +2572,19 @@ func (c *controller) drainNodeForInPlace(ctx context.Context, machine *v1alpha1.
 		c.driver,
 		c.pvcLister,
 		c.pvLister,
+		c.storageClassLister,
 		c.pdbLister,
 		c.nodeLister,
 		c.podLister,
 		c.volumeAttachmentHandler,
 		c.podSynced,
 	)
+	drainOptions.DeleteOnly = isDeleteOnlyDrain(machine)
+	drainOptions.EvictionLimiter = c.evictionLimiter
+	drainOptions.NodeLocalVolumePodPolicy = c.safetyOptions.NodeLocalVolumePodDrainPolicy
+	drainOptions.TerminalPodPolicy = c.safetyOptions.TerminalPodDrainPolicy
+	drainOptions.PriorityOrderedEviction = c.safetyOptions.PriorityOrderedDrainEviction
+	drainOptions.SkipCordon = machine.Annotations[machineutils.MachineInPlaceUpdateSkipCordon] == "true"
 
 	klog.V(3).Infof("(drainNode) Invoking RunDrain, forceDeletePods: %t, timeOutDuration: %s", forceDeletePods, timeOutDuration)
 	err = drainOptions.RunDrain(ctx)
@@ -1471,11 +2603,52 @@ func (c *controller) drainNodeForInPlace(ctx context.Context, machine *v1alpha1.
 
 		description = fmt.Sprintf("Drain failed due to - %s. Will retry in next sync. %s", err.Error(), machineutils.InitiateDrain)
 		state = v1alpha1.MachineStateProcessing
+		recordReconcileError(reconcileFlowInPlace, err)
 	}
 
 	return c.updateMachineStatusAndNodeCondition(ctx, machine, description, state, err)
 }
 
+// targetClusterProbe is the result of probing the reachability of the target cluster and the time of the probe
+type targetClusterProbe struct {
+	unreachable bool
+	probedAt    time.Time
+}
+
+// isTargetClusterUnreachable returns whether the APIServer of the target cluster is unreachable, probed by a dummy GET call
+// on node objects. The result of the probe is reused for the TargetClusterReachabilityCacheTTL. It returns false if
+// the machine controller runs without a target cluster or the hold of machines during the unreachability of the
+// target cluster is disabled.
+func (c *controller) isTargetClusterUnreachable(ctx context.Context) bool {
+	if c.targetCoreClient == nil || c.safetyOptions.TargetClusterUnreachableRetryPeriod.Duration <= 0 {
+		return false
+	}
+	if probe := c.targetClusterReachability.Load(); probe != nil && time.Since(probe.probedAt) < machineutils.TargetClusterReachabilityCacheTTL {
+		return probe.unreachable
+	}
+	_, err := c.targetCoreClient.CoreV1().Nodes().Get(ctx, "dummy_name", metav1.GetOptions{})
+	unreachable := c.isTargetClusterUnreachableError(err)
+	c.targetClusterReachability.Store(&targetClusterProbe{unreachable: unreachable, probedAt: time.Now()})
+	return unreachable
+}
+
+// isTargetClusterUnreachableError returns whether the error of a call to the target cluster is caused by its APIServer
+// being unreachable or unavailable, rather than by the call itself. It returns false if the hold of machines during
+// the unreachability of the target cluster is disabled.
+func (c *controller) isTargetClusterUnreachableError(err error) bool {
+	if err == nil || c.safetyOptions.TargetClusterUnreachableRetryPeriod.Duration <= 0 {
+		return false
+	}
+	if apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	if utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
 // drainNode attempts to drain the node backed by the machine object
 func (c *controller) drainNode(ctx context.Context, deleteMachineRequest *driver.DeleteMachineRequest) (machineutils.RetryPeriod, error) {
 	var (
@@ -1491,13 +2664,14 @@ func (c *controller) drainNode(ctx context.Context, deleteMachineRequest *driver
 		readOnlyFileSystemCondition, nodeReadyCondition v1.NodeCondition
 
 		// Initialization
+		retryPeriod                               = machineutils.ShortRetry
 		machine                                   = deleteMachineRequest.Machine
 		maxEvictRetries                           = int32(math.Min(float64(*c.getEffectiveMaxEvictRetries(machine)), c.getEffectiveDrainTimeout(machine).Seconds()/drain.PodEvictionRetryInterval.Seconds()))
 		pvDetachTimeOut                           = c.safetyOptions.PvDetachTimeout.Duration
 		pvReattachTimeOut                         = c.safetyOptions.PvReattachTimeout.Duration
 		timeOutDuration                           = c.getEffectiveDrainTimeout(deleteMachineRequest.Machine).Duration
 		nodeName                                  = machine.Labels[v1alpha1.NodeLabelKey]
-		nodeNotReadyDuration                      = 5 * time.Minute
+		nodeNotReadyDuration                      = c.getEffectiveNodeNotReadyForceDrainTimeout(nodeName)
 		ReadonlyFilesystem   v1.NodeConditionType = "ReadonlyFilesystem"
 	)
 
@@ -1526,13 +2700,15 @@ func (c *controller) drainNode(ctx context.Context, deleteMachineRequest *driver
 			klog.Warningf("(drainNode) Node %q for machine %q doesn't exist, so drain will finish instantly", nodeName, machine.Name)
 		}
 
-		if !isConditionEmpty(nodeReadyCondition) && (nodeReadyCondition.Status != v1.ConditionTrue) && (time.Since(nodeReadyCondition.LastTransitionTime.Time) > nodeNotReadyDuration) {
-			message := "Setting forceDeletePods & forceDeleteMachine to true for drain as machine is NotReady for over 5min"
+		if machineutils.IsMachineQuarantined(machine) {
+			klog.V(2).Infof("(drainNode) Not forcing the drain of quarantined machine %q based on the conditions of node %q", machine.Name, nodeName)
+		} else if !isConditionEmpty(nodeReadyCondition) && (nodeReadyCondition.Status != v1.ConditionTrue) && (c.timeSinceStoredTimestamp(machine, "LastTransitionTime of the Ready condition", nodeReadyCondition.LastTransitionTime.Time) > nodeNotReadyDuration) {
+			message := fmt.Sprintf("Setting forceDeletePods & forceDeleteMachine to true for drain as machine is NotReady for over %s", nodeNotReadyDuration)
 			forceDeleteMachine = true
 			forceDeletePods = true
 			printLogInitError(message, &err, &description, machine, false)
-		} else if !isConditionEmpty(readOnlyFileSystemCondition) && (readOnlyFileSystemCondition.Status != v1.ConditionFalse) && (time.Since(readOnlyFileSystemCondition.LastTransitionTime.Time) > nodeNotReadyDuration) {
-			message := "Setting forceDeletePods & forceDeleteMachine to true for drain as machine is in ReadonlyFilesystem for over 5min"
+		} else if !isConditionEmpty(readOnlyFileSystemCondition) && (readOnlyFileSystemCondition.Status != v1.ConditionFalse) && (c.timeSinceStoredTimestamp(machine, "LastTransitionTime of the ReadonlyFilesystem condition", readOnlyFileSystemCondition.LastTransitionTime.Time) > nodeNotReadyDuration) {
+			message := fmt.Sprintf("Setting forceDeletePods & forceDeleteMachine to true for drain as machine is in ReadonlyFilesystem for over %s", nodeNotReadyDuration)
 			forceDeleteMachine = true
 			forceDeletePods = true
 			printLogInitError(message, &err, &description, machine, false)
@@ -1541,6 +2717,14 @@ func (c *controller) drainNode(ctx context.Context, deleteMachineRequest *driver
 
 	if skipDrain {
 		state = v1alpha1.MachineStateProcessing
+	} else if !forceDeleteLabelPresent && c.isTargetClusterUnreachable(ctx) {
+		// neither the node nor its pods can be observed, hence the drain is held instead of being forced or failed
+		klog.Warningf("(drainNode) Holding the drain of machine %q as the APIServer of the target cluster is unreachable", machine.Name)
+
+		description = fmt.Sprintf("%s. Will retry in next sync. %s", machineutils.TargetClusterUnreachable, machineutils.InitiateDrain)
+		err = fmt.Errorf("%s", description)
+		state = v1alpha1.MachineStateProcessing
+		retryPeriod = machineutils.RetryPeriod(c.safetyOptions.TargetClusterUnreachableRetryPeriod.Duration)
 	} else {
 		timeOutOccurred = utiltime.HasTimeOutOccurred(*machine.DeletionTimestamp, timeOutDuration)
 
@@ -1573,20 +2757,72 @@ func (c *controller) drainNode(ctx context.Context, deleteMachineRequest *driver
 			)
 		}
 
-		// update node with the machine's phase prior to termination
-		if err = c.UpdateNodeTerminationCondition(ctx, machine); err != nil {
+		// update node with the machine's phase prior to termination, unless it is only to be set once the node is drained
+		var conditionSet bool
+		setConditionAfterDrain := c.safetyOptions.NodeTerminationConditionTiming == machineutils.NodeTerminationConditionTimingAfterDrain
+		if setConditionAfterDrain {
+			klog.V(3).Infof("(drainNode) Deferring the termination condition of node %q of machine %q until it is drained", nodeName, machine.Name)
+		} else if conditionSet, err = c.UpdateNodeTerminationCondition(ctx, machine); err != nil {
 			if forceDeleteMachine {
 				klog.Warningf("Failed to update node conditions: %v. However, since it's a force deletion shall continue deletion of VM.", err)
+			} else if c.isTargetClusterUnreachableError(err) {
+				klog.Warningf("(drainNode) Holding the drain of machine %q as the APIServer of the target cluster is unreachable: %v", machine.Name, err)
+
+				description = fmt.Sprintf("%s - %s. Will retry in next sync. %s", machineutils.TargetClusterUnreachable, err.Error(), machineutils.InitiateDrain)
+				state = v1alpha1.MachineStateProcessing
+				retryPeriod = machineutils.RetryPeriod(c.safetyOptions.TargetClusterUnreachableRetryPeriod.Duration)
+
+				skipDrain = true
 			} else {
 				klog.Errorf("Drain failed due to failure in update of node conditions: %v", err)
 
 				description = fmt.Sprintf("Drain failed due to failure in update of node conditions - %s. Will retry in next sync. %s", err.Error(), machineutils.InitiateDrain)
 				state = v1alpha1.MachineStateFailed
 
+				skipDrain = true
+			}
+		} else if propagationRetry := c.safetyOptions.NodeTerminationConditionPropagationRetry.Duration; conditionSet && propagationRetry > 0 && !forceDeleteMachine {
+			// give the newly set condition time to propagate to the caches before draining the node
+			klog.V(2).Infof("Set termination condition on node %q of machine %q, waiting %s for its propagation before the drain", nodeName, machine.Name, propagationRetry)
+
+			description = fmt.Sprintf("%s. %s", machineutils.NodeTerminationConditionSet, machineutils.InitiateDrain)
+			err = fmt.Errorf("%s", description)
+			state = v1alpha1.MachineStateProcessing
+			retryPeriod = machineutils.RetryPeriod(propagationRetry)
+
+			skipDrain = true
+		}
+
+		if !skipDrain && !forceDeletePods && c.safetyOptions.NoExecuteTaintDrainPolicy == machineutils.NoExecuteTaintDrainPolicyWait {
+			if taint, evictedPods := c.getNoExecuteTaintEvictions(nodeName); taint != nil {
+				// the pods are already evicted due to the taint, hence they aren't evicted concurrently by the drain
+				klog.V(2).Infof("(drainNode) Holding the drain of machine %q as %d pod(s) on node %q are evicted due to its NoExecute taint %q", machine.Name, evictedPods, nodeName, taint.Key)
+
+				description = fmt.Sprintf("%s %q, %d pod(s) remaining. Will retry in next sync. %s", machineutils.NoExecuteTaintEvictionInProgress, taint.Key, evictedPods, machineutils.InitiateDrain)
+				err = fmt.Errorf("%s", description)
+				state = v1alpha1.MachineStateProcessing
+
 				skipDrain = true
 			}
 		}
 
+		if !skipDrain {
+			if hookErr := c.runPreDrainHooks(ctx, machine, nodeName); hookErr != nil {
+				if forceDeleteMachine {
+					klog.Warningf("(drainNode) Pre-drain hook failed for machine %q: %v. However, since it's a force deletion shall continue the drain.", machine.Name, hookErr)
+				} else {
+					klog.Warningf("(drainNode) Holding the drain of machine %q as a pre-drain hook failed: %v", machine.Name, hookErr)
+
+					description = fmt.Sprintf("%s - %s. Will retry in next sync. %s", machineutils.PreDrainHookFailed, hookErr, machineutils.InitiateDrain)
+					err = fmt.Errorf("%s", description)
+					state = v1alpha1.MachineStateProcessing
+					recordReconcileError(reconcileFlowDelete, hookErr)
+
+					skipDrain = true
+				}
+			}
+		}
+
 		if !skipDrain {
 			buf := bytes.NewBuffer([]byte{})
 			errBuf := bytes.NewBuffer([]byte{})
@@ -1609,25 +2845,70 @@ func (c *controller) drainNode(ctx context.Context, deleteMachineRequest *driver
 				c.driver,
 				c.pvcLister,
 				c.pvLister,
+				c.storageClassLister,
 				c.pdbLister,
 				c.nodeLister,
 				c.podLister,
 				c.volumeAttachmentHandler,
 				c.podSynced,
 			)
-			klog.V(3).Infof("(drainNode) Invoking RunDrain, forceDeleteMachine: %t, forceDeletePods: %t, timeOutDuration: %s", forceDeletePods, forceDeleteMachine, timeOutDuration)
-			err = drainOptions.RunDrain(ctx)
+			drainOptions.DeleteOnly = isDeleteOnlyDrain(machine)
+			drainOptions.EvictionLimiter = c.evictionLimiter
+			drainOptions.NodeLocalVolumePodPolicy = c.safetyOptions.NodeLocalVolumePodDrainPolicy
+			drainOptions.TerminalPodPolicy = c.safetyOptions.TerminalPodDrainPolicy
+			drainOptions.PriorityOrderedEviction = c.safetyOptions.PriorityOrderedDrainEviction
+			drainOptions.SkipCordon = c.isSoleMachineOfPool(machine)
+			drainOptions.SkipPodSelector = getDrainSkipPodSelector(deleteMachineRequest.MachineClass)
+			// the progress updates change the machine, hence the latest version is used for the status update after the drain
+			progressMachine := machine
+			if progressInterval := c.safetyOptions.MachineDrainProgressUpdateInterval.Duration; progressInterval > 0 {
+				drainOptions.ProgressInterval = progressInterval
+				drainOptions.ProgressFn = func(remainingPods int, elapsed time.Duration) {
+					if updatedMachine, err := c.updateDrainProgress(ctx, progressMachine, remainingPods, elapsed); err == nil {
+						progressMachine = updatedMachine
+					}
+				}
+			}
+			fastPath := !forceDeletePods && c.safetyOptions.DrainFastPathForNodesWithoutEvictablePods && hasNoEvictablePods(drainOptions, nodeName)
+			if fastPath {
+				// the node is still cordoned, only the eviction of pods and the wait for the detachment of volumes are skipped
+				klog.V(2).Infof("(drainNode) Skipping the eviction of pods from node %q of machine %q as it has no pods to evict", nodeName, machine.Name)
+				if !drainOptions.SkipCordon {
+					err = drainOptions.RunCordonOrUncordon(ctx, true)
+				}
+			} else {
+				klog.V(3).Infof("(drainNode) Invoking RunDrain, forceDeleteMachine: %t, forceDeletePods: %t, timeOutDuration: %s", forceDeletePods, forceDeleteMachine, timeOutDuration)
+				err = drainOptions.RunDrain(ctx)
+			}
+			machine = progressMachine
 			if err == nil {
 				// Drain successful
 				klog.V(2).Infof("Drain successful for machine %q ,providerID %q, backing node %q. \nBuf:%v \nErrBuf:%v", machine.Name, getProviderID(machine), getNodeName(machine), buf, errBuf)
 
-				if forceDeletePods {
+				if fastPath {
+					description = fmt.Sprintf("%s. %s", machineutils.DrainSkippedNoEvictablePods, machineutils.InitiateVMDeletion)
+				} else if forceDeletePods {
 					description = fmt.Sprintf("Force Drain successful. %s", machineutils.DelVolumesAttachments)
 				} else { // regular drain already waits for vol detach and attach for another node.
 					description = fmt.Sprintf("Drain successful. %s", machineutils.InitiateVMDeletion)
 				}
 				err = fmt.Errorf("%s", description)
 				state = v1alpha1.MachineStateProcessing
+				machine = setDrainBlockedByWebhookCondition(machine, nil)
+
+				if setConditionAfterDrain {
+					if _, updateErr := c.UpdateNodeTerminationCondition(ctx, machine); updateErr != nil {
+						if forceDeleteMachine {
+							klog.Warningf("Failed to update node conditions: %v. However, since it's a force deletion shall continue deletion of VM.", updateErr)
+						} else {
+							klog.Errorf("Failed to update node conditions after the drain: %v", updateErr)
+
+							description = fmt.Sprintf("Drain successful but failed to update node conditions - %s. Will retry in next sync. %s", updateErr.Error(), machineutils.InitiateDrain)
+							err = fmt.Errorf("%s", description)
+							state = v1alpha1.MachineStateFailed
+						}
+					}
+				}
 
 				// Return error even when machine object is updated
 			} else if err != nil && forceDeleteMachine {
@@ -1636,11 +2917,30 @@ func (c *controller) drainNode(ctx context.Context, deleteMachineRequest *driver
 
 				description = fmt.Sprintf("Drain failed due to - %s. However, since it's a force deletion shall continue deletion of VM. %s", err.Error(), machineutils.DelVolumesAttachments)
 				state = v1alpha1.MachineStateProcessing
+				recordReconcileError(reconcileFlowDelete, err)
+
+				if setConditionAfterDrain {
+					if _, updateErr := c.UpdateNodeTerminationCondition(ctx, machine); updateErr != nil {
+						klog.Warningf("Failed to update node conditions: %v. However, since it's a force deletion shall continue deletion of VM.", updateErr)
+					}
+				}
+			} else if c.isTargetClusterUnreachableError(err) {
+				klog.Warningf("(drainNode) Holding the drain of machine %q as the APIServer of the target cluster is unreachable: %v", machine.Name, err)
+
+				description = fmt.Sprintf("%s - %s. Will retry in next sync. %s", machineutils.TargetClusterUnreachable, err.Error(), machineutils.InitiateDrain)
+				state = v1alpha1.MachineStateProcessing
+				retryPeriod = machineutils.RetryPeriod(c.safetyOptions.TargetClusterUnreachableRetryPeriod.Duration)
 			} else {
 				klog.Warningf("Drain failed for machine %q , providerID %q ,backing node %q. \nBuf:%v \nErrBuf:%v \nErr-Message:%v", machine.Name, getProviderID(machine), getNodeName(machine), buf, errBuf, err)
 
 				description = fmt.Sprintf("Drain failed due to - %s. Will retry in next sync. %s", err.Error(), machineutils.InitiateDrain)
 				state = v1alpha1.MachineStateFailed
+				recordReconcileError(reconcileFlowDelete, err)
+
+				if rejection, ok := drain.GetWebhookRejection(err); ok {
+					klog.Warningf("(drainNode) Drain of machine %q is blocked as admission webhook %q denies the eviction of pods: %s", machine.Name, rejection.Webhook, rejection.Message)
+					machine = setDrainBlockedByWebhookCondition(machine, rejection)
+				}
 			}
 		}
 	}
@@ -1665,7 +2965,123 @@ func (c *controller) drainNode(ctx context.Context, deleteMachineRequest *driver
 		return updateRetryPeriod, updateErr
 	}
 
-	return machineutils.ShortRetry, err
+	return retryPeriod, err
+}
+
+// getNoExecuteTaintEvictions returns a NoExecute taint of another controller on the node along with the number of pods
+// a drain would evict which don't tolerate it, i.e. which are evicted due to the taint. The taints maintained by the
+// machine controller itself, i.e. the unhealthy taint and the nodeLabelTaints, are not considered. It returns nil if
+// there is no such taint or all pods tolerate it.
+func (c *controller) getNoExecuteTaintEvictions(nodeName string) (*v1.Taint, int) {
+	node, err := c.nodeLister.Get(nodeName)
+	if err != nil {
+		return nil, 0
+	}
+
+	var pods []v1.Pod
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect != v1.TaintEffectNoExecute || taint.Key == machineutils.TaintNodeUnhealthy || c.isNodeLabelTaint(taint) {
+			continue
+		}
+		if pods == nil {
+			if pods, err = drain.DryRun(c.podLister, nodeName); err != nil {
+				klog.Warningf("Failed to list the pods evicted due to the NoExecute taints of node %q, draining it regardless: %v", nodeName, err)
+				return nil, 0
+			}
+		}
+
+		evictedPods := 0
+		for _, pod := range pods {
+			if !tolerationsTolerateTaint(pod.Spec.Tolerations, taint) {
+				evictedPods++
+			}
+		}
+		if evictedPods > 0 {
+			return taint, evictedPods
+		}
+	}
+	return nil, 0
+}
+
+// isNodeLabelTaint returns true if the taint is one of the nodeLabelTaints maintained by the machine controller
+func (c *controller) isNodeLabelTaint(taint *v1.Taint) bool {
+	for _, nodeLabelTaint := range c.nodeLabelTaints {
+		if nodeLabelTaint.Taint.MatchTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// tolerationsTolerateTaint returns true if any of the tolerations tolerates the taint
+func tolerationsTolerateTaint(tolerations []v1.Toleration, taint *v1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNoEvictablePods returns true if the node has no pods the drain with the options would evict or delete, e.g. as it
+// only runs DaemonSet and mirror pods. It returns false if the pods can't be listed, so that the node is drained regardless.
+func hasNoEvictablePods(drainOptions *drain.Options, nodeName string) bool {
+	pods, err := drainOptions.DryRun()
+	if err != nil {
+		klog.Warningf("Failed to list the pods to evict from node %q, draining it regardless: %v", nodeName, err)
+		return false
+	}
+	return len(pods) == 0
+}
+
+// updateDrainProgress updates the last operation of the machine with the number of pods remaining on its node
+// and the time elapsed while it is drained. Failures are only logged, as the drain continues regardless.
+func (c *controller) updateDrainProgress(ctx context.Context, machine *v1alpha1.Machine, remainingPods int, elapsed time.Duration) (*v1alpha1.Machine, error) {
+	clone := machine.DeepCopy()
+	clone.Status.LastOperation.Description = fmt.Sprintf("Drain in progress, %d pod(s) remaining after %s. %s", remainingPods, elapsed.Round(time.Second), machineutils.InitiateDrain)
+	clone.Status.LastOperation.LastUpdateTime = metav1.Now()
+
+	updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Warningf("Failed to update the drain progress of machine %q: %v", machine.Name, err)
+		return nil, err
+	}
+	klog.V(3).Infof("Drain of machine %q in progress, %d pod(s) remaining after %s", machine.Name, remainingPods, elapsed.Round(time.Second))
+	return updatedMachine, nil
+}
+
+// setDrainBlockedByWebhookCondition returns a copy of the machine with the DrainBlockedByWebhook condition naming the
+// admission webhook which denied the eviction of a pod. A nil rejection clears a condition set by an earlier drain.
+func setDrainBlockedByWebhookCondition(machine *v1alpha1.Machine, rejection *drain.WebhookRejection) *v1alpha1.Machine {
+	var condition v1.NodeCondition
+	if rejection != nil {
+		message := rejection.Message
+		if message == "" {
+			message = "no reason given"
+		}
+		condition = v1.NodeCondition{
+			Type:               machineutils.MachineDrainBlockedByWebhook,
+			Status:             v1.ConditionTrue,
+			Reason:             machineutils.MachineDrainBlockedByWebhookReason,
+			Message:            fmt.Sprintf("Admission webhook %q denied the eviction of a pod: %s", rejection.Webhook, message),
+			LastTransitionTime: metav1.Now(),
+		}
+	} else if cond := getMachineCondition(machine, machineutils.MachineDrainBlockedByWebhook); cond != nil && cond.Status == v1.ConditionTrue {
+		condition = v1.NodeCondition{
+			Type:               machineutils.MachineDrainBlockedByWebhook,
+			Status:             v1.ConditionFalse,
+			Reason:             "DrainSuccessful",
+			Message:            "The node was drained",
+			LastTransitionTime: metav1.Now(),
+		}
+	} else {
+		return machine
+	}
+
+	clone := machine.DeepCopy()
+	clone.Status.Conditions = nodeops.CloneAndAddCondition(clone.Status.Conditions, condition)
+	return clone
 }
 
 // deleteNodeVolAttachments deletes VolumeAttachment(s) for a node before moving to VM deletion stage.
@@ -1698,121 +3114,953 @@ func (c *controller) deleteNodeVolAttachments(ctx context.Context, deleteMachine
 			klog.Errorf("(deleteNodeVolAttachments) Error obtaining VolumeAttachment(s) for node %q, machine %q: %s", nodeName, machine.Name, err)
 			return retryPeriod, err
 		}
-		if len(liveNodeVolAttachments) != 0 {
-			err = deleteVolumeAttachmentsForNode(ctx, c.targetCoreClient.StorageV1().VolumeAttachments(), nodeName, liveNodeVolAttachments)
-			if err != nil {
-				klog.Errorf("(deleteNodeVolAttachments) Error deleting volume attachments for node %q, machine %q: %s", nodeName, machine.Name, err)
-			} else {
-				klog.V(3).Infof("(deleteNodeVolAttachments) Successfully deleted all volume attachments for node %q, machine %q", nodeName, machine.Name)
-			}
-			return retryPeriod, nil
+		if len(liveNodeVolAttachments) != 0 {
+			err = deleteVolumeAttachmentsForNode(ctx, c.targetCoreClient.StorageV1().VolumeAttachments(), nodeName, liveNodeVolAttachments)
+			if err != nil {
+				klog.Errorf("(deleteNodeVolAttachments) Error deleting volume attachments for node %q, machine %q: %s", nodeName, machine.Name, err)
+			} else {
+				klog.V(3).Infof("(deleteNodeVolAttachments) Successfully deleted all volume attachments for node %q, machine %q", nodeName, machine.Name)
+			}
+			return retryPeriod, nil
+		}
+		description = fmt.Sprintf("No Live VolumeAttachments for node: %s. Moving to VM Deletion. %s", nodeName, machineutils.InitiateVMDeletion)
+		state = v1alpha1.MachineStateProcessing
+	}
+	now := metav1.Now()
+	klog.V(4).Infof("(deleteVolumeAttachmentsForNode) For node %q, machine %q, set LastOperation.Description: %q", nodeName, machine.Name, description)
+	updateRetryPeriod, updateErr := c.machineStatusUpdate(
+		ctx,
+		machine,
+		v1alpha1.LastOperation{
+			Description:    description,
+			State:          state,
+			Type:           machine.Status.LastOperation.Type,
+			LastUpdateTime: now,
+		},
+		machine.Status.CurrentStatus,
+		machine.Status.LastKnownState,
+	)
+
+	if updateErr != nil {
+		return updateRetryPeriod, updateErr
+	}
+
+	return retryPeriod, err
+}
+
+// deleteVM attempts to delete the VM backed by the machine object
+func (c *controller) deleteVM(ctx context.Context, deleteMachineRequest *driver.DeleteMachineRequest) (machineutils.RetryPeriod, error) {
+	var (
+		machine        = deleteMachineRequest.Machine
+		retryRequired  machineutils.RetryPeriod
+		description    string
+		state          v1alpha1.MachineState
+		lastKnownState string
+		auditMessage   string
+	)
+
+	if retry, err := c.forceDeleteNodeBeforeVM(ctx, machine); err != nil {
+		return retry, err
+	}
+
+	conflict, err := c.getProviderIDConflict(machine)
+	if err != nil {
+		recordReconcileError(reconcileFlowDelete, err)
+		return machineutils.ShortRetry, err
+	} else if conflict != "" {
+		return c.holdVMDeletion(ctx, machine, conflict)
+	}
+	machine, retry, err := c.releaseVMDeletionHold(ctx, machine)
+	if err != nil {
+		return retry, err
+	}
+
+	if unsafePods, err := c.getCriticalDaemonSetPodsNotSafeToTerminate(machine); err != nil {
+		recordReconcileError(reconcileFlowDelete, err)
+		return machineutils.ShortRetry, err
+	} else if len(unsafePods) != 0 {
+		return c.waitForCriticalDaemonSetPods(ctx, machine, unsafePods)
+	}
+
+	if deleteMachineRequest.MachineClass != nil && deleteMachineRequest.MachineClass.Annotations[machineutils.MachineClassDeleteNodeBeforeVM] == "true" {
+		if retry, err := c.deleteNodeBeforeVM(ctx, machine); err != nil {
+			return retry, err
+		}
+	}
+
+	if deleteMachineRequest.MachineClass != nil && deleteMachineRequest.MachineClass.Annotations[machineutils.MachineClassSnapshotBeforeDeletion] == "true" &&
+		machine.Annotations[machineutils.MachineSnapshotID] == "" {
+		if retry, err := c.snapshotVM(ctx, deleteMachineRequest); err != nil {
+			return retry, err
+		}
+	}
+
+	if pending := c.getPendingPreDeletionHooks(machine); len(pending) > 0 {
+		return c.runPreDeletionHook(ctx, machine, pending[0])
+	}
+
+	releasePermit, ok := c.acquireProviderOperationPermit(machine)
+	if !ok {
+		return machineutils.ShortRetry, fmt.Errorf("VM deletion of machine %q is throttled as the maximum of concurrent provider operations for MachineClass %q is reached", machine.Name, machine.Spec.Class.Name)
+	}
+	deleteMachineResponse, err := c.driver.DeleteMachine(ctx, deleteMachineRequest)
+	releasePermit()
+	// the VM may be gone or changed even if the deletion failed
+	c.deletionMachineStatusCache.Delete(machine.Spec.ProviderID)
+
+	updatedMachine, clearErr := c.clearVMDeletionFailingSince(ctx, machine, err)
+	if clearErr != nil {
+		return machineutils.ShortRetry, clearErr
+	}
+	machine = updatedMachine
+
+	if err != nil {
+
+		klog.Errorf("Error while deleting machine %s: %s", machine.Name, err)
+
+		if machineErr, ok := status.FromError(err); !ok || machineErr.Code() != codes.NotFound {
+			updatedMachine, attempts, countErr := c.countForceDeleteVMAttempt(ctx, machine)
+			if countErr != nil {
+				return machineutils.ShortRetry, countErr
+			}
+			machine = updatedMachine
+			if maxAttempts := int(c.safetyOptions.MaxForceDeleteVMAttempts); maxAttempts > 0 && attempts >= maxAttempts {
+				recordReconcileError(reconcileFlowDelete, err)
+				return c.abandonVMDeletion(ctx, machine, attempts, err)
+			}
+		}
+
+		if machineErr, ok := status.FromError(err); ok {
+			switch machineErr.Code() {
+			case codes.Unknown, codes.Aborted:
+				retryRequired = machineutils.ShortRetry
+				description = fmt.Sprintf("VM deletion failed due to - %s. However, will re-try in the next resync. %s", err.Error(), machineutils.InitiateVMDeletion)
+				state = v1alpha1.MachineStateFailed
+				recordReconcileError(reconcileFlowDelete, err)
+			case codes.DeadlineExceeded, codes.Unavailable:
+				recordReconcileError(reconcileFlowDelete, err)
+				return c.handleVMDeletionProviderUnreachable(ctx, machine, machineErr.Code(), err)
+			case codes.Unauthenticated:
+				recordReconcileError(reconcileFlowDelete, err)
+				return c.handleVMDeletionAuthFailure(ctx, machine, err)
+			case codes.DeletionProtected:
+				recordReconcileError(reconcileFlowDelete, err)
+				return c.holdVMDeletionProtected(ctx, machine, err)
+			case codes.NotFound:
+				retryRequired = machineutils.ShortRetry
+				description = fmt.Sprintf("VM not found. Continuing deletion flow. %s", machineutils.InitiateNodeDeletion)
+				state = v1alpha1.MachineStateProcessing
+				auditMessage = fmt.Sprintf("VM %q of the machine not found, considered deleted", getProviderID(machine))
+			default:
+				retryRequired = machineutils.LongRetry
+				description = fmt.Sprintf("VM deletion failed due to - %s. Aborting operation. %s", err.Error(), machineutils.InitiateVMDeletion)
+				state = v1alpha1.MachineStateFailed
+				recordReconcileError(reconcileFlowDelete, err)
+			}
+		} else {
+			retryRequired = machineutils.LongRetry
+			description = fmt.Sprintf("Error occurred while decoding machine error: %s. %s", err.Error(), machineutils.InitiateVMDeletion)
+			state = v1alpha1.MachineStateFailed
+			recordReconcileError(reconcileFlowDelete, err)
+		}
+
+	} else {
+		retryRequired = machineutils.ShortRetry
+		description = fmt.Sprintf("VM deletion was successful. %s", machineutils.InitiateNodeDeletion)
+		state = v1alpha1.MachineStateProcessing
+		auditMessage = fmt.Sprintf("VM %q of the machine deleted", getProviderID(machine))
+
+		err = fmt.Errorf("Machine deletion in process. %s", description)
+
+		if cond := getMachineCondition(machine, machineutils.MachineDeletionProtected); cond != nil && cond.Status == v1.ConditionTrue {
+			machine = machine.DeepCopy()
+			machine.Status.Conditions = nodeops.CloneAndAddCondition(machine.Status.Conditions, v1.NodeCondition{
+				Type:               machineutils.MachineDeletionProtected,
+				Status:             v1.ConditionFalse,
+				Reason:             "DeletionProtectionCleared",
+				Message:            "The VM was deleted after its deletion protection was disabled",
+				LastTransitionTime: metav1.Now(),
+			})
+		}
+	}
+
+	if deleteMachineResponse != nil && deleteMachineResponse.LastKnownState != "" {
+		lastKnownState = deleteMachineResponse.LastKnownState
+	}
+
+	updateRetryPeriod, updateErr := c.machineStatusUpdate(
+		ctx,
+		machine,
+		v1alpha1.LastOperation{
+			Description:    description,
+			State:          state,
+			Type:           v1alpha1.MachineOperationDelete,
+			LastUpdateTime: metav1.Now(),
+		},
+		// Let the clone.Status.CurrentStatus (LastUpdateTime) be as it was before.
+		// This helps while computing when the drain timeout to determine if force deletion is to be triggered.
+		// Ref - https://github.com/gardener/machine-controller-manager/blob/rel-v0.34.0/pkg/util/provider/machinecontroller/machine_util.go#L872
+		machine.Status.CurrentStatus,
+		lastKnownState,
+	)
+
+	if updateErr != nil {
+		return updateRetryPeriod, updateErr
+	}
+
+	if auditMessage != "" {
+		c.recordDeletionAuditEvent(machine, machineutils.MachineVMDeletedReason, auditMessage)
+	}
+
+	return retryRequired, err
+}
+
+// deleteNodeBeforeVM deletes the node object backed by the machine object ahead of the VM deletion.
+// A node object which is already gone is not treated as an error.
+func (c *controller) deleteNodeBeforeVM(ctx context.Context, machine *v1alpha1.Machine) (machineutils.RetryPeriod, error) {
+	nodeName := machine.Labels[v1alpha1.NodeLabelKey]
+	if nodeName == "" || c.targetCoreClient == nil {
+		return machineutils.ShortRetry, nil
+	}
+
+	if c.nodeDeletionRateLimiter != nil && !c.nodeDeletionRateLimiter.TryAccept() {
+		klog.V(3).Infof("Deletion of node %q associated with machine %q is throttled, will be retried", nodeName, machine.Name)
+		return machineutils.ShortRetry, fmt.Errorf("Machine deletion in process. Deletion of node object is throttled")
+	}
+
+	klog.V(3).Infof("Deleting node %q associated with machine %q before its VM", nodeName, machine.Name)
+	err := c.targetCoreClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+	if err == nil || apierrors.IsNotFound(err) {
+		return machineutils.ShortRetry, nil
+	}
+
+	description := fmt.Sprintf("Deletion of Node Object %q before VM deletion failed due to error: %s. %s", nodeName, err, machineutils.InitiateVMDeletion)
+	klog.Error(description)
+	recordReconcileError(reconcileFlowDelete, err)
+	updateRetryPeriod, updateErr := c.machineStatusUpdate(
+		ctx,
+		machine,
+		v1alpha1.LastOperation{
+			Description:    description,
+			State:          v1alpha1.MachineStateFailed,
+			Type:           v1alpha1.MachineOperationDelete,
+			LastUpdateTime: metav1.Now(),
+		},
+		machine.Status.CurrentStatus,
+		machine.Status.LastKnownState,
+	)
+	if updateErr != nil {
+		return updateRetryPeriod, updateErr
+	}
+
+	return machineutils.ShortRetry, err
+}
+
+// forceDeleteNodeBeforeVM deletes the node object backed by the machine object ahead of the VM deletion, if requested
+// by an operator with the MachineForceNodeDeletion annotation. The VM deletion continues to be retried regardless.
+func (c *controller) forceDeleteNodeBeforeVM(ctx context.Context, machine *v1alpha1.Machine) (machineutils.RetryPeriod, error) {
+	nodeName := machine.Labels[v1alpha1.NodeLabelKey]
+	if machine.Annotations[machineutils.MachineForceNodeDeletion] != "true" || nodeName == "" || c.nodeLister == nil {
+		return machineutils.ShortRetry, nil
+	}
+	if _, err := c.nodeLister.Get(nodeName); err != nil {
+		// the node is already gone, or its deletion is left to the node deletion step of the flow
+		return machineutils.ShortRetry, nil
+	}
+
+	klog.Warningf("Deleting node %q of machine %q before its VM as requested by annotation %q", nodeName, machine.Name, machineutils.MachineForceNodeDeletion)
+	c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.MachineForcedNodeDeletionReason, "Deleting node %q before the VM of the machine is deleted as requested by annotation %q, the VM deletion continues to be retried", nodeName, machineutils.MachineForceNodeDeletion)
+	return c.deleteNodeBeforeVM(ctx, machine)
+}
+
+// getProviderIDConflict checks if more than one node in the target cluster carries the providerID of the machine.
+// It returns a message identifying the conflicting nodes and machines, or an empty string if there is no conflict.
+func (c *controller) getProviderIDConflict(machine *v1alpha1.Machine) (string, error) {
+	if c.nodeLister == nil || machine.Spec.ProviderID == "" {
+		return "", nil
+	}
+
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+	conflictingNodes := sets.New[string]()
+	for _, node := range nodes {
+		if node.Spec.ProviderID == machine.Spec.ProviderID {
+			conflictingNodes.Insert(node.Name)
+		}
+	}
+	if conflictingNodes.Len() < 2 {
+		return "", nil
+	}
+
+	machines, err := c.machineLister.Machines(c.namespace).List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+	conflictingMachines := sets.New[string]()
+	for _, m := range machines {
+		if conflictingNodes.Has(m.Labels[v1alpha1.NodeLabelKey]) {
+			conflictingMachines.Insert(m.Name)
+		}
+	}
+
+	return fmt.Sprintf("Nodes %v of machines %v share the providerID %q", sets.List(conflictingNodes), sets.List(conflictingMachines), machine.Spec.ProviderID), nil
+}
+
+// acquireProviderOperationPermit acquires one of the permits for the create and delete operations of the provider
+// for the MachineClass of the machine, if their concurrency is limited by the MaxConcurrentProviderOperationsPerClass.
+// It returns false if no permit could be acquired within the providerOperationPermitTimeout, otherwise the returned
+// func releases the permit.
+func (c *controller) acquireProviderOperationPermit(machine *v1alpha1.Machine) (func(), bool) {
+	maxOperations := c.safetyOptions.MaxConcurrentProviderOperationsPerClass
+	if maxOperations <= 0 {
+		return func() {}, true
+	}
+
+	key := fmt.Sprintf("provider-operations:%s/%s", machine.Namespace, machine.Spec.Class.Name)
+	c.permitGiver.RegisterPermits(key, maxOperations)
+	if !c.permitGiver.TryPermit(key, providerOperationPermitTimeout) {
+		klog.V(3).Infof("Could not acquire a permit for a provider operation of machine %q within %s", machine.Name, providerOperationPermitTimeout)
+		return nil, false
+	}
+	return func() { c.permitGiver.ReleasePermit(key) }, true
+}
+
+// acquireInPlaceUpdateDrainPermit acquires one of the permits for the drains of the machines selected for an in-place
+// update, if their concurrency is limited by the MaxConcurrentInPlaceUpdateDrains. It returns false if no permit could
+// be acquired within the inPlaceUpdateDrainPermitTimeout, otherwise the returned func releases the permit.
+func (c *controller) acquireInPlaceUpdateDrainPermit(machine *v1alpha1.Machine) (func(), bool) {
+	maxDrains := c.safetyOptions.MaxConcurrentInPlaceUpdateDrains
+	if maxDrains <= 0 {
+		return func() {}, true
+	}
+
+	c.permitGiver.RegisterPermits(inPlaceUpdateDrainPermitKey, maxDrains)
+	if !c.permitGiver.TryPermit(inPlaceUpdateDrainPermitKey, inPlaceUpdateDrainPermitTimeout) {
+		klog.V(3).Infof("Could not acquire a permit for the in-place update drain of machine %q within %s", machine.Name, inPlaceUpdateDrainPermitTimeout)
+		return nil, false
+	}
+	return func() { c.permitGiver.ReleasePermit(inPlaceUpdateDrainPermitKey) }, true
+}
+
+// createMachineWithFallbackInstanceTypes creates the VM of the machine. As long as the provider reports the instance type
+// as exhausted, the creation is retried with the next fallback instance type configured on the MachineClass.
+func (c *controller) createMachineWithFallbackInstanceTypes(ctx context.Context, createMachineRequest *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
+	createMachineResponse, err := c.driver.CreateMachine(ctx, createMachineRequest)
+
+	var fallbackInstanceTypes []string
+	if createMachineRequest.MachineClass != nil {
+		for _, instanceType := range strings.Split(createMachineRequest.MachineClass.Annotations[machineutils.MachineClassFallbackInstanceTypes], ",") {
+			if instanceType = strings.TrimSpace(instanceType); instanceType != "" {
+				fallbackInstanceTypes = append(fallbackInstanceTypes, instanceType)
+			}
+		}
+	}
+
+	for _, instanceType := range fallbackInstanceTypes {
+		if machineErr, ok := status.FromError(err); err == nil || !ok || machineErr.Code() != codes.ResourceExhausted {
+			break
+		}
+		machine := createMachineRequest.Machine
+		klog.Warningf("Instance type for machine %q is exhausted, retrying its creation with the fallback instance type %q: %v", machine.Name, instanceType, err)
+		c.recorder.Eventf(machine, v1.EventTypeNormal, machineutils.InstanceTypeFallbackReason, "Retrying the creation with the fallback instance type %q as the previous instance type is exhausted", instanceType)
+
+		createMachineRequest.InstanceType = instanceType
+		createMachineResponse, err = c.driver.CreateMachine(ctx, createMachineRequest)
+	}
+	return createMachineResponse, err
+}
+
+// snapshotVM takes a snapshot of the VM backed by the machine object ahead of the VM deletion and records
+// the snapshot ID on the machine object. Providers which do not support snapshots don't block the VM deletion.
+func (c *controller) snapshotVM(ctx context.Context, deleteMachineRequest *driver.DeleteMachineRequest) (machineutils.RetryPeriod, error) {
+	machine := deleteMachineRequest.Machine
+
+	snapshotMachineResponse, err := driver.SnapshotMachine(ctx, c.driver, &driver.SnapshotMachineRequest{
+		Machine:      machine,
+		MachineClass: deleteMachineRequest.MachineClass,
+		Secret:       deleteMachineRequest.Secret,
+	})
+	if err != nil {
+		if machineErr, ok := status.FromError(err); ok {
+			switch machineErr.Code() {
+			case codes.Unimplemented:
+				klog.Warningf("Driver does not support snapshots, deleting VM of machine %q without a snapshot", machine.Name)
+				return machineutils.ShortRetry, nil
+			case codes.NotFound:
+				klog.Warningf("VM of machine %q not found, skipping its snapshot", machine.Name)
+				return machineutils.ShortRetry, nil
+			}
+		}
+
+		description := fmt.Sprintf("Snapshot of VM before its deletion failed due to error: %s. %s", err, machineutils.InitiateVMDeletion)
+		klog.Error(description)
+		updateRetryPeriod, updateErr := c.machineStatusUpdate(
+			ctx,
+			machine,
+			v1alpha1.LastOperation{
+				Description:    description,
+				State:          v1alpha1.MachineStateFailed,
+				Type:           v1alpha1.MachineOperationDelete,
+				LastUpdateTime: metav1.Now(),
+			},
+			machine.Status.CurrentStatus,
+			machine.Status.LastKnownState,
+		)
+		if updateErr != nil {
+			return updateRetryPeriod, updateErr
+		}
+
+		return machineutils.ShortRetry, err
+	}
+
+	clone := machine.DeepCopy()
+	if clone.Annotations == nil {
+		clone.Annotations = make(map[string]string)
+	}
+	clone.Annotations[machineutils.MachineSnapshotID] = snapshotMachineResponse.SnapshotID
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("Failed to record snapshot %q of VM of machine %q: %s", snapshotMachineResponse.SnapshotID, machine.Name, err)
+		return machineutils.ShortRetry, err
+	}
+
+	klog.V(2).Infof("Snapshot %q taken of VM of machine %q before its deletion", snapshotMachineResponse.SnapshotID, machine.Name)
+	c.recorder.Eventf(machine, v1.EventTypeNormal, machineutils.MachineSnapshotTakenReason, "Snapshot %q taken of VM before its deletion", snapshotMachineResponse.SnapshotID)
+
+	// Requeue so that the VM is deleted after the snapshot ID is recorded
+	return machineutils.ShortRetry, fmt.Errorf("Machine deletion in process. Snapshot %q of VM taken", snapshotMachineResponse.SnapshotID)
+}
+
+// recordVMDeletionFailingSince returns since when the VM deletion of the machine persistently fails for the cause
+// recorded in the given annotation. The current time is recorded in the annotation on the first failure.
+func (c *controller) recordVMDeletionFailingSince(ctx context.Context, machine *v1alpha1.Machine, annotation string) (*v1alpha1.Machine, time.Time, error) {
+	if failingSince, err := time.Parse(time.RFC3339, machine.Annotations[annotation]); err == nil {
+		return machine, failingSince, nil
+	}
+
+	failingSince := time.Now()
+	clone := machine.DeepCopy()
+	if clone.Annotations == nil {
+		clone.Annotations = make(map[string]string)
+	}
+	clone.Annotations[annotation] = failingSince.Format(time.RFC3339)
+	updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("Failed to record the start of the failures of the VM deletion of machine %q in annotation %q: %s", machine.Name, annotation, err)
+		return machine, failingSince, err
+	}
+	return updatedMachine, failingSince, nil
+}
+
+// clearVMDeletionFailingSince removes the annotations recording since when the VM deletion of the machine persistently
+// fails, whose cause is no longer the cause of the given error of the last DeleteMachine call, e.g. as the provider
+// is reachable again. Their timeouts then start over on the next failure.
+func (c *controller) clearVMDeletionFailingSince(ctx context.Context, machine *v1alpha1.Machine, deleteErr error) (*v1alpha1.Machine, error) {
+	var ongoing string
+	if machineErr, ok := status.FromError(deleteErr); deleteErr != nil && ok {
+		switch machineErr.Code() {
+		case codes.DeadlineExceeded, codes.Unavailable:
+			ongoing = machineutils.MachineDeletionProviderUnreachableSince
+		case codes.Unauthenticated:
+			ongoing = machineutils.MachineDeletionAuthFailureSince
+		}
+	}
+
+	var clone *v1alpha1.Machine
+	for _, annotation := range []string{machineutils.MachineDeletionProviderUnreachableSince, machineutils.MachineDeletionAuthFailureSince} {
+		if _, ok := machine.Annotations[annotation]; !ok || annotation == ongoing {
+			continue
+		}
+		if clone == nil {
+			clone = machine.DeepCopy()
+		}
+		delete(clone.Annotations, annotation)
+	}
+	if clone == nil {
+		return machine, nil
+	}
+
+	updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("Failed to clear the start of the recovered failures of the VM deletion of machine %q: %s", machine.Name, err)
+		return machine, err
+	}
+	klog.V(3).Infof("Cleared the start of the recovered failures of the VM deletion of machine %q", machine.Name)
+	return updatedMachine, nil
+}
+
+// handleVMDeletionProviderUnreachable keeps retrying the VM deletion of a machine which fails as the provider is unreachable,
+// and applies the configured MachineDeletionProviderUnreachablePolicy once the failures persist beyond the MachineDeletionProviderUnreachableTimeout
+func (c *controller) handleVMDeletionProviderUnreachable(ctx context.Context, machine *v1alpha1.Machine, code codes.Code, deleteErr error) (machineutils.RetryPeriod, error) {
+	machine, failingSince, recordErr := c.recordVMDeletionFailingSince(ctx, machine, machineutils.MachineDeletionProviderUnreachableSince)
+	if recordErr != nil {
+		return machineutils.ShortRetry, recordErr
+	}
+
+	var (
+		policy        = c.safetyOptions.MachineDeletionProviderUnreachablePolicy
+		retryRequired = machineutils.ShortRetry
+		state         = v1alpha1.MachineStateFailed
+		description   string
+		err           = deleteErr
+	)
+
+	switch {
+	case time.Since(failingSince) < c.safetyOptions.MachineDeletionProviderUnreachableTimeout.Duration:
+		description = fmt.Sprintf("VM deletion failed as the provider is unreachable since %s - %s. However, will re-try in the next resync. %s", failingSince.Format(time.RFC3339), deleteErr, machineutils.InitiateVMDeletion)
+	case policy == machineutils.DeletionProviderUnreachablePolicyForceRemoveFinalizer && machine.Annotations[machineutils.MachineForceFinalizerRemoval] == "true":
+		description = fmt.Sprintf("VM deletion failed as the provider is unreachable since %s. Continuing deletion flow without deleting the VM as confirmed by the %s annotation. %s", failingSince.Format(time.RFC3339), machineutils.MachineForceFinalizerRemoval, machineutils.InitiateNodeDeletion)
+		state = v1alpha1.MachineStateProcessing
+		err = fmt.Errorf("Machine deletion in process. %s", description)
+		klog.Warningf("Removing finalizer of machine %q with providerID %q without deleting its VM, as confirmed by an operator", machine.Name, getProviderID(machine))
+		c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.MachineDeletionStalledReason, "VM deletion failed as the provider is unreachable since %s. The finalizer is removed without deleting the VM as confirmed by an operator", failingSince.Format(time.RFC3339))
+	default:
+		hint := "The deletion is retried once the provider is reachable again"
+		if policy == machineutils.DeletionProviderUnreachablePolicyForceRemoveFinalizer {
+			hint = fmt.Sprintf("%s, or confirm the removal of the finalizer without deleting the VM with the annotation %s=true", hint, machineutils.MachineForceFinalizerRemoval)
+		}
+		description = fmt.Sprintf("VM deletion is stalled as the provider is unreachable since %s - %s. %s. %s", failingSince.Format(time.RFC3339), deleteErr, hint, machineutils.InitiateVMDeletion)
+		state = v1alpha1.MachineStateDeletionStalled
+		retryRequired = machineutils.LongRetry
+		klog.Warning(description)
+		c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.MachineDeletionStalledReason, "VM deletion is stalled as the provider is unreachable since %s. %s", failingSince.Format(time.RFC3339), hint)
+	}
+
+	updateRetryPeriod, updateErr := c.machineStatusUpdate(
+		ctx,
+		machine,
+		v1alpha1.LastOperation{
+			Description:    description,
+			ErrorCode:      code.String(),
+			State:          state,
+			Type:           v1alpha1.MachineOperationDelete,
+			LastUpdateTime: metav1.Now(),
+		},
+		machine.Status.CurrentStatus,
+		machine.Status.LastKnownState,
+	)
+	if updateErr != nil {
+		return updateRetryPeriod, updateErr
+	}
+
+	return retryRequired, err
+}
+
+// handleVMDeletionAuthFailure keeps retrying the VM deletion of a machine which fails due to authentication errors,
+// and applies the configured MachineDeletionAuthFailurePolicy once the failures persist beyond the MachineDeletionAuthFailureTimeout
+func (c *controller) handleVMDeletionAuthFailure(ctx context.Context, machine *v1alpha1.Machine, deleteErr error) (machineutils.RetryPeriod, error) {
+	machine, failingSince, recordErr := c.recordVMDeletionFailingSince(ctx, machine, machineutils.MachineDeletionAuthFailureSince)
+	if recordErr != nil {
+		return machineutils.ShortRetry, recordErr
+	}
+
+	var (
+		policy        = c.safetyOptions.MachineDeletionAuthFailurePolicy
+		retryRequired = machineutils.ShortRetry
+		state         = v1alpha1.MachineStateFailed
+		description   string
+		err           = deleteErr
+	)
+
+	switch {
+	case time.Since(failingSince) < c.safetyOptions.MachineDeletionAuthFailureTimeout.Duration:
+		description = fmt.Sprintf("VM deletion failed due to authentication error since %s - %s. However, will re-try in the next resync. %s", failingSince.Format(time.RFC3339), deleteErr, machineutils.InitiateVMDeletion)
+	case policy == machineutils.DeletionAuthFailurePolicyForceRemoveFinalizer && machine.Annotations[machineutils.MachineForceFinalizerRemoval] == "true":
+		description = fmt.Sprintf("VM deletion failed due to authentication errors since %s. Continuing deletion flow without deleting the VM as confirmed by the %s annotation. %s", failingSince.Format(time.RFC3339), machineutils.MachineForceFinalizerRemoval, machineutils.InitiateNodeDeletion)
+		state = v1alpha1.MachineStateProcessing
+		err = fmt.Errorf("Machine deletion in process. %s", description)
+		klog.Warningf("Removing finalizer of machine %q with providerID %q without deleting its VM, as confirmed by an operator", machine.Name, getProviderID(machine))
+		c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.MachineDeletionAuthFailureReason, "VM deletion failed due to authentication errors since %s. The finalizer is removed without deleting the VM as confirmed by an operator", failingSince.Format(time.RFC3339))
+	default:
+		hint := "Fix the credentials in the secret of the MachineClass"
+		if policy == machineutils.DeletionAuthFailurePolicyForceRemoveFinalizer {
+			hint = fmt.Sprintf("%s or confirm the removal of the finalizer without deleting the VM with the annotation %s=true", hint, machineutils.MachineForceFinalizerRemoval)
+		}
+		description = fmt.Sprintf("VM deletion is on hold as it failed due to authentication errors since %s - %s. %s. %s", failingSince.Format(time.RFC3339), deleteErr, hint, machineutils.InitiateVMDeletion)
+		retryRequired = machineutils.LongRetry
+		klog.Warning(description)
+		c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.MachineDeletionAuthFailureReason, "VM deletion is on hold as it failed due to authentication errors since %s. %s", failingSince.Format(time.RFC3339), hint)
+	}
+
+	updateRetryPeriod, updateErr := c.machineStatusUpdate(
+		ctx,
+		machine,
+		v1alpha1.LastOperation{
+			Description:    description,
+			ErrorCode:      codes.Unauthenticated.String(),
+			State:          state,
+			Type:           v1alpha1.MachineOperationDelete,
+			LastUpdateTime: metav1.Now(),
+		},
+		machine.Status.CurrentStatus,
+		machine.Status.LastKnownState,
+	)
+	if updateErr != nil {
+		return updateRetryPeriod, updateErr
+	}
+
+	return retryRequired, err
+}
+
+// countForceDeleteVMAttempt records a failed DeleteMachine call of a machine labeled force-deletion on the machine,
+// if the MaxForceDeleteVMAttempts is configured. It returns the updated machine along with the number of failed calls,
+// which is 0 for other machines.
+func (c *controller) countForceDeleteVMAttempt(ctx context.Context, machine *v1alpha1.Machine) (*v1alpha1.Machine, int, error) {
+	if forceDelete, _ := strconv.ParseBool(machine.Labels["force-deletion"]); !forceDelete || c.safetyOptions.MaxForceDeleteVMAttempts <= 0 {
+		return machine, 0, nil
+	}
+
+	attempts, err := strconv.Atoi(machine.Annotations[machineutils.MachineForceDeleteVMAttempts])
+	if err != nil || attempts < 0 {
+		attempts = 0
+	}
+	attempts++
+
+	clone := machine.DeepCopy()
+	if clone.Annotations == nil {
+		clone.Annotations = make(map[string]string)
+	}
+	clone.Annotations[machineutils.MachineForceDeleteVMAttempts] = strconv.Itoa(attempts)
+	updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).Update(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Errorf("Failed to record the failed VM deletion attempt of force deleted machine %q: %s", machine.Name, err)
+		return machine, 0, err
+	}
+	return updatedMachine, attempts, nil
+}
+
+// abandonVMDeletion continues the deletion flow of a machine labeled force-deletion with the deletion of its node,
+// as its VM deletion failed MaxForceDeleteVMAttempts times. The VM is not confirmed to be deleted, hence it may be
+// left behind at the provider along with its disks and data.
+func (c *controller) abandonVMDeletion(ctx context.Context, machine *v1alpha1.Machine, attempts int, deleteErr error) (machineutils.RetryPeriod, error) {
+	description := fmt.Sprintf("VM deletion failed %d times, last due to - %s. Continuing deletion flow without confirmation of the VM deletion as the machine is labeled force-deletion. "+
+		"The VM %q may still exist at the provider, its disks and their data may be leaked or lost and have to be cleaned up manually. %s", attempts, deleteErr, getProviderID(machine), machineutils.InitiateNodeDeletion)
+	klog.Warningf("%s for machine %q", description, machine.Name)
+	c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.MachineVMDeletionAbandonedReason, "VM deletion failed %d times, continuing the deletion of the machine labeled force-deletion without confirmation that VM %q is deleted", attempts, getProviderID(machine))
+
+	updateRetryPeriod, updateErr := c.machineStatusUpdate(
+		ctx,
+		machine,
+		v1alpha1.LastOperation{
+			Description:    description,
+			State:          v1alpha1.MachineStateProcessing,
+			Type:           v1alpha1.MachineOperationDelete,
+			LastUpdateTime: metav1.Now(),
+		},
+		machine.Status.CurrentStatus,
+		machine.Status.LastKnownState,
+	)
+	if updateErr != nil {
+		return updateRetryPeriod, updateErr
+	}
+
+	return machineutils.ShortRetry, fmt.Errorf("Machine deletion in process. %s", description)
+}
+
+// holdVMDeletionProtected keeps the machine in the VM deletion step with the DeletionProtected condition and records
+// an event, as the provider refuses to delete the VM while deletion protection is enabled for it. The deletion is
+// retried infrequently, so that it continues once the protection is disabled.
+func (c *controller) holdVMDeletionProtected(ctx context.Context, machine *v1alpha1.Machine, deleteErr error) (machineutils.RetryPeriod, error) {
+	hint := "Disable the deletion protection of the VM at the provider to continue the deletion"
+	description := fmt.Sprintf("VM deletion is on hold as deletion protection is enabled for the VM - %s. %s. %s", deleteErr, hint, machineutils.InitiateVMDeletion)
+
+	clone := machine.DeepCopy()
+	clone.Status.Conditions = nodeops.CloneAndAddCondition(clone.Status.Conditions, v1.NodeCondition{
+		Type:               machineutils.MachineDeletionProtected,
+		Status:             v1.ConditionTrue,
+		Reason:             machineutils.MachineDeletionProtectedReason,
+		Message:            hint,
+		LastTransitionTime: metav1.Now(),
+	})
+	clone.Status.LastOperation = v1alpha1.LastOperation{
+		Description:    description,
+		ErrorCode:      codes.DeletionProtected.String(),
+		State:          v1alpha1.MachineStateFailed,
+		Type:           v1alpha1.MachineOperationDelete,
+		LastUpdateTime: metav1.Now(),
+	}
+
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		klog.Warningf("Machine/status UPDATE failed for machine %q. Retrying, error: %s", machine.Name, err)
+		if apierrors.IsConflict(err) {
+			return machineutils.ConflictRetry, err
+		}
+		return machineutils.ShortRetry, err
+	}
+	klog.Warning(description)
+	c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.MachineDeletionProtectedReason, "VM deletion is on hold as deletion protection is enabled for the VM. %s", hint)
+
+	return machineutils.MediumRetry, deleteErr
+}
+
+// runPreDrainHooks runs the pre-drain hooks for the machine and its node. Nodes which are gone aren't drained,
+// hence the hooks are not run for them.
+func (c *controller) runPreDrainHooks(ctx context.Context, machine *v1alpha1.Machine, nodeName string) error {
+	if len(c.preDrainHooks) == 0 {
+		return nil
+	}
+	node, err := c.nodeLister.Get(nodeName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return drain.RunPreDrainHooks(ctx, c.preDrainHooks, c.safetyOptions.PreDrainHookTimeout.Duration, machine, node)
+}
+
+// getMachineCondition returns the condition of the given type of the machine, if present
+func getMachineCondition(machine *v1alpha1.Machine, conditionType v1.NodeConditionType) *v1.NodeCondition {
+	for i := range machine.Status.Conditions {
+		if machine.Status.Conditions[i].Type == conditionType {
+			return &machine.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// holdVMDeletion keeps the machine in the VM deletion step with the ProviderIDConflict condition, as deleting the VM
+// could delete the VM backing another machine. The event is only recorded once the hold is persisted in the status
+// and the condition wasn't set yet, so that the event and the status of the machine agree.
+func (c *controller) holdVMDeletion(ctx context.Context, machine *v1alpha1.Machine, conflict string) (machineutils.RetryPeriod, error) {
+	holdErr := fmt.Errorf("VM deletion for machine %q is on hold due to conflicting providerID %q", machine.Name, machine.Spec.ProviderID)
+	description := fmt.Sprintf("%s. VM deletion is on hold until the conflict is resolved. %s", conflict, machineutils.InitiateVMDeletion)
+	cond := getMachineCondition(machine, machineutils.MachineProviderIDConflict)
+	alreadyHeld := cond != nil && cond.Status == v1.ConditionTrue && cond.Message == conflict
+	if alreadyHeld && machine.Status.LastOperation.Description == description {
+		return machineutils.MediumRetry, holdErr
+	}
+
+	clone := machine.DeepCopy()
+	clone.Status.Conditions = nodeops.CloneAndAddCondition(clone.Status.Conditions, v1.NodeCondition{
+		Type:               machineutils.MachineProviderIDConflict,
+		Status:             v1.ConditionTrue,
+		Reason:             machineutils.ProviderIDConflictReason,
+		Message:            conflict,
+		LastTransitionTime: metav1.Now(),
+	})
+	clone.Status.LastOperation = v1alpha1.LastOperation{
+		Description:    description,
+		State:          v1alpha1.MachineStateFailed,
+		Type:           v1alpha1.MachineOperationDelete,
+		LastUpdateTime: metav1.Now(),
+	}
+
+	if _, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{}); err != nil {
+		klog.Warningf("Machine/status UPDATE failed for machine %q. Retrying, error: %s", machine.Name, err)
+		if apierrors.IsConflict(err) {
+			return machineutils.ConflictRetry, err
+		}
+		return machineutils.ShortRetry, err
+	}
+	if !alreadyHeld {
+		c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.ProviderIDConflictReason, "%s. VM deletion is on hold until the conflict is resolved", conflict)
+	}
+
+	return machineutils.MediumRetry, holdErr
+}
+
+// releaseVMDeletionHold sets the ProviderIDConflict condition of the machine to false once the conflict of its
+// providerID is resolved, and returns the updated machine
+func (c *controller) releaseVMDeletionHold(ctx context.Context, machine *v1alpha1.Machine) (*v1alpha1.Machine, machineutils.RetryPeriod, error) {
+	if cond := getMachineCondition(machine, machineutils.MachineProviderIDConflict); cond == nil || cond.Status != v1.ConditionTrue {
+		return machine, machineutils.ShortRetry, nil
+	}
+
+	clone := machine.DeepCopy()
+	clone.Status.Conditions = nodeops.CloneAndAddCondition(clone.Status.Conditions, v1.NodeCondition{
+		Type:               machineutils.MachineProviderIDConflict,
+		Status:             v1.ConditionFalse,
+		Reason:             "ProviderIDConflictResolved",
+		Message:            "No other node shares the providerID of the machine anymore",
+		LastTransitionTime: metav1.Now(),
+	})
+	updatedMachine, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		klog.Warningf("Machine/status UPDATE failed for machine %q. Retrying, error: %s", machine.Name, err)
+		if apierrors.IsConflict(err) {
+			return machine, machineutils.ConflictRetry, err
+		}
+		return machine, machineutils.ShortRetry, err
+	}
+	klog.V(2).Infof("Released the VM deletion hold of machine %q as the conflict of its providerID %q is resolved", machine.Name, machine.Spec.ProviderID)
+	return updatedMachine, machineutils.ShortRetry, nil
+}
+
+// getCriticalDaemonSetPodsNotSafeToTerminate returns the critical DaemonSet pods matching the CriticalDaemonSetPodSelector
+// on the node of the machine which don't report the SafeToTerminate condition yet. Machines with the force-deletion
+// label, machines whose drain timeout elapsed and machines whose node is already gone don't wait for them.
+func (c *controller) getCriticalDaemonSetPodsNotSafeToTerminate(machine *v1alpha1.Machine) ([]string, error) {
+	nodeName := machine.Labels[v1alpha1.NodeLabelKey]
+	if c.safetyOptions.CriticalDaemonSetPodSelector == "" || c.podLister == nil || nodeName == "" {
+		return nil, nil
+	}
+	if forceDeletion, _ := strconv.ParseBool(machine.Labels["force-deletion"]); forceDeletion {
+		return nil, nil
+	}
+	if machine.DeletionTimestamp != nil && utiltime.HasTimeOutOccurred(*machine.DeletionTimestamp, c.getEffectiveDrainTimeout(machine).Duration) {
+		klog.Warningf("Not waiting for the critical DaemonSet pods on node %q of machine %q as its drain timeout elapsed", nodeName, machine.Name)
+		return nil, nil
+	}
+	if _, err := c.nodeLister.Get(nodeName); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
 		}
-		description = fmt.Sprintf("No Live VolumeAttachments for node: %s. Moving to VM Deletion. %s", nodeName, machineutils.InitiateVMDeletion)
-		state = v1alpha1.MachineStateProcessing
+		return nil, err
 	}
-	now := metav1.Now()
-	klog.V(4).Infof("(deleteVolumeAttachmentsForNode) For node %q, machine %q, set LastOperation.Description: %q", nodeName, machine.Name, description)
-	updateRetryPeriod, updateErr := c.machineStatusUpdate(
-		ctx,
-		machine,
-		v1alpha1.LastOperation{
-			Description:    description,
-			State:          state,
-			Type:           machine.Status.LastOperation.Type,
-			LastUpdateTime: now,
-		},
-		machine.Status.CurrentStatus,
-		machine.Status.LastKnownState,
-	)
 
-	if updateErr != nil {
-		return updateRetryPeriod, updateErr
+	selector, err := labels.Parse(c.safetyOptions.CriticalDaemonSetPodSelector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.podLister.List(selector)
+	if err != nil {
+		return nil, err
 	}
 
-	return retryPeriod, err
+	unsafePods := sets.New[string]()
+	for _, pod := range pods {
+		if pod.Spec.NodeName != nodeName || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		if controllerRef := metav1.GetControllerOf(pod); controllerRef == nil || controllerRef.Kind != "DaemonSet" {
+			continue
+		}
+		if !isPodSafeToTerminate(pod) {
+			unsafePods.Insert(pod.Namespace + "/" + pod.Name)
+		}
+	}
+
+	return sets.List(unsafePods), nil
 }
 
-// deleteVM attempts to delete the VM backed by the machine object
-func (c *controller) deleteVM(ctx context.Context, deleteMachineRequest *driver.DeleteMachineRequest) (machineutils.RetryPeriod, error) {
-	var (
-		machine        = deleteMachineRequest.Machine
-		retryRequired  machineutils.RetryPeriod
-		description    string
-		state          v1alpha1.MachineState
-		lastKnownState string
-	)
+// checkNodePodDensity emits a Warning event on the machine once its node hosts more pods than the NodePodDensityThreshold,
+// hinting at a scheduling imbalance. The event is emitted again only after the node fell back below the threshold in the
+// meantime. Terminated pods are not counted.
+func (c *controller) checkNodePodDensity(machine *v1alpha1.Machine) {
+	nodeName := getNodeName(machine)
+	threshold := c.safetyOptions.NodePodDensityThreshold
+	if threshold <= 0 || c.podIndexer == nil || nodeName == "" {
+		return
+	}
 
-	deleteMachineResponse, err := c.driver.DeleteMachine(ctx, deleteMachineRequest)
+	pods, err := c.podIndexer.ByIndex(podNodeNameIndex, nodeName)
 	if err != nil {
+		klog.Errorf("Failed to list pods to check the pod density of node %q of machine %q: %s", nodeName, machine.Name, err)
+		return
+	}
 
-		klog.Errorf("Error while deleting machine %s: %s", machine.Name, err)
-
-		if machineErr, ok := status.FromError(err); ok {
-			switch machineErr.Code() {
-			case codes.Unknown, codes.DeadlineExceeded, codes.Aborted, codes.Unavailable:
-				retryRequired = machineutils.ShortRetry
-				description = fmt.Sprintf("VM deletion failed due to - %s. However, will re-try in the next resync. %s", err.Error(), machineutils.InitiateVMDeletion)
-				state = v1alpha1.MachineStateFailed
-			case codes.NotFound:
-				retryRequired = machineutils.ShortRetry
-				description = fmt.Sprintf("VM not found. Continuing deletion flow. %s", machineutils.InitiateNodeDeletion)
-				state = v1alpha1.MachineStateProcessing
-			default:
-				retryRequired = machineutils.LongRetry
-				description = fmt.Sprintf("VM deletion failed due to - %s. Aborting operation. %s", err.Error(), machineutils.InitiateVMDeletion)
-				state = v1alpha1.MachineStateFailed
-			}
-		} else {
-			retryRequired = machineutils.LongRetry
-			description = fmt.Sprintf("Error occurred while decoding machine error: %s. %s", err.Error(), machineutils.InitiateVMDeletion)
-			state = v1alpha1.MachineStateFailed
+	count := 0
+	for _, obj := range pods {
+		pod, ok := obj.(*v1.Pod)
+		if ok && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			count++
 		}
+	}
 
-	} else {
-		retryRequired = machineutils.ShortRetry
-		description = fmt.Sprintf("VM deletion was successful. %s", machineutils.InitiateNodeDeletion)
-		state = v1alpha1.MachineStateProcessing
-
-		err = fmt.Errorf("Machine deletion in process. %s", description)
+	exceeded := count > threshold
+	previous, _ := c.podDensityExceeded.Swap(machine.UID, exceeded)
+	if exceeded && previous != true {
+		klog.V(3).Infof("Node %q of machine %q hosts %d pods, exceeding the pod density threshold of %d", nodeName, machine.Name, count, threshold)
+		c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.NodePodDensityExceededReason, "Node %q hosts %d pods, exceeding the pod density threshold of %d", nodeName, count, threshold)
 	}
+}
 
-	if deleteMachineResponse != nil && deleteMachineResponse.LastKnownState != "" {
-		lastKnownState = deleteMachineResponse.LastKnownState
+// isPodSafeToTerminate checks if the pod reports the SafeToTerminate condition
+func isPodSafeToTerminate(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == machineutils.PodSafeToTerminateCondition {
+			return condition.Status == v1.ConditionTrue
+		}
 	}
+	return false
+}
+
+// waitForCriticalDaemonSetPods keeps the machine in the VM deletion step
+// until the critical DaemonSet pods on its node are safe to terminate
+func (c *controller) waitForCriticalDaemonSetPods(ctx context.Context, machine *v1alpha1.Machine, unsafePods []string) (machineutils.RetryPeriod, error) {
+	description := fmt.Sprintf("VM deletion is waiting for the critical DaemonSet pods %v to be safe to terminate. %s", unsafePods, machineutils.InitiateVMDeletion)
+	klog.V(3).Infof("%s Machine: %q", description, machine.Name)
 
 	updateRetryPeriod, updateErr := c.machineStatusUpdate(
 		ctx,
 		machine,
 		v1alpha1.LastOperation{
 			Description:    description,
-			State:          state,
+			State:          v1alpha1.MachineStateProcessing,
 			Type:           v1alpha1.MachineOperationDelete,
 			LastUpdateTime: metav1.Now(),
 		},
-		// Let the clone.Status.CurrentStatus (LastUpdateTime) be as it was before.
-		// This helps while computing when the drain timeout to determine if force deletion is to be triggered.
-		// Ref - https://github.com/gardener/machine-controller-manager/blob/rel-v0.34.0/pkg/util/provider/machinecontroller/machine_util.go#L872
 		machine.Status.CurrentStatus,
-		lastKnownState,
+		machine.Status.LastKnownState,
 	)
-
 	if updateErr != nil {
 		return updateRetryPeriod, updateErr
 	}
 
-	return retryRequired, err
+	return machineutils.ShortRetry, fmt.Errorf("Machine deletion in process. VM deletion is waiting for the critical DaemonSet pods %v to be safe to terminate", unsafePods)
+}
+
+// confirmMachineGeneration ensures that a destructive deletion step isn't performed based on a stale machine object.
+// If the generation of the machine advanced since its deletion flow started, e.g. as its providerID changed,
+// the latest generation is observed and the step is deferred to the next reconciliation of the machine.
+func (c *controller) confirmMachineGeneration(ctx context.Context, machine *v1alpha1.Machine, step string) (machineutils.RetryPeriod, error) {
+	observedGeneration := machine.Status.ObservedGeneration
+	if observedGeneration == 0 {
+		// Deletion flow was started without observing the generation
+		return machineutils.ShortRetry, nil
+	}
+
+	latestMachine, err := c.controlMachineClient.Machines(machine.Namespace).Get(ctx, machine.Name, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("Error occurred while fetching the latest object of machine %q: %s", machine.Name, err)
+		return machineutils.ShortRetry, err
+	}
+	if latestMachine.Generation == observedGeneration {
+		return machineutils.ShortRetry, nil
+	}
+
+	clone := latestMachine.DeepCopy()
+	clone.Status.ObservedGeneration = latestMachine.Generation
+	clone.Status.LastOperation = v1alpha1.LastOperation{
+		Description:    fmt.Sprintf("Generation of the machine advanced from %d to %d since its deletion started, deferring the step. %s", observedGeneration, latestMachine.Generation, step),
+		State:          v1alpha1.MachineStateProcessing,
+		Type:           v1alpha1.MachineOperationDelete,
+		LastUpdateTime: metav1.Now(),
+	}
+
+	_, err = c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
+	if err != nil {
+		// Keep retrying until update goes through
+		klog.Errorf("Machine/status UPDATE failed for machine %q. Retrying, error: %s", machine.Name, err)
+	} else {
+		klog.Warningf("Generation of machine %q advanced from %d to %d since its deletion started, deferring the step %q", machine.Name, observedGeneration, latestMachine.Generation, step)
+		// Return error to re-attempt the step with the latest machine object
+		err = fmt.Errorf("Machine deletion step deferred as the generation of machine %q advanced", machine.Name)
+	}
+
+	if apierrors.IsConflict(err) {
+		return machineutils.ConflictRetry, err
+	}
+	return machineutils.ShortRetry, err
 }
 
 // deleteNodeObject attempts to delete the node object backed by the machine object
 func (c *controller) deleteNodeObject(ctx context.Context, machine *v1alpha1.Machine) (machineutils.RetryPeriod, error) {
 	var (
-		err         error
-		description string
-		state       v1alpha1.MachineState
+		err          error
+		description  string
+		state        v1alpha1.MachineState
+		auditMessage string
 	)
 
 	nodeName := machine.Labels[v1alpha1.NodeLabelKey]
 
+	if nodeName != "" && c.nodeDeletionRateLimiter != nil && !c.nodeDeletionRateLimiter.TryAccept() {
+		klog.V(3).Infof("Deletion of node %q associated with machine %q is throttled, will be retried", nodeName, machine.Name)
+		return machineutils.ShortRetry, fmt.Errorf("Machine deletion in process. Deletion of node object is throttled")
+	}
+
 	if nodeName != "" {
 		// Delete node object
 		err = c.targetCoreClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
@@ -1820,6 +4068,7 @@ func (c *controller) deleteNodeObject(ctx context.Context, machine *v1alpha1.Mac
 		if err != nil && !apierrors.IsNotFound(err) {
 			// If its an error, and any other error than object not found
 			description = fmt.Sprintf("Deletion of Node Object %q failed due to error: %s. %s", nodeName, err, machineutils.InitiateNodeDeletion)
+			recordReconcileError(reconcileFlowDelete, err)
 			klog.Error(description)
 			state = v1alpha1.MachineStateFailed
 		} else if err == nil {
@@ -1827,16 +4076,19 @@ func (c *controller) deleteNodeObject(ctx context.Context, machine *v1alpha1.Mac
 			klog.V(3).Info(description)
 			state = v1alpha1.MachineStateProcessing
 			err = fmt.Errorf("Machine deletion in process. Deletion of node object was successful")
+			auditMessage = fmt.Sprintf("Node %q of the machine deleted", nodeName)
 		} else {
 			description = fmt.Sprintf("No node object found for %q, continuing deletion flow. %s", nodeName, machineutils.InitiateFinalizerRemoval)
 			klog.Warning(description)
 			state = v1alpha1.MachineStateProcessing
+			auditMessage = fmt.Sprintf("Node %q of the machine not found, considered deleted", nodeName)
 		}
 	} else {
 		description = fmt.Sprintf("Label %q not present on machine %q or no associated node object found, continuing deletion flow. %s", v1alpha1.NodeLabelKey, machine.Name, machineutils.InitiateFinalizerRemoval)
 		klog.Error(description)
 		state = v1alpha1.MachineStateProcessing
 		err = fmt.Errorf("Machine deletion in process. No node object found")
+		auditMessage = "Machine has no node, considered deleted"
 	}
 
 	updateRetryPeriod, updateErr := c.machineStatusUpdate(
@@ -1859,6 +4111,10 @@ func (c *controller) deleteNodeObject(ctx context.Context, machine *v1alpha1.Mac
 		return updateRetryPeriod, updateErr
 	}
 
+	if auditMessage != "" {
+		c.recordDeletionAuditEvent(machine, machineutils.MachineNodeDeletedReason, auditMessage)
+	}
+
 	return machineutils.ShortRetry, err
 }
 
@@ -1873,6 +4129,87 @@ func (c *controller) getEffectiveDrainTimeout(machine *v1alpha1.Machine) *metav1
 	return effectiveDrainTimeout
 }
 
+// getEffectiveNodeNotReadyForceDrainTimeout returns the duration for which the node has to be NotReady or have a
+// ReadonlyFilesystem before its drain is forced, as configured for the first node label selector matching the node.
+// Otherwise the MachineUnreachableGracePeriod is returned.
+func (c *controller) getEffectiveNodeNotReadyForceDrainTimeout(nodeName string) time.Duration {
+	defaultNodeNotReadyDuration := c.safetyOptions.MachineUnreachableGracePeriod.Duration
+
+	if len(c.safetyOptions.NodeNotReadyForceDrainTimeouts) == 0 || c.nodeLister == nil {
+		return defaultNodeNotReadyDuration
+	}
+	node, err := c.nodeLister.Get(nodeName)
+	if err != nil {
+		return defaultNodeNotReadyDuration
+	}
+	for _, m := range c.safetyOptions.NodeNotReadyForceDrainTimeouts {
+		selector, err := labels.Parse(m.Selector)
+		if err != nil {
+			klog.Warningf("Ignoring invalid node label selector %q: %s", m.Selector, err)
+			continue
+		}
+		if selector.Matches(labels.Set(node.Labels)) {
+			return m.Timeout.Duration
+		}
+	}
+	return defaultNodeNotReadyDuration
+}
+
+// isDeleteOnlyDrain returns true if the pods on the machine's node are to be deleted instead of evicted during drain
+func isDeleteOnlyDrain(machine *v1alpha1.Machine) bool {
+	return machine.Annotations[machineutils.MachineDrainEvictionPolicy] == machineutils.DrainEvictionPolicyDeleteOnly
+}
+
+// isSoleMachineOfPool returns true if the machine is the only member of its pool, i.e. the MachineDeployment
+// (or the MachineSet, if not owned by a MachineDeployment) owning it has at most one replica and allows no surge.
+// Cordoning the node of such a machine before its drain is pointless, as there is no other node to reschedule its pods to.
+func (c *controller) isSoleMachineOfPool(machine *v1alpha1.Machine) bool {
+	machineSetRef := metav1.GetControllerOf(machine)
+	if machineSetRef == nil || machineSetRef.Kind != "MachineSet" {
+		return false
+	}
+	machineSet, err := c.machineSetLister.MachineSets(machine.Namespace).Get(machineSetRef.Name)
+	if err != nil {
+		klog.Warningf("Could not fetch MachineSet %q of machine %q, cordoning its node before drain: %s", machineSetRef.Name, machine.Name, err)
+		return false
+	}
+
+	machineDeploymentRef := metav1.GetControllerOf(machineSet)
+	if machineDeploymentRef == nil || machineDeploymentRef.Kind != "MachineDeployment" {
+		return machineSet.Spec.Replicas <= 1
+	}
+	machineDeployment, err := c.machineDeploymentLister.MachineDeployments(machine.Namespace).Get(machineDeploymentRef.Name)
+	if err != nil {
+		klog.Warningf("Could not fetch MachineDeployment %q of machine %q, cordoning its node before drain: %s", machineDeploymentRef.Name, machine.Name, err)
+		return false
+	}
+	if machineDeployment.Spec.Replicas > 1 {
+		return false
+	}
+	maxSurge, err := getMaxSurge(machineDeployment)
+	if err != nil {
+		klog.Warningf("Could not resolve the max surge of MachineDeployment %q of machine %q, cordoning its node before drain: %s", machineDeployment.Name, machine.Name, err)
+		return false
+	}
+	return maxSurge == 0
+}
+
+// getMaxSurge returns the number of machines the MachineDeployment may create above its desired replicas during an update
+func getMaxSurge(machineDeployment *v1alpha1.MachineDeployment) (int, error) {
+	var maxSurge *intstr.IntOrString
+	switch strategy := machineDeployment.Spec.Strategy; {
+	case strategy.Type == v1alpha1.RollingUpdateMachineDeploymentStrategyType && strategy.RollingUpdate != nil:
+		maxSurge = strategy.RollingUpdate.MaxSurge
+	case strategy.Type == v1alpha1.InPlaceUpdateMachineDeploymentStrategyType && strategy.InPlaceUpdate != nil &&
+		strategy.InPlaceUpdate.OrchestrationType != v1alpha1.OrchestrationTypeManual:
+		maxSurge = strategy.InPlaceUpdate.MaxSurge
+	}
+	if maxSurge == nil {
+		return 0, nil
+	}
+	return intstr.GetScaledValueFromIntOrPercent(maxSurge, int(machineDeployment.Spec.Replicas), true)
+}
+
 // getEffectiveMaxEvictRetries returns the maxEvictRetries set on the machine-object, otherwise returns the evict retries set using the global-flag.
 func (c *controller) getEffectiveMaxEvictRetries(machine *v1alpha1.Machine) *int32 {
 	var maxEvictRetries *int32
@@ -1916,39 +4253,140 @@ func (c *controller) getEffectiveInPlaceUpdateTimeout(machine *v1alpha1.Machine)
 	return effectiveDependenciesUpdateTimeout
 }
 
-// getEffectiveNodeConditions returns the nodeConditions set on the machine-object, otherwise returns the conditions set using the global-flag.
+// getEffectiveNodeConditions returns the nodeConditions set on the machine-object, otherwise the conditions set using the
+// machineutils.MachineClassNodeConditions annotation on its MachineClass, otherwise returns the conditions set using the global-flag.
 func (c *controller) getEffectiveNodeConditions(machine *v1alpha1.Machine) *string {
 	var effectiveNodeConditions *string
 	if machine.Spec.MachineConfiguration != nil && machine.Spec.MachineConfiguration.NodeConditions != nil {
 		effectiveNodeConditions = machine.Spec.MachineConfiguration.NodeConditions
+	} else if classNodeConditions, ok := c.getMachineClassNodeConditions(machine); ok {
+		effectiveNodeConditions = &classNodeConditions
 	} else {
 		effectiveNodeConditions = &c.nodeConditions
 	}
 	return effectiveNodeConditions
 }
 
-// UpdateNodeTerminationCondition updates termination condition on the node object
-func (c *controller) UpdateNodeTerminationCondition(ctx context.Context, machine *v1alpha1.Machine) error {
-	if machine.Status.CurrentStatus.Phase == "" || machine.Status.CurrentStatus.Phase == v1alpha1.MachineCrashLoopBackOff {
+// getMachineClassNodeConditions returns the node conditions set using the machineutils.MachineClassNodeConditions
+// annotation on the MachineClass of the machine, if any
+func (c *controller) getMachineClassNodeConditions(machine *v1alpha1.Machine) (string, bool) {
+	if machine.Spec.Class.Name == "" {
+		return "", false
+	}
+	machineClass, err := c.machineClassLister.MachineClasses(machine.Namespace).Get(machine.Spec.Class.Name)
+	if err != nil {
+		klog.V(4).Infof("Could not fetch MachineClass %q of machine %q to check for its node conditions: %s", machine.Spec.Class.Name, machine.Name, err)
+		return "", false
+	}
+	nodeConditions, ok := machineClass.Annotations[machineutils.MachineClassNodeConditions]
+	return nodeConditions, ok
+}
+
+// getDrainSkipPodSelector returns the selector of the pods left on the node by the drain, set using the
+// machineutils.MachineClassDrainSkipPodSelector annotation on the MachineClass, or nil if none is set
+func getDrainSkipPodSelector(machineClass *v1alpha1.MachineClass) labels.Selector {
+	if machineClass == nil {
+		return nil
+	}
+	selectorValue, ok := machineClass.Annotations[machineutils.MachineClassDrainSkipPodSelector]
+	if !ok {
+		return nil
+	}
+	selector, err := labels.Parse(selectorValue)
+	if err != nil {
+		klog.Warningf("Ignoring invalid value %q of annotation %q on MachineClass %q: %s", selectorValue, machineutils.MachineClassDrainSkipPodSelector, machineClass.Name, err)
 		return nil
 	}
+	return selector
+}
+
+// getOnCreationTimeoutPolicy returns the policy set using the machineutils.MachineClassOnCreationTimeout annotation on the
+// MachineClass of the machine, defaulting to machineutils.OnCreationTimeoutPolicyFail
+func (c *controller) getOnCreationTimeoutPolicy(machine *v1alpha1.Machine) string {
+	if machine.Spec.Class.Name == "" {
+		return machineutils.OnCreationTimeoutPolicyFail
+	}
+	machineClass, err := c.machineClassLister.MachineClasses(machine.Namespace).Get(machine.Spec.Class.Name)
+	if err != nil {
+		klog.V(4).Infof("Could not fetch MachineClass %q of machine %q to check for its creation timeout policy: %s", machine.Spec.Class.Name, machine.Name, err)
+		return machineutils.OnCreationTimeoutPolicyFail
+	}
+
+	switch policy := machineClass.Annotations[machineutils.MachineClassOnCreationTimeout]; policy {
+	case "", machineutils.OnCreationTimeoutPolicyFail:
+		return machineutils.OnCreationTimeoutPolicyFail
+	case machineutils.OnCreationTimeoutPolicyRecreate:
+		return machineutils.OnCreationTimeoutPolicyRecreate
+	default:
+		klog.Warningf("Ignoring invalid value %q of annotation %q on MachineClass %q", policy, machineutils.MachineClassOnCreationTimeout, machineClass.Name)
+		return machineutils.OnCreationTimeoutPolicyFail
+	}
+}
+
+// recreateMachineOnCreationTimeout deletes the machine whose node didn't join within the creation timeout, instead of
+// marking it Failed, so that its MachineSet recreates it. The description of the timeout is recorded in the last
+// operation of the machine first, so that it remains visible while the machine is being deleted.
+func (c *controller) recreateMachineOnCreationTimeout(ctx context.Context, machine *v1alpha1.Machine, description string) (machineutils.RetryPeriod, error) {
+	if machine.Status.LastOperation.Description != description {
+		clone := machine.DeepCopy()
+		clone.Status.LastOperation = v1alpha1.LastOperation{
+			Description:    description,
+			State:          v1alpha1.MachineStateFailed,
+			Type:           machine.Status.LastOperation.Type,
+			LastUpdateTime: metav1.Now(),
+		}
+		if _, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("Last operation of machine %q could not be updated before its recreation after its creation timeout: %s", machine.Name, err)
+			if apierrors.IsConflict(err) {
+				return machineutils.ConflictRetry, err
+			}
+			return machineutils.ShortRetry, err
+		}
+	}
+
+	if err := c.controlMachineClient.Machines(machine.Namespace).Delete(ctx, machine.Name, metav1.DeleteOptions{}); err != nil {
+		klog.Errorf("Machine %q could not be deleted to be recreated after its creation timeout: %s", machine.Name, err)
+		return machineutils.ShortRetry, err
+	}
+
+	klog.V(2).Infof("Machine %q marked for deletion to be recreated after its creation timeout", machine.Name)
+	c.recorder.Eventf(machine, v1.EventTypeWarning, machineutils.MachineRecreatedOnCreationTimeoutReason, "%s Deleting the machine so that it is recreated", description)
+
+	// Return error to end the reconcile, the deletion flow takes over
+	return machineutils.ShortRetry, errMachineRecreationTriggered
+}
+
+// UpdateNodeTerminationCondition updates termination condition on the node object. It returns whether the
+// condition was written, which is not the case if it is already present on the node, preferably seen in the cache.
+func (c *controller) UpdateNodeTerminationCondition(ctx context.Context, machine *v1alpha1.Machine) (bool, error) {
+	if machine.Status.CurrentStatus.Phase == "" || machine.Status.CurrentStatus.Phase == v1alpha1.MachineCrashLoopBackOff {
+		return false, nil
+	}
 
 	nodeName := machine.Labels[v1alpha1.NodeLabelKey]
 
+	if node, err := c.nodeLister.Get(nodeName); err == nil && c.hasNodeTerminationCondition(nodeops.GetCondition(node, c.nodeTerminationConditionType), machine.Status.CurrentStatus.Phase) {
+		klog.V(4).Infof("Termination condition is already present on the cached node %q of machine %q", nodeName, machine.Name)
+		return false, nil
+	}
+
 	terminationCondition := v1.NodeCondition{
-		Type:               machineutils.NodeTerminationCondition,
+		Type:               c.nodeTerminationConditionType,
 		Status:             v1.ConditionTrue,
 		LastHeartbeatTime:  metav1.Now(),
 		LastTransitionTime: metav1.Now(),
 	}
 
 	// check if condition already exists
-	cond, err := nodeops.GetNodeCondition(ctx, c.targetCoreClient, nodeName, machineutils.NodeTerminationCondition)
+	cond, err := nodeops.GetNodeCondition(ctx, c.targetCoreClient, nodeName, c.nodeTerminationConditionType)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return false, nil
 		}
-		return err
+		return false, err
+	}
+	if c.hasNodeTerminationCondition(cond, machine.Status.CurrentStatus.Phase) {
+		return false, nil
 	}
 
 	if cond != nil && machine.Status.CurrentStatus.Phase == v1alpha1.MachineTerminating {
@@ -1957,13 +4395,35 @@ func (c *controller) UpdateNodeTerminationCondition(ctx context.Context, machine
 		terminationCondition.Message = cond.Message
 	} else {
 		setTerminationReasonByPhase(machine.Status.CurrentStatus.Phase, &terminationCondition)
+		if c.nodeTerminationConditionReason != "" {
+			terminationCondition.Reason = c.nodeTerminationConditionReason
+		}
 	}
 
 	err = nodeops.AddOrUpdateConditionsOnNode(ctx, c.targetCoreClient, nodeName, terminationCondition)
 	if apierrors.IsNotFound(err) {
-		return nil
+		return false, nil
 	}
-	return err
+	return err == nil, err
+}
+
+// hasNodeTerminationCondition returns whether the given termination condition of a node is already set as it would be
+// for a machine in the given phase, in which case it doesn't have to be set again
+func (c *controller) hasNodeTerminationCondition(cond *v1.NodeCondition, phase v1alpha1.MachinePhase) bool {
+	if cond == nil || cond.Status != v1.ConditionTrue {
+		return false
+	}
+	if phase == v1alpha1.MachineTerminating {
+		// the reason of a node which is already terminating is retained
+		return true
+	}
+
+	expected := v1.NodeCondition{}
+	setTerminationReasonByPhase(phase, &expected)
+	if c.nodeTerminationConditionReason != "" {
+		expected.Reason = c.nodeTerminationConditionReason
+	}
+	return cond.Reason == expected.Reason
 }
 
 func (c *controller) updateMachineToFailedState(ctx context.Context, description string, machine, clone *v1alpha1.Machine) (bool, error) {
@@ -1976,11 +4436,11 @@ func (c *controller) updateMachineToFailedState(ctx context.Context, description
 		Type:           machine.Status.LastOperation.Type,
 		LastUpdateTime: metav1.Now(),
 	}
-	clone.Status.CurrentStatus = v1alpha1.CurrentStatus{
+	machineutils.SetCurrentStatus(&clone.Status, v1alpha1.CurrentStatus{
 		Phase: v1alpha1.MachineFailed,
 		// TimeoutActive:  false,
 		LastUpdateTime: metav1.Now(),
-	}
+	})
 
 	_, err := c.controlMachineClient.Machines(clone.Namespace).UpdateStatus(ctx, clone, metav1.UpdateOptions{})
 	updated := false
@@ -2025,7 +4485,7 @@ func (c *controller) canMarkMachineFailed(machineDeployName, machineName, namesp
 				inProgress++
 			case v1alpha1.MachineFailed:
 				failed++
-			case v1alpha1.MachinePending:
+			case v1alpha1.MachinePending, v1alpha1.MachineVerifying:
 				pending++
 			case v1alpha1.MachineCrashLoopBackOff:
 				crashLooping++
@@ -2148,6 +4608,24 @@ func getNodeName(machine *v1alpha1.Machine) string {
 	return machine.Labels[v1alpha1.NodeLabelKey]
 }
 
+// getIdempotencyKey returns the key passed to the driver to dedupe the creation of the VM of the machine.
+// It is derived from the UID, so that it stays the same across retries and restarts of the controller.
+func getIdempotencyKey(machine *v1alpha1.Machine) string {
+	return string(machine.UID)
+}
+
+// getPlacementGroup returns the placement group the VM of the machine is created in, preferring the one annotated on the
+// machine over the one of its MachineClass
+func getPlacementGroup(machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass) string {
+	if placementGroup := machine.Annotations[machineutils.MachinePlacementGroup]; placementGroup != "" {
+		return placementGroup
+	}
+	if machineClass != nil {
+		return machineClass.Annotations[machineutils.MachinePlacementGroup]
+	}
+	return ""
+}
+
 func getMachineDeploymentName(machine *v1alpha1.Machine) string {
 	return machine.Labels["name"]
 }
@@ -2174,13 +4652,53 @@ func (c *controller) getNodeName(ctx context.Context, request *driver.GetMachine
 		return matchingNodeName, nil
 	}
 	klog.Errorf("Error trying to get node matching machine %s: %v. Will try to get the node name by calling driver.GetMachineStatus instead.", request.Machine.Name, err)
-	statusResp, err := c.driver.GetMachineStatus(ctx, request)
+	statusResp, err := c.getMachineStatusForDeletion(ctx, request)
 	if err == nil {
 		return statusResp.NodeName, nil
 	}
 	return "", err
 }
 
+// cachedDeletionMachineStatus is the result of a GetMachineStatus call of the deletion flow for a VM
+type cachedDeletionMachineStatus struct {
+	response  *driver.GetMachineStatusResponse
+	err       error
+	fetchedAt time.Time
+}
+
+// getMachineStatusForDeletion returns the result of the GetMachineStatus call for the VM of the machine being deleted
+// from the cache if it was fetched within the DeletionMachineStatusCacheTTL, and calls the driver otherwise.
+// Apart from the NotFound error, which is a definite result, errors returned by the driver are not cached.
+func (c *controller) getMachineStatusForDeletion(ctx context.Context, request *driver.GetMachineStatusRequest) (*driver.GetMachineStatusResponse, error) {
+	ttl := c.safetyOptions.DeletionMachineStatusCacheTTL.Duration
+	providerID := request.Machine.Spec.ProviderID
+	if ttl <= 0 || providerID == "" {
+		return c.driver.GetMachineStatus(ctx, request)
+	}
+
+	if cached, ok := c.deletionMachineStatusCache.Load(providerID); ok {
+		entry := cached.(*cachedDeletionMachineStatus)
+		if time.Since(entry.fetchedAt) < ttl {
+			klog.V(4).Infof("Using the cached status of VM %q of machine %q fetched at %s", providerID, request.Machine.Name, entry.fetchedAt)
+			return entry.response, entry.err
+		}
+	}
+
+	response, err := c.driver.GetMachineStatus(ctx, request)
+	if err != nil {
+		if machineErr, ok := status.FromError(err); !ok || machineErr.Code() != codes.NotFound {
+			c.deletionMachineStatusCache.Delete(providerID)
+			return nil, err
+		}
+	}
+	c.deletionMachineStatusCache.Store(providerID, &cachedDeletionMachineStatus{
+		response:  response,
+		err:       err,
+		fetchedAt: time.Now(),
+	})
+	return response, err
+}
+
 func (c *controller) fetchMatchingNodeName(machineName string) (string, error) {
 	var nodes []*v1.Node
 	nodes, err := c.nodeLister.List(labels.Everything())
@@ -12,16 +12,27 @@ import (
 	"time"
 
 	machinev1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	fakemachineapi "github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/typed/machine/v1alpha1/fake"
 	"github.com/gardener/machine-controller-manager/pkg/fakeclient"
 	"github.com/gardener/machine-controller-manager/pkg/util/nodeops"
 	"github.com/gardener/machine-controller-manager/pkg/util/permits"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/drain"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/options"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	bootstraptokenapi "k8s.io/cluster-bootstrap/token/api"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 )
@@ -417,8 +428,108 @@ var _ = Describe("machine_util", func() {
 		)
 	})
 
+	Describe("#syncNodeLabelTaints", func() {
+		var (
+			gpuTaint      corev1.Taint
+			machineObject *machinev1.Machine
+		)
+
+		BeforeEach(func() {
+			gpuTaint = corev1.Taint{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}
+			machineObject = newMachine(
+				&machinev1.MachineTemplateSpec{
+					Spec: machinev1.MachineSpec{
+						Class: machinev1.ClassSpec{
+							Kind: "MachineClass",
+							Name: "test-machine-class",
+						},
+					},
+				},
+				&machinev1.MachineStatus{},
+				nil, nil, map[string]string{machinev1.NodeLabelKey: "test-node-0"}, true, metav1.Now())
+		})
+
+		// syncNodeLabelTaints syncs the given node and returns its updated state
+		syncNodeLabelTaints := func(nodeObject *corev1.Node) (*corev1.Node, error) {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			c, trackers := createController(stop, testNamespace, []runtime.Object{machineObject}, nil, []runtime.Object{nodeObject}, nil, false)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+			c.nodeLabelTaints = []options.NodeLabelTaint{{Selector: "gpu=true", Taint: gpuTaint}}
+
+			_, syncErr := c.syncNodeLabelTaints(context.TODO(), machineObject)
+
+			node, err := c.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), nodeObject.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			return node, syncErr
+		}
+
+		It("should add the derived taint when the node label is added and remove it once the label is removed", func() {
+			userTaint := corev1.Taint{Key: "dedicated", Value: "user", Effect: corev1.TaintEffectNoSchedule}
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-node-0",
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{userTaint},
+				},
+			}
+
+			By("not touching the node as long as it doesn't match the selector")
+			node, err := syncNodeLabelTaints(node)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Spec.Taints).To(ConsistOf(userTaint))
+
+			By("adding the derived taint once the label is added")
+			node.Labels = map[string]string{"gpu": "true"}
+			node, err = syncNodeLabelTaints(node)
+			Expect(err).To(Equal(errSuccessfulNodeLabelTaintsSync))
+			Expect(node.Spec.Taints).To(ConsistOf(userTaint, gpuTaint))
+			Expect(node.Annotations).To(HaveKey(machineutils.LastAppliedNodeLabelTaintsAnnotation))
+
+			By("not updating the node again as long as the label is present")
+			node, err = syncNodeLabelTaints(node)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Spec.Taints).To(ConsistOf(userTaint, gpuTaint))
+
+			By("removing the derived taint once the label is removed")
+			delete(node.Labels, "gpu")
+			node, err = syncNodeLabelTaints(node)
+			Expect(err).To(Equal(errSuccessfulNodeLabelTaintsSync))
+			Expect(node.Spec.Taints).To(ConsistOf(userTaint))
+			Expect(node.Annotations).ToNot(HaveKey(machineutils.LastAppliedNodeLabelTaintsAnnotation))
+		})
+
+		It("should not take over a matching taint which was not applied by MCM", func() {
+			userGPUTaint := corev1.Taint{Key: "gpu", Value: "user", Effect: corev1.TaintEffectNoSchedule}
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test-node-0",
+					Labels: map[string]string{"gpu": "true"},
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{userGPUTaint},
+				},
+			}
+
+			node, err := syncNodeLabelTaints(node)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Spec.Taints).To(ConsistOf(userGPUTaint))
+			Expect(node.Annotations).ToNot(HaveKey(machineutils.LastAppliedNodeLabelTaintsAnnotation))
+
+			delete(node.Labels, "gpu")
+			node, err = syncNodeLabelTaints(node)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Spec.Taints).To(ConsistOf(userGPUTaint))
+		})
+	})
+
 	Describe("#SyncMachineLabels", func() {
-		type setup struct{}
+		type setup struct {
+			denylist []string
+		}
 		type action struct {
 			node    *corev1.Node
 			machine *machinev1.Machine
@@ -456,7 +567,7 @@ var _ = Describe("machine_util", func() {
 					}
 				}
 
-				labelsChanged := SyncMachineLabels(testMachine, testNode, lastAppliedALT.Labels)
+				labelsChanged := SyncMachineLabels(testMachine, testNode, lastAppliedALT.Labels, data.setup.denylist)
 
 				waitForCacheSync(stop, c)
 
@@ -846,6 +957,68 @@ var _ = Describe("machine_util", func() {
 					labelsChanged: true,
 				},
 			}),
+
+			Entry("when labels match the denylist", &data{
+				setup: setup{
+					denylist: []string{"node-role.kubernetes.io/", "reserved"},
+				},
+				action: action{
+					node: &corev1.Node{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "v1",
+							Kind:       "Node",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-node-0",
+							Labels: map[string]string{
+								"key1":                           "value1",
+								"node-role.kubernetes.io/worker": "node-value",
+								"reserved-key":                   "node-value",
+							},
+							Annotations: map[string]string{
+								machineutils.LastAppliedALTAnnotation: "{\"metadata\":{\"creationTimestamp\":null,\"labels\":{\"key1\":\"value1\",\"reserved-key\":\"value\"}}}",
+							},
+						},
+					},
+					machine: newMachine(
+						&machinev1.MachineTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels: map[string]string{
+									"test-label": "test-label",
+								},
+							},
+							Spec: machinev1.MachineSpec{
+								NodeTemplateSpec: machinev1.NodeTemplateSpec{
+									ObjectMeta: metav1.ObjectMeta{
+										Labels: map[string]string{
+											"key1":                           "value1",
+											"node-role.kubernetes.io/worker": "template-value",
+											"node-role.kubernetes.io/master": "template-value",
+										},
+									},
+								},
+							},
+						},
+						nil, nil, nil, nil, true, metav1.Now()),
+				},
+				expect: expect{
+					node: &corev1.Node{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "v1",
+							Kind:       "Node",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-node-0",
+							Labels: map[string]string{
+								"key1":                           "value1",
+								"node-role.kubernetes.io/worker": "node-value",
+								"reserved-key":                   "node-value",
+							},
+						},
+					},
+					labelsChanged: false,
+				},
+			}),
 		)
 	})
 
@@ -1978,6 +2151,57 @@ var _ = Describe("machine_util", func() {
 		)
 	})
 
+	Describe("#SetCurrentStatus", func() {
+		It("should accumulate the time spent in each phase across phase transitions", func() {
+			start := time.Now().Add(-time.Hour)
+			at := func(offset time.Duration) metav1.Time {
+				return metav1.NewTime(start.Add(offset))
+			}
+			status := &machinev1.MachineStatus{}
+
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachinePending, LastUpdateTime: at(0)})
+			Expect(status.PhaseDurations).To(BeEmpty())
+
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachineRunning, LastUpdateTime: at(2 * time.Minute)})
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachineUnknown, LastUpdateTime: at(12 * time.Minute)})
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachineRunning, LastUpdateTime: at(13 * time.Minute)})
+			// an update within the same phase neither ends the phase nor restarts it
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachineRunning, LastUpdateTime: at(16 * time.Minute)})
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachineTerminating, LastUpdateTime: at(20 * time.Minute)})
+
+			Expect(status.CurrentStatus.Phase).To(Equal(machinev1.MachineTerminating))
+			Expect(status.PhaseDurations).To(Equal(map[string]metav1.Duration{
+				string(machinev1.MachinePending): {Duration: 2 * time.Minute},
+				string(machinev1.MachineRunning): {Duration: 17 * time.Minute},
+				string(machinev1.MachineUnknown): {Duration: time.Minute},
+			}))
+			Expect(status.DeepCopy().PhaseDurations).To(Equal(status.PhaseDurations))
+		})
+
+		It("should record the time of the last transition to each known phase", func() {
+			start := time.Now().Add(-time.Hour)
+			at := func(offset time.Duration) metav1.Time {
+				return metav1.NewTime(start.Add(offset))
+			}
+			status := &machinev1.MachineStatus{}
+
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachinePending, LastUpdateTime: at(0)})
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachineCrashLoopBackOff, LastUpdateTime: at(time.Minute)})
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachinePending, LastUpdateTime: at(3 * time.Minute)})
+			// an update within the same phase isn't a transition
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachinePending, LastUpdateTime: at(4 * time.Minute)})
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: machinev1.MachineRunning, LastUpdateTime: at(5 * time.Minute)})
+			// unknown phases aren't recorded
+			machineutils.SetCurrentStatus(status, machinev1.CurrentStatus{Phase: "Bogus", LastUpdateTime: at(6 * time.Minute)})
+
+			Expect(status.PhaseTransitionTimes).To(Equal(map[machinev1.MachinePhase]metav1.Time{
+				machinev1.MachinePending:          at(3 * time.Minute),
+				machinev1.MachineCrashLoopBackOff: at(time.Minute),
+				machinev1.MachineRunning:          at(5 * time.Minute),
+			}))
+		})
+	})
+
 	Describe("#validateNodeTemplate", func() {
 		type setup struct {
 			machineClass *machinev1.MachineClass
@@ -2128,6 +2352,15 @@ var _ = Describe("machine_util", func() {
 			for _, o := range data.setup.nodes {
 				targetCoreObjects = append(targetCoreObjects, o)
 			}
+			// The bootstrap token of the machine is valid throughout its creation timeout
+			_, tokenSecretName := getTokenIDAndSecretName(data.setup.targetMachineName)
+			targetCoreObjects = append(targetCoreObjects, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: tokenSecretName, Namespace: metav1.NamespaceSystem},
+				Type:       bootstraptokenapi.SecretTypeBootstrapToken,
+				Data: map[string][]byte{
+					bootstraptokenapi.BootstrapTokenExpirationKey: []byte(metav1.Now().Add(20 * time.Minute).Format(time.RFC3339)),
+				},
+			})
 
 			c, trackers = createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects, nil, false)
 			defer trackers.Stop()
@@ -2533,6 +2766,526 @@ var _ = Describe("machine_util", func() {
 			}),
 		)
 
+		Context("when the MachineClass configures a verification condition", func() {
+			It("should keep the joined machine Verifying until the verification passes and then mark it Running", func() {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				const verificationCondition corev1.NodeConditionType = "SmokeTestPassed"
+				machineClass := &machinev1.MachineClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "machineClass",
+						Namespace:   testNamespace,
+						Annotations: map[string]string{machineutils.MachineClassVerificationCondition: string(verificationCondition)},
+					},
+				}
+				machine := newHealthyMachine(machineSet1Deploy1, "node-0", machinev1.MachinePending)
+				machine.Spec.Class = machinev1.ClassSpec{Kind: machineutils.MachineClassKind, Name: machineClass.Name}
+				machine.Status.LastOperation = machinev1.LastOperation{
+					Description: "Creating machine on cloud provider",
+					State:       machinev1.MachineStateProcessing,
+					Type:        machinev1.MachineOperationCreate,
+				}
+				creationStart := metav1.NewTime(time.Now().Add(-5 * time.Minute).Truncate(time.Second))
+				machine.Status.CurrentStatus.LastUpdateTime = creationStart
+				nodeStatus := &corev1.NodeStatus{
+					Phase:      corev1.NodeRunning,
+					Conditions: append(nodeConditions(true, false, false, false, false), corev1.NodeCondition{Type: verificationCondition, Status: corev1.ConditionFalse}),
+				}
+				node := newNode(1, nil, nil, &corev1.NodeSpec{}, nodeStatus)
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machineClass, machine}, nil, []runtime.Object{node}, nil, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, c)
+
+				By("failing verification")
+				_, err := c.reconcileMachineHealth(context.TODO(), machine)
+				Expect(err).To(Equal(errSuccessfulPhaseUpdate))
+				machine, err = c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineVerifying))
+				Expect(machine.Status.CurrentStatus.LastUpdateTime.Time).To(BeTemporally("==", creationStart.Time))
+				Expect(machine.Status.LastOperation.State).To(Equal(machinev1.MachineStateProcessing))
+				Expect(machine.Status.LastOperation.Description).To(ContainSubstring(string(verificationCondition)))
+
+				By("passing verification")
+				node.Status.Conditions[len(node.Status.Conditions)-1].Status = corev1.ConditionTrue
+				_, err = c.targetCoreClient.CoreV1().Nodes().UpdateStatus(context.TODO(), node, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Eventually(func() corev1.ConditionStatus {
+					cachedNode, err := c.nodeLister.Get(node.Name)
+					if err != nil {
+						return ""
+					}
+					return cachedNode.Status.Conditions[len(cachedNode.Status.Conditions)-1].Status
+				}).Should(Equal(corev1.ConditionTrue))
+
+				_, err = c.reconcileMachineHealth(context.TODO(), machine)
+				Expect(err).To(Equal(errSuccessfulPhaseUpdate))
+				machine, err = c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(machine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineRunning))
+				Expect(machine.Status.LastOperation.State).To(Equal(machinev1.MachineStateSuccessful))
+				Expect(machine.Status.LastOperation.Type).To(Equal(machinev1.MachineOperationCreate))
+			})
+		})
+
+		DescribeTable("##Pending machine whose node keeps the critical-components-not-ready taint",
+			func(nodeAge, timeout time.Duration, expectedPhase machinev1.MachinePhase) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newHealthyMachine(machineSet1Deploy1, "node-0", machinev1.MachinePending)
+				machine.Status.LastOperation = machinev1.LastOperation{
+					Description: "Creating machine on cloud provider",
+					State:       machinev1.MachineStateProcessing,
+					Type:        machinev1.MachineOperationCreate,
+				}
+				node := newNode(
+					1,
+					nil,
+					nil,
+					&corev1.NodeSpec{
+						Taints: []corev1.Taint{
+							{
+								Key:    machineutils.TaintNodeCriticalComponentsNotReady,
+								Effect: corev1.TaintEffectNoSchedule,
+							},
+						},
+					},
+					&corev1.NodeStatus{
+						Phase:      corev1.NodeRunning,
+						Conditions: nodeConditions(true, false, false, false, false),
+					},
+				)
+				node.CreationTimestamp = metav1.NewTime(time.Now().Add(-nodeAge))
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, []runtime.Object{node}, nil, false)
+				defer trackers.Stop()
+				c.safetyOptions.MachineCriticalComponentsNotReadyTimeout = metav1.Duration{Duration: timeout}
+				waitForCacheSync(stop, c)
+
+				_, _ = c.reconcileMachineHealth(context.TODO(), machine)
+
+				updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).To(BeNil())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(expectedPhase))
+				if expectedPhase == machinev1.MachineFailed {
+					Expect(updatedMachine.Status.LastOperation.State).To(Equal(machinev1.MachineStateFailed))
+					Expect(updatedMachine.Status.LastOperation.Type).To(Equal(machinev1.MachineOperationCreate))
+					Expect(updatedMachine.Status.LastOperation.Description).To(ContainSubstring(machineutils.TaintNodeCriticalComponentsNotReady))
+				}
+			},
+			Entry("should mark the machine Failed once the taint outlives the timeout, even within the creation timeout", 15*time.Minute, 10*time.Minute, machinev1.MachineFailed),
+			Entry("should keep the machine Pending while the taint is within the timeout", 5*time.Minute, 10*time.Minute, machinev1.MachinePending),
+			Entry("should keep the machine Pending if the timeout is disabled", 15*time.Minute, time.Duration(0), machinev1.MachinePending),
+		)
+
+		DescribeTable("##Pending machine whose bootstrap token expired before its node joined",
+			func(tokenExpiration *time.Duration, expectedPhase machinev1.MachinePhase) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newMachine(
+					&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+					&machinev1.MachineStatus{
+						CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachinePending, LastUpdateTime: metav1.NewTime(time.Now().Add(-5 * time.Minute))},
+						LastOperation: machinev1.LastOperation{
+							Description: "Creating machine on cloud provider",
+							State:       machinev1.MachineStateProcessing,
+							Type:        machinev1.MachineOperationCreate,
+						},
+					},
+					nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+				var targetCoreObjects []runtime.Object
+				if tokenExpiration != nil {
+					_, secretName := getTokenIDAndSecretName(machine.Name)
+					targetCoreObjects = append(targetCoreObjects, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: metav1.NamespaceSystem},
+						Type:       bootstraptokenapi.SecretTypeBootstrapToken,
+						Data: map[string][]byte{
+							bootstraptokenapi.BootstrapTokenExpirationKey: []byte(metav1.Now().Add(*tokenExpiration).Format(time.RFC3339)),
+						},
+					})
+				}
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, targetCoreObjects, nil, false)
+				defer trackers.Stop()
+				waitForCacheSync(stop, c)
+
+				retryPeriod, err := c.reconcileMachineHealth(context.TODO(), machine)
+
+				updatedMachine, getErr := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(getErr).To(BeNil())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(expectedPhase))
+				if expectedPhase == machinev1.MachineFailed {
+					Expect(retryPeriod).To(Equal(machineutils.ShortRetry))
+					Expect(err).To(Equal(errSuccessfulPhaseUpdate))
+					Expect(updatedMachine.Status.LastOperation.State).To(Equal(machinev1.MachineStateFailed))
+					Expect(updatedMachine.Status.LastOperation.Type).To(Equal(machinev1.MachineOperationCreate))
+					Expect(updatedMachine.Status.LastOperation.Description).To(ContainSubstring("Bootstrap token"))
+					Expect(c.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(machineutils.BootstrapTokenExpiredReason)))
+				} else {
+					Expect(c.recorder.(*record.FakeRecorder).Events).ToNot(Receive())
+				}
+			},
+			Entry("should mark the machine Failed right away, even within the creation timeout", ptr.To(-time.Minute), machinev1.MachineFailed),
+			Entry("should mark the machine Failed if the bootstrap token was already cleaned up", nil, machinev1.MachineFailed),
+			Entry("should keep the machine Pending while the bootstrap token is valid", ptr.To(15*time.Minute), machinev1.MachinePending),
+		)
+
+		DescribeTable("##Machine whose creation timeout occurred while the target cluster is unreachable",
+			func(retryPeriod time.Duration, expectedPhase machinev1.MachinePhase) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newMachine(
+					&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+					&machinev1.MachineStatus{CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachinePending, LastUpdateTime: metav1.NewTime(time.Now().Add(-25 * time.Minute))}},
+					nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, nil, nil, false)
+				defer trackers.Stop()
+				c.safetyOptions.TargetClusterUnreachableRetryPeriod = metav1.Duration{Duration: retryPeriod}
+				waitForCacheSync(stop, c)
+
+				c.targetCoreClient.(*fakeclient.Clientset).PrependReactor("*", "nodes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewServiceUnavailable("target cluster is down")
+				})
+
+				retryPeriodAfterReconcile, err := c.reconcileMachineHealth(context.TODO(), machine)
+
+				updatedMachine, getErr := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(getErr).To(BeNil())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(expectedPhase))
+				if expectedPhase == machinev1.MachineFailed {
+					Expect(retryPeriodAfterReconcile).To(Equal(machineutils.ShortRetry))
+					Expect(err).To(Equal(errSuccessfulPhaseUpdate))
+				} else {
+					Expect(retryPeriodAfterReconcile).To(Equal(machineutils.LongRetry))
+					Expect(err).ToNot(HaveOccurred())
+				}
+			},
+			Entry("should hold the machine instead of marking it Failed", 30*time.Second, machinev1.MachinePending),
+			Entry("should mark the machine Failed if the hold is disabled", time.Duration(0), machinev1.MachineFailed),
+		)
+
+		It("should probe the reachability of the target cluster at most once per TTL", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			c, trackers = createController(stop, testNamespace, nil, nil, nil, nil, false)
+			defer trackers.Stop()
+			c.safetyOptions.TargetClusterUnreachableRetryPeriod = metav1.Duration{Duration: 30 * time.Second}
+			waitForCacheSync(stop, c)
+
+			var probes int
+			c.targetCoreClient.(*fakeclient.Clientset).PrependReactor("get", "nodes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+				probes++
+				return true, nil, apierrors.NewServiceUnavailable("target cluster is down")
+			})
+
+			Expect(c.isTargetClusterUnreachable(context.TODO())).To(BeTrue())
+			Expect(c.isTargetClusterUnreachable(context.TODO())).To(BeTrue())
+			Expect(probes).To(Equal(1))
+
+			c.targetClusterReachability.Store(&targetClusterProbe{unreachable: true, probedAt: time.Now().Add(-machineutils.TargetClusterReachabilityCacheTTL)})
+			Expect(c.isTargetClusterUnreachable(context.TODO())).To(BeTrue())
+			Expect(probes).To(Equal(2))
+		})
+
+		DescribeTable("##Machine whose creation timeout occurred with an on-creation-timeout policy on its MachineClass",
+			func(policy string, controlled bool, expectRecreated bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machineClass := &machinev1.MachineClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "machineClass",
+						Namespace: testNamespace,
+					},
+				}
+				if policy != "" {
+					machineClass.Annotations = map[string]string{machineutils.MachineClassOnCreationTimeout: policy}
+				}
+				machine := newMachine(
+					&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+					&machinev1.MachineStatus{
+						CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachinePending, LastUpdateTime: metav1.NewTime(time.Now().Add(-25 * time.Minute))},
+						LastOperation: machinev1.LastOperation{
+							Description: "Creating machine on cloud provider",
+							State:       machinev1.MachineStateProcessing,
+							Type:        machinev1.MachineOperationCreate,
+						},
+					},
+					nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, false, metav1.Now())
+				machine.Spec.Class = machinev1.ClassSpec{Kind: machineutils.MachineClassKind, Name: machineClass.Name}
+				if controlled {
+					machine.OwnerReferences = []metav1.OwnerReference{{APIVersion: "machine.sapcloud.io/v1alpha1", Kind: "MachineSet", Name: machineSet1Deploy1, Controller: ptr.To(true)}}
+				}
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machineClass, machine}, nil, nil, nil, false)
+				defer trackers.Stop()
+				// the machine is kept by its finalizer once deleted
+				var deleted bool
+				c.controlMachineClient.(*fakemachineapi.FakeMachineV1alpha1).PrependReactor("delete", "machines", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+					deleted = true
+					return true, nil, nil
+				})
+				waitForCacheSync(stop, c)
+
+				retryPeriod, err := c.reconcileMachineHealth(context.TODO(), machine)
+				Expect(retryPeriod).To(Equal(machineutils.ShortRetry))
+
+				updatedMachine, getErr := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(getErr).ToNot(HaveOccurred())
+				Expect(deleted).To(Equal(expectRecreated))
+				if expectRecreated {
+					Expect(err).To(Equal(errMachineRecreationTriggered))
+					Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachinePending))
+					Expect(updatedMachine.Status.LastOperation.State).To(Equal(machinev1.MachineStateFailed))
+					Expect(updatedMachine.Status.LastOperation.Description).To(ContainSubstring("failed to join the cluster"))
+					Expect(c.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(machineutils.MachineRecreatedOnCreationTimeoutReason)))
+				} else {
+					Expect(err).To(Equal(errSuccessfulPhaseUpdate))
+					Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineFailed))
+					Expect(updatedMachine.Status.LastOperation.State).To(Equal(machinev1.MachineStateFailed))
+					Expect(updatedMachine.Status.LastOperation.Description).To(ContainSubstring("failed to join the cluster"))
+					Expect(c.recorder.(*record.FakeRecorder).Events).ToNot(Receive())
+				}
+			},
+			Entry("should mark the machine Failed without a policy", "", true, false),
+			Entry("should mark the machine Failed with the fail policy", machineutils.OnCreationTimeoutPolicyFail, true, false),
+			Entry("should delete the machine to be recreated with the recreate policy", machineutils.OnCreationTimeoutPolicyRecreate, true, true),
+			Entry("should mark the machine Failed with the recreate policy if no MachineSet controls it", machineutils.OnCreationTimeoutPolicyRecreate, false, false),
+			Entry("should mark the machine Failed with an invalid policy", "replace", true, false),
+		)
+
+		DescribeTable("##Unknown machine whose status was last updated in the future due to clock skew",
+			func(observedFor time.Duration, expectedPhase machinev1.MachinePhase) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newMachine(
+					&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+					&machinev1.MachineStatus{Conditions: nodeConditions(false, false, false, false, false), CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineUnknown, LastUpdateTime: metav1.NewTime(time.Now().Add(time.Hour))}},
+					&metav1.OwnerReference{Name: machineSet1Deploy1},
+					nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+				node := newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{Phase: corev1.NodeRunning, Conditions: nodeConditions(false, false, false, false, false)})
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, []runtime.Object{node}, nil, false)
+				defer trackers.Stop()
+				c.permitGiver = permits.NewPermitGiver(5*time.Second, 1*time.Second)
+				defer c.permitGiver.Close()
+				waitForCacheSync(stop, c)
+
+				_, _ = c.reconcileMachineHealth(context.TODO(), machine)
+				updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineUnknown))
+
+				if observedFor > 0 {
+					// simulate that the timestamp has been observed in the future for a while
+					c.futureTimestamps.Range(func(_, observed any) bool {
+						observed.(*futureTimestamp).observedAt = time.Now().Add(-observedFor)
+						return true
+					})
+					_, _ = c.reconcileMachineHealth(context.TODO(), machine)
+				}
+
+				updatedMachine, err = c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(expectedPhase))
+			},
+			Entry("should not mark the machine Failed prematurely", time.Duration(0), machinev1.MachineUnknown),
+			Entry("should keep the machine Unknown while observed for less than the health timeout", 5*time.Minute, machinev1.MachineUnknown),
+			Entry("should mark the machine Failed once observed for longer than the health timeout, before the clock catches up", 15*time.Minute, machinev1.MachineFailed),
+		)
+
+		DescribeTable("##Unknown machine whose node has the critical-components-not-ready taint",
+			func(taintPresent bool, nodeAge, timeout time.Duration, expectedPhase machinev1.MachinePhase) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newMachine(
+					&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+					&machinev1.MachineStatus{Conditions: nodeConditions(false, false, false, false, false), CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineUnknown, LastUpdateTime: metav1.NewTime(time.Now().Add(-15 * time.Minute))}},
+					&metav1.OwnerReference{Name: machineSet1Deploy1},
+					nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+				nodeSpec := &corev1.NodeSpec{}
+				if taintPresent {
+					nodeSpec.Taints = []corev1.Taint{
+						{
+							Key:    machineutils.TaintNodeCriticalComponentsNotReady,
+							Effect: corev1.TaintEffectNoSchedule,
+						},
+					}
+				}
+				node := newNode(1, nil, nil, nodeSpec, &corev1.NodeStatus{Phase: corev1.NodeRunning, Conditions: nodeConditions(false, false, false, false, false)})
+				node.CreationTimestamp = metav1.NewTime(time.Now().Add(-nodeAge))
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, []runtime.Object{node}, nil, false)
+				defer trackers.Stop()
+				c.safetyOptions.MachineCriticalComponentsNotReadyTimeout = metav1.Duration{Duration: timeout}
+				c.permitGiver = permits.NewPermitGiver(5*time.Second, 1*time.Second)
+				defer c.permitGiver.Close()
+				waitForCacheSync(stop, c)
+
+				_, _ = c.reconcileMachineHealth(context.TODO(), machine)
+
+				updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(expectedPhase))
+				if expectedPhase == machinev1.MachineUnknown {
+					Expect(updatedMachine.Status.LastOperation.Description).To(HaveSuffix(machineutils.MachineHealthTimeoutExtended))
+					Expect(updatedMachine.Status.CurrentStatus.LastUpdateTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+				}
+			},
+			Entry("should restart the health timeout while the node is still initializing", true, 20*time.Minute, 30*time.Minute, machinev1.MachineUnknown),
+			Entry("should mark the machine Failed once the node cleared the taint", false, 20*time.Minute, 30*time.Minute, machinev1.MachineFailed),
+			Entry("should mark the machine Failed if the node didn't clear the taint within the critical components timeout", true, 40*time.Minute, 30*time.Minute, machinev1.MachineFailed),
+			Entry("should mark the machine Failed if the critical components timeout is disabled", true, 20*time.Minute, time.Duration(0), machinev1.MachineFailed),
+		)
+
+		DescribeTable("##Running machine whose node is cordoned",
+			func(nodeLabels map[string]string, expectCondition bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newHealthyMachine(machineSet1Deploy1, "node-0", machinev1.MachineRunning)
+				machine.DeletionTimestamp = nil
+				node := newNode(1, nil, nil, &corev1.NodeSpec{Unschedulable: true}, &corev1.NodeStatus{
+					Phase:      corev1.NodeRunning,
+					Conditions: nodeConditions(true, false, false, false, false),
+				})
+				node.Labels = nodeLabels
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, []runtime.Object{node}, nil, false)
+				defer trackers.Stop()
+				c.safetyOptions.UnschedulableJoiningNodePolicy = machineutils.UnschedulableJoiningNodePolicyUncordon
+				waitForCacheSync(stop, c)
+
+				_, _ = c.reconcileMachineHealth(context.TODO(), machine)
+
+				updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineRunning))
+				if expectCondition {
+					condition := getNodeExternallyCordonedCondition(updatedMachine)
+					Expect(condition).ToNot(BeNil())
+					Expect(condition.Message).To(ContainSubstring("node-0"))
+				} else {
+					Expect(getNodeExternallyCordonedCondition(updatedMachine)).To(BeNil())
+				}
+
+				updatedNode, err := c.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "node-0", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+			},
+			Entry("should set the NodeExternallyCordoned condition and not uncordon the node cordoned by another actor", nil, true),
+			Entry("should not set the NodeExternallyCordoned condition for a node cordoned for an in-place update", map[string]string{machinev1.LabelKeyNodeCandidateForUpdate: "true"}, false),
+		)
+
+		DescribeTable("##Pending machine whose node joins the cluster cordoned",
+			func(policy string, expectUnschedulable bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newHealthyMachine(machineSet1Deploy1, "node-0", machinev1.MachinePending)
+				machine.Status.LastOperation = machinev1.LastOperation{
+					Description: "Creating machine on cloud provider",
+					State:       machinev1.MachineStateProcessing,
+					Type:        machinev1.MachineOperationCreate,
+				}
+				node := newNode(
+					1,
+					nil,
+					nil,
+					&corev1.NodeSpec{Unschedulable: true},
+					&corev1.NodeStatus{
+						Phase:      corev1.NodeRunning,
+						Conditions: nodeConditions(true, false, false, false, false),
+					},
+				)
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, []runtime.Object{node}, nil, false)
+				defer trackers.Stop()
+				c.safetyOptions.UnschedulableJoiningNodePolicy = policy
+				waitForCacheSync(stop, c)
+
+				retryPeriod, err := c.reconcileMachineHealth(context.TODO(), machine)
+				Expect(retryPeriod).To(Equal(machineutils.ShortRetry))
+				Expect(err).To(Equal(errSuccessfulPhaseUpdate))
+
+				updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).To(BeNil())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineRunning))
+				Expect(updatedMachine.Status.LastOperation.Type).To(Equal(machinev1.MachineOperationCreate))
+				Expect(updatedMachine.Status.LastOperation.State).To(Equal(machinev1.MachineStateSuccessful))
+
+				updatedNode, err := c.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				Expect(err).To(BeNil())
+				Expect(updatedNode.Spec.Unschedulable).To(Equal(expectUnschedulable))
+			},
+			Entry("should mark the machine Running and uncordon its node if the policy is uncordon", machineutils.UnschedulableJoiningNodePolicyUncordon, false),
+			Entry("should mark the machine Running and keep its node cordoned if the policy is keep", machineutils.UnschedulableJoiningNodePolicyKeep, true),
+		)
+
+		DescribeTable("##Unknown machine whose node recovers",
+			func(nodeTaintsRemovedOnRecovery []string, conflicts int, expectedTaints []corev1.Taint) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				unhealthyTaint := corev1.Taint{Key: "remediation.example.com/unhealthy", Effect: corev1.TaintEffectNoSchedule}
+				userTaint := corev1.Taint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}
+
+				machine := newHealthyMachine(machineSet1Deploy1, "node-0", machinev1.MachineUnknown)
+				node := newNode(
+					1,
+					nil,
+					nil,
+					&corev1.NodeSpec{Taints: []corev1.Taint{unhealthyTaint, userTaint}},
+					&corev1.NodeStatus{
+						Phase:      corev1.NodeRunning,
+						Conditions: nodeConditions(true, false, false, false, false),
+					},
+				)
+
+				c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, []runtime.Object{node}, nil, false)
+				defer trackers.Stop()
+				c.nodeTaintsRemovedOnRecovery = nodeTaintsRemovedOnRecovery
+				waitForCacheSync(stop, c)
+				c.targetCoreClient.(*fakeclient.Clientset).PrependReactor("update", "nodes", func(_ k8stesting.Action) (bool, runtime.Object, error) {
+					if conflicts == 0 {
+						return false, nil, nil
+					}
+					conflicts--
+					return true, nil, apierrors.NewConflict(corev1.Resource("nodes"), node.Name, errors.New("the object has been modified"))
+				})
+
+				retryPeriod, err := c.reconcileMachineHealth(context.TODO(), machine)
+				Expect(retryPeriod).To(Equal(machineutils.ShortRetry))
+				Expect(err).To(Equal(errSuccessfulPhaseUpdate))
+
+				updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+				Expect(err).To(BeNil())
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineRunning))
+
+				updatedNode, err := c.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				Expect(err).To(BeNil())
+				Expect(updatedNode.Spec.Taints).To(Equal(expectedTaints))
+			},
+			Entry("should remove the configured unhealthy taint and keep the user taints",
+				[]string{"remediation.example.com/unhealthy"}, 0,
+				[]corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}),
+			Entry("should remove the configured unhealthy taint from the re-fetched node on a conflict",
+				[]string{"remediation.example.com/unhealthy"}, 1,
+				[]corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}),
+			Entry("should keep all taints if the removal is disabled",
+				nil, 0,
+				[]corev1.Taint{{Key: "remediation.example.com/unhealthy", Effect: corev1.TaintEffectNoSchedule}, {Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}),
+		)
+
 		DescribeTable("##Meltdown scenario when many machines Unknown for over 10min(healthTimeout)", func(data *data) {
 			stop := make(chan struct{})
 			defer close(stop)
@@ -2826,6 +3579,619 @@ var _ = Describe("machine_util", func() {
 				},
 			}),
 		)
+
+		Context("when the driver reports custom conditions", func() {
+			DescribeTable("##table",
+				func(providerConditions string, expectedPhase machinev1.MachinePhase) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					maintenanceCondition := corev1.NodeCondition{
+						Type:   "ScheduledMaintenance",
+						Status: corev1.ConditionTrue,
+						Reason: "MaintenanceWindowScheduled",
+					}
+					machine := newMachine(
+						&machinev1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0),
+							Spec: machinev1.MachineSpec{
+								Class: machinev1.ClassSpec{Kind: machineutils.MachineClassKind, Name: "machineclass-0"},
+							},
+						},
+						&machinev1.MachineStatus{Conditions: nodeConditions(true, false, false, false, false), CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineRunning, LastUpdateTime: metav1.Now()}},
+						nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+					controlMachineObjects := []runtime.Object{
+						&machinev1.MachineClass{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:       "machineclass-0",
+								Namespace:  testNamespace,
+								Finalizers: []string{MCMFinalizerName},
+							},
+						},
+						machine,
+					}
+					targetCoreObjects := []runtime.Object{
+						newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{Phase: corev1.NodeRunning, Conditions: nodeConditions(true, false, false, false, false)}),
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID-0", "node-0", "", nil, nil)
+					fakeDriver.(*driver.FakeDriver).Conditions = []corev1.NodeCondition{maintenanceCondition}
+					c, trackers = createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					c.providerConditions = providerConditions
+					waitForCacheSync(stop, c)
+
+					_, err := c.reconcileMachineHealth(context.TODO(), machine)
+					Expect(err).To(HaveOccurred())
+
+					updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(updatedMachine.Status.Conditions).To(ContainElement(maintenanceCondition))
+					Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(expectedPhase))
+				},
+				Entry("should mark the machine Unknown if the condition is configured as a provider condition", "ScheduledMaintenance", machinev1.MachineUnknown),
+				Entry("should keep the machine Running if the condition isn't configured to influence its health", "SomeOtherCondition", machinev1.MachineRunning),
+			)
+		})
+
+		Context("when the node NotReady policy is reboot-before-replace", func() {
+			DescribeTable("##table",
+				func(rebootSupported bool, lastOperationDescription string, expectRebooted bool, expectedPhase machinev1.MachinePhase) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machine := newMachine(
+						&machinev1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0),
+							Spec: machinev1.MachineSpec{
+								Class: machinev1.ClassSpec{Kind: machineutils.MachineClassKind, Name: "machineclass-0"},
+							},
+						},
+						&machinev1.MachineStatus{
+							Conditions:    nodeConditions(false, false, false, false, false),
+							CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineUnknown, LastUpdateTime: metav1.NewTime(time.Now().Add(-15 * time.Minute))},
+							LastOperation: machinev1.LastOperation{Description: lastOperationDescription, Type: machinev1.MachineOperationHealthCheck},
+						},
+						nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+					controlMachineObjects := []runtime.Object{
+						&machinev1.MachineClass{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:       "machineclass-0",
+								Namespace:  testNamespace,
+								Finalizers: []string{MCMFinalizerName},
+							},
+						},
+						machine,
+					}
+					targetCoreObjects := []runtime.Object{
+						newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{Phase: corev1.NodeRunning, Conditions: nodeConditions(false, false, false, false, false)}),
+					}
+
+					fakeDriver := driver.NewFakeDriver(true, "fakeID-0", "node-0", "", nil, nil)
+					fakeDriver.(*driver.FakeDriver).RebootSupported = rebootSupported
+					c, trackers = createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects, fakeDriver, false)
+					defer trackers.Stop()
+					c.safetyOptions.NodeNotReadyPolicy = machineutils.NodeNotReadyPolicyRebootBeforeReplace
+					c.permitGiver = permits.NewPermitGiver(5*time.Second, 1*time.Second)
+					defer c.permitGiver.Close()
+					waitForCacheSync(stop, c)
+
+					_, _ = c.reconcileMachineHealth(context.TODO(), machine)
+
+					updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(fakeDriver.(*driver.FakeDriver).Rebooted).To(Equal(expectRebooted))
+					Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(expectedPhase))
+					if expectRebooted {
+						Expect(updatedMachine.Status.LastOperation.Description).To(ContainSubstring(machineutils.MachineRebootTriggered))
+						Expect(updatedMachine.Status.CurrentStatus.LastUpdateTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+					}
+				},
+				Entry("should reboot the VM instead of marking the machine Failed", true, "", true, machinev1.MachineUnknown),
+				Entry("should mark the machine Failed if its VM was already rebooted", true, "Machine is unhealthy. "+machineutils.MachineRebootTriggered, false, machinev1.MachineFailed),
+				Entry("should mark the machine Failed if the driver doesn't support reboots", false, "", false, machinev1.MachineFailed),
+			)
+		})
+
+		Context("when node conditions flap rapidly", func() {
+			DescribeTable("##table",
+				func(updateInterval time.Duration, flaps int, expectedUpdates int) {
+					stop := make(chan struct{})
+					defer close(stop)
+
+					machine := newMachine(
+						&machinev1.MachineTemplateSpec{
+							ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0),
+						},
+						&machinev1.MachineStatus{
+							Conditions:    nodeConditions(true, false, false, false, false),
+							CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineRunning, LastUpdateTime: metav1.Now()},
+							LastOperation: machinev1.LastOperation{Type: machinev1.MachineOperationHealthCheck, State: machinev1.MachineStateSuccessful},
+						},
+						nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+					node := newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{Phase: corev1.NodeRunning, Conditions: nodeConditions(true, false, false, false, false)})
+
+					c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, []runtime.Object{node}, nil, false)
+					defer trackers.Stop()
+					c.safetyOptions.MachineConditionsUpdateInterval = metav1.Duration{Duration: updateInterval}
+					waitForCacheSync(stop, c)
+
+					updates := 0
+					for i := 0; i < flaps; i++ {
+						status := corev1.ConditionTrue
+						if i%2 == 1 {
+							status = corev1.ConditionFalse
+						}
+						node.Status.Conditions = append(nodeConditions(true, false, false, false, false), corev1.NodeCondition{Type: "FrequentKubeletRestart", Status: status})
+						_, err := c.targetCoreClient.CoreV1().Nodes().UpdateStatus(context.TODO(), node, metav1.UpdateOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						Eventually(func() []corev1.NodeCondition {
+							n, _ := c.nodeLister.Get(node.Name)
+							return n.Status.Conditions
+						}).Should(Equal(node.Status.Conditions))
+
+						current, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						_, _ = c.reconcileMachineHealth(context.TODO(), current)
+
+						updated, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(updated.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineRunning))
+						if !apiequality.Semantic.DeepEqual(current.Status.Conditions, updated.Status.Conditions) {
+							updates++
+						}
+					}
+					Expect(updates).To(Equal(expectedUpdates))
+				},
+				Entry("should update the machine status on every change if debouncing is disabled", time.Duration(0), 5, 5),
+				Entry("should update the machine status at most once per update interval", time.Hour, 5, 1),
+			)
+		})
+	})
+
+	Describe("#getMachineStatusCached", func() {
+		var (
+			c          *controller
+			trackers   *fakeclient.FakeObjectTrackers
+			fakeDriver *driver.FakeDriver
+			machine    *machinev1.Machine
+			stop       chan struct{}
+		)
+
+		maintenanceCondition := corev1.NodeCondition{Type: "ScheduledMaintenance", Status: corev1.ConditionTrue}
+		rebootCondition := corev1.NodeCondition{Type: "ScheduledReboot", Status: corev1.ConditionTrue}
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			machine = newMachine(
+				&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+				nil, nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+			machine.UID = "machine-uid-0"
+			machine.Generation = 1
+
+			fakeDriver = driver.NewFakeDriver(true, "fakeID-0", "node-0", "", nil, nil).(*driver.FakeDriver)
+			fakeDriver.Conditions = []corev1.NodeCondition{maintenanceCondition}
+			c, trackers = createController(stop, testNamespace, nil, nil, nil, fakeDriver, false)
+			c.safetyOptions.MachineStatusCacheTTL = metav1.Duration{Duration: time.Minute}
+			waitForCacheSync(stop, c)
+		})
+
+		AfterEach(func() {
+			trackers.Stop()
+			close(stop)
+		})
+
+		getConditions := func(machine *machinev1.Machine) []corev1.NodeCondition {
+			response, err := c.getMachineStatusCached(context.TODO(), &driver.GetMachineStatusRequest{Machine: machine}, c.safetyOptions.MachineStatusCacheTTL.Duration)
+			Expect(err).ToNot(HaveOccurred())
+			return response.Conditions
+		}
+
+		It("should serve the status from the cache within the TTL and invalidate it on spec changes", func() {
+			Expect(getConditions(machine)).To(ConsistOf(maintenanceCondition))
+
+			fakeDriver.Conditions = []corev1.NodeCondition{rebootCondition}
+			Expect(getConditions(machine)).To(ConsistOf(maintenanceCondition))
+
+			updatedMachine := machine.DeepCopy()
+			updatedMachine.Generation = 2
+			c.updateMachine(machine, updatedMachine)
+			Expect(getConditions(updatedMachine)).To(ConsistOf(rebootCondition))
+		})
+
+		It("should invalidate the cached status on the deletion of the machine", func() {
+			Expect(getConditions(machine)).To(ConsistOf(maintenanceCondition))
+
+			fakeDriver.Conditions = []corev1.NodeCondition{rebootCondition}
+			c.deleteMachine(machine)
+			Expect(getConditions(machine)).To(ConsistOf(rebootCondition))
+		})
+
+		It("should not cache the status if the TTL is 0", func() {
+			c.safetyOptions.MachineStatusCacheTTL = metav1.Duration{}
+			Expect(getConditions(machine)).To(ConsistOf(maintenanceCondition))
+
+			fakeDriver.Conditions = []corev1.NodeCondition{rebootCondition}
+			Expect(getConditions(machine)).To(ConsistOf(rebootCondition))
+		})
+
+		It("should reuse the provider conditions within the refresh interval even if the TTL is 0", func() {
+			machine.Spec.Class = machinev1.ClassSpec{Kind: machineutils.MachineClassKind, Name: "machineclass-0"}
+			controlMachineObjects := []runtime.Object{
+				&machinev1.MachineClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "machineclass-0",
+						Namespace:  testNamespace,
+						Finalizers: []string{MCMFinalizerName},
+					},
+				},
+			}
+			classStop := make(chan struct{})
+			defer close(classStop)
+			c, classTrackers := createController(classStop, testNamespace, controlMachineObjects, nil, nil, fakeDriver, false)
+			defer classTrackers.Stop()
+			c.providerConditions = string(maintenanceCondition.Type)
+			waitForCacheSync(classStop, c)
+
+			conditions, err := c.getProviderConditions(context.TODO(), machine)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conditions).To(ConsistOf(maintenanceCondition))
+
+			fakeDriver.Conditions = []corev1.NodeCondition{rebootCondition}
+			conditions, err = c.getProviderConditions(context.TODO(), machine)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conditions).To(ConsistOf(maintenanceCondition))
+		})
+	})
+
+	Describe("#getMachineStatusForDeletion", func() {
+		var (
+			c          *controller
+			trackers   *fakeclient.FakeObjectTrackers
+			fakeDriver *driver.FakeDriver
+			machine    *machinev1.Machine
+			stop       chan struct{}
+		)
+
+		BeforeEach(func() {
+			stop = make(chan struct{})
+			machine = newMachine(
+				&machinev1.MachineTemplateSpec{
+					ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0),
+					Spec:       machinev1.MachineSpec{ProviderID: "fakeID-0"},
+				},
+				&machinev1.MachineStatus{
+					CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineTerminating},
+					LastOperation: machinev1.LastOperation{Description: machineutils.InitiateVMDeletion, Type: machinev1.MachineOperationDelete},
+				},
+				nil, nil, nil, true, metav1.Now())
+
+			fakeDriver = driver.NewFakeDriver(true, "fakeID-0", "node-0", "", nil, nil).(*driver.FakeDriver)
+			c, trackers = createController(stop, testNamespace, []runtime.Object{machine}, nil, nil, fakeDriver, false)
+			c.safetyOptions.DeletionMachineStatusCacheTTL = metav1.Duration{Duration: time.Minute}
+			waitForCacheSync(stop, c)
+		})
+
+		AfterEach(func() {
+			trackers.Stop()
+			close(stop)
+		})
+
+		getNodeName := func() (string, error) {
+			response, err := c.getMachineStatusForDeletion(context.TODO(), &driver.GetMachineStatusRequest{Machine: machine})
+			if err != nil {
+				return "", err
+			}
+			return response.NodeName, nil
+		}
+
+		It("should serve the status from the cache within the TTL", func() {
+			Expect(getNodeName()).To(Equal("node-0"))
+
+			fakeDriver.NodeName = "node-1"
+			Expect(getNodeName()).To(Equal("node-0"))
+		})
+
+		It("should call the driver again once the TTL expired", func() {
+			Expect(getNodeName()).To(Equal("node-0"))
+
+			fakeDriver.NodeName = "node-1"
+			cached, ok := c.deletionMachineStatusCache.Load(machine.Spec.ProviderID)
+			Expect(ok).To(BeTrue())
+			cached.(*cachedDeletionMachineStatus).fetchedAt = time.Now().Add(-2 * time.Minute)
+			Expect(getNodeName()).To(Equal("node-1"))
+		})
+
+		It("should cache the NotFound error of a VM which doesn't exist, but no other errors", func() {
+			fakeDriver.VMExists = false
+			_, err := getNodeName()
+			Expect(err).To(HaveOccurred())
+
+			fakeDriver.VMExists = true
+			_, err = getNodeName()
+			Expect(err).To(HaveOccurred())
+
+			c.deletionMachineStatusCache.Delete(machine.Spec.ProviderID)
+			fakeDriver.Err = status.Error(codes.Unavailable, "provider is unavailable")
+			_, err = getNodeName()
+			Expect(err).To(HaveOccurred())
+
+			fakeDriver.Err = nil
+			Expect(getNodeName()).To(Equal("node-0"))
+		})
+
+		It("should invalidate the cached status once the VM is deleted", func() {
+			Expect(getNodeName()).To(Equal("node-0"))
+
+			_, _ = c.deleteVM(context.TODO(), &driver.DeleteMachineRequest{Machine: machine, MachineClass: &machinev1.MachineClass{}, Secret: &corev1.Secret{}})
+			_, ok := c.deletionMachineStatusCache.Load(machine.Spec.ProviderID)
+			Expect(ok).To(BeFalse())
+
+			_, err := getNodeName()
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not cache the status if the TTL is 0", func() {
+			c.safetyOptions.DeletionMachineStatusCacheTTL = metav1.Duration{}
+			Expect(getNodeName()).To(Equal("node-0"))
+
+			fakeDriver.NodeName = "node-1"
+			Expect(getNodeName()).To(Equal("node-1"))
+		})
+	})
+
+	Describe("#reconcileMachineQuarantine", func() {
+		var (
+			c        *controller
+			trackers *fakeclient.FakeObjectTrackers
+			stop     chan struct{}
+			machine  *machinev1.Machine
+		)
+
+		setup := func(annotations map[string]string, phase machinev1.MachinePhase, nodeReady, nodeUnschedulable bool, nodeAnnotations map[string]string) {
+			stop = make(chan struct{})
+			machine = newMachine(
+				&machinev1.MachineTemplateSpec{
+					ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0),
+					Spec: machinev1.MachineSpec{
+						Class: machinev1.ClassSpec{Kind: machineutils.MachineClassKind, Name: "machineclass-0"},
+					},
+				},
+				&machinev1.MachineStatus{
+					Conditions:    nodeConditions(nodeReady, false, false, false, false),
+					CurrentStatus: machinev1.CurrentStatus{Phase: phase, LastUpdateTime: metav1.NewTime(time.Now().Add(-15 * time.Minute))},
+					LastOperation: machinev1.LastOperation{Type: machinev1.MachineOperationHealthCheck},
+				},
+				nil, annotations, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+			machine.DeletionTimestamp = nil
+			controlMachineObjects := []runtime.Object{
+				&machinev1.MachineClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       "machineclass-0",
+						Namespace:  testNamespace,
+						Finalizers: []string{MCMFinalizerName},
+					},
+				},
+				machine,
+			}
+			targetCoreObjects := []runtime.Object{
+				newNode(1, nil, nodeAnnotations, &corev1.NodeSpec{Unschedulable: nodeUnschedulable}, &corev1.NodeStatus{Phase: corev1.NodeRunning, Conditions: nodeConditions(nodeReady, false, false, false, false)}),
+			}
+
+			c, trackers = createController(stop, testNamespace, controlMachineObjects, nil, targetCoreObjects, nil, false)
+			c.permitGiver = permits.NewPermitGiver(5*time.Second, 1*time.Second)
+			waitForCacheSync(stop, c)
+		}
+
+		AfterEach(func() {
+			c.permitGiver.Close()
+			trackers.Stop()
+			close(stop)
+		})
+
+		// reconcile runs reconcileClusterMachine on the latest version of the machine, like the machine controller would
+		reconcile := func() (*machinev1.Machine, *corev1.Node) {
+			current, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, _ = c.reconcileClusterMachine(context.TODO(), current)
+
+			updatedMachine, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			updatedNode, err := c.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "node-0", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(func() bool {
+				n, _ := c.nodeLister.Get("node-0")
+				return n.Spec.Unschedulable
+			}).Should(Equal(updatedNode.Spec.Unschedulable))
+			return updatedMachine, updatedNode
+		}
+
+		It("should cordon the node and suppress the health timeout of a quarantined machine", func() {
+			setup(map[string]string{machineutils.MachineQuarantine: "true"}, machinev1.MachineUnknown, false, false, nil)
+
+			for i := 0; i < 2; i++ {
+				updatedMachine, updatedNode := reconcile()
+				Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineQuarantined))
+				Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+				Expect(updatedNode.Annotations).To(HaveKey(machineutils.NodeCordonedForQuarantine))
+			}
+		})
+
+		It("should enqueue the machine once its quarantine annotation is added or removed", func() {
+			setup(nil, machinev1.MachineRunning, true, false, nil)
+
+			quarantinedMachine := machine.DeepCopy()
+			quarantinedMachine.Annotations = map[string]string{machineutils.MachineQuarantine: "true"}
+			c.updateMachine(machine, quarantinedMachine)
+			Expect(c.machineQueue.Len()).To(Equal(1))
+			key, _ := c.machineQueue.Get()
+			c.machineQueue.Done(key)
+
+			c.updateMachine(quarantinedMachine, machine)
+			Expect(c.machineQueue.Len()).To(Equal(1))
+		})
+
+		It("should uncordon the node and restore the health checks of a machine released from quarantine", func() {
+			setup(nil, machinev1.MachineQuarantined, true, true, map[string]string{machineutils.NodeCordonedForQuarantine: "true"})
+
+			updatedMachine, updatedNode := reconcile()
+			Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineUnknown))
+			Expect(updatedMachine.Status.CurrentStatus.LastUpdateTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(updatedNode.Spec.Unschedulable).To(BeFalse())
+			Expect(updatedNode.Annotations).ToNot(HaveKey(machineutils.NodeCordonedForQuarantine))
+
+			updatedMachine, _ = reconcile()
+			Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineRunning))
+		})
+
+		It("should keep the node cordoned before the quarantine cordoned once the machine is released", func() {
+			setup(map[string]string{machineutils.MachineQuarantine: "true"}, machinev1.MachineRunning, true, true, nil)
+
+			updatedMachine, updatedNode := reconcile()
+			Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineQuarantined))
+			Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+			Expect(updatedNode.Annotations).ToNot(HaveKey(machineutils.NodeCordonedForQuarantine))
+
+			delete(updatedMachine.Annotations, machineutils.MachineQuarantine)
+			_, err := c.controlMachineClient.Machines(testNamespace).Update(context.TODO(), updatedMachine, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedMachine, updatedNode = reconcile()
+			Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineUnknown))
+			Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+		})
+
+		It("should not uncordon the node cordoned by another actor before the machine was quarantined", func() {
+			setup(nil, machinev1.MachineQuarantined, true, true, nil)
+			current, err := c.controlMachineClient.Machines(testNamespace).Get(context.TODO(), machine.Name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			current.Status.Conditions = append(current.Status.Conditions, corev1.NodeCondition{
+				Type:   machineutils.NodeExternallyCordoned,
+				Status: corev1.ConditionTrue,
+			})
+			_, err = c.controlMachineClient.Machines(testNamespace).UpdateStatus(context.TODO(), current, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			updatedMachine, updatedNode := reconcile()
+			Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineUnknown))
+			Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+
+			updatedMachine, updatedNode = reconcile()
+			Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineRunning))
+			Expect(getNodeExternallyCordonedCondition(updatedMachine)).ToNot(BeNil())
+			Expect(updatedNode.Spec.Unschedulable).To(BeTrue())
+		})
+
+		It("should fail an unhealthy machine released from quarantine only after another health timeout", func() {
+			setup(nil, machinev1.MachineQuarantined, false, true, map[string]string{machineutils.NodeCordonedForQuarantine: "true"})
+
+			updatedMachine, _ := reconcile()
+			Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineUnknown))
+
+			updatedMachine, _ = reconcile()
+			Expect(updatedMachine.Status.CurrentStatus.Phase).To(Equal(machinev1.MachineUnknown))
+		})
+	})
+
+	Describe("#checkNodePodDensity", func() {
+		newPod := func(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+				Spec:       corev1.PodSpec{NodeName: nodeName},
+				Status:     corev1.PodStatus{Phase: phase},
+			}
+		}
+
+		DescribeTable("##table",
+			func(threshold int, pods []*corev1.Pod, expectEvent bool) {
+				stop := make(chan struct{})
+				defer close(stop)
+
+				machine := newMachine(
+					&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+					&machinev1.MachineStatus{CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineRunning}},
+					nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+				machine.DeletionTimestamp = nil
+				targetCoreObjects := []runtime.Object{
+					newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{}),
+				}
+				for _, pod := range pods {
+					targetCoreObjects = append(targetCoreObjects, pod)
+				}
+
+				c, trackers := createController(stop, testNamespace, []runtime.Object{machine}, nil, targetCoreObjects, nil, false)
+				defer trackers.Stop()
+				c.safetyOptions.NodePodDensityThreshold = threshold
+				waitForCacheSync(stop, c)
+
+				c.checkNodePodDensity(machine)
+
+				events := c.recorder.(*record.FakeRecorder).Events
+				if expectEvent {
+					Expect(events).To(Receive(And(
+						ContainSubstring(corev1.EventTypeWarning),
+						ContainSubstring(machineutils.NodePodDensityExceededReason),
+						ContainSubstring(fmt.Sprintf("hosts %d pods, exceeding the pod density threshold of %d", threshold+1, threshold)),
+					)))
+				} else {
+					Expect(events).ToNot(Receive())
+				}
+			},
+			Entry("should emit an event if the node hosts more pods than the threshold", 2, []*corev1.Pod{
+				newPod("pod-0", "node-0", corev1.PodRunning),
+				newPod("pod-1", "node-0", corev1.PodRunning),
+				newPod("pod-2", "node-0", corev1.PodPending),
+			}, true),
+			Entry("should not emit an event if the node hosts as many pods as the threshold", 2, []*corev1.Pod{
+				newPod("pod-0", "node-0", corev1.PodRunning),
+				newPod("pod-1", "node-0", corev1.PodRunning),
+			}, false),
+			Entry("should not count terminated pods and pods of other nodes", 2, []*corev1.Pod{
+				newPod("pod-0", "node-0", corev1.PodRunning),
+				newPod("pod-1", "node-0", corev1.PodSucceeded),
+				newPod("pod-2", "node-0", corev1.PodFailed),
+				newPod("pod-3", "node-1", corev1.PodRunning),
+			}, false),
+			Entry("should not emit an event if the check is disabled", 0, []*corev1.Pod{
+				newPod("pod-0", "node-0", corev1.PodRunning),
+			}, false),
+		)
+
+		It("should only emit an event once the threshold is crossed", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			machine := newMachine(
+				&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+				&machinev1.MachineStatus{CurrentStatus: machinev1.CurrentStatus{Phase: machinev1.MachineRunning}},
+				nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now())
+			machine.DeletionTimestamp = nil
+			targetCoreObjects := []runtime.Object{
+				newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{}),
+				newPod("pod-0", "node-0", corev1.PodRunning),
+				newPod("pod-1", "node-0", corev1.PodRunning),
+			}
+
+			c, trackers := createController(stop, testNamespace, []runtime.Object{machine}, nil, targetCoreObjects, nil, false)
+			defer trackers.Stop()
+			c.safetyOptions.NodePodDensityThreshold = 1
+			waitForCacheSync(stop, c)
+			events := c.recorder.(*record.FakeRecorder).Events
+
+			c.checkNodePodDensity(machine)
+			Expect(events).To(Receive(ContainSubstring(machineutils.NodePodDensityExceededReason)))
+
+			c.checkNodePodDensity(machine)
+			Expect(events).ToNot(Receive())
+
+			c.safetyOptions.NodePodDensityThreshold = 2
+			c.checkNodePodDensity(machine)
+			Expect(events).ToNot(Receive())
+
+			c.safetyOptions.NodePodDensityThreshold = 1
+			c.checkNodePodDensity(machine)
+			Expect(events).To(Receive(ContainSubstring(machineutils.NodePodDensityExceededReason)))
+		})
 	})
 
 	Describe("#updateNodeConditionBasedOnLabel", func() {
@@ -3457,12 +4823,68 @@ var _ = Describe("machine_util", func() {
 				},
 			}),
 		)
+
+		It("should defer the drains of selected machines beyond MaxConcurrentInPlaceUpdateDrains", func() {
+			const maxDrains = 2
+
+			stop := make(chan struct{})
+			defer close(stop)
+
+			machine := newMachine(
+				&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+				nil,
+				nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0", machinev1.LabelKeyNodeSelectedForUpdate: "true"}, true, metav1.Now())
+			node := newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:               machinev1.NodeInPlaceUpdate,
+						Status:             corev1.ConditionTrue,
+						LastTransitionTime: metav1.Now(),
+						Reason:             machinev1.SelectedForUpdate,
+						Message:            "Node is selected for in-place update",
+					},
+				},
+			})
+
+			c, trackers := createController(stop, testNamespace, []runtime.Object{machine}, nil, []runtime.Object{node}, nil, false)
+			defer trackers.Stop()
+			c.permitGiver = permits.NewPermitGiver(5*time.Second, 1*time.Second)
+			defer c.permitGiver.Close()
+			c.safetyOptions.MaxConcurrentInPlaceUpdateDrains = maxDrains
+			waitForCacheSync(stop, c)
+
+			// the nodes of other selected machines are being drained
+			var releasePermits []func()
+			for i := 0; i < maxDrains; i++ {
+				releasePermit, ok := c.acquireInPlaceUpdateDrainPermit(machine)
+				Expect(ok).To(BeTrue())
+				releasePermits = append(releasePermits, releasePermit)
+			}
+
+			retryPeriod, err := c.inPlaceUpdate(context.TODO(), machine)
+			Expect(retryPeriod).To(Equal(machineutils.ShortRetry))
+			Expect(err).To(MatchError(ContainSubstring("deferred")))
+			updatedNode, err := c.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "node-0", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updatedNode.Spec.Unschedulable).To(BeFalse())
+			Expect(nodeops.GetCondition(updatedNode, machinev1.NodeInPlaceUpdate).Reason).To(Equal(machinev1.SelectedForUpdate))
+
+			releasePermits[0]()
+
+			retryPeriod, err = c.inPlaceUpdate(context.TODO(), machine)
+			Expect(retryPeriod).To(Equal(machineutils.MediumRetry))
+			Expect(err).To(MatchError(fmt.Sprintf("node %s is ready for in-place update", "node-0")))
+			updatedNode, err = c.targetCoreClient.CoreV1().Nodes().Get(context.TODO(), "node-0", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nodeops.GetCondition(updatedNode, machinev1.NodeInPlaceUpdate).Reason).To(Equal(machinev1.ReadyForUpdate))
+		})
 	})
 
 	Describe("#drainNodeForInPlace", func() {
 		type setup struct {
-			machine *machinev1.Machine
-			node    *corev1.Node
+			machine       *machinev1.Machine
+			node          *corev1.Node
+			preDrainHooks []drain.PreDrainHook
 		}
 
 		type expect struct {
@@ -3494,6 +4916,8 @@ var _ = Describe("machine_util", func() {
 
 				c.permitGiver = permits.NewPermitGiver(5*time.Second, 1*time.Second)
 				defer c.permitGiver.Close()
+				c.preDrainHooks = data.setup.preDrainHooks
+				c.safetyOptions.PreDrainHookTimeout = metav1.Duration{Duration: 100 * time.Millisecond}
 
 				waitForCacheSync(stop, c)
 
@@ -3534,6 +4958,73 @@ var _ = Describe("machine_util", func() {
 					node:        nil,
 				},
 			}),
+			Entry("when a pre-drain hook fails", &data{
+				setup: setup{
+					machine: newMachine(
+						&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+						nil,
+						nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now()),
+					node:          newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{}),
+					preDrainHooks: []drain.PreDrainHook{&fakePreDrainHook{name: "quiesce", err: errors.New("application is still busy"), calls: &[]string{}}},
+				},
+				expect: expect{
+					retryPeriod: machineutils.ShortRetry,
+					err:         fmt.Errorf("pre-drain hook 1 of 1 failed: application is still busy"),
+				},
+			}),
+			Entry("when a pre-drain hook exceeds its timeout", &data{
+				setup: setup{
+					machine: newMachine(
+						&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+						nil,
+						nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now()),
+					node:          newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{}),
+					preDrainHooks: []drain.PreDrainHook{&fakePreDrainHook{name: "quiesce", block: true, calls: &[]string{}}},
+				},
+				expect: expect{
+					retryPeriod: machineutils.ShortRetry,
+					err:         fmt.Errorf("pre-drain hook 1 of 1 failed: context deadline exceeded"),
+				},
+			}),
+			Entry("when a pre-drain hook fails after the drain timeout", &data{
+				setup: setup{
+					machine: newMachine(
+						&machinev1.MachineTemplateSpec{ObjectMeta: *newObjectMeta(&metav1.ObjectMeta{GenerateName: machineSet1Deploy1}, 0)},
+						nil,
+						nil, nil, map[string]string{machinev1.NodeLabelKey: "node-0"}, true, metav1.Now()),
+					node: newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{
+						Conditions: []corev1.NodeCondition{
+							{
+								Type:               machinev1.NodeInPlaceUpdate,
+								Status:             corev1.ConditionTrue,
+								LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+								Reason:             machinev1.SelectedForUpdate,
+							},
+						},
+					}),
+					preDrainHooks: []drain.PreDrainHook{&fakePreDrainHook{name: "quiesce", err: errors.New("application is still busy"), calls: &[]string{}}},
+				},
+				expect: expect{
+					retryPeriod: machineutils.ShortRetry,
+					err:         nil,
+					node: &corev1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "node-0",
+						},
+						Status: corev1.NodeStatus{
+							Conditions: []corev1.NodeCondition{
+								{
+									Type:               machinev1.NodeInPlaceUpdate,
+									Status:             corev1.ConditionTrue,
+									LastTransitionTime: metav1.Now(),
+									Reason:             machinev1.DrainSuccessful,
+									Message:            "Node draining successful",
+								},
+							},
+						},
+					},
+				},
+			}),
 			Entry("when node is not ready for over 5 minutes", &data{
 				setup: setup{
 					machine: newMachine(
@@ -3668,4 +5159,153 @@ var _ = Describe("machine_util", func() {
 			}),
 		)
 	})
+
+	Describe("#getDrainSkipPodSelector", func() {
+		DescribeTable("##table",
+			func(annotations map[string]string, expectedSelector string) {
+				machineClass := &machinev1.MachineClass{ObjectMeta: metav1.ObjectMeta{Name: "machineClass", Annotations: annotations}}
+
+				selector := getDrainSkipPodSelector(machineClass)
+				if expectedSelector == "" {
+					Expect(selector).To(BeNil())
+				} else {
+					Expect(selector.String()).To(Equal(expectedSelector))
+				}
+			},
+			Entry("should skip no pods without the annotation", nil, ""),
+			Entry("should parse the selector of the annotation", map[string]string{machineutils.MachineClassDrainSkipPodSelector: "app in (agent,exporter),!managed"}, "app in (agent,exporter),!managed"),
+			Entry("should ignore an invalid selector", map[string]string{machineutils.MachineClassDrainSkipPodSelector: "app in agent"}, ""),
+		)
+	})
+
+	Describe("#operationTimeoutDriver", func() {
+		safetyOptions := options.SafetyOptions{
+			DriverCreateMachineTimeout:    metav1.Duration{Duration: 10 * time.Minute},
+			DriverDeleteMachineTimeout:    metav1.Duration{Duration: 5 * time.Minute},
+			DriverGetMachineStatusTimeout: metav1.Duration{Duration: 30 * time.Second},
+		}
+
+		DescribeTable("##table",
+			func(operation string, annotations map[string]string, expectedTimeout time.Duration) {
+				recorder := &deadlineRecordingDriver{Driver: driver.NewFakeDriver(true, "fakeID", "fakeNode", "", nil, nil)}
+				d := newOperationTimeoutDriver(recorder, safetyOptions)
+				machine := newMachine(&machinev1.MachineTemplateSpec{}, &machinev1.MachineStatus{}, nil, nil, nil, false, metav1.Now())
+				machineClass := &machinev1.MachineClass{ObjectMeta: metav1.ObjectMeta{Name: "machineClass", Annotations: annotations}}
+
+				var err error
+				switch operation {
+				case "create":
+					_, err = d.CreateMachine(context.TODO(), &driver.CreateMachineRequest{Machine: machine, MachineClass: machineClass})
+				case "delete":
+					_, err = d.DeleteMachine(context.TODO(), &driver.DeleteMachineRequest{Machine: machine, MachineClass: machineClass})
+				case "status":
+					_, err = d.GetMachineStatus(context.TODO(), &driver.GetMachineStatusRequest{Machine: machine, MachineClass: machineClass})
+				}
+				Expect(err).ToNot(HaveOccurred())
+
+				if expectedTimeout == 0 {
+					Expect(recorder.hasDeadline).To(BeFalse())
+				} else {
+					Expect(recorder.hasDeadline).To(BeTrue())
+					Expect(time.Until(recorder.deadline)).To(BeNumerically("~", expectedTimeout, 5*time.Second))
+				}
+			},
+			Entry("should bound CreateMachine by the create timeout", "create", nil, 10*time.Minute),
+			Entry("should bound DeleteMachine by the delete timeout", "delete", nil, 5*time.Minute),
+			Entry("should bound GetMachineStatus by the status timeout", "status", nil, 30*time.Second),
+			Entry("should bound CreateMachine by the timeout of the MachineClass annotation", "create", map[string]string{machineutils.MachineClassCreateMachineTimeout: "20m"}, 20*time.Minute),
+			Entry("should bound DeleteMachine by the timeout of the MachineClass annotation", "delete", map[string]string{machineutils.MachineClassDeleteMachineTimeout: "15m"}, 15*time.Minute),
+			Entry("should leave GetMachineStatus unbounded with a timeout of 0 in the MachineClass annotation", "status", map[string]string{machineutils.MachineClassGetMachineStatusTimeout: "0s"}, time.Duration(0)),
+			Entry("should ignore the annotation of another operation", "status", map[string]string{machineutils.MachineClassCreateMachineTimeout: "20m"}, 30*time.Second),
+			Entry("should ignore an invalid annotation", "delete", map[string]string{machineutils.MachineClassDeleteMachineTimeout: "soon"}, 5*time.Minute),
+		)
+
+		It("should report a call exceeding its timeout as DeadlineExceeded", func() {
+			d := newOperationTimeoutDriver(&blockingDriver{Driver: driver.NewFakeDriver(true, "fakeID", "fakeNode", "", nil, nil)}, safetyOptions)
+			machineClass := &machinev1.MachineClass{ObjectMeta: metav1.ObjectMeta{Name: "machineClass", Annotations: map[string]string{machineutils.MachineClassCreateMachineTimeout: "10ms"}}}
+
+			_, err := d.CreateMachine(context.TODO(), &driver.CreateMachineRequest{Machine: &machinev1.Machine{}, MachineClass: machineClass})
+			machineErr, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(machineErr.Code()).To(Equal(codes.DeadlineExceeded))
+		})
+
+		It("should leave the errors of calls completing in time unchanged", func() {
+			fakeDriver := driver.NewFakeDriver(true, "fakeID", "fakeNode", "", status.Error(codes.Internal, "delete failed"), nil)
+			d := newOperationTimeoutDriver(fakeDriver, safetyOptions)
+
+			_, err := d.DeleteMachine(context.TODO(), &driver.DeleteMachineRequest{Machine: &machinev1.Machine{}})
+			machineErr, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(machineErr.Code()).To(Equal(codes.Internal))
+		})
+
+		It("should leave the calls unbounded without configured timeouts", func() {
+			recorder := &deadlineRecordingDriver{Driver: driver.NewFakeDriver(true, "fakeID", "fakeNode", "", nil, nil)}
+			d := newOperationTimeoutDriver(recorder, options.SafetyOptions{})
+
+			_, err := d.CreateMachine(context.TODO(), &driver.CreateMachineRequest{Machine: &machinev1.Machine{}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(recorder.hasDeadline).To(BeFalse())
+		})
+
+		It("should forward the snapshots and reboots to drivers implementing them", func() {
+			fakeDriver := &driver.FakeDriver{SnapshotID: "snapshot-0", RebootSupported: true}
+			d := newOperationTimeoutDriver(fakeDriver, safetyOptions)
+
+			snapshot, err := driver.SnapshotMachine(context.TODO(), d, &driver.SnapshotMachineRequest{Machine: &machinev1.Machine{}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.SnapshotID).To(Equal("snapshot-0"))
+			_, err = driver.RebootMachine(context.TODO(), d, &driver.RebootMachineRequest{Machine: &machinev1.Machine{}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeDriver.Rebooted).To(BeTrue())
+		})
+
+		It("should report the snapshots and reboots as unimplemented for drivers not implementing them", func() {
+			// deadlineRecordingDriver only implements the Driver interface
+			d := newOperationTimeoutDriver(&deadlineRecordingDriver{Driver: driver.NewFakeDriver(true, "fakeID", "fakeNode", "", nil, nil)}, safetyOptions)
+
+			_, err := driver.SnapshotMachine(context.TODO(), d, &driver.SnapshotMachineRequest{Machine: &machinev1.Machine{}})
+			machineErr, ok := status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(machineErr.Code()).To(Equal(codes.Unimplemented))
+			_, err = driver.RebootMachine(context.TODO(), d, &driver.RebootMachineRequest{Machine: &machinev1.Machine{}})
+			machineErr, ok = status.FromError(err)
+			Expect(ok).To(BeTrue())
+			Expect(machineErr.Code()).To(Equal(codes.Unimplemented))
+		})
+	})
 })
+
+// deadlineRecordingDriver records the deadline of the context of its last CreateMachine, DeleteMachine or
+// GetMachineStatus call
+type deadlineRecordingDriver struct {
+	driver.Driver
+	deadline    time.Time
+	hasDeadline bool
+}
+
+func (d *deadlineRecordingDriver) CreateMachine(ctx context.Context, req *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
+	d.deadline, d.hasDeadline = ctx.Deadline()
+	return d.Driver.CreateMachine(ctx, req)
+}
+
+func (d *deadlineRecordingDriver) DeleteMachine(ctx context.Context, req *driver.DeleteMachineRequest) (*driver.DeleteMachineResponse, error) {
+	d.deadline, d.hasDeadline = ctx.Deadline()
+	return d.Driver.DeleteMachine(ctx, req)
+}
+
+func (d *deadlineRecordingDriver) GetMachineStatus(ctx context.Context, req *driver.GetMachineStatusRequest) (*driver.GetMachineStatusResponse, error) {
+	d.deadline, d.hasDeadline = ctx.Deadline()
+	return d.Driver.GetMachineStatus(ctx, req)
+}
+
+// blockingDriver blocks its CreateMachine calls until their context is done and returns the raw context error
+type blockingDriver struct {
+	driver.Driver
+}
+
+func (d *blockingDriver) CreateMachine(ctx context.Context, _ *driver.CreateMachineRequest) (*driver.CreateMachineResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
@@ -75,6 +75,10 @@ func (c *controller) reconcileClusterMachineClassKey(key string) error {
 	class, err := c.machineClassLister.MachineClasses(c.namespace).Get(name)
 	if errors.IsNotFound(err) {
 		klog.Infof("%s %q: Not doing work because it has been deleted", machineutils.MachineClassKind, key)
+		// Keep the last validated MachineClass as long as machines referring it are still to be deleted
+		if machines, err := c.findMachinesForClass(machineutils.MachineClassKind, name); err == nil && len(machines) == 0 {
+			c.machineClassCache.Delete(name)
+		}
 		return nil
 	}
 	if err != nil {
@@ -6,16 +6,34 @@
 package controller
 
 import (
+	"errors"
 	"strconv"
 
 	v1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
 	"github.com/gardener/machine-controller-manager/pkg/util/provider/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 )
 
+// Flows of the machine controller used as label of the reconcile errors metric
+const (
+	reconcileFlowCreate  = "create"
+	reconcileFlowDelete  = "delete"
+	reconcileFlowHealth  = "health"
+	reconcileFlowInPlace = "inplace"
+)
+
+// Categories of errors used as label of the reconcile errors metric
+const (
+	reconcileErrorCategoryProvider = "provider"
+	reconcileErrorCategoryAPI      = "api"
+	reconcileErrorCategoryInternal = "internal"
+)
+
 // Describe is method required to implement the prometheus.Collect interface.
 func (c *controller) Describe(ch chan<- *prometheus.Desc) {
 	ch <- metrics.MachineCountDesc
@@ -79,9 +97,9 @@ func updateMachineCSPhaseMetric(machine *v1alpha1.Machine, mMeta metav1.ObjectMe
 		phase = -3
 	case v1alpha1.MachineCrashLoopBackOff:
 		phase = -2
-	case v1alpha1.MachineUnknown:
+	case v1alpha1.MachineUnknown, v1alpha1.MachineQuarantined:
 		phase = -1
-	case v1alpha1.MachinePending:
+	case v1alpha1.MachinePending, v1alpha1.MachineVerifying:
 		phase = 0
 	case v1alpha1.MachineRunning:
 		phase = 1
@@ -123,3 +141,27 @@ func updateMachineInfoMetric(mMeta metav1.ObjectMeta, mSpec v1alpha1.MachineSpec
 		"spec_class_name":      mSpec.Class.Name,
 		"node_name":            mMeta.Labels[v1alpha1.NodeLabelKey]}).Set(float64(1))
 }
+
+// recordReconcileError increments the reconcile errors metric for the flow. Errors carrying a machine error code
+// returned by the driver are categorized as provider errors, errors returned by an API server as API errors
+// and any other errors as internal errors.
+func recordReconcileError(flow string, err error) {
+	if err == nil {
+		return
+	}
+	metrics.ReconcileErrors.With(prometheus.Labels{
+		"flow":     flow,
+		"category": getReconcileErrorCategory(err),
+	}).Inc()
+}
+
+func getReconcileErrorCategory(err error) string {
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) {
+		return reconcileErrorCategoryAPI
+	}
+	if _, ok := status.FromError(err); ok {
+		return reconcileErrorCategoryProvider
+	}
+	return reconcileErrorCategoryInternal
+}
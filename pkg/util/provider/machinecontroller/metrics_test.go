@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and Gardener contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/driver"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/codes"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machinecodes/status"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/metrics"
+)
+
+var _ = Describe("metrics", func() {
+	Describe("#recordReconcileError", func() {
+		objMeta := &metav1.ObjectMeta{
+			GenerateName: "machine",
+			Namespace:    testNamespace,
+		}
+
+		// reconcileErrors returns the current value of the reconcile errors metric for each category of the flow
+		reconcileErrors := func(flow string) map[string]float64 {
+			values := map[string]float64{}
+			for _, category := range []string{reconcileErrorCategoryProvider, reconcileErrorCategoryAPI, reconcileErrorCategoryInternal} {
+				values[category] = testutil.ToFloat64(metrics.ReconcileErrors.WithLabelValues(flow, category))
+			}
+			return values
+		}
+
+		It("should count a failed VM creation as provider error of the create flow", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			secret := &corev1.Secret{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+				Data:       map[string][]byte{"userData": []byte("test")},
+			}
+			machineClass := &v1alpha1.MachineClass{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+				SecretRef:  newSecretReference(objMeta, 0),
+			}
+			machine := newMachine(&v1alpha1.MachineTemplateSpec{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+				Spec: v1alpha1.MachineSpec{
+					Class: v1alpha1.ClassSpec{Kind: "MachineClass", Name: machineClass.Name},
+				},
+			}, nil, nil, nil, nil, true, metav1.Now())
+
+			fakeDriver := driver.NewFakeDriver(false, "", "", "", status.Error(codes.Internal, "Provider is returning error on create call"), nil)
+			c, trackers := createController(stop, testNamespace, []runtime.Object{machineClass, machine}, []runtime.Object{secret}, nil, fakeDriver, false)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			before := reconcileErrors(reconcileFlowCreate)
+			_, err := c.triggerCreationFlow(context.TODO(), &driver.CreateMachineRequest{
+				Machine:      machine,
+				MachineClass: machineClass,
+				Secret:       secret,
+			})
+			Expect(err).To(HaveOccurred())
+
+			after := reconcileErrors(reconcileFlowCreate)
+			Expect(after[reconcileErrorCategoryProvider]).To(Equal(before[reconcileErrorCategoryProvider] + 1))
+			Expect(after[reconcileErrorCategoryAPI]).To(Equal(before[reconcileErrorCategoryAPI]))
+			Expect(after[reconcileErrorCategoryInternal]).To(Equal(before[reconcileErrorCategoryInternal]))
+		})
+
+		It("should count a failed machine status update as API error of the health flow", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+
+			// The machine isn't known to the API server, so that the update of its changed conditions fails
+			machine := newMachine(&v1alpha1.MachineTemplateSpec{
+				ObjectMeta: *newObjectMeta(objMeta, 0),
+			}, &v1alpha1.MachineStatus{
+				Conditions:    nodeConditions(true, false, false, false, false),
+				CurrentStatus: v1alpha1.CurrentStatus{Phase: v1alpha1.MachineRunning, LastUpdateTime: metav1.Now()},
+			}, nil, nil, map[string]string{v1alpha1.NodeLabelKey: "node-0"}, true, metav1.Now())
+			node := newNode(1, nil, nil, &corev1.NodeSpec{}, &corev1.NodeStatus{Phase: corev1.NodeRunning, Conditions: nodeConditions(false, false, false, false, false)})
+
+			c, trackers := createController(stop, testNamespace, nil, nil, []runtime.Object{node}, nil, false)
+			defer trackers.Stop()
+			waitForCacheSync(stop, c)
+
+			before := reconcileErrors(reconcileFlowHealth)
+			_, err := c.reconcileMachineHealth(context.TODO(), machine)
+			Expect(err).To(HaveOccurred())
+
+			after := reconcileErrors(reconcileFlowHealth)
+			Expect(after[reconcileErrorCategoryAPI]).To(Equal(before[reconcileErrorCategoryAPI] + 1))
+			Expect(after[reconcileErrorCategoryProvider]).To(Equal(before[reconcileErrorCategoryProvider]))
+			Expect(after[reconcileErrorCategoryInternal]).To(Equal(before[reconcileErrorCategoryInternal]))
+		})
+	})
+})
@@ -5,6 +5,7 @@
 package controller
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
@@ -12,6 +13,7 @@ import (
 	"math/big"
 	"net/url"
 	"strings"
+	"text/template"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -22,6 +24,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/gardener/machine-controller-manager/pkg/util/provider/machineutils"
 )
 
 const (
@@ -107,6 +110,55 @@ func (c *controller) addMachineNameToUserData(machine *v1alpha1.Machine, secret
 	return nil
 }
 
+// userDataTemplateValues are the machine-scoped values which can be used in the user-data
+// of a MachineClass opting into templating with the MachineClassUserDataTemplating annotation
+type userDataTemplateValues struct {
+	MachineName      string
+	MachineNamespace string
+	Zone             string
+	Region           string
+	InstanceType     string
+}
+
+// renderUserDataTemplate renders the user-data of the secret as a Go template with the values of the machine,
+// if the MachineClass opts into it with the MachineClassUserDataTemplating annotation
+func (c *controller) renderUserDataTemplate(machine *v1alpha1.Machine, machineClass *v1alpha1.MachineClass, secret *corev1.Secret) error {
+	if machineClass == nil || machineClass.Annotations[machineutils.MachineClassUserDataTemplating] != "true" {
+		return nil
+	}
+
+	userDataB, exists := secret.Data["userData"]
+	if !exists {
+		// If userData key is not founds
+		return fmt.Errorf("userdata field not found in secret for machine %q", machine.Name)
+	}
+
+	tmpl, err := template.New("userData").Option("missingkey=error").Parse(string(userDataB))
+	if err != nil {
+		return fmt.Errorf("failed to parse user-data template of MachineClass %q for machine %q: %w", machineClass.Name, machine.Name, err)
+	}
+
+	values := userDataTemplateValues{
+		MachineName:      machine.Name,
+		MachineNamespace: machine.Namespace,
+	}
+	if machineClass.NodeTemplate != nil {
+		values.Zone = machineClass.NodeTemplate.Zone
+		values.Region = machineClass.NodeTemplate.Region
+		values.InstanceType = machineClass.NodeTemplate.InstanceType
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, values); err != nil {
+		return fmt.Errorf("failed to render user-data template of MachineClass %q for machine %q: %w", machineClass.Name, machine.Name, err)
+	}
+	klog.V(4).Infof("rendered user-data template of MachineClass %q for machine %q", machineClass.Name, machine.Name)
+
+	secret.Data["userData"] = rendered.Bytes()
+
+	return nil
+}
+
 func (c *controller) getBootstrapTokenOrCreateIfNotExist(ctx context.Context, machine *v1alpha1.Machine) (secret *corev1.Secret, err error) {
 	tokenID, secretName := getTokenIDAndSecretName(machine.Name)
 
@@ -121,7 +173,7 @@ func (c *controller) getBootstrapTokenOrCreateIfNotExist(ctx context.Context, ma
 				bootstraptokenapi.BootstrapTokenDescriptionKey:      []byte(fmt.Sprintf("A bootstrap token for machine %q generated by MachineControllerManager.", machine.Name)),
 				bootstraptokenapi.BootstrapTokenIDKey:               []byte(tokenID),
 				bootstraptokenapi.BootstrapTokenSecretKey:           []byte(bootstrapTokenSecretKey),
-				bootstraptokenapi.BootstrapTokenExpirationKey:       []byte(metav1.Now().Add(c.getEffectiveCreationTimeout(machine).Duration).Format(time.RFC3339)),
+				bootstraptokenapi.BootstrapTokenExpirationKey:       []byte(metav1.Now().Add(c.getBootstrapTokenTTL(machine)).Format(time.RFC3339)),
 				bootstraptokenapi.BootstrapTokenUsageAuthentication: []byte("true"),
 				bootstraptokenapi.BootstrapTokenUsageSigningKey:     []byte("true"),
 				bootstraptokenapi.BootstrapTokenExtraGroupsKey:      []byte(c.bootstrapTokenAuthExtraGroups),
@@ -174,3 +226,37 @@ func getTokenIDAndSecretName(machineName string) (string, string) {
 	secretName := bootstraptokenutil.BootstrapTokenSecretName(tokenID)
 	return tokenID, secretName
 }
+
+// getBootstrapTokenTTL returns the lifetime of the bootstrap token created for the node of the machine
+func (c *controller) getBootstrapTokenTTL(machine *v1alpha1.Machine) time.Duration {
+	if c.safetyOptions.BootstrapTokenTTL.Duration > 0 {
+		return c.safetyOptions.BootstrapTokenTTL.Duration
+	}
+	return c.getEffectiveCreationTimeout(machine).Duration
+}
+
+// bootstrapTokenExpired checks whether the bootstrap token of the pending machine expired before its node joined
+// the cluster. A missing token secret counts as expired, as the token is created before the VM and expired tokens
+// are removed by the token cleaner of the target cluster. It returns false if the expiry can't be derived from the secret.
+func (c *controller) bootstrapTokenExpired(machine *v1alpha1.Machine, node *corev1.Node) bool {
+	if node != nil || c.bootstrapTokenSecretLister == nil {
+		return false
+	}
+
+	_, secretName := getTokenIDAndSecretName(machine.Name)
+	secret, err := c.bootstrapTokenSecretLister.Secrets(metav1.NamespaceSystem).Get(secretName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true
+		}
+		klog.Warningf("Could not fetch bootstrap token secret %q of machine %q: %s", secretName, machine.Name, err)
+		return false
+	}
+
+	expiration, err := time.Parse(time.RFC3339, string(secret.Data[bootstraptokenapi.BootstrapTokenExpirationKey]))
+	if err != nil {
+		// Tokens without a valid expiration don't expire
+		return false
+	}
+	return time.Now().After(expiration)
+}
@@ -7,6 +7,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -87,6 +88,29 @@ chmod "0640" "/var/lib/gardener-node-agent/credentials/machine-name"
 			Expect(userDataSecret.Data["userData"]).To(Equal([]byte("foobar")))
 		})
 
+		It("should let the bootstrap token expire after the bootstrap token TTL", func() {
+			machineController.safetyOptions.MachineCreationTimeout = metav1.Duration{Duration: 20 * time.Minute}
+			machineController.safetyOptions.BootstrapTokenTTL = metav1.Duration{Duration: time.Hour}
+			Expect(machineController.addBootstrapTokenToUserData(ctx, machine, userDataSecret)).To(Succeed())
+
+			tokenSecret, err := targetCoreClient.CoreV1().Secrets(metav1.NamespaceSystem).Get(ctx, tokenSecretName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			expiration, err := time.Parse(time.RFC3339, string(tokenSecret.Data[bootstraptokenapi.BootstrapTokenExpirationKey]))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expiration).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		})
+
+		It("should let the bootstrap token expire after the creation timeout without a bootstrap token TTL", func() {
+			machineController.safetyOptions.MachineCreationTimeout = metav1.Duration{Duration: 20 * time.Minute}
+			Expect(machineController.addBootstrapTokenToUserData(ctx, machine, userDataSecret)).To(Succeed())
+
+			tokenSecret, err := targetCoreClient.CoreV1().Secrets(metav1.NamespaceSystem).Get(ctx, tokenSecretName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			expiration, err := time.Parse(time.RFC3339, string(tokenSecret.Data[bootstraptokenapi.BootstrapTokenExpirationKey]))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expiration).To(BeTemporally("~", time.Now().Add(20*time.Minute), time.Minute))
+		})
+
 		It("should fail if the secret does not contain a userData key", func() {
 			delete(userDataSecret.Data, "userData")
 			Expect(machineController.addBootstrapTokenToUserData(ctx, machine, userDataSecret)).To(MatchError(ContainSubstring("userdata field not found in secret for machine")))
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 )
@@ -47,6 +49,11 @@ const (
 	// Default priority for a machine is set to 3
 	MachinePriority = "machinepriority.machine.sapcloud.io"
 
+	// MachineDeletionCost is the annotation holding the deletion cost of a machine, the equivalent of the
+	// pod deletion cost for the cluster autoscaler. The lower its cost the more likely it is to be deleted first.
+	// It reflects the order in which MCM deletes the machines of a machineSet, see MachinePriority.
+	MachineDeletionCost = "machine.sapcloud.io/deletion-cost"
+
 	// MachineClassKind is used to identify the machineClassKind for generic machineClasses
 	MachineClassKind = "MachineClass"
 
@@ -67,15 +74,406 @@ const (
 	// NodeTerminationCondition describes nodes that are terminating
 	NodeTerminationCondition v1.NodeConditionType = "Terminating"
 
+	// NodeExternallyCordoned is the condition of machines whose node is cordoned by another actor than MCM,
+	// which MCM doesn't uncordon
+	NodeExternallyCordoned v1.NodeConditionType = "NodeExternallyCordoned"
+
+	// PodSafeToTerminateCondition is the condition reported by critical DaemonSet pods, e.g. of CSI drivers,
+	// once the VM of their node can be deleted safely
+	PodSafeToTerminateCondition v1.PodConditionType = "SafeToTerminate"
+
 	// TaintNodeCriticalComponentsNotReady is the name of a gardener taint
 	// indicating that a node is not yet ready to have user workload scheduled
 	TaintNodeCriticalComponentsNotReady = "node.gardener.cloud/critical-components-not-ready"
 
+	// TaintNodeUnhealthy is the key of the taint reserved for MCM on the nodes of unhealthy machines.
+	TaintNodeUnhealthy = "node.machine.sapcloud.io/unhealthy"
+
 	// MachineLabelKey defines the labels which contains the name of the machine of a node
 	MachineLabelKey = "node.gardener.cloud/machine-name"
 
 	// LabelKeyMachineSetScaleUpDisabled is the label key that indicates scaling up of the machine set is disabled.
 	LabelKeyMachineSetScaleUpDisabled = "node.machine.sapcloud.io/scale-up-disabled"
+
+	// LabelKeySpotMachine is the label key on a machine or MachineClass that indicates the machine is backed by a spot VM
+	LabelKeySpotMachine = "machine.sapcloud.io/spot"
+
+	// LabelKeyMachineDeployment is the label key on a machine and its node holding the name of the MachineDeployment
+	// owning the machine's MachineSet, so that tooling can group machines and nodes by MachineDeployment directly
+	LabelKeyMachineDeployment = "machine.sapcloud.io/machinedeployment"
+
+	// MachineFlowHistoryEnabled annotation on a machine enables recording of its creation and deletion flow steps
+	MachineFlowHistoryEnabled = "machine.sapcloud.io/flow-history-enabled"
+
+	// MachineFlowHistory annotation contains the last MaxMachineFlowHistoryEntries creation and deletion flow steps
+	// of a machine along with their timestamps, if enabled using the MachineFlowHistoryEnabled annotation
+	MachineFlowHistory = "machine.sapcloud.io/flow-history"
+
+	// MaxMachineFlowHistoryEntries is the number of flow steps retained in the MachineFlowHistory annotation
+	MaxMachineFlowHistoryEntries = 10
+
+	// MachineClassDeleteNodeBeforeVM annotation on a MachineClass makes the deletion flow delete the node object
+	// before the VM, for providers which require the kubelet to be deregistered before the VM can be deleted
+	MachineClassDeleteNodeBeforeVM = "machine.sapcloud.io/delete-node-before-vm"
+
+	// MachineClassVerificationCondition annotation on a MachineClass names a node condition, e.g. set by a smoke test,
+	// which has to be True before a newly joined machine is marked Running. Until then the machine is Verifying.
+	MachineClassVerificationCondition = "machine.sapcloud.io/verification-condition"
+
+	// MachineClassNodeConditions annotation on a MachineClass holds a comma-separated list of node conditions evaluated
+	// for the health of its machines, overriding the node conditions configured for the machine controller
+	MachineClassNodeConditions = "machine.sapcloud.io/node-conditions"
+
+	// MachineClassUserDataTemplating annotation on a MachineClass makes the creation flow render the user-data
+	// of its secret as a Go template with machine-scoped values, e.g. {{ .MachineName }} or {{ .Zone }}
+	MachineClassUserDataTemplating = "machine.sapcloud.io/user-data-templating"
+
+	// MachineClassFallbackInstanceTypes annotation on a MachineClass holds a comma-separated, ordered list of instance
+	// types the creation of its machines is retried with, as long as the provider reports the previous type as exhausted
+	MachineClassFallbackInstanceTypes = "machine.sapcloud.io/fallback-instance-types"
+
+	// InstanceTypeFallbackReason is the event reason used when the creation of a machine is retried with a fallback instance type
+	InstanceTypeFallbackReason = "InstanceTypeFallback"
+
+	// MachineClassMaxMachines annotation on a MachineClass limits the number of machines
+	// backed by a VM, or being created, that may reference the MachineClass in its namespace
+	MachineClassMaxMachines = "machine.sapcloud.io/max-machines"
+
+	// MachineClassDrainSkipPodSelector annotation on a MachineClass holds a label selector, e.g. "app=node-agent", of the pods
+	// left on the node by the drain during the deletion of its machines, e.g. agents managed separately
+	MachineClassDrainSkipPodSelector = "machine.sapcloud.io/drain-skip-pod-selector"
+
+	// MachineClassOnCreationTimeout annotation on a MachineClass holds the policy applied to its machines whose node
+	// doesn't join, or isn't verified, within the creation timeout. One of OnCreationTimeoutPolicyFail and
+	// OnCreationTimeoutPolicyRecreate, defaulting to the former.
+	MachineClassOnCreationTimeout = "machine.sapcloud.io/on-creation-timeout"
+
+	// OnCreationTimeoutPolicyFail is the policy which marks machines whose node doesn't join within the creation timeout
+	// Failed, keeping their description of the failure until their MachineSet replaces them
+	OnCreationTimeoutPolicyFail = "fail"
+
+	// OnCreationTimeoutPolicyRecreate is the policy which deletes machines whose node doesn't join within the creation
+	// timeout right away, without marking them Failed, so that their MachineSet recreates them
+	OnCreationTimeoutPolicyRecreate = "recreate"
+
+	// MachineRecreatedOnCreationTimeoutReason is the event reason used when a machine is deleted to be recreated as its
+	// node didn't join within the creation timeout
+	MachineRecreatedOnCreationTimeoutReason = "MachineRecreatedOnCreationTimeout"
+
+	// MachineCreationOnHold specifies that machine creation is held back until the quota permits it
+	MachineCreationOnHold = "Machine creation is on hold"
+
+	// MachineCreationHeld is the type of the condition set on a machine whose creation is held back,
+	// e.g. until the quota permits it
+	MachineCreationHeld = "CreationHeld"
+
+	// MachineQuotaExceededReason is the event reason used when machine creation is held due to quota
+	MachineQuotaExceededReason = "MachineQuotaExceeded"
+
+	// MachineDependsOn annotation on a machine holds a label selector of machines in its namespace
+	// which have to be Running before the VM of the machine is created
+	MachineDependsOn = "machine.sapcloud.io/depends-on"
+
+	// MachineDependencyPendingReason is the event reason used when machine creation is held until its dependencies are Running
+	MachineDependencyPendingReason = "MachineDependencyPending"
+
+	// ProviderIDConflictReason is the event reason used when several nodes share the providerID of a machine
+	ProviderIDConflictReason = "ProviderIDConflict"
+
+	// MachineProviderIDConflict is the type of the condition set on a machine whose VM deletion is held as
+	// several nodes share its providerID
+	MachineProviderIDConflict = "ProviderIDConflict"
+
+	// MachineDrainEvictionPolicy annotation on a machine overrides how pods are removed while draining its node
+	MachineDrainEvictionPolicy = "machine.sapcloud.io/drain-eviction-policy"
+
+	// DrainEvictionPolicyDeleteOnly is the MachineDrainEvictionPolicy which deletes pods directly instead of
+	// using the eviction API, while still respecting their PodDisruptionBudgets
+	DrainEvictionPolicyDeleteOnly = "delete-only"
+
+	// MachineTeardownOrder annotation on a MachineDeployment or MachineSet specifies the order in which its machines
+	// are terminated one after another when it is scaled to zero. See TeardownOrderFewestPodsFirst and TeardownOrderMostPodsFirst.
+	MachineTeardownOrder = "machine.sapcloud.io/teardown-order"
+
+	// TeardownOrderFewestPodsFirst is the MachineTeardownOrder which terminates the machines whose nodes run the fewest pods first
+	TeardownOrderFewestPodsFirst = "fewest-pods-first"
+
+	// TeardownOrderMostPodsFirst is the MachineTeardownOrder which terminates the machines whose nodes run the most pods first
+	TeardownOrderMostPodsFirst = "most-pods-first"
+
+	// MachineInPlaceUpdateOrder annotation on a MachineDeployment specifies the order in which the machines of its old
+	// MachineSets are selected for an in-place update. See InPlaceUpdateOrderOldestNodeFirst.
+	MachineInPlaceUpdateOrder = "machine.sapcloud.io/in-place-update-order"
+
+	// InPlaceUpdateOrderOldestNodeFirst is the MachineInPlaceUpdateOrder which selects the machines whose nodes were created first
+	InPlaceUpdateOrderOldestNodeFirst = "oldest-node-first"
+
+	// MachineDeploymentInPlaceSkipCordon annotation on a MachineDeployment makes its in-place updates skip the cordon of
+	// the nodes of the machines selected for update, e.g. for node pools running only DaemonSet pods.
+	// The machines are annotated with MachineInPlaceUpdateSkipCordon when they are selected for update.
+	MachineDeploymentInPlaceSkipCordon = "inplace.machine.sapcloud.io/skip-cordon"
+
+	// MachineInPlaceUpdateSkipCordon annotation on a machine selected for an in-place update makes the machine controller
+	// drain its node without cordoning it. The node is then left as is instead of being uncordoned after the update.
+	MachineInPlaceUpdateSkipCordon = "machine.sapcloud.io/in-place-update-skip-cordon"
+
+	// MachineInPlaceUpdateSelection annotation on a MachineDeployment specifies how the machines selected for an in-place
+	// update are distributed across its old MachineSets. By default the oldest MachineSets are drained first.
+	// See InPlaceUpdateSelectionProportional.
+	MachineInPlaceUpdateSelection = "machine.sapcloud.io/in-place-update-selection"
+
+	// InPlaceUpdateSelectionProportional is the MachineInPlaceUpdateSelection which selects machines from each old MachineSet
+	// proportionally to its replicas
+	InPlaceUpdateSelectionProportional = "proportional"
+
+	// MachineZoneSpread annotation on a MachineDeployment or MachineSet holds the comma-separated zones across which
+	// its machines are balanced when they are created, by setting the MachinePreferredZone annotation on them
+	MachineZoneSpread = "machine.sapcloud.io/zone-spread"
+
+	// MachinePreferredZone annotation on a machine holds the zone its VM is preferably created in,
+	// which is passed to the driver in the CreateMachineRequest
+	MachinePreferredZone = "machine.sapcloud.io/preferred-zone"
+
+	// MachinePlacementGroup annotation on a machine or its MachineClass holds the provider placement group its VM is
+	// created in, which is passed to the driver in the CreateMachineRequest. The annotation of the machine, usually set
+	// in the machine template of its MachineDeployment, takes precedence over the one of the MachineClass.
+	MachinePlacementGroup = "machine.sapcloud.io/placement-group"
+
+	// LastAppliedNodeLabelTaintsAnnotation contains the taints applied on the node object because its labels matched
+	// a configured node label selector, so that they can be removed once the node no longer matches
+	LastAppliedNodeLabelTaintsAnnotation = "node.machine.sapcloud.io/last-applied-label-taints"
+
+	// MachineMaxAge annotation on a MachineDeployment, MachineSet or MachineClass holds the maximum age
+	// (as a Go duration, e.g. "720h") after which machines are gracefully rotated
+	MachineMaxAge = "machine.sapcloud.io/max-age"
+
+	// MachineMaxAgeExceededReason is the event reason used when a machine is marked for deletion as it exceeded its maximum age
+	MachineMaxAgeExceededReason = "MachineMaxAgeExceeded"
+
+	// MachineClassSnapshotBeforeDeletion annotation on a MachineClass makes the deletion flow take a snapshot
+	// of the VM via the driver after the node is drained and before the VM is deleted
+	MachineClassSnapshotBeforeDeletion = "machine.sapcloud.io/snapshot-before-deletion"
+
+	// MachineSnapshotID annotation on a machine holds the ID of the snapshot taken of its VM before its deletion
+	MachineSnapshotID = "machine.sapcloud.io/snapshot-id"
+
+	// MachineSnapshotTakenReason is the event reason used when a snapshot of the VM was taken before its deletion
+	MachineSnapshotTakenReason = "MachineSnapshotTaken"
+
+	// MachineClassCreateMachineTimeout annotation on a MachineClass holds the timeout (as a Go duration, e.g. "10m") of a
+	// single CreateMachine call of the driver for its machines, overriding the DriverCreateMachineTimeout
+	MachineClassCreateMachineTimeout = "machine.sapcloud.io/create-machine-timeout"
+
+	// MachineClassDeleteMachineTimeout annotation on a MachineClass holds the timeout (as a Go duration) of a
+	// single DeleteMachine call of the driver for its machines, overriding the DriverDeleteMachineTimeout
+	MachineClassDeleteMachineTimeout = "machine.sapcloud.io/delete-machine-timeout"
+
+	// MachineClassGetMachineStatusTimeout annotation on a MachineClass holds the timeout (as a Go duration) of a
+	// single GetMachineStatus call of the driver for its machines, overriding the DriverGetMachineStatusTimeout
+	MachineClassGetMachineStatusTimeout = "machine.sapcloud.io/get-machine-status-timeout"
+
+	// MachinePreDeletionHooksCompleted annotation on a machine holds the names of the pre-deletion hooks, as a JSON
+	// list, which already succeeded during its deletion
+	MachinePreDeletionHooksCompleted = "machine.sapcloud.io/pre-deletion-hooks-completed"
+
+	// MachineDeletionAuthFailureSince annotation on a machine holds the time (in RFC3339) since when
+	// the deletion of its VM fails due to authentication errors
+	MachineDeletionAuthFailureSince = "machine.sapcloud.io/deletion-auth-failure-since"
+
+	// MachineForceFinalizerRemoval annotation on a machine is set by an operator to confirm that the finalizer
+	// of the machine may be removed without deleting its VM, if the VM deletion persistently fails due to
+	// authentication errors or an unreachable provider and the respective ForceRemoveFinalizer policy is configured
+	MachineForceFinalizerRemoval = "machine.sapcloud.io/force-finalizer-removal"
+
+	// MachineForceNodeDeletion annotation on a machine is set by an operator to have the node object of the machine
+	// deleted right away, before its VM deletion completes, e.g. to free the node name while the VM deletion is stuck
+	MachineForceNodeDeletion = "machine.sapcloud.io/force-node-deletion"
+
+	// MachineForcedNodeDeletionReason is the event reason used when the node of a machine is deleted before its VM
+	// as requested with the MachineForceNodeDeletion annotation
+	MachineForcedNodeDeletionReason = "MachineForcedNodeDeletion"
+
+	// MachineForceDeleteVMAttempts annotation on a machine labeled force-deletion holds the number of failed
+	// DeleteMachine calls of its deletion, which is limited by the MaxForceDeleteVMAttempts
+	MachineForceDeleteVMAttempts = "machine.sapcloud.io/force-delete-vm-attempts"
+
+	// MachineVMDeletionAbandonedReason is the event reason used when the deletion of a machine labeled force-deletion
+	// continues without confirmation of the VM deletion, as its DeleteMachine calls failed MaxForceDeleteVMAttempts times
+	MachineVMDeletionAbandonedReason = "MachineVMDeletionAbandoned"
+
+	// MachineDeletionAuthFailureReason is the event reason used when the VM deletion persistently fails due to authentication errors
+	MachineDeletionAuthFailureReason = "MachineDeletionAuthFailure"
+
+	// DeletionAuthFailurePolicyHold is the policy which holds the deletion of machines whose VM deletion
+	// persistently fails due to authentication errors until the credentials are fixed
+	DeletionAuthFailurePolicyHold = "Hold"
+
+	// DeletionAuthFailurePolicyForceRemoveFinalizer is the policy which continues the deletion of machines whose
+	// VM deletion persistently fails due to authentication errors without deleting the VM, once confirmed
+	// by an operator with the MachineForceFinalizerRemoval annotation
+	DeletionAuthFailurePolicyForceRemoveFinalizer = "ForceRemoveFinalizer"
+
+	// MachineDeletionProviderUnreachableSince annotation on a machine holds the time (in RFC3339) since when
+	// the deletion of its VM fails as the provider is unreachable
+	MachineDeletionProviderUnreachableSince = "machine.sapcloud.io/deletion-provider-unreachable-since"
+
+	// MachineDeletionProtected is the type of the condition set on a machine whose VM deletion is held as
+	// deletion protection is enabled for the VM at the provider
+	MachineDeletionProtected = "DeletionProtected"
+
+	// MachineDeletionProtectedReason is the event reason used when the VM deletion is held due to deletion protection
+	MachineDeletionProtectedReason = "MachineDeletionProtected"
+
+	// MachineDrainBlockedByWebhook is the type of the condition set on a machine whose drain fails as an admission
+	// webhook denies the eviction of a pod on its node
+	MachineDrainBlockedByWebhook = "DrainBlockedByWebhook"
+
+	// MachineDrainBlockedByWebhookReason is the reason of the DrainBlockedByWebhook condition while the drain is blocked
+	MachineDrainBlockedByWebhookReason = "EvictionDeniedByWebhook"
+
+	// MachineDeletionStalledReason is the event reason used when the VM deletion persistently fails as the provider is unreachable
+	MachineDeletionStalledReason = "MachineDeletionStalled"
+
+	// DeletionProviderUnreachablePolicyStall is the policy which parks the deletion of machines whose VM deletion
+	// persistently fails as the provider is unreachable in the DeletionStalled state, retrying it less frequently
+	DeletionProviderUnreachablePolicyStall = "Stall"
+
+	// DeletionProviderUnreachablePolicyForceRemoveFinalizer is the policy which continues the deletion of machines
+	// whose VM deletion persistently fails as the provider is unreachable without deleting the VM, once confirmed
+	// by an operator with the MachineForceFinalizerRemoval annotation
+	DeletionProviderUnreachablePolicyForceRemoveFinalizer = "ForceRemoveFinalizer"
+
+	// NodeNotReadyPolicyReplace is the policy which replaces machines whose node is NotReady for longer than the health timeout
+	NodeNotReadyPolicyReplace = "replace"
+
+	// NodeNotReadyPolicyRebootBeforeReplace is the policy which first reboots the VM of machines whose node is NotReady
+	// for longer than the health timeout while the VM is still reported by the provider, and only replaces them if
+	// the node doesn't become Ready within another health timeout
+	NodeNotReadyPolicyRebootBeforeReplace = "reboot-before-replace"
+
+	// UnschedulableJoiningNodePolicyKeep is the policy which leaves the nodes of newly created machines, which join
+	// the cluster cordoned, unschedulable
+	UnschedulableJoiningNodePolicyKeep = "keep"
+
+	// UnschedulableJoiningNodePolicyUncordon is the policy which uncordons the nodes of newly created machines, which
+	// join the cluster cordoned e.g. by a bootstrap flow, once they are healthy and the machine is marked Running
+	UnschedulableJoiningNodePolicyUncordon = "uncordon"
+
+	// NoExecuteTaintDrainPolicyDrain is the policy which drains the node of a machine regardless of NoExecute taints
+	// of other controllers, evicting the pods concurrently with the taint-based eviction
+	NoExecuteTaintDrainPolicyDrain = "drain"
+
+	// NoExecuteTaintDrainPolicyWait is the policy which holds the drain of the node of a machine while pods not
+	// tolerating a NoExecute taint of another controller are still on the node, i.e. are evicted due to the taint
+	NoExecuteTaintDrainPolicyWait = "wait"
+
+	// NodeTerminationConditionTimingBeforeDrain is the timing which sets the termination condition on the node of a
+	// machine before it is drained, letting external controllers react while the pods are evicted
+	NodeTerminationConditionTimingBeforeDrain = "before-drain"
+
+	// NodeTerminationConditionTimingAfterDrain is the timing which sets the termination condition on the node of a
+	// machine only once it was drained, i.e. once its volumes are no longer in use, right before the VM is deleted
+	NodeTerminationConditionTimingAfterDrain = "after-drain"
+
+	// NodeTerminationConditionSet specifies that the termination condition was set on the node of a machine and the
+	// drain waits for its propagation
+	NodeTerminationConditionSet = "Set termination condition on node, waiting for its propagation"
+
+	// PreDrainHookFailed specifies that a pre-drain hook failed, hence the drain is retried
+	PreDrainHookFailed = "Pre-drain hook failed"
+
+	// NoExecuteTaintEvictionInProgress specifies that the drain of the node of a machine is held as pods are evicted
+	// due to a NoExecute taint of another controller
+	NoExecuteTaintEvictionInProgress = "Drain held as pods are evicted due to a NoExecute taint of the node"
+
+	// DrainSkippedNoEvictablePods specifies that the drain of the node of a machine was skipped as it has no pods to evict
+	DrainSkippedNoEvictablePods = "Drain skipped as node has no pods to evict"
+
+	// TargetClusterUnreachable specifies that a step of a machine is held as the APIServer of the target cluster is unreachable
+	TargetClusterUnreachable = "APIServer of the target cluster is unreachable, holding the machine"
+
+	// MachineRebootTriggered specifies that the VM of a machine whose node is NotReady was rebooted before replacing the machine
+	MachineRebootTriggered = "VM reboot triggered before replacing the machine"
+
+	// MachineHealthTimeoutExtended specifies that the health timeout of a machine was restarted as its node still has the
+	// critical-components-not-ready taint, i.e. is still initializing
+	MachineHealthTimeoutExtended = "Health timeout restarted as the node is still initializing"
+
+	// MachineRebootedReason is the event reason used when the VM of a machine whose node is NotReady was rebooted
+	MachineRebootedReason = "MachineRebooted"
+
+	// BootstrapTokenExpiredReason is the event reason used when a pending machine is marked as failed as its
+	// bootstrap token expired before its node joined the cluster
+	BootstrapTokenExpiredReason = "BootstrapTokenExpired"
+
+	// MachineDeletionCorrelationID is the annotation of the events of the final deletion steps of a machine,
+	// holding the UID of the machine to correlate them
+	MachineDeletionCorrelationID = "machine.sapcloud.io/deletion-correlation-id"
+
+	// MachineVMDeletedReason is the event reason used when the VM of a machine being deleted is gone
+	MachineVMDeletedReason = "MachineVMDeleted"
+
+	// MachineNodeDeletedReason is the event reason used when the node of a machine being deleted is gone
+	MachineNodeDeletedReason = "MachineNodeDeleted"
+
+	// MachineFinalizerRemovedReason is the event reason used when the finalizer of a machine being deleted is removed
+	MachineFinalizerRemovedReason = "MachineFinalizerRemoved"
+
+	// NodePodDensityExceededReason is the event reason used when the node of a machine hosts more pods than the NodePodDensityThreshold
+	NodePodDensityExceededReason = "NodePodDensityExceeded"
+
+	// MachineQuarantine annotation on a machine set to "true" by an operator puts the machine into the Quarantined phase,
+	// which cordons its node and suspends health based actions like its replacement, while keeping the machine running.
+	// Removing the annotation restores the normal handling of the machine.
+	MachineQuarantine = "machine.sapcloud.io/quarantine"
+
+	// NodeCordonedForQuarantine annotation on a node marks that MCM cordoned it when its machine was quarantined,
+	// so that only such a node is uncordoned once the machine is released, and a node cordoned before stays cordoned
+	NodeCordonedForQuarantine = "node.machine.sapcloud.io/cordoned-for-quarantine"
+
+	// MachinePauseReconcile annotation on a machine set to "true" by an operator makes the machine controller
+	// skip all reconciliation of the machine, including its deletion, until the annotation is removed
+	MachinePauseReconcile = "machine.sapcloud.io/pause-reconcile"
+
+	// MachineDeletionDryRun annotation on a machine set to "true" by an operator makes the machine controller only
+	// describe the remaining steps of the deletion of the machine in its last operation, without performing any of them,
+	// until the annotation is removed
+	MachineDeletionDryRun = "machine.sapcloud.io/deletion-dry-run"
+
+	// DeletionDryRun prefixes the description of the last operation of a machine planned by a deletion dry run
+	DeletionDryRun = "Deletion dry run, planned steps"
+
+	// MachineQuarantinedReason is the event reason used when a machine is quarantined
+	MachineQuarantinedReason = "MachineQuarantined"
+
+	// MachineQuarantineReleasedReason is the event reason used when a machine is released from quarantine
+	MachineQuarantineReleasedReason = "MachineQuarantineReleased"
+
+	// MachineLabelDriftReason is the event reason used when a machine owned by a MachineSet no longer matches its selector
+	MachineLabelDriftReason = "MachineLabelDrift"
+
+	// LabelDriftPolicyRelease is the policy which releases machines whose labels drifted out of the selector
+	// of their MachineSet, so that the MachineSet replaces them
+	LabelDriftPolicyRelease = "Release"
+
+	// LabelDriftPolicyReadopt is the policy which re-adds the selector labels of the MachineSet to machines
+	// whose labels drifted out of it, so that the MachineSet keeps them
+	LabelDriftPolicyReadopt = "Readopt"
+
+	// LabelDriftPolicyDelete is the policy which deletes machines whose labels drifted out of the selector
+	// of their MachineSet, so that the MachineSet replaces them without leaking the drifted machines
+	LabelDriftPolicyDelete = "Delete"
+
+	// MachineLastSafetyAction annotation on a machine holds the safety reconciler which last acted on the machine
+	// and the time (in RFC3339) it did so, separated by an "@", e.g. "orphan-vms@2024-01-01T00:00:00Z"
+	MachineLastSafetyAction = "machine.sapcloud.io/last-safety-action"
+
+	// SafetyReconcilerOrphanVMs identifies the safety reconciler which deletes orphan VMs in the MachineLastSafetyAction annotation
+	SafetyReconcilerOrphanVMs = "orphan-vms"
+
+	// SafetyReconcilerAPIServer identifies the safety reconciler which resets the machine health checks once the APIServers
+	// are reachable again in the MachineLastSafetyAction annotation
+	SafetyReconcilerAPIServer = "api-server"
 )
 
 // RetryPeriod is an alias for specifying the retry period
@@ -93,10 +491,40 @@ const (
 	LongRetry RetryPeriod = RetryPeriod(10 * time.Minute)
 )
 
+// LastSuccessfulReconcileUpdateInterval is the minimum interval between updates of the LastSuccessfulReconcile in the
+// status of a machine, bounding the status updates of frequently reconciled machines. It's shorter than the LongRetry,
+// so that the periodic reconciles of a healthy machine keep it up to date.
+const LastSuccessfulReconcileUpdateInterval = 5 * time.Minute
+
+// ProviderConditionsRefreshInterval is the minimum period for which the result of the GetMachineStatus call fetching
+// the provider conditions of a machine is reused by its health checks, so that they don't call the provider API on
+// every reconcile of the machine
+const ProviderConditionsRefreshInterval = time.Minute
+
+// TargetClusterReachabilityCacheTTL is the period for which the result of probing the reachability of the APIServer of
+// the target cluster is reused, so that the machines reconciled concurrently don't probe it each
+const TargetClusterReachabilityCacheTTL = 10 * time.Second
+
 // EssentialTaints are taints on node object which if added/removed, require an immediate reconcile by machine controller
 // TODO: update this when taints for ALT updation and PostCreate operations is introduced.
 var EssentialTaints = []string{TaintNodeCriticalComponentsNotReady}
 
+// knownMachinePhases are the phases recorded in the PhaseTransitionTimes of machines, bounding their size
+var knownMachinePhases = sets.New(
+	v1alpha1.MachinePending,
+	v1alpha1.MachineAvailable,
+	v1alpha1.MachineRunning,
+	v1alpha1.MachineVerifying,
+	v1alpha1.MachineQuarantined,
+	v1alpha1.MachineTerminating,
+	v1alpha1.MachineUnknown,
+	v1alpha1.MachineFailed,
+	v1alpha1.MachineCrashLoopBackOff,
+	v1alpha1.MachineInPlaceUpdating,
+	v1alpha1.MachineInPlaceUpdateSuccessful,
+	v1alpha1.MachineInPlaceUpdateFailed,
+)
+
 // IsMachineFailedOrTerminating returns true if machine is Failed or already being Terminated.
 func IsMachineFailedOrTerminating(machine *v1alpha1.Machine) bool {
 	if !machine.GetDeletionTimestamp().IsZero() || machine.Status.CurrentStatus.Phase == v1alpha1.MachineFailed {
@@ -115,7 +543,58 @@ func IsMachineFailed(p *v1alpha1.Machine) bool {
 	return p.Status.CurrentStatus.Phase == v1alpha1.MachineFailed
 }
 
+// IsMachineQuarantined checks if machine is annotated to be quarantined
+func IsMachineQuarantined(m *v1alpha1.Machine) bool {
+	return m.Annotations[MachineQuarantine] == "true"
+}
+
+// IsMachineReconcilePaused checks if machine is annotated to pause its reconciliation
+func IsMachineReconcilePaused(m *v1alpha1.Machine) bool {
+	return m.Annotations[MachinePauseReconcile] == "true"
+}
+
+// IsMachineDeletionDryRun checks if machine is annotated to only plan its deletion
+func IsMachineDeletionDryRun(m *v1alpha1.Machine) bool {
+	return m.Annotations[MachineDeletionDryRun] == "true"
+}
+
 // IsMachineTriggeredForDeletion checks if machine was triggered for deletion
 func IsMachineTriggeredForDeletion(m *v1alpha1.Machine) bool {
 	return m.Annotations[MachinePriority] == "1" || m.Annotations[TriggerDeletionByMCM] == "true"
 }
+
+// SetCurrentStatus sets the current status of the machine. If the status transitions the machine to another phase,
+// the time spent in the previous phase since its recorded transition time is added to the PhaseDurations of the
+// machine, and the transition time is recorded in the PhaseTransitionTimes of the new phase. Only known phases are
+// recorded.
+func SetCurrentStatus(machineStatus *v1alpha1.MachineStatus, currentStatus v1alpha1.CurrentStatus) {
+	previous := machineStatus.CurrentStatus
+	if previous.Phase == currentStatus.Phase {
+		machineStatus.CurrentStatus = currentStatus
+		return
+	}
+
+	transitionTime := currentStatus.LastUpdateTime
+	if transitionTime.IsZero() {
+		transitionTime = metav1.Now()
+	}
+	if knownMachinePhases.Has(previous.Phase) {
+		if previousTransitionTime := machineStatus.PhaseTransitionTimes[previous.Phase]; !previousTransitionTime.IsZero() {
+			if spent := transitionTime.Sub(previousTransitionTime.Time); spent > 0 {
+				if machineStatus.PhaseDurations == nil {
+					machineStatus.PhaseDurations = make(map[string]metav1.Duration)
+				}
+				phaseDuration := machineStatus.PhaseDurations[string(previous.Phase)]
+				phaseDuration.Duration += spent
+				machineStatus.PhaseDurations[string(previous.Phase)] = phaseDuration
+			}
+		}
+	}
+	if knownMachinePhases.Has(currentStatus.Phase) {
+		if machineStatus.PhaseTransitionTimes == nil {
+			machineStatus.PhaseTransitionTimes = make(map[v1alpha1.MachinePhase]metav1.Time)
+		}
+		machineStatus.PhaseTransitionTimes[currentStatus.Phase] = transitionTime
+	}
+	machineStatus.CurrentStatus = currentStatus
+}
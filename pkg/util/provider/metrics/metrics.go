@@ -47,6 +47,29 @@ var (
 		Name:      "status_condition",
 		Help:      "Information of the mcm managed Machines' status conditions.",
 	}, []string{"name", "namespace", "condition"})
+
+	// MachineQueueDepth Number of machines waiting in the machine queue, partitioned by MachineClass.
+	MachineQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: machineSubsystem,
+		Name:      "queue_depth",
+		Help:      "Number of machines waiting in the machine queue, partitioned by MachineClass.",
+	}, []string{"machineclass"})
+
+	// MachineReconcileDuration Time (in seconds) it takes to reconcile a machine, partitioned by MachineClass.
+	MachineReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: machineSubsystem,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Time (in seconds) it takes to reconcile a machine, partitioned by MachineClass.",
+	}, []string{"machineclass"})
+
+	// ReconcileErrors Number of errors while reconciling machines, partitioned by flow and error category.
+	ReconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of errors while reconciling machines, partitioned by flow (create, delete, health, inplace) and error category (provider, api, internal).",
+	}, []string{"flow", "category"})
 )
 
 // variables for subsystem: cloud_api
@@ -113,6 +136,9 @@ func registerMachineSubsystemMetrics() {
 	prometheus.MustRegister(MachineInfo)
 	prometheus.MustRegister(MachineStatusCondition)
 	prometheus.MustRegister(MachineCSPhase)
+	prometheus.MustRegister(MachineQueueDepth)
+	prometheus.MustRegister(MachineReconcileDuration)
+	prometheus.MustRegister(ReconcileErrors)
 }
 
 func registerCloudAPISubsystemMetrics() {
@@ -26,9 +26,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
+	"time"
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 )
@@ -98,6 +101,109 @@ func (v PortRangeVar) Type() string {
 	return "port-range"
 }
 
+// NodeSelectorTimeoutsVar is used to store a list of node label selector to timeout mappings
+type NodeSelectorTimeoutsVar struct {
+	Val *[]NodeSelectorTimeout
+}
+
+// Set is used to add a mapping of the form <selector>:<duration> to NodeSelectorTimeoutsVar
+func (v NodeSelectorTimeoutsVar) Set(s string) error {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return fmt.Errorf("%q is not of the form <selector>:<duration>", s)
+	}
+	selector, timeout := s[:i], s[i+1:]
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("%q is not a valid label selector: %v", selector, err)
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid duration: %v", timeout, err)
+	}
+	if v.Val == nil {
+		// it's okay to panic here since this is programmer error
+		panic("the slice pointer passed into NodeSelectorTimeoutsVar should not be nil")
+	}
+	*v.Val = append(*v.Val, NodeSelectorTimeout{Selector: selector, Timeout: metav1.Duration{Duration: duration}})
+	return nil
+}
+
+// String is used to get NodeSelectorTimeoutsVar in string format
+func (v NodeSelectorTimeoutsVar) String() string {
+	if v.Val == nil {
+		return ""
+	}
+	mappings := make([]string, 0, len(*v.Val))
+	for _, m := range *v.Val {
+		mappings = append(mappings, fmt.Sprintf("%s:%s", m.Selector, m.Timeout.Duration))
+	}
+	return strings.Join(mappings, ",")
+}
+
+// Type is used to determine the type of NodeSelectorTimeoutsVar
+func (v NodeSelectorTimeoutsVar) Type() string {
+	return "selector:duration"
+}
+
+// NodeLabelTaintsVar is used to store a list of node label selector to taint mappings
+type NodeLabelTaintsVar struct {
+	Val *[]NodeLabelTaint
+}
+
+// Set is used to add a mapping of the form <selector>:<key>[=<value>]:<effect> to NodeLabelTaintsVar
+func (v NodeLabelTaintsVar) Set(s string) error {
+	i := strings.Index(s, ":")
+	if i < 0 {
+		return fmt.Errorf("%q is not of the form <selector>:<key>[=<value>]:<effect>", s)
+	}
+	selector, taintSpec := s[:i], s[i+1:]
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("%q is not a valid label selector: %v", selector, err)
+	}
+	taint, err := parseTaint(taintSpec)
+	if err != nil {
+		return err
+	}
+	if v.Val == nil {
+		// it's okay to panic here since this is programmer error
+		panic("the slice pointer passed into NodeLabelTaintsVar should not be nil")
+	}
+	*v.Val = append(*v.Val, NodeLabelTaint{Selector: selector, Taint: taint})
+	return nil
+}
+
+// String is used to get NodeLabelTaintsVar in string format
+func (v NodeLabelTaintsVar) String() string {
+	if v.Val == nil {
+		return ""
+	}
+	mappings := make([]string, 0, len(*v.Val))
+	for _, m := range *v.Val {
+		mappings = append(mappings, fmt.Sprintf("%s:%s", m.Selector, m.Taint.ToString()))
+	}
+	return strings.Join(mappings, ",")
+}
+
+// Type is used to determine the type of NodeLabelTaintsVar
+func (v NodeLabelTaintsVar) Type() string {
+	return "selector:taint"
+}
+
+// parseTaint parses a taint of the form <key>[=<value>]:<effect>
+func parseTaint(s string) (v1.Taint, error) {
+	keyValue, effect, found := strings.Cut(s, ":")
+	if !found || keyValue == "" {
+		return v1.Taint{}, fmt.Errorf("%q is not a taint of the form <key>[=<value>]:<effect>", s)
+	}
+	switch v1.TaintEffect(effect) {
+	case v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute:
+	default:
+		return v1.Taint{}, fmt.Errorf("%q is not a valid taint effect", effect)
+	}
+	key, value, _ := strings.Cut(keyValue, "=")
+	return v1.Taint{Key: key, Value: value, Effect: v1.TaintEffect(effect)}, nil
+}
+
 // ConvertObjToConfigMap converts an object to a ConfigMap.
 // This is specifically meant for ComponentConfigs.
 func ConvertObjToConfigMap(name string, obj runtime.Object) (*v1.ConfigMap, error) {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	mcmoptions "github.com/gardener/machine-controller-manager/pkg/options"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -71,8 +72,40 @@ type MachineControllerConfiguration struct {
 	//NodeCondition is the string of known NodeConditions. If any of these NodeCondition is set for a timeout period, the machine  will be declared failed and will replaced.
 	NodeConditions string
 
+	// ProviderConditions is the string of custom conditions reported by the driver via GetMachineStatus. If any of these
+	// conditions is set to True for a timeout period, the machine will be declared failed and will be replaced.
+	ProviderConditions string
+
 	//BootstrapTokenAuthExtraGroups is a comma-separated string of groups to set bootstrap token's "auth-extra-groups" field to.
 	BootstrapTokenAuthExtraGroups string
+
+	// NodeTerminationConditionType is the type of the condition set on the node of a machine while it is being deleted.
+	NodeTerminationConditionType string
+
+	// NodeTerminationConditionReason is the reason of the condition set on the node of a machine while it is being deleted.
+	// If empty, the reason is derived from the phase of the machine.
+	NodeTerminationConditionReason string
+
+	// MachinePriorityComputer is the name of the computer used to set the priority annotation on newly created machines.
+	MachinePriorityComputer string
+
+	// MachineQueueFairness makes the machine controller dequeue machines round-robin across MachineClasses.
+	MachineQueueFairness bool
+
+	// NodeLabelTaints are the taints maintained by MCM on the nodes of machines matching their node label selector.
+	NodeLabelTaints []NodeLabelTaint
+
+	// NodeLabelPropagationDenylist are the prefixes of label keys which are never added, modified or removed on nodes
+	// while propagating the labels of the node templates of machines.
+	NodeLabelPropagationDenylist []string
+
+	// NodeTaintsRemovedOnRecovery are the keys of the taints removed from the node of a machine which recovers and is Running again.
+	// Empty disables the removal.
+	NodeTaintsRemovedOnRecovery []string
+
+	// MachinePreDeletionHooks are the URLs of the HTTP endpoints called in order before the VM of a machine is deleted.
+	// The VM deletion waits until each of them succeeded.
+	MachinePreDeletionHooks []string
 }
 
 // SafetyOptions are used to configure the upper-limit and lower-limit
@@ -84,9 +117,23 @@ type SafetyOptions struct {
 	// Timeout (in duration) used while health-check of
 	// a machine before it is declared as failed
 	MachineHealthTimeout metav1.Duration
+	// Timeout (in duration) for which the node of a pending machine can have the
+	// critical-components-not-ready taint after joining before the machine is declared as failed.
+	// Within it, the taint also extends the MachineHealthTimeout of unknown machines.
+	// A value of 0 disables it, leaving such machines to the MachineCreationTimeout.
+	MachineCriticalComponentsNotReadyTimeout metav1.Duration
 	// Timeout (in duration) used while draining of machine before deletion,
 	// beyond which it forcefully deletes machine
 	MachineDrainTimeout metav1.Duration
+	// Interval (in duration) at which the status of a machine is updated with the progress of its drain,
+	// i.e. the number of pods remaining on its node and the time elapsed. A value of 0 disables the updates.
+	MachineDrainProgressUpdateInterval metav1.Duration
+	// Period (in duration) after which the drain of a machine is continued once the termination condition was
+	// newly set on its node, giving it time to propagate to the caches. A value of 0 drains the node right away.
+	NodeTerminationConditionPropagationRetry metav1.Duration
+	// Timing of setting the termination condition on the node of a machine relative to its drain.
+	// One of before-drain, after-drain.
+	NodeTerminationConditionTiming string
 	// Timeout (in duration) used while in-place updating of a machine,
 	// beyond which it is declared as failed
 	MachineInPlaceUpdateTimeout metav1.Duration
@@ -97,6 +144,99 @@ type SafetyOptions struct {
 	PvDetachTimeout metav1.Duration
 	// Timeout (in duration) used while waiting for PV to reattach on new node
 	PvReattachTimeout metav1.Duration
+	// Durations for which the node of a machine has to be NotReady or have a ReadonlyFilesystem
+	// before its drain is forced during machine deletion, per node label selector.
+	// The first matching selector wins, otherwise the MachineUnreachableGracePeriod is used.
+	NodeNotReadyForceDrainTimeouts []NodeSelectorTimeout
+	// Period (in duration) for which the node of a machine has to be NotReady or have a ReadonlyFilesystem
+	// before its drain is forced during machine deletion, unless overridden by the NodeNotReadyForceDrainTimeouts.
+	MachineUnreachableGracePeriod metav1.Duration
+	// Label selector of the critical DaemonSet pods, e.g. of CSI drivers, which have to report the
+	// SafeToTerminate condition on the node of a machine before its VM is deleted.
+	// An empty selector disables the wait.
+	CriticalDaemonSetPodSelector string
+	// Maximum number of node objects deleted per second in the target cluster
+	// while deleting machines. A value of 0 disables the rate limiting.
+	NodeDeletionQPS float32
+	// Maximum number of concurrent pod evictions across all drains of machines.
+	// A value of 0 disables the limit.
+	MaxConcurrentPodEvictions int
+	// Number of pods on the node of a machine above which a Warning event is emitted on the machine,
+	// hinting at a scheduling imbalance. A value of 0 disables the check.
+	NodePodDensityThreshold int
+	// Maximum number of concurrent create and delete operations of the provider for the machines of a MachineClass.
+	// A value of 0 disables the limit.
+	MaxConcurrentProviderOperationsPerClass int
+	// Maximum number of machines selected for an in-place update whose nodes are drained concurrently.
+	// A value of 0 disables the limit.
+	MaxConcurrentInPlaceUpdateDrains int
+	// Policy applied while draining a machine to the pods using node-local volumes of a StorageClass
+	// with the WaitForFirstConsumer binding mode. One of evict, skip, delete-pvc.
+	NodeLocalVolumePodDrainPolicy string
+	// Policy applied while draining a machine to the pods in the Succeeded or Failed phase. One of skip, evict.
+	TerminalPodDrainPolicy string
+	// Evict the pods while draining a machine in groups of ascending priority, so that the pods
+	// of the highest priority are evicted last
+	PriorityOrderedDrainEviction bool
+	// Skip the eviction of pods during the deletion of a machine whose node has no pods to evict, e.g. only DaemonSet
+	// and mirror pods, and proceed to the VM deletion right after cordoning the node
+	DrainFastPathForNodesWithoutEvictablePods bool
+	// Timeout (in duration) for which the VM deletion of a machine has to fail due to
+	// authentication errors before the MachineDeletionAuthFailurePolicy is applied
+	MachineDeletionAuthFailureTimeout metav1.Duration
+	// Policy applied to machines whose VM deletion persistently fails due to authentication errors.
+	// One of Hold, ForceRemoveFinalizer.
+	MachineDeletionAuthFailurePolicy string
+	// Timeout (in duration) for which the VM deletion of a machine has to fail as the provider is
+	// unreachable before the MachineDeletionProviderUnreachablePolicy is applied
+	MachineDeletionProviderUnreachableTimeout metav1.Duration
+	// Policy applied to machines whose VM deletion persistently fails as the provider is unreachable.
+	// One of Stall, ForceRemoveFinalizer.
+	MachineDeletionProviderUnreachablePolicy string
+	// Maximum number of failed DeleteMachine calls for a machine labeled force-deletion, after which its deletion
+	// continues with the deletion of the node and the removal of the finalizer without confirmation of the VM deletion.
+	// A value of 0 keeps retrying the VM deletion.
+	MaxForceDeleteVMAttempts int32
+	// Policy applied to machines whose node is NotReady for longer than the health timeout.
+	// One of replace, reboot-before-replace.
+	NodeNotReadyPolicy string
+	// Policy applied to the nodes of newly created machines which join the cluster cordoned.
+	// One of keep, uncordon.
+	UnschedulableJoiningNodePolicy string
+	// Policy applied to the drain of a machine whose node has a NoExecute taint of another controller, which
+	// already evicts the pods not tolerating it. One of drain, wait.
+	NoExecuteTaintDrainPolicy string
+	// Minimum interval (in duration) between updates of the machine status reflecting changed
+	// node conditions, coalescing rapid changes. Phase changes are not delayed.
+	// A value of 0 disables the debouncing.
+	MachineConditionsUpdateInterval metav1.Duration
+	// Period (in duration) for which the result of the GetMachineStatus call of the health checks of a machine
+	// is cached. The cached result is invalidated on spec changes or the deletion of the machine.
+	// The result is cached for at least the ProviderConditionsRefreshInterval.
+	MachineStatusCacheTTL metav1.Duration
+	// Period (in duration) for which the result of the GetMachineStatus call of the deletion flow of a machine is cached
+	// per provider ID, so that short retries of the deletion don't call the provider again. The cached result is
+	// invalidated by the deletion of the VM. A value of 0 disables the caching.
+	DeletionMachineStatusCacheTTL metav1.Duration
+	// Period (in duration) after which the drain and the health timeout of a machine are re-evaluated while the
+	// APIServer of the target cluster is unreachable. Meanwhile the machine is neither force drained nor marked Failed.
+	// A value of 0 disables the hold.
+	TargetClusterUnreachableRetryPeriod metav1.Duration
+	// Timeout (in duration) of a single call of a pre-deletion hook of a machine
+	MachinePreDeletionHookTimeout metav1.Duration
+	// Timeout (in duration) of a single call of a pre-drain hook of a machine
+	PreDrainHookTimeout metav1.Duration
+	// Timeouts (in duration) of a single CreateMachine, DeleteMachine and GetMachineStatus call of the driver,
+	// unless overridden by the respective annotation of the MachineClass. A value of 0 leaves the call unbounded.
+	DriverCreateMachineTimeout    metav1.Duration
+	DriverDeleteMachineTimeout    metav1.Duration
+	DriverGetMachineStatusTimeout metav1.Duration
+	// Name of a ConfigMap in the control namespace mapping the names of MachineClasses to the maximum number of
+	// machines which may reference them, unless overridden by the max-machines annotation of the MachineClass
+	MachineQuotaConfigMap string
+	// Lifetime (in duration) of the bootstrap tokens created for the nodes of new machines.
+	// A value of 0 lets the tokens expire after the creation timeout of the machine.
+	BootstrapTokenTTL metav1.Duration
 
 	// Timeout (in duration) for which the APIServer can be down before
 	// declare the machine controller frozen by safety controller
@@ -116,6 +256,22 @@ type SafetyOptions struct {
 	MachineControllerFrozen bool
 }
 
+// NodeSelectorTimeout maps a node label selector to a timeout
+type NodeSelectorTimeout struct {
+	// Selector is the label selector nodes have to match
+	Selector string
+	// Timeout (in duration) applicable to the matching nodes
+	Timeout metav1.Duration
+}
+
+// NodeLabelTaint maps a node label selector to a taint
+type NodeLabelTaint struct {
+	// Selector is the label selector nodes have to match
+	Selector string
+	// Taint to be maintained on the matching nodes
+	Taint corev1.Taint
+}
+
 // LeaderElectionConfiguration defines the configuration of leader election
 // clients for components that can run with leader election enabled.
 type LeaderElectionConfiguration struct {